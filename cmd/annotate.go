@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// annotateCmd represents the annotate command.
+var annotateCmd = &cobra.Command{
+	Use:   "annotate --pr <number> [files...]",
+	Short: "Post findings as pull request review comments",
+	Long: `The 'annotate' command checks the provided protobuf files and posts the findings
+as review comments on the lines of a GitHub pull request or a GitLab merge request.
+Comments already posted by previous runs are not duplicated, and findings on lines
+outside the diff are skipped. The token is taken from GITHUB_TOKEN or GITLAB_TOKEN,
+the repository and the API URL are taken from the CI environment variables
+(GITHUB_REPOSITORY and GITHUB_API_URL, CI_PROJECT_ID and CI_API_V4_URL).
+The command doesn't fail on findings, run 'check' to gate the pipeline.`,
+	Example: `protolinter annotate --pr 42 api/*.proto                              # Annotate a GitHub pull request
+protolinter annotate --provider gitlab --pr "$CI_MERGE_REQUEST_IID" api/*.proto  # Annotate a GitLab merge request`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		provider, _ := cmd.Flags().GetString("provider")
+		repository, _ := cmd.Flags().GetString("repo")
+		pullRequest, _ := cmd.Flags().GetInt("pr")
+
+		checker.ExecuteAnnotate(cmd.Context(), files, &checker.AnnotateOptions{
+			ConfigPath:  configPath,
+			Provider:    provider,
+			Repository:  repository,
+			PullRequest: pullRequest,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	annotateCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	annotateCmd.Flags().Int("pr", 0,
+		"number of the pull request or merge request to annotate")
+	annotateCmd.Flags().String("provider", "",
+		fmt.Sprintf("code hosting platform: %s or %s (default is %s inside GitLab CI and %s otherwise)",
+			checker.ReviewProviderGitHub,
+			checker.ReviewProviderGitLab,
+			checker.ReviewProviderGitLab,
+			checker.ReviewProviderGitHub))
+	annotateCmd.Flags().String("repo", "",
+		"GitHub repository (owner/name) or GitLab project (ID or path), "+
+			"taken from GITHUB_REPOSITORY or CI_PROJECT_ID by default")
+
+	config.AddOverrideFlags(annotateCmd.Flags())
+
+	rootCmd.AddCommand(annotateCmd)
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 
 	"github.com/oshokin/protolinter/internal/checker"
 	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // checkCmd represents the check command.
@@ -15,23 +19,203 @@ var checkCmd = &cobra.Command{
 	Long: `The 'check' command analyzes the provided protobuf files to ensure they
 comply with coding conventions and standards. It verifies that the files are
 properly formatted and follow recommended practices.`,
-	Example: "protolinter check --config=config.yaml file.proto       # Analyze a specific protobuf file",
-	Args:    cobra.MinimumNArgs(1),
+	Example: `protolinter check --config=config.yaml file.proto       # Analyze a specific protobuf file
+protolinter check --auto                                   # Discover and analyze all scopes in the repository
+git diff --name-only main -- '*.proto' | protolinter check --files-from -  # Analyze changed files`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if isPersistentWorker, _ := cmd.Flags().GetBool("persistent_worker"); isPersistentWorker {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		if isAuto, _ := cmd.Flags().GetBool("auto"); isAuto {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+
+		if filesFrom, _ := cmd.Flags().GetString("files-from"); filesFrom != "" {
+			return cobra.ArbitraryArgs(cmd, args)
+		}
+
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, files []string) {
-		configPath, _ := cmd.Flags().GetString("config")
-		isMimirFile, _ := cmd.Flags().GetBool("mimir")
+		if isPersistentWorker, _ := cmd.Flags().GetBool("persistent_worker"); isPersistentWorker {
+			workerProtocol, _ := cmd.Flags().GetString("worker-protocol")
+
+			checker.ExecuteWorker(cmd.Context(), &checker.WorkerOptions{
+				Protocol: workerProtocol,
+				Handle:   handleCheckWorkRequest,
+			})
 
-		checker.ExecuteCheck(files, configPath, isMimirFile)
+			return
+		}
+
+		checker.ExecuteCheck(cmd.Context(), files, getCheckOptions(cmd.Flags()))
 	},
 }
 
 func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
-	checkCmd.Flags().StringP("config", "c", "",
+	addCheckFlags(checkCmd.Flags())
+
+	checkCmd.Flags().Bool("persistent_worker", false,
+		"run as a Bazel persistent worker, reading work requests with the arguments of the command from stdin")
+	checkCmd.Flags().String("worker-protocol", checker.WorkerProtocolProto,
+		fmt.Sprintf("protocol of the persistent worker: %s or %s",
+			checker.WorkerProtocolProto,
+			checker.WorkerProtocolJSON))
+
+	rootCmd.AddCommand(checkCmd)
+}
+
+// addCheckFlags defines the flags of the check command, which are also accepted by work requests.
+func addCheckFlags(flags *pflag.FlagSet) {
+	flags.StringP("config", "c", "",
 		fmt.Sprintf("path to the custom configuration file (default is '%s')",
 			config.DefaultConfigName))
-	checkCmd.Flags().BoolP("mimir", "m", false,
-		"path to the mimir file containing a list of paths containing protobuf files, "+
-			"if this flag is set, the first file specified as an argument is expected to be the mimir file")
+	flags.BoolP("mimir", "m", false,
+		"treat arguments as mimir files (or glob patterns of them) containing lists of paths to protobuf files, "+
+			"useful in monorepos having one mimir file per service")
+	flags.Bool("auto", false,
+		"walk the directory tree (the argument or the working directory) discovering mimir files, "+
+			"buf modules and configuration files, and check each scope with its own configuration")
+	flags.String("files-from", "",
+		"path to the file listing the files to check one per line, in addition to the arguments, "+
+			"'-' reads the list from stdin, e.g. from git diff")
+	flags.String("manifest", "",
+		"path to the JSON file to record the inputs of the run into "+
+			"(linter version, configuration checksum, checked files and downloaded dependencies)")
+	flags.StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the results: %s, %s, %s or %s, "+
+			"which is a unified diff of the fixes available for the findings without applying them",
+			checker.OutputFormatText,
+			checker.OutputFormatJSON,
+			checker.OutputFormatHTML,
+			checker.OutputFormatPatch))
+	flags.String("output-file", "",
+		"path to the file the results are written to (default is stdout)")
+	flags.String("report-dir", "",
+		"path to the directory to write a report per checked file in the output format into, "+
+			"mirroring the paths of the files, along with an index of the reports")
+	flags.String("group-by", "",
+		fmt.Sprintf("group findings by the specified key, supported keys: %s", checker.GroupByOwner))
+	flags.String("locale", "",
+		fmt.Sprintf("language of diagnostic messages: %s or %s, overrides the configuration, "+
+			"names of checks are never translated",
+			config.LocaleEnglish,
+			config.LocaleRussian))
+	flags.String("expect", "",
+		"path to the golden file the findings must match exactly, "+
+			"the run fails on any difference instead of failing on errors")
+	flags.Bool("update-expect", false,
+		"overwrite the golden file specified by --expect with the current findings")
+	flags.Bool("progress", false,
+		"show the number of compiled and checked files, the current file and ETA, "+
+			"as a progress bar on a terminal or as periodic log lines otherwise")
 
-	rootCmd.AddCommand(checkCmd)
+	flags.Bool("debug-rules", false,
+		"log every evaluation of a check on a descriptor and the total time spent per check when the run is finished")
+
+	flags.StringArray("only-descriptor", nil,
+		"check only descriptors having the specified full name prefix, like excluded_descriptors entries, "+
+			"can be specified multiple times")
+
+	flags.StringArray("only-check", nil,
+		"run only the specified check, even if it's excluded by the configuration, "+
+			"can be specified multiple times")
+
+	flags.Bool("fail-fast", false,
+		"stop checking at the first file with errors, useful for pre-commit hooks")
+
+	flags.String("profile", "",
+		"name of the profile from the profiles section of the configuration applied over the rest of it, "+
+			"e.g. ci or local")
+
+	config.AddOverrideFlags(flags)
+}
+
+// getCheckOptions returns the options of the check command set by the flags.
+func getCheckOptions(flags *pflag.FlagSet) *checker.CheckOptions {
+	configPath, _ := flags.GetString("config")
+	isMimirFile, _ := flags.GetBool("mimir")
+	isAuto, _ := flags.GetBool("auto")
+	filesFrom, _ := flags.GetString("files-from")
+	manifestPath, _ := flags.GetString("manifest")
+	outputFormat, _ := flags.GetString("output")
+	outputPath, _ := flags.GetString("output-file")
+	reportDir, _ := flags.GetString("report-dir")
+	groupBy, _ := flags.GetString("group-by")
+	locale, _ := flags.GetString("locale")
+	expectPath, _ := flags.GetString("expect")
+	updateExpect, _ := flags.GetBool("update-expect")
+	showProgress, _ := flags.GetBool("progress")
+	debugRules, _ := flags.GetBool("debug-rules")
+	onlyDescriptors, _ := flags.GetStringArray("only-descriptor")
+	onlyChecks, _ := flags.GetStringArray("only-check")
+	failFast, _ := flags.GetBool("fail-fast")
+	quiet, _ := flags.GetBool("quiet")
+
+	return &checker.CheckOptions{
+		ConfigPath:      configPath,
+		IsMimirFile:     isMimirFile,
+		IsAuto:          isAuto,
+		FilesFrom:       filesFrom,
+		ManifestPath:    manifestPath,
+		OutputFormat:    outputFormat,
+		OutputPath:      outputPath,
+		ReportDir:       reportDir,
+		GroupBy:         groupBy,
+		Locale:          locale,
+		ExpectPath:      expectPath,
+		UpdateExpect:    updateExpect,
+		ShowProgress:    showProgress,
+		DebugRules:      debugRules,
+		OnlyDescriptors: onlyDescriptors,
+		OnlyChecks:      onlyChecks,
+		FailFast:        failFast,
+		Quiet:           quiet,
+	}
+}
+
+// handleCheckWorkRequest runs the check command with the arguments of a work request,
+// writing both the logs and the results to the output of the work response.
+func handleCheckWorkRequest(ctx context.Context, args []string, output io.Writer) int {
+	flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	flags.SetOutput(output)
+
+	addLogFlags(flags)
+	addCheckFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		fmt.Fprintln(output, err.Error())
+
+		return 1
+	}
+
+	config.BindOverrideFlags(flags)
+
+	log, closeLog, err := newLoggerFromFlags(flags, output)
+	if err != nil {
+		fmt.Fprintln(output, err.Error())
+
+		return 1
+	}
+
+	defer func() { _ = closeLog() }()
+
+	ctx = logger.ToContext(ctx, log)
+
+	opts := getCheckOptions(flags)
+	opts.Output = output
+
+	isPassed, err := checker.RunCheck(ctx, flags.Args(), opts)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+
+		return 1
+	}
+
+	if !isPassed {
+		return 1
+	}
+
+	return 0
 }
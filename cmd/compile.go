@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// compileCmd represents the compile command.
+var compileCmd = &cobra.Command{
+	Use:   "compile [files...]",
+	Short: "Compile protobuf files without running checks",
+	Long: `The 'compile' command only compiles the provided protobuf files, resolving imports
+the same way the 'check' command does, and reports syntax and link errors
+in the standard diagnostic format. It's a fast pre-commit gate
+when the full set of checks is overkill.`,
+	Example: "protolinter compile api/*.proto       # Report syntax and link errors",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteCompile(cmd.Context(), files, &checker.CompileOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	compileCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	compileCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the results: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	compileCmd.Flags().String("output-file", "",
+		"path to the file the results are written to (default is stdout)")
+
+	config.AddOverrideFlags(compileCmd.Flags())
+
+	rootCmd.AddCommand(compileCmd)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Maintain the configuration file",
+	Long: `The 'config' command groups subcommands that help to keep
+the configuration file in sync with the checked protobuf files.`,
+}
+
+// configPruneCmd represents the config prune command.
+var configPruneCmd = &cobra.Command{
+	Use:   "prune [files...]",
+	Short: "Report exclusions that no longer have any effect",
+	Long: `The 'prune' command reports the entries of 'excluded_descriptors' that no longer
+match any descriptor in the provided files and the entries of 'excluded_checks'
+that wouldn't report anything if enabled. Descriptor lists rot quickly after refactors.
+The command fails if unused entries are found, unless they are removed with --write.`,
+	Example: "protolinter config prune --write api/*.proto       # Remove unused exclusions from the configuration",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		write, _ := cmd.Flags().GetBool("write")
+
+		checker.ExecuteConfigPrune(cmd.Context(), files, &checker.ConfigPruneOptions{
+			ConfigPath: configPath,
+			Write:      write,
+		})
+	},
+}
+
+// configGenerateCmd represents the config generate command.
+var configGenerateCmd = &cobra.Command{
+	Use:   "generate [files...]",
+	Short: "Print the configuration excluding every descriptor with findings",
+	Long: `The 'generate' command checks the provided protobuf files and prints the configuration file
+with every descriptor having findings appended to 'excluded_descriptors', so the current state
+can be accepted as a baseline. Every appended descriptor is preceded by comments listing
+the failed checks with their severities and whether 'protolinter format' fixes them,
+so reviewers of the baseline can decide to fix a descriptor instead of excluding it.`,
+	Example: "protolinter config generate -o baseline.yaml api/*.proto       # Write the baseline configuration",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteConfigGenerate(cmd.Context(), files, &checker.ConfigGenerateOptions{
+			ConfigPath: configPath,
+			OutputPath: outputPath,
+		})
+	},
+}
+
+// configImpactCmd represents the config impact command.
+var configImpactCmd = &cobra.Command{
+	Use:   "impact [files...]",
+	Short: "Compare findings under the current and a candidate configuration",
+	Long: `The 'impact' command checks the provided protobuf files under both the current
+and the candidate configuration and reports the findings added and removed by the candidate
+per check, so the rollout of a check can be assessed before it's turned on.
+The command never fails because of the findings themselves.`,
+	Example: "protolinter config impact --candidate strict.yaml api/*.proto       # Preview the findings of a stricter configuration",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		candidatePath, _ := cmd.Flags().GetString("candidate")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteConfigImpact(cmd.Context(), files, &checker.ConfigImpactOptions{
+			ConfigPath:    configPath,
+			CandidatePath: candidatePath,
+			OutputFormat:  outputFormat,
+			OutputPath:    outputPath,
+		})
+	},
+}
+
+// configValidateCmd represents the config validate command.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration file",
+	Long: `The 'validate' command loads the configuration file and reports the checks
+it mentions that are unknown, renamed or retired, suggesting replacements.
+Renamed and retired checks keep working and are reported as warnings,
+the command fails only if the configuration is invalid or mentions unknown checks.`,
+	Example: "protolinter config validate --config api/.protolinter.yaml       # Validate a specific configuration",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+
+		checker.ExecuteConfigValidate(cmd.Context(), &checker.ConfigValidateOptions{
+			ConfigPath: configPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	configPruneCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	configPruneCmd.Flags().BoolP("write", "w", false,
+		"remove the unused entries from the configuration file")
+
+	configGenerateCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file the exclusions are added to (default is '%s')",
+			config.DefaultConfigName))
+	configGenerateCmd.Flags().StringP("output-file", "o", "",
+		"path to the file the configuration is written to (default is stdout)")
+
+	configImpactCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the current configuration file (default is '%s')",
+			config.DefaultConfigName))
+	configImpactCmd.Flags().String("candidate", "",
+		"path to the candidate configuration file compared with the current one")
+	configImpactCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the report: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	configImpactCmd.Flags().String("output-file", "",
+		"path to the file the report is written to (default is stdout)")
+
+	configValidateCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+
+	config.AddOverrideFlags(configPruneCmd.Flags())
+	config.AddOverrideFlags(configValidateCmd.Flags())
+	config.AddOverrideFlags(configImpactCmd.Flags())
+	config.AddOverrideFlags(configGenerateCmd.Flags())
+
+	configCmd.AddCommand(configPruneCmd)
+	configCmd.AddCommand(configImpactCmd)
+	configCmd.AddCommand(configGenerateCmd)
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// coverageCmd represents the coverage command.
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [files...]",
+	Short: "Report how many descriptors every check actually covers",
+	Long: `The 'coverage' command evaluates the checks on the provided protobuf files twice:
+as configured and with excluded_checks, excluded_descriptors and checks excluded by overrides removed.
+For every check it reports the number of descriptors the check applies to (eligible),
+the ones hidden by the exclusions (excluded), the ones passing and failing it,
+and the percentage of eligible descriptors that are actually checked.
+The command doesn't fail on findings.`,
+	Example: `protolinter coverage api/**/*.proto                                  # Print the coverage table
+protolinter coverage api/**/*.proto -o json --output-file coverage.json  # Export the coverage for a dashboard`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteCoverage(cmd.Context(), files, &checker.CoverageOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	coverageCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	coverageCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the report: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	coverageCmd.Flags().String("output-file", "",
+		"path to the file the report is written to (default is stdout)")
+
+	config.AddOverrideFlags(coverageCmd.Flags())
+
+	rootCmd.AddCommand(coverageCmd)
+}
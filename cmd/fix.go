@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/spf13/cobra"
+)
+
+// fixCmd represents the fix command.
+var fixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Apply the fixes recorded in the JSON report of a previous check",
+	Long: `The 'fix' command applies the fixes recorded in the JSON report written by
+'protolinter check --output json', without checking the files again.
+Findings having a fix are marked with the "fix" field, e.g. "format" for the findings
+fixed by formatting the file. Files are fixed according to their current content,
+so applying a report again or after later edits is safe.
+It enables a two-phase CI workflow: the report is produced by the lint job of a pull request,
+and a bot applies the fixes and pushes them as a commit.`,
+	Example: `protolinter check -o json --output-file findings.json api/**/*.proto
+protolinter fix --from-report findings.json   # Apply the fixes of the report`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		reportPath, _ := cmd.Flags().GetString("from-report")
+
+		checker.ExecuteFix(cmd.Context(), &checker.FixOptions{
+			ReportPath: reportPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	fixCmd.Flags().String("from-report", "",
+		"path to the JSON report of a previous 'protolinter check --output json' run")
+
+	rootCmd.AddCommand(fixCmd)
+}
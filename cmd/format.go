@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/spf13/cobra"
+)
+
+// formatCmd represents the format command.
+var formatCmd = &cobra.Command{
+	Use:   "format [files...]",
+	Short: "Reformat protobuf files into the canonical layout",
+	Long: `The 'format' command reformats the provided protobuf files into the canonical layout:
+the syntax, the package, sorted imports, aligned options and then definitions,
+indented by two spaces with comments kept next to the elements they belong to.
+By default the formatted files are printed to stdout.
+With --write the files are rewritten in place, with --check the files that aren't formatted
+are listed and the command fails, which is meant for CI.`,
+	Example: `protolinter format api/orders.proto          # Print the formatted file
+protolinter format -w api/**/*.proto          # Rewrite the files in place
+protolinter format --check api/**/*.proto     # Fail if any file isn't formatted`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		check, _ := cmd.Flags().GetBool("check")
+		write, _ := cmd.Flags().GetBool("write")
+
+		checker.ExecuteFormat(cmd.Context(), files, &checker.FormatOptions{
+			Check: check,
+			Write: write,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	formatCmd.Flags().Bool("check", false,
+		"list the files that aren't formatted and exit with code 1 if there are any")
+	formatCmd.Flags().BoolP("write", "w", false,
+		"write the formatted content back to the files instead of stdout")
+
+	rootCmd.AddCommand(formatCmd)
+}
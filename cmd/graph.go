@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// graphCmd represents the graph command.
+var graphCmd = &cobra.Command{
+	Use:   "graph [files...]",
+	Short: "Export the import graph of protobuf files",
+	Long: `The 'graph' command compiles the provided protobuf files and writes the graph
+of their imports, including standard imports and downloaded dependencies,
+in Graphviz DOT or JSON format. It's useful for visualizing dependencies
+and for detecting newly introduced cross-domain imports in CI.`,
+	Example: `protolinter graph api/*.proto | dot -Tsvg > imports.svg       # Render the import graph
+protolinter graph api/*.proto -o json --output-file graph.json  # Export the import graph as JSON`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteGraph(cmd.Context(), files, &checker.GraphOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	graphCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	graphCmd.Flags().StringP("output", "o", checker.OutputFormatDOT,
+		fmt.Sprintf("format of the graph: %s or %s", checker.OutputFormatDOT, checker.OutputFormatJSON))
+	graphCmd.Flags().String("output-file", "",
+		"path to the file the graph is written to (default is stdout)")
+
+	config.AddOverrideFlags(graphCmd.Flags())
+
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// grepCmd represents the grep command.
+var grepCmd = &cobra.Command{
+	Use:   "grep [files...]",
+	Short: "Search protobuf descriptors by full name",
+	Long: `The 'grep' command compiles the provided protobuf files and lists the descriptors
+declared in them whose full names match the regular expression, with their kinds and locations.
+The kinds can be narrowed down with --kind, which makes ad-hoc API audits trivial.`,
+	Example: `protolinter grep --kind field --regex '_id$' api/*.proto        # List identifier fields
+protolinter grep --kind method --regex 'Delete' api/*.proto -o json  # List deleting methods as JSON`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		regex, _ := cmd.Flags().GetString("regex")
+		kinds, _ := cmd.Flags().GetStringSlice("kind")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteGrep(cmd.Context(), files, &checker.GrepOptions{
+			ConfigPath:   configPath,
+			Regex:        regex,
+			Kinds:        kinds,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	grepCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	grepCmd.Flags().StringP("regex", "e", "",
+		"regular expression the full names of descriptors must match (default matches every descriptor)")
+	grepCmd.Flags().StringSliceP("kind", "k", nil,
+		fmt.Sprintf("kind of descriptors to list, can be repeated or comma-separated: %s (default is all kinds)",
+			strings.Join(checker.SymbolKinds, ", ")))
+	grepCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the descriptors: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	grepCmd.Flags().String("output-file", "",
+		"path to the file the descriptors are written to (default is stdout)")
+
+	config.AddOverrideFlags(grepCmd.Flags())
+
+	rootCmd.AddCommand(grepCmd)
+}
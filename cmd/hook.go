@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// hookCmd represents the hook command.
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Integrate with git hooks",
+	Long: `The 'hook' command groups subcommands that run the linter
+from git hooks, giving developers feedback before CI.`,
+}
+
+// hookInstallCmd represents the hook install command.
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install a git pre-commit hook linting the staged protobuf files",
+	Long: `The 'install' command writes a git pre-commit hook into the current repository.
+The hook passes the staged protobuf files to 'check --files-from -' and blocks
+the commit if any of them has errors. An existing hook is overwritten only
+if it was installed by protolinter, unless --force is specified.
+With --pre-commit-config, the stanza for the .pre-commit-config.yaml file
+of the pre-commit framework is printed instead.`,
+	Example: `protolinter hook install                                  # Write the pre-commit hook
+protolinter hook install --pre-commit-config >> .pre-commit-config.yaml  # Use the pre-commit framework`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		preCommitConfig, _ := cmd.Flags().GetBool("pre-commit-config")
+		force, _ := cmd.Flags().GetBool("force")
+
+		checker.ExecuteHookInstall(cmd.Context(), &checker.HookInstallOptions{
+			ConfigPath:      configPath,
+			PreCommitConfig: preCommitConfig,
+			Force:           force,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	hookInstallCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file the hook passes to the linter (default is '%s')",
+			config.DefaultConfigName))
+	hookInstallCmd.Flags().Bool("pre-commit-config", false,
+		"print the stanza of the pre-commit framework configuration instead of writing a git hook")
+	hookInstallCmd.Flags().Bool("force", false,
+		"overwrite an existing pre-commit hook that wasn't installed by protolinter")
+
+	hookCmd.AddCommand(hookInstallCmd)
+	rootCmd.AddCommand(hookCmd)
+}
@@ -13,11 +13,18 @@ var listCmd = &cobra.Command{
 found in the provided files.`,
 	Example: "protolinter list file.proto       # Generate a list of full protobuf element names",
 	Args:    cobra.MinimumNArgs(1),
-	Run: func(_ *cobra.Command, files []string) {
-		checker.ExecuteListProtoFullNames(files)
+	Run: func(cmd *cobra.Command, files []string) {
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteListProtoFullNames(cmd.Context(), files, &checker.ListOptions{
+			OutputPath: outputPath,
+		})
 	},
 }
 
 func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	listCmd.Flags().String("output-file", "",
+		"path to the file the results are written to (default is stdout)")
+
 	rootCmd.AddCommand(listCmd)
 }
@@ -1,11 +1,21 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"os"
 
+	"github.com/oshokin/protolinter/internal/common"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
 )
 
+// closeLogger releases the resources held by the logger, such as the log file.
+var closeLogger = func() error { return nil }
+
 var rootCmd = &cobra.Command{
 	Use:   "protolinter",
 	Short: "A tool to lint and analyze Protocol Buffer files",
@@ -18,12 +28,61 @@ Configuration:
   This YAML file can be used to define excluded checks and descriptors, allowing you
   to fine-tune the analysis to your project's needs.
 Example '.protolinter.yaml' configuration can be found in .protolinter.example.yaml`,
-	Version: "1.0.0",
+	Version: common.Version(),
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		// The logger is owned by the run context, the global one stays a fallback.
+		log, closer, err := newLoggerFromFlags(cmd.Flags(), nil)
+		if err != nil {
+			return err
+		}
+
+		closeLogger = closer
+
+		cmd.SetContext(logger.ToContext(cmd.Context(), log))
+		config.BindOverrideFlags(cmd.Flags())
+
+		return nil
+	},
 }
 
 // Execute runs the root command.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	_ = closeLogger()
+
+	if err != nil {
 		os.Exit(1)
 	}
 }
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	// The bare version is easy to parse in scripts, the "version" command prints the details.
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
+
+	addLogFlags(rootCmd.PersistentFlags())
+}
+
+// addLogFlags defines the flags configuring the logger.
+func addLogFlags(flags *pflag.FlagSet) {
+	flags.String("log-format", logger.FormatConsole,
+		fmt.Sprintf("format of the logs: %s or %s", logger.FormatConsole, logger.FormatJSON))
+	flags.String("log-file", "",
+		"path to the file the logs are appended to (default is stderr)")
+	flags.BoolP("quiet", "q", false,
+		"show only warnings and errors, the check command writes nothing at all if the run passes")
+}
+
+// newLoggerFromFlags creates the logger configured by the flags, writing to the writer unless a log file is set.
+func newLoggerFromFlags(flags *pflag.FlagSet, writer io.Writer) (*zap.SugaredLogger, func() error, error) {
+	format, _ := flags.GetString("log-format")
+	filePath, _ := flags.GetString("log-file")
+	quiet, _ := flags.GetBool("quiet")
+
+	return logger.NewFromOptions(&logger.Options{
+		Format:   format,
+		FilePath: filePath,
+		Quiet:    quiet,
+		Writer:   writer,
+	})
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd represents the rules command.
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Work with the checks performed by the linter",
+	Long: `The 'rules' command groups subcommands that work with the metadata
+of the checks performed by the linter.`,
+}
+
+// rulesDocsCmd represents the rules docs command.
+var rulesDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate Markdown documentation for every check",
+	Long: `The 'docs' command generates one Markdown page per check from the structured
+check metadata (description, rationale, good and bad examples, configuration options),
+plus an index page, keeping user-facing documentation in sync with the code.`,
+	Example: "protolinter rules docs -o docs/rules/       # Generate documentation into docs/rules",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		outputDir, _ := cmd.Flags().GetString("output")
+
+		checker.ExecuteRulesDocs(cmd.Context(), outputDir)
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	rulesDocsCmd.Flags().StringP("output", "o", "docs/rules", "path to the output directory")
+
+	rulesCmd.AddCommand(rulesDocsCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
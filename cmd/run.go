@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// runCmd represents the run command.
+var runCmd = &cobra.Command{
+	Use:   "run [files...]",
+	Short: "Execute the tasks listed in the configuration in one process",
+	Long: fmt.Sprintf(`The 'run' command executes the tasks listed in the 'tasks' key of the configuration file
+on the provided protobuf files in order, compiling the files once for all of them,
+and writes a combined report with a section per task.
+Supported tasks are: %s, the default list is: %s.
+Tasks after a failed compilation are skipped, the command fails if any task fails.`,
+		strings.Join(config.SupportedTasks, ", "),
+		strings.Join(config.DefaultTasks, ", ")),
+	Example: `protolinter run api/**/*.proto                                    # Execute the configured tasks
+protolinter run api/**/*.proto --tasks compile,lint,score          # Override the list of tasks
+protolinter run api/**/*.proto -o json --output-file report.json  # Export the combined report`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteRun(cmd.Context(), files, &checker.RunOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	runCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	runCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the report: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	runCmd.Flags().String("output-file", "",
+		"path to the file the report is written to (default is stdout)")
+
+	config.AddOverrideFlags(runCmd.Flags())
+
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// scoreCmd represents the score command.
+var scoreCmd = &cobra.Command{
+	Use:   "score [files...]",
+	Short: "Rank packages by compliance with the checks",
+	Long: `The 'score' command checks the provided protobuf files and scores every package
+from 0 to 100 by the share of its descriptors not covered by findings.
+Findings are weighted by their severity and the category of their check,
+the weights are set in the 'score' section of the configuration file.
+Packages are ranked from the best score to the worst, the command doesn't fail on findings.`,
+	Example: `protolinter score api/**/*.proto                               # Print the ranked table
+protolinter score api/**/*.proto -o json --output-file score.json  # Export the scores for a dashboard`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteScore(cmd.Context(), files, &checker.ScoreOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	scoreCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	scoreCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the scores: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	scoreCmd.Flags().String("output-file", "",
+		"path to the file the scores are written to (default is stdout)")
+
+	config.AddOverrideFlags(scoreCmd.Flags())
+
+	rootCmd.AddCommand(scoreCmd)
+}
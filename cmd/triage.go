@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// triageCmd represents the triage command.
+var triageCmd = &cobra.Command{
+	Use:   "triage [files...]",
+	Short: "Step through findings interactively, fixing, suppressing or excluding them",
+	Long: `The 'triage' command checks the provided protobuf files and shows the findings one by one,
+asking what to do with every one of them:
+  f - fix the finding by formatting the file, offered for the findings the formatter fixes;
+  s - suppress the check for the descriptor with a 'protolinter:disable' directive above its declaration;
+  e - add the descriptor to excluded_descriptors of the configuration file;
+  n or Enter - skip the finding;
+  q - stop, keeping everything done before.
+The findings of a file are shown from the bottom up, so the inserted directives don't shift the lines
+of the findings left. It's meant for cleaning up a baseline of an existing repository.`,
+	Example: `protolinter triage api/**/*.proto                   # Triage the findings of the files
+protolinter triage -c lint/protolinter.yaml api/*.proto  # Add the exclusions to the specified configuration`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+
+		checker.ExecuteTriage(cmd.Context(), files, &checker.TriageOptions{
+			ConfigPath: configPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	triageCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the configuration file exclusions are added to (default is '%s')",
+			config.DefaultConfigName))
+
+	rootCmd.AddCommand(triageCmd)
+}
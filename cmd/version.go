@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/spf13/cobra"
+)
+
+// versionCmd represents the version command.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version of the linter",
+	Long: `The 'version' command prints the version of the linter, the revision and
+the Go version it was built with, and the number of checks.
+With --json, the information is written as JSON for scripts and CI.
+With --check-update, the latest release is queried to tell whether an update is available.`,
+	Example: `protolinter version --json                 # Print the build information as JSON
+protolinter version --check-update         # Tell whether a newer release is available`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		isJSON, _ := cmd.Flags().GetBool("json")
+		checkUpdate, _ := cmd.Flags().GetBool("check-update")
+
+		checker.ExecuteVersion(cmd.Context(), &checker.VersionOptions{
+			IsJSON:      isJSON,
+			CheckUpdate: checkUpdate,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	versionCmd.Flags().Bool("json", false,
+		"write the version information as JSON")
+	versionCmd.Flags().Bool("check-update", false,
+		"query the GitHub releases API for the latest version")
+
+	rootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// whereCmd represents the where command.
+var whereCmd = &cobra.Command{
+	Use:   "where <symbol> [files...]",
+	Short: "Find where protobuf symbols are defined",
+	Long: `The 'where' command compiles the provided protobuf files and prints the file, line
+and kind of every symbol whose full name starts with the specified one, including symbols
+of imported files. It's handy when curating excluded_descriptors entries by hand,
+since an entry excludes every descriptor its name is a prefix of.`,
+	Example: `protolinter where foo.bar.OrderV1.status api/*.proto  # Find the definition of a field
+protolinter where foo.bar.Order api/*.proto -o json    # List the symbols starting with foo.bar.Order as JSON`,
+	Args: cobra.MinimumNArgs(2), //nolint: gomnd // The symbol and at least one file.
+	Run: func(cmd *cobra.Command, args []string) {
+		configPath, _ := cmd.Flags().GetString("config")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("output-file")
+
+		checker.ExecuteWhere(cmd.Context(), args[0], args[1:], &checker.WhereOptions{
+			ConfigPath:   configPath,
+			OutputFormat: outputFormat,
+			OutputPath:   outputPath,
+		})
+	},
+}
+
+func init() { //nolint: gochecknoinits // Code is generated by cobra-cli.
+	whereCmd.Flags().StringP("config", "c", "",
+		fmt.Sprintf("path to the custom configuration file (default is '%s')",
+			config.DefaultConfigName))
+	whereCmd.Flags().StringP("output", "o", checker.OutputFormatText,
+		fmt.Sprintf("format of the symbols: %s or %s", checker.OutputFormatText, checker.OutputFormatJSON))
+	whereCmd.Flags().String("output-file", "",
+		"path to the file the symbols are written to (default is stdout)")
+
+	config.AddOverrideFlags(whereCmd.Flags())
+
+	rootCmd.AddCommand(whereCmd)
+}
@@ -0,0 +1,192 @@
+package checker
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// acronymPluralSuffix is the suffix of plural acronyms like IDs or Urls.
+const acronymPluralSuffix = "s"
+
+// Classes of characters of identifiers, words are split where the class changes.
+const (
+	identifierRuneLetter = iota
+	identifierRuneDigit
+	identifierRuneOther
+)
+
+// checkAcronymStyle checks that acronyms in the name of the descriptor are spelled in the configured style,
+// e.g. GetHttpUrlV1 rather than GetHTTPURLV1 if acronyms are capitalized.
+func (c *ProtoChecker) checkAcronymStyle(
+	desc protoreflect.Descriptor,
+	result *CheckResult,
+	kind string,
+	logName string,
+) {
+	c.runRule(IdentifierAcronymStyle, desc, func() {
+		var (
+			name     = string(desc.Name())
+			style    = c.config.GetAcronymStyle()
+			expected = respellAcronyms(name, c.config.GetAcronyms(), style)
+		)
+
+		if expected == name {
+			return
+		}
+
+		result.AddFindingf(
+			IdentifierAcronymStyle,
+			desc,
+			"%s %s must be named %s to spell acronyms in %s style",
+			result.localize(kind),
+			logName,
+			expected,
+			style)
+	})
+}
+
+// respellAcronyms returns the PascalCase name with the acronyms spelled in the style.
+// Runs of capital letters are respelled only if they consist of the acronyms entirely,
+// so unlisted abbreviations like CIDR are kept as is.
+func respellAcronyms(name string, acronyms []string, style string) string {
+	var (
+		words  = splitIdentifierWords(name)
+		result strings.Builder
+	)
+
+	for i, word := range words {
+		isFollowedByDigits := i+1 < len(words) && unicode.IsDigit(rune(words[i+1][0]))
+
+		if style == config.AcronymStyleUpper {
+			result.WriteString(upperAcronymWord(word, acronyms))
+		} else {
+			result.WriteString(capitalizeAcronymWord(word, acronyms, isFollowedByDigits))
+		}
+	}
+
+	return result.String()
+}
+
+// capitalizeAcronymWord returns the word with the acronyms it consists of capitalized, e.g. HTTPURL as HttpUrl.
+// A word followed by digits may end with a letter of the next word, like ID and V of IDV1.
+func capitalizeAcronymWord(word string, acronyms []string, isFollowedByDigits bool) string {
+	base, suffix := word, ""
+	if strings.HasSuffix(word, acronymPluralSuffix) {
+		base, suffix = strings.TrimSuffix(word, acronymPluralSuffix), acronymPluralSuffix
+	}
+
+	if base == "" || strings.ToUpper(base) != base {
+		return word
+	}
+
+	if parts, ok := splitAcronyms(base, acronyms); ok {
+		return capitalizeWords(parts) + suffix
+	}
+
+	if isFollowedByDigits && suffix == "" && len(base) > 1 {
+		if parts, ok := splitAcronyms(base[:len(base)-1], acronyms); ok {
+			return capitalizeWords(parts) + base[len(base)-1:]
+		}
+	}
+
+	return word
+}
+
+// upperAcronymWord returns the word in upper case if it's a capitalized acronym, e.g. Url as URL.
+func upperAcronymWord(word string, acronyms []string) string {
+	for _, candidate := range []string{word, strings.TrimSuffix(word, acronymPluralSuffix)} {
+		if candidate == "" || capitalizeWords([]string{candidate}) != candidate {
+			continue
+		}
+
+		if containsString(acronyms, strings.ToUpper(candidate)) {
+			return strings.ToUpper(candidate) + strings.TrimPrefix(word, candidate)
+		}
+	}
+
+	return word
+}
+
+// splitAcronyms splits the upper case word into the acronyms it consists of, returning false if it doesn't.
+func splitAcronyms(word string, acronyms []string) ([]string, bool) {
+	if word == "" {
+		return nil, true
+	}
+
+	for _, acronym := range acronyms {
+		if acronym == "" || !strings.HasPrefix(word, acronym) {
+			continue
+		}
+
+		if rest, ok := splitAcronyms(word[len(acronym):], acronyms); ok {
+			return append([]string{acronym}, rest...), true
+		}
+	}
+
+	return nil, false
+}
+
+func capitalizeWords(words []string) string {
+	var result strings.Builder
+
+	for _, word := range words {
+		result.WriteString(word[:1])
+		result.WriteString(strings.ToLower(word[1:]))
+	}
+
+	return result.String()
+}
+
+// splitIdentifierWords splits the identifier into words: capitalized words, runs of capital letters,
+// digits and other characters. A run of capital letters followed by a lowercase letter ends before its last letter,
+// except for the plural suffix, so HTTPServer is split into HTTP and Server while IDs is kept whole.
+func splitIdentifierWords(name string) []string {
+	var (
+		runes  = []rune(name)
+		result []string
+		start  int
+	)
+
+	for i := 1; i < len(runes); i++ {
+		var (
+			previous, current = runes[i-1], runes[i]
+			isBoundary        bool
+		)
+
+		switch {
+		case unicode.IsLower(previous) && unicode.IsUpper(current):
+			isBoundary = true
+		case unicode.IsUpper(previous) && unicode.IsUpper(current) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			isPlural := string(runes[i+1]) == acronymPluralSuffix && (i+2 == len(runes) || !unicode.IsLower(runes[i+2]))
+			isBoundary = !isPlural
+		default:
+			isBoundary = identifierRuneClass(previous) != identifierRuneClass(current)
+		}
+
+		if isBoundary {
+			result = append(result, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		result = append(result, string(runes[start:]))
+	}
+
+	return result
+}
+
+// identifierRuneClass tells letters, digits and other characters apart.
+func identifierRuneClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r):
+		return identifierRuneLetter
+	case unicode.IsDigit(r):
+		return identifierRuneDigit
+	default:
+		return identifierRuneOther
+	}
+}
@@ -0,0 +1,34 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestRespellAcronyms(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		expected string
+	}{
+		{name: "GetHTTPURLV1", style: config.AcronymStyleCapitalized, expected: "GetHttpUrlV1"},
+		{name: "GetOrderByIDV1", style: config.AcronymStyleCapitalized, expected: "GetOrderByIdV1"},
+		{name: "ListOrderIDsV1", style: config.AcronymStyleCapitalized, expected: "ListOrderIdsV1"},
+		{name: "HTTPServer", style: config.AcronymStyleCapitalized, expected: "HttpServer"},
+		{name: "HTTP2Settings", style: config.AcronymStyleCapitalized, expected: "Http2Settings"},
+		{name: "CIDRBlock", style: config.AcronymStyleCapitalized, expected: "CIDRBlock"},
+		{name: "Identity", style: config.AcronymStyleCapitalized, expected: "Identity"},
+		{name: "Status", style: config.AcronymStyleCapitalized, expected: "Status"},
+		{name: "GetHttpUrlV1", style: config.AcronymStyleUpper, expected: "GetHTTPURLV1"},
+		{name: "ListOrderIdsV1", style: config.AcronymStyleUpper, expected: "ListOrderIDsV1"},
+		{name: "Identity", style: config.AcronymStyleUpper, expected: "Identity"},
+		{name: "ApisList", style: config.AcronymStyleUpper, expected: "APIsList"},
+	}
+
+	for _, tt := range tests {
+		if actual := respellAcronyms(tt.name, config.DefaultAcronyms, tt.style); actual != tt.expected {
+			t.Errorf("got %s for %s in %s style, want %s", actual, tt.name, tt.style, tt.expected)
+		}
+	}
+}
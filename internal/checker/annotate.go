@@ -0,0 +1,459 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+const (
+	// ReviewProviderGitHub posts findings as GitHub pull request review comments.
+	ReviewProviderGitHub = "github"
+	// ReviewProviderGitLab posts findings as GitLab merge request discussions.
+	ReviewProviderGitLab = "gitlab"
+
+	defaultGitHubAPIURL = "https://api.github.com"
+	defaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+	// reviewCommentMarker is a hidden marker of the comments posted by protolinter,
+	// used to avoid posting the same comment on every run.
+	reviewCommentMarker = "<!-- protolinter -->"
+	reviewPageSize      = 100
+)
+
+type (
+	// reviewClient posts review comments to a code hosting platform.
+	reviewClient interface {
+		// listComments returns the review comments already posted to the pull request.
+		listComments(ctx context.Context) ([]*reviewComment, error)
+		// postComment posts a review comment to the pull request.
+		postComment(ctx context.Context, comment *reviewComment) error
+	}
+
+	// reviewComment is a comment on a line of a file of the pull request.
+	reviewComment struct {
+		path string
+		line int
+		body string
+	}
+
+	// reviewAPI sends JSON requests to the REST API of a code hosting platform.
+	reviewAPI struct {
+		baseURL string
+		headers map[string]string
+		client  *http.Client
+	}
+
+	// reviewAPIError is an error response of the REST API.
+	reviewAPIError struct {
+		statusCode int
+		message    string
+	}
+
+	githubReviewClient struct {
+		api        *reviewAPI
+		repository string
+		number     int
+		commitID   string
+	}
+
+	gitlabReviewClient struct {
+		api      *reviewAPI
+		project  string
+		number   int
+		diffRefs *gitlabDiffRefs
+	}
+
+	githubReviewComment struct {
+		Body         string `json:"body"`
+		Path         string `json:"path"`
+		Line         int    `json:"line,omitempty"`
+		OriginalLine int    `json:"original_line,omitempty"`
+		CommitID     string `json:"commit_id,omitempty"`
+		Side         string `json:"side,omitempty"`
+	}
+
+	gitlabDiffRefs struct {
+		BaseSHA  string `json:"base_sha"`
+		StartSHA string `json:"start_sha"`
+		HeadSHA  string `json:"head_sha"`
+	}
+
+	gitlabPosition struct {
+		PositionType string `json:"position_type"`
+		BaseSHA      string `json:"base_sha"`
+		StartSHA     string `json:"start_sha"`
+		HeadSHA      string `json:"head_sha"`
+		OldPath      string `json:"old_path"`
+		NewPath      string `json:"new_path"`
+		NewLine      int    `json:"new_line"`
+	}
+
+	gitlabNote struct {
+		Body     string          `json:"body"`
+		Position *gitlabPosition `json:"position,omitempty"`
+	}
+
+	gitlabDiscussion struct {
+		Notes []*gitlabNote `json:"notes"`
+	}
+)
+
+// newReviewClient creates the client of the provider, taking the token, the API URL
+// and the repository (unless it's specified) from the CI environment variables.
+// If the provider isn't specified, GitLab is used inside GitLab CI and GitHub otherwise.
+func newReviewClient(opts *AnnotateOptions) (reviewClient, error) {
+	provider := opts.Provider
+	if provider == "" {
+		provider = ReviewProviderGitHub
+		if os.Getenv("GITLAB_CI") != "" {
+			provider = ReviewProviderGitLab
+		}
+	}
+
+	switch provider {
+	case ReviewProviderGitHub:
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			return nil, errors.New("GITHUB_TOKEN environment variable is not set")
+		}
+
+		repository := firstNonEmpty(opts.Repository, os.Getenv("GITHUB_REPOSITORY"))
+		if repository == "" {
+			return nil, errors.New("repository is not specified, use --repo or GITHUB_REPOSITORY")
+		}
+
+		return &githubReviewClient{
+			api: &reviewAPI{
+				baseURL: firstNonEmpty(os.Getenv("GITHUB_API_URL"), defaultGitHubAPIURL),
+				headers: map[string]string{
+					"Accept":        "application/vnd.github+json",
+					"Authorization": "Bearer " + token,
+				},
+				client: http.DefaultClient,
+			},
+			repository: repository,
+			number:     opts.PullRequest,
+		}, nil
+	case ReviewProviderGitLab:
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			return nil, errors.New("GITLAB_TOKEN environment variable is not set")
+		}
+
+		project := firstNonEmpty(opts.Repository, os.Getenv("CI_PROJECT_ID"))
+		if project == "" {
+			return nil, errors.New("project is not specified, use --repo or CI_PROJECT_ID")
+		}
+
+		return &gitlabReviewClient{
+			api: &reviewAPI{
+				baseURL: firstNonEmpty(os.Getenv("CI_API_V4_URL"), defaultGitLabAPIURL),
+				headers: map[string]string{
+					"PRIVATE-TOKEN": token,
+				},
+				client: http.DefaultClient,
+			},
+			project: project,
+			number:  opts.PullRequest,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown review provider: %s", provider)
+	}
+}
+
+// firstNonEmpty returns the first of the values that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// postReviewComments posts the findings as review comments, skipping the ones already posted.
+// Findings without a line and findings on lines outside the diff, which the platforms reject, are skipped.
+func postReviewComments(ctx context.Context, client reviewClient, findings []*Finding) (int, int, error) {
+	existingComments, err := client.listComments(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list review comments: %w", err)
+	}
+
+	postedComments := make(map[reviewComment]struct{}, len(existingComments))
+
+	for _, comment := range existingComments {
+		if strings.Contains(comment.body, reviewCommentMarker) {
+			postedComments[*comment] = struct{}{}
+		}
+	}
+
+	var posted, skipped int
+
+	for _, finding := range findings {
+		if finding.Line == 0 {
+			skipped++
+
+			continue
+		}
+
+		comment := reviewComment{
+			path: filepath.ToSlash(finding.Path),
+			line: finding.Line,
+			body: formatReviewComment(finding),
+		}
+
+		if _, ok := postedComments[comment]; ok {
+			continue
+		}
+
+		postedComments[comment] = struct{}{}
+
+		err = client.postComment(ctx, &comment)
+		if err == nil {
+			posted++
+
+			continue
+		}
+
+		var apiErr *reviewAPIError
+		if !errors.As(err, &apiErr) || !apiErr.isRejected() {
+			return posted, skipped, fmt.Errorf("failed to post review comment on %s:%d: %w",
+				comment.path,
+				comment.line,
+				err)
+		}
+
+		skipped++
+
+		logger.Warnf(ctx, "Skipping review comment on %s:%d, %s", comment.path, comment.line, err.Error())
+	}
+
+	return posted, skipped, nil
+}
+
+func formatReviewComment(finding *Finding) string {
+	return fmt.Sprintf("**protolinter** %s `%s`: %s\n\n%s",
+		finding.Severity,
+		finding.Check,
+		finding.Message,
+		reviewCommentMarker)
+}
+
+func (c *githubReviewClient) listComments(ctx context.Context) ([]*reviewComment, error) {
+	var result []*reviewComment
+
+	for page := 1; ; page++ {
+		var comments []*githubReviewComment
+
+		err := c.api.do(ctx,
+			http.MethodGet,
+			fmt.Sprintf("/repos/%s/pulls/%d/comments?per_page=%d&page=%d", c.repository, c.number, reviewPageSize, page),
+			nil,
+			&comments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range comments {
+			// Comments on lines changed by later commits keep only the original line.
+			line := comment.Line
+			if line == 0 {
+				line = comment.OriginalLine
+			}
+
+			result = append(result, &reviewComment{
+				path: comment.Path,
+				line: line,
+				body: comment.Body,
+			})
+		}
+
+		if len(comments) < reviewPageSize {
+			return result, nil
+		}
+	}
+}
+
+func (c *githubReviewClient) postComment(ctx context.Context, comment *reviewComment) error {
+	if c.commitID == "" {
+		var pullRequest struct {
+			Head struct {
+				SHA string `json:"sha"`
+			} `json:"head"`
+		}
+
+		err := c.api.do(ctx,
+			http.MethodGet,
+			fmt.Sprintf("/repos/%s/pulls/%d", c.repository, c.number),
+			nil,
+			&pullRequest)
+		if err != nil {
+			return err
+		}
+
+		c.commitID = pullRequest.Head.SHA
+	}
+
+	return c.api.do(ctx,
+		http.MethodPost,
+		fmt.Sprintf("/repos/%s/pulls/%d/comments", c.repository, c.number),
+		&githubReviewComment{
+			Body:     comment.body,
+			Path:     comment.path,
+			Line:     comment.line,
+			CommitID: c.commitID,
+			Side:     "RIGHT",
+		},
+		nil)
+}
+
+func (c *gitlabReviewClient) listComments(ctx context.Context) ([]*reviewComment, error) {
+	var result []*reviewComment
+
+	for page := 1; ; page++ {
+		var discussions []*gitlabDiscussion
+
+		err := c.api.do(ctx,
+			http.MethodGet,
+			fmt.Sprintf("/projects/%s/merge_requests/%d/discussions?per_page=%d&page=%d",
+				url.PathEscape(c.project),
+				c.number,
+				reviewPageSize,
+				page),
+			nil,
+			&discussions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, discussion := range discussions {
+			for _, note := range discussion.Notes {
+				if note.Position == nil {
+					continue
+				}
+
+				result = append(result, &reviewComment{
+					path: note.Position.NewPath,
+					line: note.Position.NewLine,
+					body: note.Body,
+				})
+			}
+		}
+
+		if len(discussions) < reviewPageSize {
+			return result, nil
+		}
+	}
+}
+
+func (c *gitlabReviewClient) postComment(ctx context.Context, comment *reviewComment) error {
+	if c.diffRefs == nil {
+		var mergeRequest struct {
+			DiffRefs *gitlabDiffRefs `json:"diff_refs"`
+		}
+
+		err := c.api.do(ctx,
+			http.MethodGet,
+			fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(c.project), c.number),
+			nil,
+			&mergeRequest)
+		if err != nil {
+			return err
+		}
+
+		if mergeRequest.DiffRefs == nil {
+			return errors.New("merge request has no diff references")
+		}
+
+		c.diffRefs = mergeRequest.DiffRefs
+	}
+
+	return c.api.do(ctx,
+		http.MethodPost,
+		fmt.Sprintf("/projects/%s/merge_requests/%d/discussions", url.PathEscape(c.project), c.number),
+		&gitlabNote{
+			Body: comment.body,
+			Position: &gitlabPosition{
+				PositionType: "text",
+				BaseSHA:      c.diffRefs.BaseSHA,
+				StartSHA:     c.diffRefs.StartSHA,
+				HeadSHA:      c.diffRefs.HeadSHA,
+				OldPath:      comment.path,
+				NewPath:      comment.path,
+				NewLine:      comment.line,
+			},
+		},
+		nil)
+}
+
+// do sends the request with the JSON-encoded body and decodes the JSON response into the result.
+func (a *reviewAPI) do(ctx context.Context, method, path string, body, result any) error {
+	var requestBody io.Reader
+
+	if body != nil {
+		encodedBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		requestBody = bytes.NewReader(encodedBody)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(a.baseURL, "/")+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	for k, v := range a.headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return &reviewAPIError{
+			statusCode: response.StatusCode,
+			message:    strings.TrimSpace(string(responseBody)),
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, result)
+}
+
+// Error returns the description of the API error.
+func (e *reviewAPIError) Error() string {
+	return fmt.Sprintf("API responded with status %d: %s", e.statusCode, e.message)
+}
+
+// isRejected reports whether the comment itself was rejected, e.g. because its line isn't part of the diff,
+// rather than the whole request failed.
+func (e *reviewAPIError) isRejected() bool {
+	return e.statusCode == http.StatusBadRequest || e.statusCode == http.StatusUnprocessableEntity
+}
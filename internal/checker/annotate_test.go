@@ -0,0 +1,158 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPostReviewCommentsGitHub(t *testing.T) {
+	var (
+		alreadyPosted = &Finding{Check: "a", Severity: "error", Message: "old", Path: "api/a.proto", Line: 3}
+		newFinding    = &Finding{Check: "b", Severity: "warning", Message: "new", Path: "api/a.proto", Line: 5}
+		outsideDiff   = &Finding{Check: "c", Severity: "error", Message: "outside", Path: "api/a.proto", Line: 9}
+		withoutLine   = &Finding{Check: "d", Severity: "error", Message: "no line", Path: "api/a.proto"}
+		postedBodies  []*githubReviewComment
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/o/r/pulls/7", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"head": {"sha": "abc"}}`))
+	})
+
+	mux.HandleFunc("/repos/o/r/pulls/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode([]*githubReviewComment{
+				{Path: "api/a.proto", OriginalLine: 3, Body: formatReviewComment(alreadyPosted)},
+				{Path: "api/a.proto", Line: 5, Body: "Written by a human"},
+			})
+
+			return
+		}
+
+		var comment *githubReviewComment
+		if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+			t.Fatal(err)
+		}
+
+		if comment.Line == outsideDiff.Line {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		postedBodies = append(postedBodies, comment)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &githubReviewClient{
+		api: &reviewAPI{
+			baseURL: server.URL,
+			client:  server.Client(),
+		},
+		repository: "o/r",
+		number:     7,
+	}
+
+	posted, skipped, err := postReviewComments(
+		context.Background(),
+		client,
+		[]*Finding{alreadyPosted, newFinding, newFinding, outsideDiff, withoutLine})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if posted != 1 || skipped != 2 {
+		t.Errorf("expected 1 posted and 2 skipped comments, got %d and %d", posted, skipped)
+	}
+
+	expectedComments := []*githubReviewComment{
+		{
+			Body:     formatReviewComment(newFinding),
+			Path:     "api/a.proto",
+			Line:     5,
+			CommitID: "abc",
+			Side:     "RIGHT",
+		},
+	}
+	if !reflect.DeepEqual(postedBodies, expectedComments) {
+		t.Errorf("expected comments %+v, got %+v", expectedComments, postedBodies)
+	}
+}
+
+func TestPostReviewCommentsGitLab(t *testing.T) {
+	var (
+		finding      = &Finding{Check: "a", Severity: "error", Message: "new", Path: "api/a.proto", Line: 3}
+		postedBodies []*gitlabNote
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/projects/group/project/merge_requests/7", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"diff_refs": {"base_sha": "b", "start_sha": "s", "head_sha": "h"}}`))
+	})
+
+	mux.HandleFunc("/projects/group/project/merge_requests/7/discussions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"notes": [{"body": "General note"}]}]`))
+
+			return
+		}
+
+		if r.URL.RawPath == "" {
+			t.Errorf("expected escaped project path, got %s", r.URL.Path)
+		}
+
+		var note *gitlabNote
+		if err := json.NewDecoder(r.Body).Decode(&note); err != nil {
+			t.Fatal(err)
+		}
+
+		postedBodies = append(postedBodies, note)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &gitlabReviewClient{
+		api: &reviewAPI{
+			baseURL: server.URL,
+			client:  server.Client(),
+		},
+		project: "group/project",
+		number:  7,
+	}
+
+	posted, skipped, err := postReviewComments(context.Background(), client, []*Finding{finding})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if posted != 1 || skipped != 0 {
+		t.Errorf("expected 1 posted and 0 skipped comments, got %d and %d", posted, skipped)
+	}
+
+	expectedNotes := []*gitlabNote{
+		{
+			Body: formatReviewComment(finding),
+			Position: &gitlabPosition{
+				PositionType: "text",
+				BaseSHA:      "b",
+				StartSHA:     "s",
+				HeadSHA:      "h",
+				OldPath:      "api/a.proto",
+				NewPath:      "api/a.proto",
+				NewLine:      3,
+			},
+		},
+	}
+	if !reflect.DeepEqual(postedBodies, expectedNotes) {
+		t.Errorf("expected notes %+v, got %+v", expectedNotes, postedBodies)
+	}
+}
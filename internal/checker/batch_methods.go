@@ -0,0 +1,117 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	batchGetVerb    = "BatchGet"
+	batchCreateVerb = "BatchCreate"
+
+	batchRequestsFieldName = "requests"
+	// validateMaxItemsKey is the key of the maximum number of items of a repeated field
+	// among the parsed values of validation rules.
+	validateMaxItemsKey = "repeated.maxItems"
+)
+
+var (
+	// batchGetRequestFieldNames are the names of the repeated field of a batch get request
+	// holding the identifiers of the resources.
+	batchGetRequestFieldNames = []string{"names", "ids"}
+	// validateOptionNames are the full names of field options holding validation rules.
+	validateOptionNames = []string{"validate.rules", "buf.validate.field"}
+)
+
+// checkBatchMethod checks that methods named like BatchGetOrdersV1 and BatchCreateOrdersV1 follow AIP-231 and AIP-233:
+// the request has a repeated names (or ids) or requests field, the response has a repeated orders field,
+// and validation rules of the repeated request field, if any, limit the number of items.
+func (c *ProtoChecker) checkBatchMethod(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	var (
+		methodName   = string(method.Name())
+		requestNames []string
+		verb         string
+	)
+
+	switch {
+	case hasVerbPrefix(methodName, batchGetVerb):
+		verb, requestNames = batchGetVerb, batchGetRequestFieldNames
+	case hasVerbPrefix(methodName, batchCreateVerb):
+		verb, requestNames = batchCreateVerb, []string{batchRequestsFieldName}
+	default:
+		return
+	}
+
+	resourcesName := methodVersionSuffixRegexp.ReplaceAllString(strings.TrimPrefix(methodName, verb), "")
+	if resourcesName == "" {
+		return
+	}
+
+	c.runRule(MethodBatchConventions, method, func() {
+		var requestField protoreflect.FieldDescriptor
+
+		for _, name := range requestNames {
+			if field := method.Input().Fields().ByName(protoreflect.Name(name)); field != nil && field.IsList() {
+				requestField = field
+
+				break
+			}
+		}
+
+		if requestField == nil {
+			result.AddFindingf(
+				MethodBatchConventions,
+				method,
+				"Request %s of method %s doesn't have repeated field %s",
+				method.Input().FullName(),
+				methodLogName,
+				strings.Join(requestNames, " or "))
+		} else if hasFieldOption(requestField, validateOptionNames) && !hasValidateMaxItems(requestField) {
+			result.AddFindingf(
+				MethodBatchConventions,
+				method,
+				"Field %s of request %s of method %s has validation rules, but doesn't limit the number of items",
+				requestField.Name(),
+				method.Input().FullName(),
+				methodLogName)
+		}
+
+		responseName := strings.Join(splitWords(resourcesName), "_")
+		if field := method.Output().Fields().ByName(protoreflect.Name(responseName)); field == nil ||
+			!field.IsList() || field.Message() == nil {
+			result.AddFindingf(
+				MethodBatchConventions,
+				method,
+				"Response %s of method %s doesn't have repeated message field %s",
+				method.Output().FullName(),
+				methodLogName,
+				responseName)
+		}
+	})
+}
+
+// hasValidateMaxItems returns true if validation rules of the field limit the number of its items.
+func hasValidateMaxItems(field protoreflect.FieldDescriptor) bool {
+	var found bool
+
+	field.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if !containsString(validateOptionNames, string(fd.FullName())) || fd.Message() == nil {
+				return true
+			}
+
+			if parser.NewOptionValues(v.Message()).Has(validateMaxItemsKey) {
+				found = true
+			}
+
+			return !found
+		})
+
+	return found
+}
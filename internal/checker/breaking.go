@@ -0,0 +1,278 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// BreakingCheck is the name findings of the breaking task are reported with.
+const BreakingCheck = "breaking"
+
+// breakingComparer collects changes of the compiled files breaking compatibility with their baselines.
+type breakingComparer struct {
+	config   *config.Config
+	findings []*Finding
+}
+
+// FindBreakingChanges compares the provided protobuf files with their versions in the baseline descriptor set
+// and returns the changes breaking wire or JSON compatibility as findings, in the order of declarations.
+// Files missing from the baseline are new, so they can't break anything and are skipped.
+func (c *ProtoChecker) FindBreakingChanges(ctx context.Context, files ...string) ([]*Finding, error) {
+	baseline, err := readDescriptorSet(c.config.GetBreakingAgainst())
+	if err != nil {
+		return nil, err
+	}
+
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := compileCacheFromContext(ctx).compile(ctx, c.compiler, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	comparer := &breakingComparer{
+		config: c.config,
+	}
+
+	for _, parsedFile := range parsedFiles {
+		previous, err := baseline.FindFileByPath(parsedFile.Path())
+		if err != nil {
+			continue
+		}
+
+		comparer.compareFiles(previous, parsedFile)
+	}
+
+	return comparer.findings, nil
+}
+
+// readDescriptorSet reads the binary FileDescriptorSet from the file.
+// Imports missing from the set are replaced by placeholders, so sets built without imports can be read too.
+func readDescriptorSet(filename string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set %s: %w", filename, err)
+	}
+
+	set := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set %s: %w", filename, err)
+	}
+
+	result, err := protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load descriptor set %s: %w", filename, err)
+	}
+
+	return result, nil
+}
+
+func (b *breakingComparer) compareFiles(previous, current protoreflect.FileDescriptor) {
+	if previous.Package() != current.Package() {
+		b.addFinding(current, "Package of file %s changed from %s to %s",
+			current.Path(),
+			previous.Package(),
+			current.Package())
+	}
+
+	b.compareMessages(current, previous.Messages(), current.Messages())
+	b.compareEnums(current, previous.Enums(), current.Enums())
+
+	for i := 0; i < previous.Services().Len(); i++ {
+		previousService := previous.Services().Get(i)
+
+		currentService := current.Services().ByName(previousService.Name())
+		if currentService == nil {
+			b.addFinding(current, "Service %s was removed", previousService.FullName())
+
+			continue
+		}
+
+		b.compareServices(previousService, currentService)
+	}
+}
+
+func (b *breakingComparer) compareServices(previous, current protoreflect.ServiceDescriptor) {
+	for i := 0; i < previous.Methods().Len(); i++ {
+		previousMethod := previous.Methods().Get(i)
+
+		currentMethod := current.Methods().ByName(previousMethod.Name())
+		if currentMethod == nil {
+			b.addFinding(current, "Method %s was removed", previousMethod.FullName())
+
+			continue
+		}
+
+		if previousMethod.Input().FullName() != currentMethod.Input().FullName() {
+			b.addFinding(currentMethod, "Input of method %s changed from %s to %s",
+				currentMethod.FullName(),
+				previousMethod.Input().FullName(),
+				currentMethod.Input().FullName())
+		}
+
+		if previousMethod.Output().FullName() != currentMethod.Output().FullName() {
+			b.addFinding(currentMethod, "Output of method %s changed from %s to %s",
+				currentMethod.FullName(),
+				previousMethod.Output().FullName(),
+				currentMethod.Output().FullName())
+		}
+
+		if previousMethod.IsStreamingClient() != currentMethod.IsStreamingClient() ||
+			previousMethod.IsStreamingServer() != currentMethod.IsStreamingServer() {
+			b.addFinding(currentMethod, "Streaming of method %s changed", currentMethod.FullName())
+		}
+	}
+}
+
+func (b *breakingComparer) compareMessages(
+	parent protoreflect.Descriptor,
+	previous, current protoreflect.MessageDescriptors,
+) {
+	for i := 0; i < previous.Len(); i++ {
+		previousMessage := previous.Get(i)
+		// Map entries change together with their fields.
+		if previousMessage.IsMapEntry() {
+			continue
+		}
+
+		currentMessage := current.ByName(previousMessage.Name())
+		if currentMessage == nil {
+			b.addFinding(parent, "Message %s was removed", previousMessage.FullName())
+
+			continue
+		}
+
+		b.compareFields(previousMessage, currentMessage)
+		b.compareMessages(currentMessage, previousMessage.Messages(), currentMessage.Messages())
+		b.compareEnums(currentMessage, previousMessage.Enums(), currentMessage.Enums())
+	}
+}
+
+func (b *breakingComparer) compareFields(previous, current protoreflect.MessageDescriptor) {
+	for i := 0; i < previous.Fields().Len(); i++ {
+		previousField := previous.Fields().Get(i)
+
+		currentField := current.Fields().ByNumber(previousField.Number())
+		if currentField == nil && current.ReservedRanges().Has(previousField.Number()) {
+			continue
+		}
+
+		if currentField == nil {
+			b.addFinding(current, "Field %d (%s) of message %s was removed without reserving its number",
+				previousField.Number(),
+				previousField.Name(),
+				current.FullName())
+
+			continue
+		}
+
+		if previousField.Name() != currentField.Name() {
+			b.addFinding(currentField, "Field %d of message %s was renamed from %s to %s",
+				currentField.Number(),
+				current.FullName(),
+				previousField.Name(),
+				currentField.Name())
+		}
+
+		previousType, currentType := getFieldTypeName(previousField), getFieldTypeName(currentField)
+		if previousType != currentType {
+			b.addFinding(currentField, "Type of field %s changed from %s to %s",
+				currentField.FullName(),
+				previousType,
+				currentType)
+		}
+
+		if previousField.Cardinality() != currentField.Cardinality() {
+			b.addFinding(currentField, "Label of field %s changed from %s to %s",
+				currentField.FullName(),
+				previousField.Cardinality(),
+				currentField.Cardinality())
+		}
+	}
+}
+
+func (b *breakingComparer) compareEnums(
+	parent protoreflect.Descriptor,
+	previous, current protoreflect.EnumDescriptors,
+) {
+	for i := 0; i < previous.Len(); i++ {
+		previousEnum := previous.Get(i)
+
+		currentEnum := current.ByName(previousEnum.Name())
+		if currentEnum == nil {
+			b.addFinding(parent, "Enum %s was removed", previousEnum.FullName())
+
+			continue
+		}
+
+		for j := 0; j < previousEnum.Values().Len(); j++ {
+			previousValue := previousEnum.Values().Get(j)
+
+			currentValue := currentEnum.Values().ByNumber(previousValue.Number())
+			if currentValue == nil && currentEnum.ReservedRanges().Has(previousValue.Number()) {
+				continue
+			}
+
+			if currentValue == nil {
+				b.addFinding(currentEnum, "Value %d (%s) of enum %s was removed without reserving its number",
+					previousValue.Number(),
+					previousValue.Name(),
+					currentEnum.FullName())
+
+				continue
+			}
+
+			if previousValue.Name() != currentValue.Name() {
+				b.addFinding(currentValue, "Value %d of enum %s was renamed from %s to %s",
+					currentValue.Number(),
+					currentEnum.FullName(),
+					previousValue.Name(),
+					currentValue.Name())
+			}
+		}
+	}
+}
+
+// addFinding reports the change at the location of the descriptor,
+// removed descriptors are reported at the location of their parent.
+func (b *breakingComparer) addFinding(desc protoreflect.Descriptor, format string, args ...interface{}) {
+	var (
+		file    = desc.ParentFile()
+		finding = &Finding{
+			Check:    BreakingCheck,
+			Severity: config.SeverityError,
+			Message:  fmt.Sprintf(format, args...),
+			Path:     file.Path(),
+		}
+	)
+
+	if _, ok := desc.(protoreflect.FileDescriptor); !ok && !b.config.GetOmitCoordinates() {
+		if sl := file.SourceLocations().ByDescriptor(desc); sl.Path != nil {
+			// Source locations are zero-based, while editors and compilers count from one.
+			finding.Line = sl.StartLine + 1
+			finding.Column = sl.StartColumn + 1
+		}
+	}
+
+	b.findings = append(b.findings, finding)
+}
+
+// getFieldTypeName returns the name of the scalar kind of the field or the full name of its message or enum.
+func getFieldTypeName(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.Message() != nil:
+		return string(field.Message().FullName())
+	case field.Enum() != nil:
+		return string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
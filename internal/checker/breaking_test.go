@@ -0,0 +1,160 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFindBreakingChanges(t *testing.T) {
+	const (
+		baselineSource = `syntax = "proto3";
+
+package orders.v1;
+
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (Order);
+  rpc DeleteOrder(GetOrderRequest) returns (Order);
+}
+
+message GetOrderRequest {
+  string order_id = 1;
+  string trace_id = 2;
+}
+
+message Order {
+  string order_id = 1;
+  int64 amount = 2;
+  string comment = 3;
+  string legacy_code = 4;
+  Status status = 5;
+}
+
+message Receipt {
+  string order_id = 1;
+}
+
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_NEW = 1;
+  STATUS_DONE = 2;
+}
+`
+		currentSource = `syntax = "proto3";
+
+package orders.v1;
+
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (stream Order);
+}
+
+message GetOrderRequest {
+  string id = 1;
+}
+
+message Order {
+  reserved 4;
+
+  string order_id = 1;
+  string amount = 2;
+  repeated string comments = 3;
+  Status status = 5;
+  string note = 6;
+}
+
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_CREATED = 1;
+}
+`
+	)
+
+	var (
+		ctx          = context.Background()
+		dir          = t.TempDir()
+		fileName     = filepath.Join(dir, "orders.proto")
+		baselinePath = filepath.Join(dir, "baseline.binpb")
+	)
+
+	if err := os.WriteFile(fileName, []byte(baselineSource), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	baseline, err := NewProtoChecker(ctx, nil).compiler.Compile(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to compile baseline: %s", err.Error())
+	}
+
+	data, err := proto.Marshal(newDescriptorSet(baseline))
+	if err != nil {
+		t.Fatalf("failed to encode baseline: %s", err.Error())
+	}
+
+	if err = os.WriteFile(baselinePath, data, 0o600); err != nil {
+		t.Fatalf("failed to write baseline: %s", err.Error())
+	}
+
+	if err = os.WriteFile(fileName, []byte(currentSource), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	cfg := &config.Config{
+		Breaking: config.BreakingOptions{Against: baselinePath},
+	}
+
+	findings, err := NewProtoChecker(ctx, cfg).FindBreakingChanges(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to find breaking changes: %s", err.Error())
+	}
+
+	// The reserved field 4 and the added field 6 aren't breaking,
+	// removed top-level declarations are reported without coordinates.
+	expected := []string{
+		"10:3: Field 1 of message orders.v1.GetOrderRequest was renamed from order_id to id",
+		"9:1: Field 2 (trace_id) of message orders.v1.GetOrderRequest was removed without reserving its number",
+		"17:3: Type of field orders.v1.Order.amount changed from int64 to string",
+		"18:3: Field 3 of message orders.v1.Order was renamed from comment to comments",
+		"18:3: Label of field orders.v1.Order.comments changed from optional to repeated",
+		"Message orders.v1.Receipt was removed",
+		"25:3: Value 1 of enum orders.v1.Status was renamed from STATUS_NEW to STATUS_CREATED",
+		"23:1: Value 2 (STATUS_DONE) of enum orders.v1.Status was removed without reserving its number",
+		"6:3: Streaming of method orders.v1.OrderService.GetOrder changed",
+		"5:1: Method orders.v1.OrderService.DeleteOrder was removed",
+	}
+
+	if len(findings) != len(expected) {
+		for _, finding := range findings {
+			t.Log(finding.String())
+		}
+
+		t.Fatalf("expected %d findings, got %d", len(expected), len(findings))
+	}
+
+	for i, finding := range findings {
+		if finding.Check != BreakingCheck || finding.Severity != config.SeverityError {
+			t.Errorf("unexpected check %s with severity %s", finding.Check, finding.Severity)
+		}
+
+		if actual := strings.TrimPrefix(finding.String(), fileName+":"); actual != expected[i] {
+			t.Errorf("expected finding %s, got %s", expected[i], actual)
+		}
+	}
+}
+
+func TestFindBreakingChangesWithoutBaseline(t *testing.T) {
+	var (
+		ctx = context.Background()
+		cfg = &config.Config{
+			Breaking: config.BreakingOptions{Against: filepath.Join(t.TempDir(), "missing.binpb")},
+		}
+	)
+
+	if _, err := NewProtoChecker(ctx, cfg).FindBreakingChanges(ctx, "orders.proto"); err == nil {
+		t.Error("expected error for missing baseline descriptor set")
+	}
+}
@@ -0,0 +1,68 @@
+package checker
+
+import (
+	"sort"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// isRunFailed returns true if the results fail the run.
+// Files checked with a configuration limiting findings per package fail it only if their packages
+// exceed the budgets, packages without a budget and files checked without budgets fail it on any error.
+func isRunFailed(results []*CheckResult) bool {
+	for _, result := range results {
+		if !result.config.HasFindingsBudgets() && result.HasErrors() {
+			return true
+		}
+	}
+
+	return len(findBudgetBreaches(results)) > 0
+}
+
+// findBudgetBreaches tallies the error findings per package and returns the packages having more of them
+// than max_findings_per_package of their configuration allows, sorted by package.
+// Files checked with a configuration without budgets aren't tallied,
+// packages matching no key of the budgets have the budget of zero, so they fail the run on any error.
+func findBudgetBreaches(results []*CheckResult) []*BudgetBreach {
+	var (
+		result   []*BudgetBreach
+		packages = make(map[string]*BudgetBreach)
+	)
+
+	for _, checkResult := range results {
+		if !checkResult.config.HasFindingsBudgets() {
+			continue
+		}
+
+		packageName := string(checkResult.File.Package())
+
+		breach, ok := packages[packageName]
+		if !ok {
+			budget, _ := checkResult.config.GetPackageFindingsBudget(packageName)
+
+			breach = &BudgetBreach{
+				Package: packageName,
+				Budget:  budget,
+			}
+			packages[packageName] = breach
+		}
+
+		for _, finding := range checkResult.Findings {
+			if finding.Severity == config.SeverityError {
+				breach.Findings++
+			}
+		}
+	}
+
+	for _, breach := range packages {
+		if breach.Findings > breach.Budget {
+			result = append(result, breach)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Package < result[j].Package
+	})
+
+	return result
+}
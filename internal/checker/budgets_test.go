@@ -0,0 +1,150 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestFindBudgetBreaches(t *testing.T) {
+	const header = "syntax = \"proto3\";\n\n"
+
+	var (
+		dir        = t.TempDir()
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+		legacy     = compileTriageFile(t, filepath.Join(dir, "legacy.proto"), header+"package legacy.orders;\n")
+		orders     = compileTriageFile(t, filepath.Join(dir, "orders.proto"), header+"package orders;\n")
+	)
+
+	source := "max_findings_per_package:\n  default: 0\n  \"legacy.*\": 2\n"
+	if err := os.WriteFile(configPath, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	newResult := func(file *CheckResult, severities ...config.Severity) *CheckResult {
+		for _, severity := range severities {
+			file.Findings = append(file.Findings, &Finding{Check: MessageNotEmpty, Severity: severity})
+		}
+
+		return file
+	}
+
+	results := []*CheckResult{
+		newResult(NewCheckResult(legacy, cfg), config.SeverityError, config.SeverityError, config.SeverityWarning),
+		newResult(NewCheckResult(orders, cfg), config.SeverityWarning),
+	}
+
+	if isRunFailed(results) {
+		t.Error("run within budgets is failed")
+	}
+
+	results = append(results, newResult(NewCheckResult(orders, cfg), config.SeverityError))
+
+	breaches := findBudgetBreaches(results)
+	if len(breaches) != 1 || *breaches[0] != (BudgetBreach{Package: "orders", Findings: 1, Budget: 0}) {
+		t.Fatalf("got breaches %+v, want orders with 1 finding", breaches)
+	}
+
+	if !isRunFailed(results) {
+		t.Error("run exceeding a budget isn't failed")
+	}
+
+	if !isRunFailed([]*CheckResult{newResult(NewCheckResult(orders, nil), config.SeverityError)}) {
+		t.Error("run without budgets isn't failed on errors")
+	}
+}
+
+func TestFindBudgetBreachesWithoutDefault(t *testing.T) {
+	const header = "syntax = \"proto3\";\n\n"
+
+	var (
+		dir        = t.TempDir()
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+		legacy     = compileTriageFile(t, filepath.Join(dir, "legacy.proto"), header+"package legacy.orders;\n")
+		orders     = compileTriageFile(t, filepath.Join(dir, "orders.proto"), header+"package orders.v1;\n")
+	)
+
+	if err := os.WriteFile(configPath, []byte("max_findings_per_package:\n  \"legacy.*\": 50\n"), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	legacyResult := NewCheckResult(legacy, cfg)
+	legacyResult.Findings = append(legacyResult.Findings, &Finding{Check: MessageNotEmpty, Severity: config.SeverityError})
+
+	if isRunFailed([]*CheckResult{legacyResult}) {
+		t.Error("run within budgets is failed")
+	}
+
+	ordersResult := NewCheckResult(orders, cfg)
+	ordersResult.Findings = append(ordersResult.Findings, &Finding{Check: MessageNotEmpty, Severity: config.SeverityError})
+
+	breaches := findBudgetBreaches([]*CheckResult{legacyResult, ordersResult})
+	if len(breaches) != 1 || *breaches[0] != (BudgetBreach{Package: "orders.v1", Findings: 1, Budget: 0}) {
+		t.Fatalf("got breaches %+v, want orders.v1 with 1 finding", breaches)
+	}
+
+	if !isRunFailed([]*CheckResult{legacyResult, ordersResult}) {
+		t.Error("run with errors of a package without a budget isn't failed")
+	}
+}
+
+func TestFailFastWithinBudgets(t *testing.T) {
+	const source = "syntax = \"proto3\";\n\npackage %s;\n\nmessage Order {}\n"
+
+	var (
+		ctx        = context.Background()
+		dir        = t.TempDir()
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+		files      = []string{filepath.Join(dir, "a.proto"), filepath.Join(dir, "b.proto")}
+	)
+
+	for i, file := range files {
+		if err := os.WriteFile(file, []byte(fmt.Sprintf(source, []string{"a", "b"}[i])), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err.Error())
+		}
+	}
+
+	for _, test := range []struct {
+		budget   int
+		expected int
+	}{
+		{budget: 100, expected: 2},
+		{budget: 0, expected: 1},
+	} {
+		budgets := fmt.Sprintf("max_findings_per_package:\n  default: %d\n", test.budget)
+		if err := os.WriteFile(configPath, []byte(budgets), 0o600); err != nil {
+			t.Fatalf("failed to write configuration: %s", err.Error())
+		}
+
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("failed to load configuration: %s", err.Error())
+		}
+
+		checker := NewProtoChecker(ctx, cfg)
+		checker.failFast = true
+
+		results, err := checker.CheckFiles(ctx, files...)
+		if err != nil {
+			t.Fatalf("failed to check files: %s", err.Error())
+		}
+
+		if len(results) != test.expected {
+			t.Errorf("budget %d: got %d checked files, want %d", test.budget, len(results), test.expected)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package checker
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/bufbuild/protocompile/linker"
 	"github.com/oshokin/protolinter/internal/config"
@@ -23,41 +25,148 @@ func (c *CheckResult) AddMessage(v string) {
 
 // AddMessagef appends a formatted informational message to the CheckResult's messages.
 func (c *CheckResult) AddMessagef(format string, args ...any) {
-	c.Messages = append(c.Messages, fmt.Sprintf(format, args...))
+	c.Messages = append(c.Messages, fmt.Sprintf(c.localize(format), args...))
 }
 
-// AddError appends an error message to the CheckResult's errors.
-func (c *CheckResult) AddError(desc protoreflect.Descriptor, v string) {
-	message := v
-	if !c.config.GetOmitCoordinates() {
-		message = c.appendErrorLocation(desc, v)
+// AddFinding appends a failed check to the CheckResult's findings.
+// The severity of the finding is determined by the configuration
+// effective for the descriptor, the finding is dropped if the check is excluded there.
+func (c *CheckResult) AddFinding(check string, desc protoreflect.Descriptor, v string) {
+	var location protoreflect.SourceLocation
+	if desc != nil {
+		location = c.File.SourceLocations().ByDescriptor(desc)
 	}
 
-	c.Errors = append(c.Errors, message)
+	c.AddFindingAt(check, desc, location, v)
 }
 
-// AddErrorf appends a formatted error message to the CheckResult's errors.
-func (c *CheckResult) AddErrorf(desc protoreflect.Descriptor, format string, args ...any) {
-	c.AddError(desc, fmt.Sprintf(format, args...))
+// AddFindingAt appends a failed check located at the specified source location,
+// used for elements that aren't descriptors themselves, such as extension ranges.
+// The descriptor determines the effective configuration, as in AddFinding.
+func (c *CheckResult) AddFindingAt(
+	check string,
+	desc protoreflect.Descriptor,
+	location protoreflect.SourceLocation,
+	v string,
+) {
+	fullName := string(c.File.Package())
+	if desc != nil {
+		fullName = string(desc.FullName())
+	}
+
+	cfg := c.config.ForScope(c.File.Path(), fullName)
+	if cfg.IsCheckExcluded(check) || !cfg.IsDescriptorTargeted(targetingName(desc, fullName)) {
+		return
+	}
+
+	if c.suppresses(check, desc) {
+		return
+	}
+
+	severity, upcoming := cfg.GetCheckSeverity(check, time.Now())
+	if upcoming != nil {
+		v = fmt.Sprintf(c.localize("%s (will be escalated from %s to %s after %s)"),
+			v,
+			upcoming.From,
+			upcoming.To,
+			upcoming.After)
+	}
+
+	finding := &Finding{
+		Check:    check,
+		Severity: severity,
+		Message:  v,
+		Path:     c.File.Path(),
+	}
+
+	if isFixedByFormatting(cfg, check) {
+		finding.Fix = FixFormat
+	}
+
+	if _, ok := desc.(protoreflect.FileDescriptor); !ok {
+		finding.descriptor = desc
+	}
+
+	c.fillFindingLocation(finding, location)
+
+	c.Findings = append(c.Findings, finding)
+	c.coverage.fail(check)
+}
+
+// AddFindingf appends a failed check with a formatted message to the CheckResult's findings.
+// The format is translated to the locale of the configuration, the check name is kept as is.
+func (c *CheckResult) AddFindingf(check string, desc protoreflect.Descriptor, format string, args ...any) {
+	c.AddFinding(check, desc, fmt.Sprintf(c.localize(format), args...))
 }
 
-// appendErrorLocation appends error location information to the error message if available.
-func (c *CheckResult) appendErrorLocation(desc protoreflect.Descriptor, message string) string {
-	var (
-		fileSourceLocations = c.File.SourceLocations()
-		sl                  = fileSourceLocations.ByDescriptor(desc)
-		row                 int
-		column              int
-	)
+// AddFindingAtf appends a failed check with a formatted message located at the specified source location.
+func (c *CheckResult) AddFindingAtf(
+	check string,
+	desc protoreflect.Descriptor,
+	location protoreflect.SourceLocation,
+	format string,
+	args ...any,
+) {
+	c.AddFindingAt(check, desc, location, fmt.Sprintf(c.localize(format), args...))
+}
 
-	if sl.Path != nil {
-		row = sl.StartLine
-		column = sl.StartColumn
+// AddWarningf appends a failed check with a formatted message reported as a warning
+// regardless of the severity of the check, used for findings a check considers less harmful.
+func (c *CheckResult) AddWarningf(check string, desc protoreflect.Descriptor, format string, args ...any) {
+	count := len(c.Findings)
+
+	c.AddFindingf(check, desc, format, args...)
+
+	if len(c.Findings) > count {
+		c.Findings[count].Severity = config.SeverityWarning
 	}
+}
+
+// localize returns the translation of the message or its format to the locale of the configuration.
+func (c *CheckResult) localize(v string) string {
+	return localize(c.config.GetLocale(), v)
+}
+
+// HasErrors returns true if any of the findings has the error severity.
+func (c *CheckResult) HasErrors() bool {
+	for _, finding := range c.Findings {
+		if finding.Severity == config.SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the finding as a human-readable message,
+// prefixed with its location unless coordinates are omitted.
+func (f *Finding) String() string {
+	if f.Line > 0 && f.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", f.Path, f.Line, f.Column, f.Message)
+	}
+
+	return f.Message
+}
+
+// fillFindingLocation fills the source location in the finding if available.
+func (c *CheckResult) fillFindingLocation(finding *Finding, sl protoreflect.SourceLocation) {
+	if c.config.GetOmitCoordinates() || sl.Path == nil {
+		return
+	}
+
+	// Source locations are zero-based, while editors and compilers count from one.
+	finding.Line = sl.StartLine + 1
+	finding.Column = sl.StartColumn + 1
+}
 
-	if row > 0 && column > 0 {
-		return fmt.Sprintf("%s:%d:%d: %s", c.File.Path(), row, column, message)
+// targetingName returns the name the descriptor is matched against targeted prefixes with.
+// Enum values are scoped within the package rather than the enum, so they are matched
+// as if they were nested in their enum, and targeting an enum targets its values.
+func targetingName(desc protoreflect.Descriptor, fullName string) string {
+	enumValue, ok := desc.(protoreflect.EnumValueDescriptor)
+	if !ok {
+		return fullName
 	}
 
-	return message
+	return strings.Join([]string{string(enumValue.Parent().FullName()), string(enumValue.Name())}, ".")
 }
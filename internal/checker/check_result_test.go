@@ -0,0 +1,66 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindingCoordinatesAreOneBased(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package orders.v1;
+
+message Order {}
+`
+
+	var (
+		ctx      = context.Background()
+		fileName = filepath.Join(t.TempDir(), "orders.proto")
+	)
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	results, err := NewProtoChecker(ctx, nil).CheckFiles(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to check file: %s", err.Error())
+	}
+
+	var finding *Finding
+
+	for _, result := range results {
+		for _, v := range result.Findings {
+			if v.Check == MessageNotEmpty {
+				finding = v
+			}
+		}
+	}
+
+	if finding == nil {
+		t.Fatalf("expected %s finding", MessageNotEmpty)
+	}
+
+	// The message starts at the first column of the fifth line, the location must not be dropped.
+	if finding.Line != 5 || finding.Column != 1 {
+		t.Errorf("expected finding at 5:1, got %d:%d", finding.Line, finding.Column)
+	}
+
+	if expected := fileName + ":5:1: "; !strings.HasPrefix(finding.String(), expected) {
+		t.Errorf("expected text finding prefixed with %s, got %s", expected, finding.String())
+	}
+
+	var buf bytes.Buffer
+	if err = json.NewEncoder(&buf).Encode(finding); err != nil {
+		t.Fatalf("failed to encode finding: %s", err.Error())
+	}
+
+	if !strings.Contains(buf.String(), `"line":5,"column":1`) {
+		t.Errorf("expected one-based coordinates in JSON, got %s", buf.String())
+	}
+}
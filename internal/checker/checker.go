@@ -3,15 +3,16 @@ package checker
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/bufbuild/protocompile"
 	"github.com/bufbuild/protocompile/linker"
 	"github.com/oshokin/protolinter/internal/config"
 	"github.com/oshokin/protolinter/internal/parser"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 const (
@@ -39,6 +40,107 @@ const (
 	FieldDescriptionEndsWithDot = "field_description_ends_with_dot"
 	// EnumValueHasComments checks if an enum value has leading comments.
 	EnumValueHasComments = "enum_value_has_comments"
+	// FieldDescriptionLength checks if a field's description length is within the configured bounds.
+	FieldDescriptionLength = "field_description_length"
+	// CommentStyleSyntax checks if documentation comments use the configured syntax.
+	CommentStyleSyntax = "comment_style_syntax"
+	// CommentStyleNoTrailing checks if descriptors aren't documented with trailing same-line comments.
+	CommentStyleNoTrailing = "comment_style_no_trailing"
+	// MessageNotEmpty checks if a message has fields unless it's used by a method or named like *Empty.
+	MessageNotEmpty = "message_not_empty"
+	// DescriptorIsReferenced checks if a message or enum is referenced within the checked files.
+	DescriptorIsReferenced = "descriptor_is_referenced"
+	// MessageNoCycles checks if a message doesn't reference itself directly or through other messages.
+	MessageNoCycles = "message_no_cycles"
+	// NoExtensions checks if extensions and extension ranges are absent when they are forbidden.
+	NoExtensions = "no_extensions"
+	// ExtensionRangeDocumented checks if extension ranges have leading comments.
+	ExtensionRangeDocumented = "extension_range_documented"
+	// ServiceHasDefaultHost checks if a service has valid google.api.default_host and google.api.oauth_scopes options.
+	ServiceHasDefaultHost = "service_has_default_host"
+	// MethodHasIdempotencyLevel checks if a method has the idempotency_level option matching its HTTP verb.
+	MethodHasIdempotencyLevel = "method_has_idempotency_level"
+	// HTTPPathMaxDepth checks if an HTTP path doesn't have more segments than configured.
+	HTTPPathMaxDepth = "http_path_max_depth"
+	// HTTPPathResourceStyle checks if an HTTP path alternates collections and resource identifiers.
+	HTTPPathResourceStyle = "http_path_resource_style"
+	// MethodGetRequestFieldsBindable checks if request fields of a GET method can be bound from query parameters.
+	MethodGetRequestFieldsBindable = "method_get_request_fields_bindable"
+	// MethodIOSamePackage checks if method input and output messages are defined in the package of the service.
+	MethodIOSamePackage = "method_io_same_package"
+	// ImportBoundaries checks if a file doesn't depend on packages forbidden for its package.
+	ImportBoundaries = "import_boundaries"
+	// CommentNotTrivial checks if a field or enum value comment doesn't merely restate its name.
+	CommentNotTrivial = "comment_not_trivial"
+	// FileHasHeader checks if the first comment block of a file starts with the configured header.
+	FileHasHeader = "file_has_header"
+	// FileElementOrder checks if the package, imports, options and definitions of a file follow the canonical order.
+	FileElementOrder = "file_element_order"
+	// StyleMaxLineLength checks if lines of a file don't exceed the configured length.
+	StyleMaxLineLength = "style_max_line_length"
+	// StyleIndentation checks if lines of a file are indented with the configured characters and width.
+	StyleIndentation = "style_indentation"
+	// JSONNameIsLowerCamel checks if an explicitly set json_name is lowerCamelCase and doesn't collide with other fields.
+	JSONNameIsLowerCamel = "json_name_is_lower_camel"
+	// MessageNoJSONNameCollisions checks if no two fields of a message serialize to the same JSON key.
+	MessageNoJSONNameCollisions = "message_no_json_name_collisions"
+	// MethodGetRequestNoOneof checks if the request of a GET method doesn't contain oneofs.
+	MethodGetRequestNoOneof = "method_get_request_no_oneof"
+	// MapKeyTypeAllowed checks if a map uses one of the allowed key types.
+	MapKeyTypeAllowed = "map_key_type_allowed"
+	// DeprecatedFieldHasRemovalNote checks if a deprecated field has a comment with its removal date.
+	DeprecatedFieldHasRemovalNote = "deprecated_field_has_removal_note"
+	// DeprecationExpired checks if a deprecated descriptor is still declared after its removal date.
+	DeprecationExpired = "deprecation_expired"
+	// ServiceMethodsSingleVersion checks if all methods of a service share the same version.
+	ServiceMethodsSingleVersion = "service_methods_single_version"
+	// PackageVersionMatchesMethods checks if versions of methods match the version of their package.
+	PackageVersionMatchesMethods = "package_version_matches_methods"
+	// OptionNoEnvironmentURLs checks if string option values don't contain environment-specific URLs.
+	OptionNoEnvironmentURLs = "option_no_environment_urls"
+	// SwaggerExternalDocsValidURL checks if the external docs URL of a method is an absolute HTTPS URL.
+	SwaggerExternalDocsValidURL = "swagger_external_docs_valid_url"
+	// GoPackageMatchesModule checks if go_package points to an existing directory of the Go module.
+	GoPackageMatchesModule = "go_package_matches_module"
+	// DirectiveIsValid checks if suppression directives in comments name known checks and suppress findings.
+	DirectiveIsValid = "directive_is_valid"
+	// ResponseNoInputOnlyFields checks if field_behavior of fields agrees with the direction
+	// of the messages they are reachable from.
+	ResponseNoInputOnlyFields = "response_no_input_only_fields"
+	// FieldSensitiveDataAnnotation checks if fields named like sensitive data are marked by a sensitivity option.
+	FieldSensitiveDataAnnotation = "field_sensitive_data_annotation"
+	// FieldPIIDebugRedact checks if fields holding personal data are marked by debug_redact.
+	FieldPIIDebugRedact = "field_pii_debug_redact"
+	// MethodVerbMatchesHTTPMethod checks if the verb a method name starts with agrees with its HTTP method.
+	MethodVerbMatchesHTTPMethod = "method_verb_matches_http_method"
+	// MethodUpdateHasFieldMask checks if the request of an updating method has a FieldMask update_mask field.
+	MethodUpdateHasFieldMask = "method_update_has_field_mask"
+	// MethodCreateReturnsResource checks if a method named Create* returns the resource it creates.
+	MethodCreateReturnsResource = "method_create_returns_resource"
+	// MethodBatchConventions checks if batch get and batch create methods have the repeated fields
+	// AIP-231 and AIP-233 suggest.
+	MethodBatchConventions = "method_batch_conventions"
+	// MethodLROConventions checks if methods returning long-running operations declare the types
+	// of their responses and metadata.
+	MethodLROConventions = "method_lro_conventions"
+	// UnknownOptionDetected reports options set by extensions unknown to the linker.
+	UnknownOptionDetected = "unknown_option_detected"
+	// ServiceMaxMethods checks if a service doesn't have more methods than configured.
+	ServiceMaxMethods = "service_max_methods"
+	// PackageMaxServices checks if a package doesn't declare more services than configured.
+	PackageMaxServices = "package_max_services"
+	// IdentifierAcronymStyle checks if acronyms in names of services, methods, messages and enums
+	// are spelled in the configured style.
+	IdentifierAcronymStyle = "identifier_acronym_style"
+	// MethodIOColocated checks if method input and output messages are defined
+	// where the configured colocation policy expects them.
+	MethodIOColocated = "method_io_colocated"
+	// NoDeprecatedImports checks if a file doesn't import deprecated packages or files nor references their types.
+	NoDeprecatedImports = "no_deprecated_imports"
+	// FileMaxLines checks if a file doesn't have more lines than configured.
+	FileMaxLines = "file_max_lines"
+	// FileMaxMessages checks if a file doesn't declare more top-level messages than configured.
+	FileMaxMessages = "file_max_messages"
 )
 
 const validMethodNamePattern = `^[A-Z][A-Za-z0-9]*V\d+$`
@@ -46,12 +148,24 @@ const validMethodNamePattern = `^[A-Z][A-Za-z0-9]*V\d+$`
 var validMethodNameRegexp = regexp.MustCompile(validMethodNamePattern)
 
 // NewProtoChecker creates a new ProtoChecker instance.
-func NewProtoChecker(ctx context.Context, cfg *config.Config) *ProtoChecker {
+// Imports not found relative to the working directory are looked up in the import roots
+// before being downloaded.
+func NewProtoChecker(ctx context.Context, cfg *config.Config, importRoots ...string) *ProtoChecker {
+	var (
+		dependencies = newDependencyRegistry(cfg)
+		modules      = newModuleRegistry(cfg.GetModuleName())
+	)
+
 	result := &ProtoChecker{
 		compiler: &protocompile.Compiler{
-			Resolver:       protocompile.WithStandardImports(getSourceResolver(ctx, cfg)),
+			Resolver: protocompile.WithStandardImports(
+				getSourceResolver(ctx, cfg, dependencies, modules, importRoots)),
 			SourceInfoMode: protocompile.SourceInfoExtraComments | protocompile.SourceInfoExtraOptionLocations,
+			// ASTs are needed to tell line comments from block comments.
+			RetainASTs: true,
 		},
+		dependencies: dependencies,
+		modules:      modules,
 	}
 
 	result.config = cfg
@@ -61,258 +175,455 @@ func NewProtoChecker(ctx context.Context, cfg *config.Config) *ProtoChecker {
 
 // CheckFiles performs checks on the provided protobuf files and returns
 // a list of CheckResult instances, each containing the checking results for a single file.
+// In fail-fast mode, files following the first file with errors aren't checked.
 // It uses the compiler and parser associated with the ProtoChecker instance.
+// If the context carries a compile cache, files compiled by previous runs are reused.
 func (c *ProtoChecker) CheckFiles(ctx context.Context, files ...string) ([]*CheckResult, error) {
-	parsedFiles, err := c.compiler.Compile(ctx, files...)
+	c.modules.addForFiles(files)
+
+	compiler := *c.compiler
+	compiler.Resolver = c.progress.wrapResolver(compiler.Resolver, files)
+
+	parsedFiles, err := compileCacheFromContext(ctx).compile(ctx, &compiler, files)
+
+	c.dependencies.logSummary(ctx, c.config)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
 	}
 
-	result := make([]*CheckResult, 0, len(parsedFiles))
+	if c.optionExtensions == nil {
+		c.optionExtensions = c.loadOptionExtensions(ctx)
+	}
+
+	var (
+		result = make([]*CheckResult, 0, len(parsedFiles))
+		index  = newDescriptorIndex(parsedFiles)
+	)
 
 	for _, parsedFile := range parsedFiles {
-		result = append(result, c.checkFile(parsedFile))
+		c.progress.advance(progressStageChecking, parsedFile.Path())
+
+		fileResult := c.checkFile(parsedFile, index)
+		result = append(result, fileResult)
+
+		// Errors within the budgets of their packages don't stop the run.
+		if c.failFast && fileResult.HasErrors() && isRunFailed(result) {
+			break
+		}
 	}
 
 	return result, nil
 }
 
-func (c *ProtoChecker) checkFile(parsedFile linker.File) *CheckResult {
+// RemoteDependencies returns the list of proto dependencies
+// downloaded while compiling files, sorted by import path.
+func (c *ProtoChecker) RemoteDependencies() []*RemoteDependency {
+	return c.dependencies.list()
+}
+
+func (c *ProtoChecker) checkFile(parsedFile linker.File, index *descriptorIndex) *CheckResult {
 	result := NewCheckResult(parsedFile, c.config)
+	result.coverage = c.coverage
+	result.directives = collectDirectives(parsedFile)
 	packageName := string(parsedFile.Package().Name())
 	parsedFileFullName := string(parsedFile.FullName())
 
+	// Files not containing targeted descriptors are skipped silently.
+	if !c.config.IsDescriptorOnTargetPath(string(parsedFile.Package())) {
+		return result
+	}
+
 	if c.shouldDescriptorBeSkipped(parsedFileFullName) {
 		result.AddMessagef("Package %s is skipped", packageName)
 
 		return result
 	}
 
-	c.checkServices(parsedFile.Services(), result, parsedFileFullName)
-	c.checkMessages(parsedFile.Messages(), result, parsedFile)
-	c.checkEnums(parsedFile.Enums(), result, parsedFile)
+	c.checkFileHeader(parsedFile, result)
+	c.checkFileElementOrder(parsedFile, result)
+	c.checkStyleMaxLineLength(parsedFile, result)
+	c.checkStyleIndentation(parsedFile, result)
+	c.checkImportBoundaries(parsedFile, result)
+	c.checkDeprecatedImports(parsedFile, result)
+	c.checkGoPackage(parsedFile, result)
+	c.checkPackageMaxServices(parsedFile, result, index)
+	c.checkFileMaxLines(parsedFile, result)
+	c.checkFileMaxMessages(parsedFile, result)
+	c.checkEnvironmentURLs(parsedFile, result, "File", parsedFile.Path())
+	c.checkUnknownOptions(parsedFile, result, "File", parsedFile.Path())
+	Walk(parsedFile, &checkVisitor{
+		checker: c,
+		result:  result,
+		file:    parsedFile,
+		index:   index,
+	})
+	c.checkDirectives(parsedFile, result)
 
 	return result
 }
 
-func (c *ProtoChecker) checkServices(
-	services protoreflect.ServiceDescriptors,
-	result *CheckResult,
-	parsedFileFullName string,
-) {
-	servicesCount := services.Len()
-	for serviceIndex := 0; serviceIndex < servicesCount; serviceIndex++ {
-		service := services.Get(serviceIndex)
-		serviceName := string(service.Name())
-		serviceFullName := string(service.FullName())
+// EnterService runs the checks of the service, its methods are skipped along with it.
+func (v *checkVisitor) EnterService(service protoreflect.ServiceDescriptor) bool {
+	c, result := v.checker, v.result
 
-		if c.shouldDescriptorBeSkipped(serviceFullName) {
-			result.AddMessagef("Service %s is skipped", serviceName)
+	serviceName := string(service.Name())
+	serviceFullName := string(service.FullName())
 
-			continue
-		}
+	if !c.config.IsDescriptorOnTargetPath(serviceFullName) {
+		return false
+	}
 
-		c.checkMethods(service.Methods(), result, serviceName, servicesCount, parsedFileFullName)
+	if c.shouldDescriptorBeSkipped(serviceFullName) {
+		result.AddMessagef("Service %s is skipped", serviceName)
+
+		return false
 	}
+
+	c.checkServiceOptions(service, result, serviceName)
+	c.checkServiceMethodVersions(service, result, serviceName)
+	c.checkServiceMaxMethods(service, result, serviceName)
+	c.checkCommentStyle(service, result, "Service", serviceName)
+	c.checkAcronymStyle(service, result, "Service", serviceName)
+	c.checkDeprecationExpired(service, result, "Service", serviceName)
+	c.checkEnvironmentURLs(service, result, "Service", serviceName)
+	c.checkUnknownOptions(service, result, "Service", serviceName)
+
+	return true
 }
 
-func (c *ProtoChecker) checkMethods(methods protoreflect.MethodDescriptors,
-	result *CheckResult,
-	serviceName string,
-	servicesCount int,
-	parsedFileFullName string,
-) {
-	for methodIndex := 0; methodIndex < methods.Len(); methodIndex++ {
-		method := methods.Get(methodIndex)
-		methodName := string(method.Name())
-		methodFullName := string(method.FullName())
-		methodLogName := c.getNameForLogs(
-			parsedFileFullName,
-			serviceName,
-			servicesCount,
-			methodFullName)
-
-		if c.shouldDescriptorBeSkipped(methodFullName) {
-			result.AddMessagef("Method %s is skipped", methodLogName)
-
-			continue
-		}
+// Method runs the checks of the method.
+func (v *checkVisitor) Method(method protoreflect.MethodDescriptor) {
+	var (
+		c                  = v.checker
+		result             = v.result
+		serviceName        = string(method.Parent().Name())
+		servicesCount      = v.file.Services().Len()
+		parsedFileFullName = string(v.file.FullName())
+	)
+
+	methodName := string(method.Name())
+	methodFullName := string(method.FullName())
+	methodLogName := c.getNameForLogs(
+		parsedFileFullName,
+		serviceName,
+		servicesCount,
+		methodFullName)
+
+	if !c.config.IsDescriptorOnTargetPath(methodFullName) {
+		return
+	}
+
+	if c.shouldDescriptorBeSkipped(methodFullName) {
+		result.AddMessagef("Method %s is skipped", methodLogName)
 
-		isMethodNameCorrect := len(validMethodNameRegexp.FindStringIndex(methodName)) > 0
-		if !c.config.IsCheckExcluded(MethodHasVersion) &&
-			!isMethodNameCorrect {
-			result.AddErrorf(
+		return
+	}
+
+	isMethodNameCorrect := len(validMethodNameRegexp.FindStringIndex(methodName)) > 0
+	c.runRule(MethodHasVersion, method, func() {
+		if !isMethodNameCorrect {
+			result.AddFindingf(
+				MethodHasVersion,
 				method,
 				"Name of method %s doesn't match regular expression: %s",
 				methodLogName,
 				validMethodNamePattern)
 		}
+	})
 
-		inputName := string(method.Input().Name())
-		inputFullName := string(method.Input().FullName())
-
-		if !c.config.IsCheckExcluded(MethodHasCorrectInputName) &&
-			isMethodNameCorrect &&
-			inputFullName != "google.protobuf.Empty" {
-			expectedInputName := strings.Join([]string{methodName, "Request"}, "")
+	inputName := string(method.Input().Name())
+	inputFullName := string(method.Input().FullName())
 
-			if inputName != expectedInputName {
-				result.AddErrorf(
-					method,
-					"Input of method %s should be named as %s",
-					methodLogName,
-					expectedInputName)
-			}
+	c.runRule(MethodHasCorrectInputName, method, func() {
+		if !isMethodNameCorrect || inputFullName == "google.protobuf.Empty" {
+			return
 		}
 
-		c.checkMethodOptions(method, result, methodLogName)
-	}
+		expectedInputName := strings.Join([]string{methodName, "Request"}, "")
+
+		if inputName != expectedInputName {
+			result.AddFindingf(
+				MethodHasCorrectInputName,
+				method,
+				"Input of method %s should be named as %s",
+				methodLogName,
+				expectedInputName)
+		}
+	})
+
+	c.checkMethodPackageVersion(method, result, methodLogName)
+	c.checkMethodPackages(method, result, methodLogName)
+	c.checkMethodColocation(method, result, methodLogName)
+	c.checkCommentStyle(method, result, "Method", methodLogName)
+	c.checkAcronymStyle(method, result, "Method", methodLogName)
+	c.checkDeprecationExpired(method, result, "Method", methodLogName)
+	c.checkEnvironmentURLs(method, result, "Method", methodLogName)
+	c.checkUnknownOptions(method, result, "Method", methodLogName)
+	c.checkMethodOptions(method, result, methodLogName)
+	c.checkCreateResponse(method, result, methodLogName)
+	c.checkBatchMethod(method, result, methodLogName)
+	c.checkLongRunningOperation(method, result, methodLogName)
 }
 
 func (c *ProtoChecker) checkMethodOptions(
-	method protoreflect.Descriptor,
+	method protoreflect.MethodDescriptor,
 	result *CheckResult,
 	methodLogName string,
 ) {
+	var (
+		httpVerb         string
+		idempotencyLevel = descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+	)
+
 	method.Options().ProtoReflect().Range(
 		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
 			optionFullName := string(fd.FullName())
-			optionMessage := v.Message()
 
 			switch optionFullName {
+			case idempotencyLevelOption:
+				idempotencyLevel = descriptorpb.MethodOptions_IdempotencyLevel(v.Enum())
 			case "google.api.http":
-				parsedOptions, err := parser.ParseProtoMessageValues(optionMessage)
-				if err != nil {
-					result.AddMessagef(
-						"Failed to parse option %s of method %s: %s",
-						optionFullName,
-						methodLogName,
-						err.Error())
-
-					return true
-				}
+				parsedOptions := parser.NewOptionValues(v.Message())
 
-				path := c.fillGoogleAPIHTTPPath(parsedOptions)
-				if !c.config.IsCheckExcluded(MethodHasHTTPPath) &&
-					path == "" {
-					result.AddErrorf(
-						method,
-						"Path of method %s is not specified",
-						methodLogName)
-				}
+				httpVerb = c.fillGoogleAPIHTTPVerb(parsedOptions)
+				c.checkMethodVerb(method, result, methodLogName, httpVerb)
 
-				if !c.config.IsCheckExcluded(MethodHasBodyTag) &&
-					c.isMethodWithRequiredBody(parsedOptions) &&
-					parsedOptions.Get("body") != "*" {
-					result.AddErrorf(
-						method,
-						"Method %s doesn't have body tag or body is not equal to *",
-						methodLogName)
-				}
-			case "grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation":
-				parsedOptions, err := parser.ParseProtoMessageValues(optionMessage)
-				if err != nil {
-					result.AddMessagef(
-						"Failed to parse option %s of method %s: %s",
-						optionFullName,
-						methodLogName,
-						err.Error())
-
-					return true
-				}
-
-				if !c.config.IsCheckExcluded(MethodHasSwaggerTags) &&
-					parsedOptions.Get("tags") == "" {
-					result.AddErrorf(
-						method,
-						"Method %s has no swagger tags",
-						methodLogName)
+				path := c.fillGoogleAPIHTTPPath(parsedOptions)
+				c.runRule(MethodHasHTTPPath, method, func() {
+					if path == "" {
+						result.AddFindingf(
+							MethodHasHTTPPath,
+							method,
+							"Path of method %s is not specified",
+							methodLogName)
+					}
+				})
+
+				if path != "" {
+					c.checkHTTPPath(method, result, methodLogName, path)
 				}
 
-				if !c.config.IsCheckExcluded(MethodHasSwaggerSummary) &&
-					parsedOptions.Get("summary") == "" {
-					result.AddErrorf(
-						method,
-						"Method %s has no swagger summary",
-						methodLogName)
+				if httpVerb == httpVerbGet {
+					c.checkGetRequestFields(method, result, methodLogName, path)
+					c.checkGetRequestOneofs(method, result, methodLogName)
 				}
 
-				if !c.config.IsCheckExcluded(MethodHasSwaggerDescription) &&
-					parsedOptions.Get("description") == "" {
-					result.AddErrorf(
-						method,
-						"Method %s has no swagger description",
-						methodLogName)
-				}
+				c.runRule(MethodHasBodyTag, method, func() {
+					if c.isMethodWithRequiredBody(parsedOptions) &&
+						parsedOptions.GetString("body") != "*" {
+						result.AddFindingf(
+							MethodHasBodyTag,
+							method,
+							"Method %s doesn't have body tag or body is not equal to *",
+							methodLogName)
+					}
+				})
+			case "grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation":
+				parsedOptions := parser.NewOptionValues(v.Message())
+
+				c.runRule(MethodHasSwaggerTags, method, func() {
+					if parsedOptions.GetString("tags") == "" {
+						result.AddFindingf(
+							MethodHasSwaggerTags,
+							method,
+							"Method %s has no swagger tags",
+							methodLogName)
+					}
+				})
+
+				c.runRule(MethodHasSwaggerSummary, method, func() {
+					if parsedOptions.GetString("summary") == "" {
+						result.AddFindingf(
+							MethodHasSwaggerSummary,
+							method,
+							"Method %s has no swagger summary",
+							methodLogName)
+					}
+				})
+
+				c.runRule(MethodHasSwaggerDescription, method, func() {
+					if parsedOptions.GetString("description") == "" {
+						result.AddFindingf(
+							MethodHasSwaggerDescription,
+							method,
+							"Method %s has no swagger description",
+							methodLogName)
+					}
+				})
+
+				c.checkSwaggerExternalDocs(method, result, methodLogName, parsedOptions)
 			}
 
 			return true
 		})
+
+	c.checkIdempotencyLevel(method, result, methodLogName, httpVerb, idempotencyLevel)
+	c.checkUpdateFieldMask(method, result, methodLogName, httpVerb)
 }
 
-func (c *ProtoChecker) checkMessages(
-	messages protoreflect.MessageDescriptors,
-	result *CheckResult,
-	parsedFile linker.File,
-) {
-	parsedFileFullName := string(parsedFile.FullName())
+// EnterMessage runs the checks of the message, its descendants are skipped along with it.
+func (v *checkVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	var (
+		c                  = v.checker
+		result             = v.result
+		parsedFile         = v.file
+		index              = v.index
+		parsedFileFullName = string(parsedFile.FullName())
+	)
+
+	messageFullName := string(message.FullName())
+
+	messageLogName := c.getNameForLogs(
+		parsedFileFullName,
+		"",
+		0,
+		messageFullName)
+
+	if !c.config.IsDescriptorOnTargetPath(messageFullName) {
+		return false
+	}
 
-	for messageIndex := 0; messageIndex < messages.Len(); messageIndex++ {
-		message := messages.Get(messageIndex)
-		messageFullName := string(message.FullName())
+	if c.shouldDescriptorBeSkipped(messageFullName) {
+		result.AddMessagef("Message %s is skipped", messageLogName)
 
-		messageLogName := c.getNameForLogs(
-			parsedFileFullName,
-			"",
-			0,
-			messageFullName)
+		return false
+	}
 
-		if c.shouldDescriptorBeSkipped(messageFullName) {
-			result.AddMessagef("Message %s is skipped", messageLogName)
+	c.runRule(MessageNotEmpty, message, func() {
+		if isPlaceholderMessage(message, index) {
+			result.AddFindingf(
+				MessageNotEmpty,
+				message,
+				"Message %s has no fields and isn't used by any method",
+				messageLogName)
+		}
+	})
+
+	c.runRule(DescriptorIsReferenced, message, func() {
+		if !message.IsMapEntry() && !index.isReferenced(message) {
+			result.AddFindingf(
+				DescriptorIsReferenced,
+				message,
+				"Message %s isn't referenced by any field, method or extension",
+				messageLogName)
+		}
+	})
 
-			continue
+	c.runRule(MessageNoCycles, message, func() {
+		if message.IsMapEntry() {
+			return
 		}
 
-		c.checkMessageFields(message.Fields(), result, parsedFileFullName)
-		c.checkMessages(message.Messages(), result, parsedFile)
-		c.checkEnums(message.Enums(), result, parsedFile)
-	}
-}
+		// The chain includes the message twice: at the start and at the end.
+		if cycle := findShortestCycle(message); cycle != nil && len(cycle)-1 > c.config.GetMessageCyclesMaxDepth() {
+			result.AddFindingf(
+				MessageNoCycles,
+				message,
+				"Message %s references itself: %s",
+				messageLogName,
+				formatCyclePath(cycle))
+		}
+	})
 
-func (c *ProtoChecker) checkMessageFields(
-	fields protoreflect.FieldDescriptors,
-	result *CheckResult,
-	parsedFileFullName string,
-) {
-	for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
-		field := fields.Get(fieldIndex)
-		fieldName := string(field.Name())
-		fieldFullName := string(field.FullName())
+	c.runRule(MessageNoJSONNameCollisions, message, func() {
+		seen := make(map[string]protoreflect.FieldDescriptor, message.Fields().Len())
+
+		for i := 0; i < message.Fields().Len(); i++ {
+			field := message.Fields().Get(i)
+			jsonName := field.JSONName()
 
-		fieldLogName := c.getNameForLogs(
-			parsedFileFullName,
-			"",
-			0,
-			fieldFullName)
+			previous, ok := seen[jsonName]
+			if !ok {
+				seen[jsonName] = field
 
-		if c.shouldDescriptorBeSkipped(fieldFullName) {
-			result.AddMessagef("Field %s is skipped", fieldLogName)
+				continue
+			}
 
-			continue
+			result.AddFindingf(
+				MessageNoJSONNameCollisions,
+				field,
+				"Field %s of message %s serializes to JSON key %s already used by field %s",
+				field.Name(),
+				messageLogName,
+				jsonName,
+				previous.Name())
 		}
+	})
+
+	c.checkCommentStyle(message, result, "Message", messageLogName)
+	c.checkAcronymStyle(message, result, "Message", messageLogName)
+	c.checkDeprecationExpired(message, result, "Message", messageLogName)
+	c.checkEnvironmentURLs(message, result, "Message", messageLogName)
+	c.checkUnknownOptions(message, result, "Message", messageLogName)
+	c.checkFieldBehaviors(message, result, index, messageLogName)
+
+	return true
+}
+
+// LeaveMessage checks the extension ranges of the message, reported after the findings of its descendants.
+func (v *checkVisitor) LeaveMessage(message protoreflect.MessageDescriptor) {
+	messageLogName := v.checker.getNameForLogs(string(v.file.FullName()), "", 0, string(message.FullName()))
+
+	v.checker.checkExtensionRanges(message, v.result, v.file, messageLogName)
+}
+
+// Field runs the checks of the field.
+func (v *checkVisitor) Field(field protoreflect.FieldDescriptor) {
+	var (
+		c                  = v.checker
+		result             = v.result
+		parsedFileFullName = string(v.file.FullName())
+	)
+
+	fieldName := string(field.Name())
+	fieldFullName := string(field.FullName())
 
-		fieldJSONName := field.JSONName()
-		if !c.config.IsCheckExcluded(FieldHasCorrectJSONName) &&
-			field.HasJSONName() &&
-			fieldName != fieldJSONName {
-			result.AddErrorf(
+	fieldLogName := c.getNameForLogs(
+		parsedFileFullName,
+		"",
+		0,
+		fieldFullName)
+
+	if c.shouldDescriptorBeSkipped(fieldFullName) {
+		result.AddMessagef("Field %s is skipped", fieldLogName)
+
+		return
+	}
+
+	fieldJSONName := field.JSONName()
+	c.runRule(FieldHasCorrectJSONName, field, func() {
+		if field.HasJSONName() && fieldName != fieldJSONName {
+			result.AddFindingf(
+				FieldHasCorrectJSONName,
 				field,
 				"Field %s has incorrect json_name tag",
 				fieldLogName)
 		}
+	})
+
+	c.checkJSONNameStyle(field, result, fieldLogName)
+	c.checkMapKeyType(field, result, fieldLogName)
+	c.checkRemovalNote(field, result, fieldLogName)
+	c.checkSensitiveData(field, result, fieldLogName)
+	c.checkPIIDebugRedact(field, result, fieldLogName)
+
+	c.runRule(CommentNotTrivial, field, func() {
+		fieldSL := field.ParentFile().SourceLocations().ByDescriptor(field)
+		if isTrivialComment(fieldSL.LeadingComments, fieldName, "", c.config.GetTrivialCommentsStrictness()) {
+			result.AddFindingf(
+				CommentNotTrivial,
+				field,
+				"Comment of field %s merely restates its name",
+				fieldLogName)
+		}
+	})
 
-		c.checkFieldOptions(field, result, fieldLogName)
-	}
+	c.checkCommentStyle(field, result, "Field", fieldLogName)
+	c.checkDeprecationExpired(field, result, "Field", fieldLogName)
+	c.checkEnvironmentURLs(field, result, "Field", fieldLogName)
+	c.checkUnknownOptions(field, result, "Field", fieldLogName)
+	c.checkFieldOptions(field, result, fieldLogName)
 }
 
 func (c *ProtoChecker) checkFieldOptions(field protoreflect.FieldDescriptor,
@@ -327,105 +638,170 @@ func (c *ProtoChecker) checkFieldOptions(field protoreflect.FieldDescriptor,
 				return true
 			}
 
-			parsedOptions, err := parser.ParseProtoMessageValues(v.Message())
-			if err != nil {
-				result.AddMessagef(
-					"Failed to parse option %s of field %s: %s",
-					optionFullName,
-					fieldLogName,
-					err.Error())
-
-				return true
-			}
+			parsedOptions := parser.NewOptionValues(v.Message())
 
-			fieldDescription := parsedOptions.Get("description")
-			if !c.config.IsCheckExcluded(FieldHasNoDescription) &&
-				fieldDescription == "" {
-				result.AddErrorf(
-					field,
-					"Field %s in doesn't have description",
-					fieldLogName)
-			}
+			fieldDescription := parsedOptions.GetString("description")
+			c.runRule(FieldHasNoDescription, field, func() {
+				if fieldDescription == "" {
+					result.AddFindingf(
+						FieldHasNoDescription,
+						field,
+						"Field %s in doesn't have description",
+						fieldLogName)
+				}
+			})
+
+			c.runRule(FieldDescriptionStartsWithCapital, field, func() {
+				if fieldDescription != "" && !startsWithCapitalLetter(fieldDescription) {
+					result.AddFindingf(
+						FieldDescriptionStartsWithCapital,
+						field,
+						"Description of field %s doesn't start with capital letter",
+						fieldLogName)
+				}
+			})
 
-			if !c.config.IsCheckExcluded(FieldDescriptionStartsWithCapital) &&
-				fieldDescription != "" &&
-				!startsWithCapitalLetter(fieldDescription) {
-				result.AddErrorf(
-					field,
-					"Description of field %s doesn't start with capital letter",
-					fieldLogName)
-			}
+			c.runRule(FieldDescriptionLength, field, func() {
+				if fieldDescription == "" {
+					return
+				}
 
-			if !c.config.IsCheckExcluded(FieldDescriptionEndsWithDot) &&
-				fieldDescription != "" &&
-				!strings.HasSuffix(fieldDescription, ".") {
-				result.AddErrorf(
-					field,
-					"Description of field %s must end with dot",
-					fieldLogName)
-			}
+				minLength, maxLength := c.config.GetFieldDescriptionLengthBounds()
+
+				switch length := utf8.RuneCountInString(fieldDescription); {
+				case length < minLength:
+					result.AddFindingf(
+						FieldDescriptionLength,
+						field,
+						"Description of field %s is shorter than %d characters",
+						fieldLogName,
+						minLength)
+				case length > maxLength:
+					result.AddFindingf(
+						FieldDescriptionLength,
+						field,
+						"Description of field %s is longer than %d characters",
+						fieldLogName,
+						maxLength)
+				}
+			})
+
+			c.runRule(FieldDescriptionEndsWithDot, field, func() {
+				if fieldDescription != "" && !strings.HasSuffix(fieldDescription, ".") {
+					result.AddFindingf(
+						FieldDescriptionEndsWithDot,
+						field,
+						"Description of field %s must end with dot",
+						fieldLogName)
+				}
+			})
 
 			return true
 		})
 }
 
-func (c *ProtoChecker) checkEnums(
-	enums protoreflect.EnumDescriptors,
-	result *CheckResult,
-	parsedFile linker.File,
-) {
-	parsedFileFullName := string(parsedFile.FullName())
+// EnterEnum runs the checks of the enum, its values are skipped along with it.
+func (v *checkVisitor) EnterEnum(enum protoreflect.EnumDescriptor) bool {
+	var (
+		c                  = v.checker
+		result             = v.result
+		index              = v.index
+		parsedFileFullName = string(v.file.FullName())
+	)
+
+	enumFullName := string(enum.FullName())
+	enumLogName := c.getNameForLogs(
+		parsedFileFullName,
+		"",
+		0,
+		enumFullName)
+
+	if !c.config.IsDescriptorOnTargetPath(enumFullName) {
+		return false
+	}
 
-	for enumIndex := 0; enumIndex < enums.Len(); enumIndex++ {
-		enum := enums.Get(enumIndex)
-		enumFullName := string(enum.FullName())
-		enumLogName := c.getNameForLogs(
-			parsedFileFullName,
-			"",
-			0,
-			enumFullName)
+	if c.shouldDescriptorBeSkipped(enumFullName) {
+		result.AddMessagef("Enum %s is skipped", enumLogName)
 
-		if c.shouldDescriptorBeSkipped(enumFullName) {
-			result.AddMessagef("Enum %s is skipped", enumLogName)
+		return false
+	}
 
-			continue
+	c.runRule(DescriptorIsReferenced, enum, func() {
+		if !index.isReferenced(enum) {
+			result.AddFindingf(
+				DescriptorIsReferenced,
+				enum,
+				"Enum %s isn't referenced by any field",
+				enumLogName)
 		}
+	})
 
-		enumValues := enum.Values()
+	c.checkCommentStyle(enum, result, "Enum", enumLogName)
+	c.checkAcronymStyle(enum, result, "Enum", enumLogName)
+	c.checkDeprecationExpired(enum, result, "Enum", enumLogName)
+	c.checkEnvironmentURLs(enum, result, "Enum", enumLogName)
+	c.checkUnknownOptions(enum, result, "Enum", enumLogName)
 
-		for enumValueIndex := 0; enumValueIndex < enumValues.Len(); enumValueIndex++ {
-			var (
-				enumValue         = enumValues.Get(enumValueIndex)
-				enumValueName     = string(enumValue.Name())
-				enumValueFullName = string(enumValue.FullName())
-				enumValueLogName  = strings.Join([]string{enumLogName, enumValueName}, ".")
-			)
-
-			if c.shouldDescriptorBeSkipped(enumValueFullName) {
-				result.AddMessagef("Enum value %s is skipped", enumValueLogName)
+	return true
+}
 
-				continue
-			}
+// EnumValue runs the checks of the enum value.
+func (v *checkVisitor) EnumValue(enumValue protoreflect.EnumValueDescriptor) {
+	var (
+		c                 = v.checker
+		result            = v.result
+		parsedFile        = v.file
+		enum              = enumValue.Parent()
+		enumLogName       = c.getNameForLogs(string(parsedFile.FullName()), "", 0, string(enum.FullName()))
+		enumValueName     = string(enumValue.Name())
+		enumValueFullName = string(enumValue.FullName())
+		enumValueLogName  = strings.Join([]string{enumLogName, enumValueName}, ".")
+	)
+
+	if c.shouldDescriptorBeSkipped(enumValueFullName) {
+		result.AddMessagef("Enum value %s is skipped", enumValueLogName)
+
+		return
+	}
 
-			if !c.config.IsCheckExcluded(EnumValueHasComments) {
-				var (
-					enumValueSL              = parsedFile.SourceLocations().ByDescriptor(enumValue)
-					noEnumValueCommentsFound bool
-				)
+	c.runRule(EnumValueHasComments, enumValue, func() {
+		var (
+			enumValueSL              = parsedFile.SourceLocations().ByDescriptor(enumValue)
+			noEnumValueCommentsFound bool
+		)
 
-				if enumValueSL.Path == nil || strings.TrimSpace(enumValueSL.LeadingComments) == "" {
-					noEnumValueCommentsFound = true
-				}
+		if enumValueSL.Path == nil || strings.TrimSpace(enumValueSL.LeadingComments) == "" {
+			noEnumValueCommentsFound = true
+		}
 
-				if noEnumValueCommentsFound {
-					result.AddErrorf(
-						enumValue,
-						"Enum value %s has no leading comments",
-						enumValueLogName)
-				}
-			}
+		if noEnumValueCommentsFound {
+			result.AddFindingf(
+				EnumValueHasComments,
+				enumValue,
+				"Enum value %s has no leading comments",
+				enumValueLogName)
 		}
-	}
+	})
+
+	c.runRule(CommentNotTrivial, enumValue, func() {
+		enumValueSL := parsedFile.SourceLocations().ByDescriptor(enumValue)
+		if isTrivialComment(
+			enumValueSL.LeadingComments,
+			enumValueName,
+			string(enum.Name()),
+			c.config.GetTrivialCommentsStrictness()) {
+			result.AddFindingf(
+				CommentNotTrivial,
+				enumValue,
+				"Comment of enum value %s merely restates its name",
+				enumValueLogName)
+		}
+	})
+
+	c.checkCommentStyle(enumValue, result, "Enum value", enumValueLogName)
+	c.checkDeprecationExpired(enumValue, result, "Enum value", enumValueLogName)
+	c.checkEnvironmentURLs(enumValue, result, "Enum value", enumValueLogName)
+	c.checkUnknownOptions(enumValue, result, "Enum value", enumValueLogName)
 }
 
 func (c *ProtoChecker) getNameForLogs(
@@ -459,21 +835,29 @@ func (c *ProtoChecker) shouldDescriptorBeSkipped(name string) bool {
 	return false
 }
 
-func (c *ProtoChecker) fillGoogleAPIHTTPPath(params url.Values) string {
-	for k, v := range params {
-		switch k {
-		case "get", "put", "post", "delete", "patch":
-			if len(v) > 0 {
-				return v[0]
-			}
+// httpRuleVerbs lists the fields of google.api.HttpRule binding a method to an HTTP method.
+var httpRuleVerbs = []string{"get", "put", "post", "delete", "patch"}
+
+func (c *ProtoChecker) fillGoogleAPIHTTPVerb(params *parser.OptionValues) string {
+	for _, verb := range httpRuleVerbs {
+		if params.Has(verb) {
+			return verb
+		}
+	}
+
+	return ""
+}
 
-			return ""
+func (c *ProtoChecker) fillGoogleAPIHTTPPath(params *parser.OptionValues) string {
+	for _, verb := range httpRuleVerbs {
+		if params.Has(verb) {
+			return params.GetString(verb)
 		}
 	}
 
 	return ""
 }
 
-func (c *ProtoChecker) isMethodWithRequiredBody(values url.Values) bool {
+func (c *ProtoChecker) isMethodWithRequiredBody(values *parser.OptionValues) bool {
 	return values.Has("post") || values.Has("put")
 }
@@ -1,144 +1,1212 @@
 package checker
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/formatter"
 	"github.com/oshokin/protolinter/internal/logger"
+	"github.com/oshokin/protolinter/internal/ownership"
 )
 
 // ExecuteCheck runs the "check" subcommand.
-func ExecuteCheck(patterns []string, configPath string, isMimirFile bool) {
-	ctx := context.Background()
+func ExecuteCheck(ctx context.Context, patterns []string, opts *CheckOptions) {
+	isPassed, err := RunCheck(ctx, patterns, opts)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	if !isPassed {
+		logger.Exit(ctx, 1)
+	}
+}
+
+// RunCheck performs the checks, writes the report and returns whether the run passed.
+// Unlike ExecuteCheck, it never exits, so it can be run many times within a process,
+// e.g. by a persistent worker.
+func RunCheck(ctx context.Context, patterns []string, opts *CheckOptions) (bool, error) {
+	if err := validateCheckOptions(opts); err != nil {
+		return false, err
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	onlyChecks := renameDeprecatedChecks(ctx, opts.OnlyChecks)
+
+	owners, err := ownership.Load(cfg.GetOwnershipFile())
+	if err != nil {
+		return false, fmt.Errorf("failed to load ownership rules: %w", err)
+	}
+
+	if opts.FilesFrom != "" {
+		listedFiles, err := readFileList(opts.FilesFrom)
+		if err != nil {
+			return false, fmt.Errorf("failed to read the list of files: %w", err)
+		}
+
+		patterns = append(patterns, listedFiles...)
+	}
+
+	scopes, discoveryErrors, err := extractCheckScopes(patterns, opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to locate files based on the provided patterns: %w", err)
+	}
+
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(scopes) == 0 {
+		return false, errors.New("list of files is empty")
+	}
+
+	if compileCacheFromContext(ctx) == nil {
+		ctx = WithCompileCache(ctx, NewCompileCache())
+	}
+
+	var (
+		results      []*CheckResult
+		sourcePaths  []string
+		dependencies []*RemoteDependency
+		configs      = map[string]*config.Config{opts.ConfigPath: cfg}
+		progress     *progressReporter
+		tracer       *ruleTracer
+	)
+
+	if opts.ShowProgress {
+		var filesCount int
+		for _, scope := range scopes {
+			filesCount += len(scope.files)
+		}
+
+		progress = newProgressReporter(ctx, filesCount)
+	}
+
+	if opts.DebugRules {
+		tracer = newRuleTracer(ctx)
+	}
+
+	for _, scope := range scopes {
+		if _, ok := configs[scope.configPath]; ok {
+			continue
+		}
+
+		scopeConfig, err := loadConfig(ctx, scope.configPath)
+		if err != nil {
+			return false, fmt.Errorf("failed to load configuration %s: %w", scope.configPath, err)
+		}
+
+		configs[scope.configPath] = scopeConfig
+	}
+
+	if !opts.FailFast {
+		precompileScopes(ctx, scopes, configs)
+	}
+
+	for _, scope := range scopes {
+		scopeConfig := configs[scope.configPath]
+
+		checker := NewProtoChecker(ctx,
+			scopeConfig.
+				WithOnlyDescriptors(opts.OnlyDescriptors).
+				WithOnlyChecks(onlyChecks).
+				WithLocale(opts.Locale),
+			scope.importRoots...)
+		checker.progress = progress
+		checker.tracer = tracer
+		checker.failFast = opts.FailFast
+
+		scopeResults, err := checker.CheckFiles(ctx, scope.files...)
+		if err != nil {
+			return false, fmt.Errorf("failed to perform checks on files: %w", err)
+		}
+
+		results = append(results, scopeResults...)
+		sourcePaths = append(sourcePaths, scope.sourcePaths...)
+		dependencies = append(dependencies, checker.RemoteDependencies()...)
+
+		if opts.FailFast && isRunFailed(results) {
+			break
+		}
+	}
+
+	progress.finish()
+	tracer.finish()
+
+	if opts.ManifestPath != "" {
+		if err = writeRunManifest(opts, sourcePaths, dependencies); err != nil {
+			return false, err
+		}
+	}
+
+	annotateOwners(results, owners, scopes)
+
+	return processCheckResults(ctx, results, opts)
+}
+
+// ExecuteCompile runs the "compile" subcommand.
+func ExecuteCompile(ctx context.Context, patterns []string, opts *CompileOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	checker := NewProtoChecker(ctx, cfg)
+
+	findings, err := checker.CompileFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to compile files: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	report := newFindingsReport(findings)
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	closeOutput()
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write report: %s", err.Error())
+	}
+
+	for _, finding := range findings {
+		if finding.Severity == config.SeverityError {
+			logger.Exit(ctx, 1)
+		}
+	}
+}
+
+// ExecuteGraph runs the "graph" subcommand.
+func ExecuteGraph(ctx context.Context, patterns []string, opts *GraphOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatDOT, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	checker := NewProtoChecker(ctx, cfg)
+
+	graph, err := checker.BuildImportGraph(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to build import graph: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = graph.WriteJSON(output)
+	} else {
+		err = graph.WriteDOT(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write graph: %s", err.Error())
+	}
+}
+
+// ExecuteWhere runs the "where" subcommand.
+func ExecuteWhere(ctx context.Context, symbol string, patterns []string, opts *WhereOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	report, err := NewProtoChecker(ctx, cfg).FindSymbols(ctx, symbol, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to find symbols: %s", err.Error())
+	}
+
+	if len(report.Symbols) == 0 {
+		logger.Fatalf(ctx, "No symbols start with %s", symbol)
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write symbols: %s", err.Error())
+	}
+}
+
+// ExecuteGrep runs the "grep" subcommand.
+func ExecuteGrep(ctx context.Context, patterns []string, opts *GrepOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	for _, kind := range opts.Kinds {
+		if !containsString(SymbolKinds, kind) {
+			logger.Fatalf(ctx, "Unknown kind: %s, expected one of: %s", kind, strings.Join(SymbolKinds, ", "))
+		}
+	}
+
+	pattern, err := regexp.Compile(opts.Regex)
+	if err != nil {
+		logger.Fatalf(ctx, "Invalid regular expression: %s", err.Error())
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	report, err := NewProtoChecker(ctx, cfg).GrepSymbols(ctx, pattern, opts.Kinds, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to search symbols: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write symbols: %s", err.Error())
+	}
+}
+
+// ExecuteScore runs the "score" subcommand.
+func ExecuteScore(ctx context.Context, patterns []string, opts *ScoreOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to perform checks on files: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	report := NewScoreReport(results, cfg)
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write scores: %s", err.Error())
+	}
+}
+
+// ExecuteCoverage runs the "coverage" subcommand.
+func ExecuteCoverage(ctx context.Context, patterns []string, opts *CoverageOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	report, err := NewProtoChecker(ctx, cfg).CollectCoverage(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to collect coverage of checks: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write coverage: %s", err.Error())
+	}
+}
+
+// ExecuteRun runs the "run" subcommand.
+func ExecuteRun(ctx context.Context, patterns []string, opts *RunOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	report, err := NewProtoChecker(ctx, cfg).RunTasks(ctx, cfg.GetTasks(), files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to run tasks: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	closeOutput()
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write report: %s", err.Error())
+	}
+
+	if !report.IsPassed() {
+		logger.Exit(ctx, 1)
+	}
+}
+
+// ExecuteFormat runs the "format" subcommand.
+func ExecuteFormat(ctx context.Context, patterns []string, opts *FormatOptions) {
+	if opts.Check && opts.Write {
+		logger.Fatal(ctx, "Flags --check and --write can't be used together")
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, protoFileExtension)
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	failed := false
+
+	for _, file := range files {
+		changed, err := formatFile(file, opts)
+		if err != nil {
+			logger.Error(ctx, err.Error())
+
+			failed = true
+
+			continue
+		}
+
+		// In the check mode the files that aren't formatted are listed and fail the run.
+		if changed && opts.Check {
+			fmt.Fprintln(os.Stdout, file)
+
+			failed = true
+		}
+	}
+
+	if failed {
+		logger.Exit(ctx, 1)
+	}
+}
+
+// formatFile formats the file and returns true if the formatted content differs from the file.
+// The formatted content is written back to the file in the write mode
+// and printed to stdout unless the check mode is set.
+func formatFile(fileName string, opts *FormatOptions) (bool, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file %s: %w", fileName, err)
+	}
+
+	formatted, err := formatter.Format(fileName, data)
+	if err != nil {
+		return false, err
+	}
+
+	changed := !bytes.Equal(data, formatted)
+
+	switch {
+	case opts.Check, opts.Write && !changed:
+		// Nothing is written.
+	case opts.Write:
+		info, err := os.Stat(fileName)
+		if err != nil {
+			return false, fmt.Errorf("failed to get info of file %s: %w", fileName, err)
+		}
+
+		if err = os.WriteFile(fileName, formatted, info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("failed to write file %s: %w", fileName, err)
+		}
+	default:
+		if _, err = os.Stdout.Write(formatted); err != nil {
+			return false, fmt.Errorf("failed to write formatted file %s: %w", fileName, err)
+		}
+	}
+
+	return changed, nil
+}
+
+// ExecuteFix runs the "fix" subcommand, applying the fixes recorded in the report of a previous run.
+// Files are fixed according to their current content, so fixing them again or after later edits is safe.
+func ExecuteFix(ctx context.Context, opts *FixOptions) {
+	if opts.ReportPath == "" {
+		logger.Fatal(ctx, "Report must be specified with --from-report")
+	}
+
+	report, err := readCheckReport(opts.ReportPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	files, unsupported := getFilesToFormat(report.findings())
+	for _, finding := range unsupported {
+		logger.Warnf(ctx, "Unknown fix %s of finding %s is skipped", finding.Fix, formatFindingText(finding))
+	}
+
+	if len(files) == 0 {
+		logger.Info(ctx, "Report has no findings with fixes")
+
+		return
+	}
+
+	failed := false
+
+	for _, file := range files {
+		changed, err := formatFile(file, &FormatOptions{Write: true})
+		if err != nil {
+			logger.Error(ctx, err.Error())
+
+			failed = true
+
+			continue
+		}
+
+		if changed {
+			fmt.Fprintf(os.Stdout, "Formatted %s\n", file)
+		}
+	}
+
+	if failed {
+		logger.Exit(ctx, 1)
+	}
+}
+
+// ExecuteTriage runs the "triage" subcommand.
+func ExecuteTriage(ctx context.Context, patterns []string, opts *TriageOptions) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = config.DefaultConfigName
+	}
+
+	cfg, err := loadConfig(ctx, configPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
 
-	cfg, err := config.LoadConfig(configPath)
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to perform checks on files: %s", err.Error())
+	}
+
+	summary, err := newTriageSession(cfg, configPath, os.Stdin, os.Stdout).run(results)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to triage findings: %s", err.Error())
+	}
+
+	logger.Infof(ctx, "Fixed: %d, suppressed: %d, excluded: %d, skipped: %d",
+		summary.Fixed,
+		summary.Suppressed,
+		summary.Excluded,
+		summary.Skipped)
+}
+
+// ExecuteAnnotate runs the "annotate" subcommand.
+func ExecuteAnnotate(ctx context.Context, patterns []string, opts *AnnotateOptions) {
+	if opts.PullRequest <= 0 {
+		logger.Fatal(ctx, "Number of the pull request must be specified with --pr")
+	}
+
+	client, err := newReviewClient(opts)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
 	if err != nil {
 		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
 	}
 
-	var files []string
-	if isMimirFile {
-		files, err = extractFilesFromMimir(patterns[0])
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to perform checks on files: %s", err.Error())
+	}
+
+	var findings []*Finding
+	for _, cr := range results {
+		findings = append(findings, cr.Findings...)
+	}
+
+	posted, skipped, err := postReviewComments(ctx, client, findings)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	logger.Infof(ctx, "Review comments are posted: %d, skipped: %d, findings: %d", posted, skipped, len(findings))
+}
+
+// ExecuteHookInstall runs the "hook install" subcommand.
+func ExecuteHookInstall(ctx context.Context, opts *HookInstallOptions) {
+	if opts.PreCommitConfig {
+		fmt.Print(buildPreCommitConfig(opts.ConfigPath))
+
+		return
+	}
+
+	hookPath, err := locateGitPreCommitHook()
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	existingHook, err := os.ReadFile(hookPath)
+
+	switch {
+	case err == nil:
+		if !opts.Force && !bytes.Contains(existingHook, []byte(preCommitHookMarker)) {
+			logger.Fatalf(ctx, "Pre-commit hook %s already exists, use --force to overwrite it", hookPath)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		logger.Fatalf(ctx, "Failed to read pre-commit hook: %s", err.Error())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(hookPath), 0o755); err != nil {
+		logger.Fatalf(ctx, "Failed to create hooks directory: %s", err.Error())
+	}
+
+	if err = os.WriteFile(hookPath, []byte(buildPreCommitHook(opts.ConfigPath)), 0o600); err != nil {
+		logger.Fatalf(ctx, "Failed to write pre-commit hook: %s", err.Error())
+	}
+
+	// WriteFile keeps the permissions of an existing file, so they are always set explicitly.
+	if err = os.Chmod(hookPath, 0o755); err != nil { //nolint: gosec // Hooks must be executable.
+		logger.Fatalf(ctx, "Failed to make pre-commit hook executable: %s", err.Error())
+	}
+
+	logger.Infof(ctx, "Pre-commit hook is installed to %s", hookPath)
+}
+
+// ExecuteVersion runs the "version" subcommand.
+func ExecuteVersion(ctx context.Context, opts *VersionOptions) {
+	info := NewVersionInfo()
+
+	if opts.CheckUpdate {
+		if err := info.checkUpdate(ctx, latestReleaseURL); err != nil {
+			logger.Fatalf(ctx, "Failed to check for updates: %s", err.Error())
+		}
+	}
+
+	var err error
+
+	if opts.IsJSON {
+		err = info.WriteJSON(os.Stdout)
 	} else {
-		files, err = extractFilesFromPatterns(patterns, "")
+		err = info.WriteText(os.Stdout)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write version: %s", err.Error())
+	}
+}
+
+// ExecuteListProtoFullNames runs the "list" subcommand.
+func ExecuteListProtoFullNames(ctx context.Context, patterns []string, opts *ListOptions) {
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	checker := NewProtoChecker(ctx, nil)
+
+	results, err := checker.ListFullNamesFromFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to list full names: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if err = writeListResults(output, results); err != nil {
+		logger.Fatalf(ctx, "Failed to write results: %s", err.Error())
+	}
+}
+
+// ExecuteConfigPrune runs the "config prune" subcommand.
+func ExecuteConfigPrune(ctx context.Context, patterns []string, opts *ConfigPruneOptions) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = config.DefaultConfigName
 	}
 
+	cfg, err := loadConfig(ctx, configPath)
 	if err != nil {
-		logger.Fatalf(ctx, "Failed to locate files based on the provided patterns: %s", err.Error())
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	if cfg == nil {
+		logger.Fatalf(ctx, "Configuration file %s is not found", configPath)
 	}
 
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
 	if len(files) == 0 {
 		logger.Fatal(ctx, "List of files is empty")
 	}
 
 	checker := NewProtoChecker(ctx, cfg)
 
-	results, err := checker.CheckFiles(ctx, files...)
+	unused, err := checker.FindUnusedExclusions(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to find unused exclusions: %s", err.Error())
+	}
+
+	if unused.IsEmpty() {
+		logger.Info(ctx, "All exclusions are in use")
+
+		return
+	}
+
+	for _, check := range unused.Checks {
+		logger.Warnf(ctx, "Excluded check %s doesn't report anything", check)
+	}
+
+	for _, descriptor := range unused.Descriptors {
+		logger.Warnf(ctx, "Excluded descriptor %s doesn't match any descriptor", descriptor)
+	}
+
+	if !opts.Write {
+		logger.Exit(ctx, 1)
+	}
+
+	if err = config.RemoveExclusions(configPath, unused.Checks, unused.Descriptors); err != nil {
+		logger.Fatalf(ctx, "Failed to prune configuration: %s", err.Error())
+	}
+
+	logger.Infof(ctx, "Removed %d unused exclusions from %s",
+		len(unused.Checks)+len(unused.Descriptors),
+		configPath)
+}
+
+// ExecuteConfigGenerate runs the "config generate" subcommand.
+func ExecuteConfigGenerate(ctx context.Context, patterns []string, opts *ConfigGenerateOptions) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = config.DefaultConfigName
+	}
+
+	cfg, err := loadConfig(ctx, configPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
+	if len(files) == 0 {
+		logger.Fatal(ctx, "List of files is empty")
+	}
+
+	results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, files...)
 	if err != nil {
 		logger.Fatalf(ctx, "Failed to perform checks on files: %s", err.Error())
 	}
 
-	processCheckResults(ctx, results)
+	data, err := generateConfig(cfg, configPath, results)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to generate configuration: %s", err.Error())
+	}
+
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	if _, err = output.Write(data); err != nil {
+		logger.Fatalf(ctx, "Failed to write configuration: %s", err.Error())
+	}
 }
 
-// ExecuteListProtoFullNames runs the "lint" subcommand.
-func ExecuteListProtoFullNames(patterns []string) {
-	ctx := context.Background()
+// ExecuteConfigImpact runs the "config impact" subcommand.
+func ExecuteConfigImpact(ctx context.Context, patterns []string, opts *ConfigImpactOptions) {
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON:
+	default:
+		logger.Fatalf(ctx, "Unknown output format: %s", opts.OutputFormat)
+	}
+
+	if opts.CandidatePath == "" {
+		logger.Fatal(ctx, "Candidate configuration must be specified with --candidate")
+	}
+
+	cfg, err := loadConfig(ctx, opts.ConfigPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
 
-	files, err := extractFilesFromPatterns(patterns, "")
+	candidateConfig, err := loadConfig(ctx, opts.CandidatePath)
 	if err != nil {
-		logger.Fatalf(ctx, "Failed to locate files based on the provided patterns: %s", err.Error())
+		logger.Fatalf(ctx, "Failed to load candidate configuration: %s", err.Error())
+	}
+
+	if candidateConfig == nil {
+		logger.Fatalf(ctx, "Candidate configuration file %s is not found", opts.CandidatePath)
 	}
 
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	logDiscoveryErrors(ctx, discoveryErrors)
+
 	if len(files) == 0 {
 		logger.Fatal(ctx, "List of files is empty")
 	}
 
-	checker := NewProtoChecker(ctx, nil)
+	// Both configurations are evaluated on the same compiled files.
+	ctx = WithCompileCache(ctx, NewCompileCache())
 
-	results, err := checker.ListFullNamesFromFiles(ctx, files...)
+	results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, files...)
 	if err != nil {
-		logger.Fatalf(ctx, "Failed to list full names: %s", err.Error())
+		logger.Fatalf(ctx, "Failed to perform checks on files: %s", err.Error())
+	}
+
+	candidateResults, err := NewProtoChecker(ctx, candidateConfig).CheckFiles(ctx, files...)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to perform checks on files with candidate configuration: %s", err.Error())
 	}
 
-	processListResults(ctx, results)
+	output, closeOutput, err := openResultsOutput(opts.OutputPath)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	defer closeOutput()
+
+	report := NewImpactReport(results, candidateResults)
+	if report.IsEmpty() && opts.OutputFormat != OutputFormatJSON {
+		logger.Info(ctx, "Candidate configuration doesn't change any finding")
+
+		return
+	}
+
+	if opts.OutputFormat == OutputFormatJSON {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
+
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to write impact report: %s", err.Error())
+	}
 }
 
-func extractFilesFromPatterns(patterns []string, extension string) ([]string, error) {
-	var (
-		alreadyAddedFiles = make(map[string]struct{}, len(patterns))
-		result            = make([]string, 0, len(patterns))
-	)
+// ExecuteConfigValidate runs the "config validate" subcommand.
+func ExecuteConfigValidate(ctx context.Context, opts *ConfigValidateOptions) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = config.DefaultConfigName
+	}
 
-	for _, pattern := range patterns {
-		files, err := filepath.Glob(pattern)
-		if err != nil {
-			return nil, err
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load configuration: %s", err.Error())
+	}
+
+	if cfg == nil {
+		logger.Fatalf(ctx, "Configuration file %s is not found", configPath)
+	}
+
+	problems := ValidateConfig(cfg)
+	if len(problems) == 0 {
+		logger.Info(ctx, "Configuration is valid")
+
+		return
+	}
+
+	var isFailed bool
+
+	for _, problem := range problems {
+		if problem.Severity == config.SeverityError {
+			isFailed = true
+
+			logger.Error(ctx, problem.Message)
+
+			continue
 		}
 
-		for _, file := range files {
-			if _, ok := alreadyAddedFiles[file]; ok {
-				continue
-			}
+		logger.Warn(ctx, problem.Message)
+	}
 
-			alreadyAddedFiles[file] = struct{}{}
+	if isFailed {
+		logger.Exit(ctx, 1)
+	}
+}
 
-			fi, _ := os.Stat(file)
-			if fi.IsDir() {
-				continue
-			}
+// ExecuteRulesDocs runs the "rules docs" subcommand.
+func ExecuteRulesDocs(ctx context.Context, outputDir string) {
+	files, err := generateRulesDocs(outputDir, Rules())
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to generate rules documentation: %s", err.Error())
+	}
 
-			if extension != "" && filepath.Ext(file) != extension {
-				continue
-			}
+	logger.Infof(ctx, "Generated %d documentation files in %s", len(files), outputDir)
+}
 
-			result = append(result, file)
+func extractCheckScopes(patterns []string, opts *CheckOptions) ([]*checkScope, []*DiscoveryError, error) {
+	if opts.IsAuto {
+		root := "."
+		if len(patterns) > 0 {
+			root = patterns[0]
 		}
+
+		return discoverCheckScopes(root, opts.ConfigPath)
 	}
 
-	return result, nil
+	if opts.IsMimirFile {
+		return extractScopesFromMimir(patterns, opts.ConfigPath)
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(patterns, "")
+	if len(files) == 0 {
+		return nil, discoveryErrors, nil
+	}
+
+	return []*checkScope{
+		{
+			configPath:  opts.ConfigPath,
+			files:       files,
+			sourcePaths: files,
+		},
+	}, discoveryErrors, nil
 }
 
-func processCheckResults(ctx context.Context, results []*CheckResult) {
-	var isCheckFailed bool
+func validateCheckOptions(opts *CheckOptions) error {
+	if opts.IsAuto && opts.IsMimirFile {
+		return errors.New("flags --auto and --mimir can't be used together")
+	}
 
-	for _, cr := range results {
-		if len(cr.Messages) == 0 && len(cr.Errors) == 0 {
-			continue
+	if opts.IsAuto && opts.FilesFrom != "" {
+		return errors.New("flags --auto and --files-from can't be used together")
+	}
+
+	if opts.UpdateExpect && opts.ExpectPath == "" {
+		return errors.New("flag --update-expect requires --expect")
+	}
+
+	switch opts.OutputFormat {
+	case "", OutputFormatText, OutputFormatJSON, OutputFormatHTML, OutputFormatPatch:
+	default:
+		return fmt.Errorf("unknown output format: %s", opts.OutputFormat)
+	}
+
+	if opts.OutputFormat == OutputFormatPatch && (opts.GroupBy != "" || opts.ReportDir != "") {
+		return errors.New("flags --group-by and --report-dir can't be used with the patch output format")
+	}
+
+	if opts.OutputFormat == OutputFormatHTML && opts.GroupBy != "" {
+		return errors.New("flag --group-by can't be used with the html output format")
+	}
+
+	switch opts.GroupBy {
+	case "", GroupByOwner:
+	default:
+		return fmt.Errorf("unknown grouping key: %s", opts.GroupBy)
+	}
+
+	if !config.IsLocaleSupported(opts.Locale) {
+		return fmt.Errorf("unsupported locale: %s", opts.Locale)
+	}
+
+	knownChecks := make(map[string]struct{}, len(registeredRules))
+	for _, rule := range registeredRules {
+		knownChecks[rule.Name] = struct{}{}
+
+		for _, alias := range rule.Aliases {
+			knownChecks[alias] = struct{}{}
 		}
+	}
 
-		if len(cr.Errors) > 0 {
-			isCheckFailed = true
+	for _, check := range opts.OnlyChecks {
+		if _, ok := knownChecks[check]; !ok {
+			return fmt.Errorf("unknown check passed to --only-check: %s", check)
 		}
+	}
+
+	return nil
+}
+
+func writeRunManifest(opts *CheckOptions, files []string, dependencies []*RemoteDependency) error {
+	manifest, err := NewRunManifest(opts.ConfigPath, files, dependencies)
+	if err != nil {
+		return fmt.Errorf("failed to create run manifest: %w", err)
+	}
+
+	if err = manifest.WriteToFile(opts.ManifestPath); err != nil {
+		return fmt.Errorf("failed to write run manifest: %w", err)
+	}
 
-		logger.Infof(ctx, "Checking file %s:", cr.File.Path())
+	return nil
+}
+
+// processCheckResults writes the report and returns whether the run passed.
+func processCheckResults(ctx context.Context, results []*CheckResult, opts *CheckOptions) (bool, error) {
+	isCheckFailed := isRunFailed(results)
 
+	// Skipped descriptors are operational details rather than results.
+	for _, cr := range results {
 		for _, message := range cr.Messages {
 			logger.Info(ctx, message)
 		}
+	}
+
+	for _, breach := range findBudgetBreaches(results) {
+		logger.Errorf(ctx, "Package %s has %d error findings, exceeding its budget of %d",
+			breach.Package,
+			breach.Findings,
+			breach.Budget)
+	}
+
+	fileOutput, closeOutput, err := openCheckResultsOutput(opts)
+	if err != nil {
+		return false, err
+	}
+
+	defer closeOutput()
+
+	// In quiet mode, the output is held back until it's known whether the run passes.
+	output := fileOutput
+
+	var quietOutput bytes.Buffer
+	if opts.Quiet {
+		output = &quietOutput
+	}
+
+	switch opts.OutputFormat {
+	case OutputFormatJSON:
+		err = NewCheckReport(results, opts.GroupBy).WriteJSON(output)
+	case OutputFormatHTML:
+		err = NewHTMLReport(results).WriteHTML(output)
+	case OutputFormatPatch:
+		err = writeFixPatch(output, results)
+	default:
+		err = NewCheckReport(results, opts.GroupBy).WriteText(output)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if opts.ReportDir != "" {
+		if err = writeReportDir(results, opts.ReportDir, opts.OutputFormat); err != nil {
+			return false, err
+		}
+	}
 
-		for _, message := range cr.Errors {
-			logger.Error(ctx, message)
+	// With a golden file, the run fails on any difference from it rather than on errors.
+	if opts.ExpectPath != "" {
+		isMatched, err := compareWithGoldenFile(ctx, output, results, opts.ExpectPath, opts.UpdateExpect)
+		if err != nil {
+			return false, err
 		}
+
+		isCheckFailed = !isMatched
 	}
 
-	if isCheckFailed {
-		os.Exit(1)
+	if opts.Quiet && isCheckFailed {
+		if _, err = quietOutput.WriteTo(fileOutput); err != nil {
+			return false, fmt.Errorf("failed to write report: %w", err)
+		}
 	}
+
+	return !isCheckFailed, nil
 }
 
-func processListResults(ctx context.Context, results []*ListResult) {
+func writeListResults(w io.Writer, results []*ListResult) error {
 	for _, lr := range results {
 		if len(lr.Messages) == 0 {
 			continue
 		}
 
-		logger.Infof(ctx, "Listing protobuf elements names from file %s:", lr.File.Path())
+		if _, err := fmt.Fprintf(w, "%s:\n", lr.File.Path()); err != nil {
+			return err
+		}
 
 		for _, message := range lr.Messages {
-			logger.Info(ctx, message)
+			if _, err := fmt.Fprintf(w, "  %s\n", message); err != nil {
+				return err
+			}
 		}
 	}
+
+	return nil
+}
+
+// openCheckResultsOutput returns the writer the check results are written to,
+// preferring the output file to the writer set in the options.
+func openCheckResultsOutput(opts *CheckOptions) (io.Writer, func(), error) {
+	if opts.OutputPath == "" && opts.Output != nil {
+		return opts.Output, func() {}, nil
+	}
+
+	return openResultsOutput(opts.OutputPath)
+}
+
+// openResultsOutput opens the file the results are written to, falling back to stdout.
+// The returned function closes the file.
+func openResultsOutput(fileName string) (io.Writer, func(), error) {
+	if fileName == "" {
+		return os.Stdout, func() {}, nil
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	return file, func() { _ = file.Close() }, nil
 }
@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	lineCommentPrefix  = "//"
+	blockCommentPrefix = "/*"
+)
+
+// checkCommentStyle checks the syntax of the leading comments of the descriptor
+// and reports comments placed on the same line after the declaration.
+// The kind is the human-readable kind of the descriptor used in messages, e.g. "Field".
+func (c *ProtoChecker) checkCommentStyle(
+	desc protoreflect.Descriptor,
+	result *CheckResult,
+	kind string,
+	logName string,
+) {
+	checkSyntax := !c.config.IsCheckExcluded(CommentStyleSyntax)
+	checkTrailing := !c.config.IsCheckExcluded(CommentStyleNoTrailing)
+
+	if !checkSyntax && !checkTrailing {
+		return
+	}
+
+	fileNode, node := findDescriptorNode(desc)
+	if node == nil {
+		return
+	}
+
+	nodeInfo := fileNode.NodeInfo(node)
+
+	c.runRule(CommentStyleSyntax, desc, func() {
+		var (
+			syntax          = c.config.GetCommentSyntax()
+			forbiddenPrefix = blockCommentPrefix
+		)
+
+		if syntax == config.CommentSyntaxBlock {
+			forbiddenPrefix = lineCommentPrefix
+		}
+
+		comments := nodeInfo.LeadingComments()
+		for i := 0; i < comments.Len(); i++ {
+			if strings.HasPrefix(comments.Index(i).RawText(), forbiddenPrefix) {
+				result.AddFindingf(
+					CommentStyleSyntax,
+					desc,
+					"%s %s must be documented with %s comments",
+					result.localize(kind),
+					logName,
+					syntax)
+
+				break
+			}
+		}
+	})
+
+	c.runRule(CommentStyleNoTrailing, desc, func() {
+		var (
+			endLine  = nodeInfo.End().Line
+			comments = nodeInfo.TrailingComments()
+		)
+
+		for i := 0; i < comments.Len(); i++ {
+			if comments.Index(i).Start().Line == endLine {
+				result.AddFindingf(
+					CommentStyleNoTrailing,
+					desc,
+					"%s %s has a trailing comment, documentation must precede the declaration",
+					result.localize(kind),
+					logName)
+
+				break
+			}
+		}
+	})
+}
+
+// findDescriptorNode returns the AST node the descriptor is declared with,
+// or nil if the AST of its file is not available.
+func findDescriptorNode(desc protoreflect.Descriptor) (*ast.FileNode, ast.Node) {
+	res, ok := desc.ParentFile().(linker.Result)
+	if !ok || res.AST() == nil {
+		return nil, nil
+	}
+
+	protoDesc, ok := desc.(interface{ AsProto() proto.Message })
+	if !ok {
+		return nil, nil
+	}
+
+	return res.AST(), res.Node(protoDesc.AsProto())
+}
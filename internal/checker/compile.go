@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// CompileCheck is the name findings of the compile-only mode are reported with.
+const CompileCheck = "compile"
+
+// CompileFiles compiles the provided protobuf files without running any checks
+// and returns syntax and link errors as findings, sorted by location.
+// Unlike checking, compiling doesn't stop at the first error.
+func (c *ProtoChecker) CompileFiles(ctx context.Context, files ...string) ([]*Finding, error) {
+	c.modules.addForFiles(files)
+
+	var (
+		mu       sync.Mutex
+		result   []*Finding
+		compiler = *c.compiler
+	)
+
+	addFinding := func(severity config.Severity, err reporter.ErrorWithPos) {
+		var (
+			position = err.GetPosition()
+			message  = err.Error()
+		)
+
+		if cause := err.Unwrap(); cause != nil {
+			message = cause.Error()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result = append(result, &Finding{
+			Check:    CompileCheck,
+			Severity: severity,
+			Message:  message,
+			Path:     position.Filename,
+			Line:     position.Line,
+			Column:   position.Col,
+		})
+	}
+
+	compiler.Reporter = reporter.NewReporter(
+		func(err reporter.ErrorWithPos) error {
+			addFinding(config.SeverityError, err)
+
+			// Returning nil makes the compiler continue and report all errors.
+			return nil
+		},
+		func(err reporter.ErrorWithPos) {
+			addFinding(config.SeverityWarning, err)
+		})
+
+	_, err := compileCacheFromContext(ctx).compile(ctx, &compiler, files)
+	if err != nil && !errors.Is(err, reporter.ErrInvalidSource) {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+
+		return result[i].Column < result[j].Column
+	})
+
+	return result, nil
+}
@@ -0,0 +1,275 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+// compileCacheCapacity is the maximum number of sets of compiled files kept by the cache,
+// the oldest set is evicted first.
+const compileCacheCapacity = 64
+
+// NewCompileCache creates an empty cache of compiled files.
+func NewCompileCache() *CompileCache {
+	return &CompileCache{
+		entries: make(map[string]*compileCacheEntry),
+	}
+}
+
+// WithCompileCache returns a copy of the context carrying the cache,
+// checks run with the context reuse the files compiled by previous runs.
+func WithCompileCache(ctx context.Context, cache *CompileCache) context.Context {
+	return context.WithValue(ctx, compileCacheKey{}, cache)
+}
+
+func compileCacheFromContext(ctx context.Context) *CompileCache {
+	cache, _ := ctx.Value(compileCacheKey{}).(*CompileCache)
+
+	return cache
+}
+
+// precompileScopes compiles the files of the scopes sharing import roots together and keeps them in the cache
+// of the context, so scopes checked with different configurations reuse the compiled files
+// instead of compiling their common imports again.
+// Failures are ignored, since every scope compiles its files on its own then and reports the errors.
+func precompileScopes(ctx context.Context, scopes []*checkScope, configs map[string]*config.Config) {
+	var (
+		cache  = compileCacheFromContext(ctx)
+		groups = make(map[string][]*checkScope)
+		keys   []string
+	)
+
+	if cache == nil {
+		return
+	}
+
+	for _, scope := range scopes {
+		key := strings.Join(scope.importRoots, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], scope)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+
+		var files []string
+		for _, scope := range group {
+			files = append(files, scope.files...)
+		}
+
+		checker := NewProtoChecker(ctx, configs[group[0].configPath], group[0].importRoots...)
+		if _, err := cache.compile(ctx, checker.compiler, files); err != nil {
+			logger.Debugf(ctx, "Failed to compile files of several scopes together: %s", err.Error())
+		}
+	}
+}
+
+// compile returns the files compiled by a previous run if none of the sources read while compiling them
+// has changed since, otherwise it compiles the files and keeps the result.
+// Without the cache, the files are always compiled.
+func (c *CompileCache) compile(
+	ctx context.Context,
+	compiler *protocompile.Compiler,
+	files []string,
+) (linker.Files, error) {
+	if c == nil {
+		return compiler.Compile(ctx, files...)
+	}
+
+	key := strings.Join(files, "\x00")
+
+	if entry := c.get(key); entry != nil && entry.isUpToDate(compiler.Resolver) {
+		// Warnings are reported again, so callers collecting them get the same results.
+		entry.replayWarnings(compiler.Reporter, nil)
+
+		return entry.files, nil
+	}
+
+	// Files compiled along with others, e.g. by scopes sharing import roots but not configurations, are reused.
+	if entry, result := c.findSuperset(files); entry != nil && entry.isUpToDate(compiler.Resolver) {
+		entry.replayWarnings(compiler.Reporter, result)
+
+		return result, nil
+	}
+
+	var (
+		resolver = &checksumResolver{
+			Resolver:  compiler.Resolver,
+			checksums: make(map[string]string),
+		}
+		entry = &compileCacheEntry{
+			checksums: resolver.checksums,
+		}
+		rep = compiler.Reporter
+		mu  sync.Mutex
+	)
+
+	if rep == nil {
+		rep = reporter.NewReporter(nil, nil)
+	}
+
+	cachingCompiler := *compiler
+	cachingCompiler.Resolver = resolver
+	cachingCompiler.Reporter = reporter.NewReporter(rep.Error, func(err reporter.ErrorWithPos) {
+		mu.Lock()
+		entry.warnings = append(entry.warnings, err)
+		mu.Unlock()
+
+		rep.Warning(err)
+	})
+
+	result, err := cachingCompiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.files = result
+	c.put(key, entry)
+
+	return result, nil
+}
+
+// findSuperset returns the newest entry compiled from all of the files among others along with the files
+// in the requested order, nil if there is none.
+func (c *CompileCache) findSuperset(files []string) (*compileCacheEntry, linker.Files) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.keys) - 1; i >= 0; i-- {
+		entry := c.entries[c.keys[i]]
+		if len(entry.files) <= len(files) {
+			continue
+		}
+
+		result := make(linker.Files, 0, len(files))
+
+		for _, file := range files {
+			compiledFile := entry.files.FindFileByPath(file)
+			if compiledFile == nil {
+				break
+			}
+
+			result = append(result, compiledFile)
+		}
+
+		if len(result) == len(files) {
+			return entry, result
+		}
+	}
+
+	return nil, nil
+}
+
+// replayWarnings reports the warnings recorded while compiling the files of the entry again.
+// If only some of the files are reused, the warnings of the other requested files are skipped.
+func (e *compileCacheEntry) replayWarnings(rep reporter.Reporter, reused linker.Files) {
+	if rep == nil {
+		return
+	}
+
+	for _, warning := range e.warnings {
+		fileName := warning.GetPosition().Filename
+		if reused != nil && e.files.FindFileByPath(fileName) != nil && reused.FindFileByPath(fileName) == nil {
+			continue
+		}
+
+		rep.Warning(warning)
+	}
+}
+
+func (c *CompileCache) get(key string) *compileCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.entries[key]
+}
+
+func (c *CompileCache) put(key string, entry *compileCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		c.keys = append(c.keys, key)
+	}
+
+	c.entries[key] = entry
+
+	if len(c.keys) > compileCacheCapacity {
+		delete(c.entries, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+}
+
+// isUpToDate returns true if every source read while compiling the files still resolves to the same contents.
+// Resolving the sources again also lets the resolver register the dependencies they are downloaded from.
+func (e *compileCacheEntry) isUpToDate(resolver protocompile.Resolver) bool {
+	for path, checksum := range e.checksums {
+		result, err := resolver.FindFileByPath(path)
+		if err != nil || result.Source == nil {
+			return false
+		}
+
+		actualChecksum, _, err := readSourceChecksum(result.Source)
+		if err != nil || actualChecksum != checksum {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FindFileByPath resolves the file by the wrapped resolver, recording the checksum of its source.
+func (r *checksumResolver) FindFileByPath(path string) (protocompile.SearchResult, error) {
+	result, err := r.Resolver.FindFileByPath(path)
+	if err != nil || result.Source == nil {
+		return result, err
+	}
+
+	checksum, body, err := readSourceChecksum(result.Source)
+	if err != nil {
+		return protocompile.SearchResult{}, fmt.Errorf("failed to read source of %s: %w", path, err)
+	}
+
+	r.mu.Lock()
+	r.checksums[path] = checksum
+	r.mu.Unlock()
+
+	result.Source = bytes.NewReader(body)
+
+	return result, nil
+}
+
+// readSourceChecksum reads the source entirely, closing it if needed,
+// and returns the checksum of its contents along with the contents.
+func readSourceChecksum(source io.Reader) (string, []byte, error) {
+	if closer, ok := source.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	body, err := io.ReadAll(source)
+	if err != nil {
+		return "", nil, err
+	}
+
+	checksum := sha256.Sum256(body)
+
+	return hex.EncodeToString(checksum[:]), body, nil
+}
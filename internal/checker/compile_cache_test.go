@@ -0,0 +1,131 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+)
+
+func TestCompileCache(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "orders.proto")
+
+	writeSource := func(source string) {
+		if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err.Error())
+		}
+	}
+
+	writeSource("syntax = \"proto3\";\n\npackage orders;\n\nmessage Order {}\n")
+
+	var (
+		ctx      = context.Background()
+		cache    = NewCompileCache()
+		compiler = &protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+		}
+	)
+
+	first, err := cache.compile(ctx, compiler, []string{fileName})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	second, err := cache.compile(ctx, compiler, []string{fileName})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	if first[0] != second[0] {
+		t.Error("unchanged file is compiled again")
+	}
+
+	writeSource("syntax = \"proto3\";\n\npackage orders;\n\nmessage Order {}\n\nmessage Item {}\n")
+
+	third, err := cache.compile(ctx, compiler, []string{fileName})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	if third[0] == second[0] {
+		t.Fatal("changed file isn't compiled again")
+	}
+
+	if count := third[0].Messages().Len(); count != 2 {
+		t.Errorf("got %d messages, want 2", count)
+	}
+}
+
+func TestCompileCacheEviction(t *testing.T) {
+	cache := NewCompileCache()
+
+	for i := 0; i <= compileCacheCapacity; i++ {
+		cache.put(string(rune('a'+i)), &compileCacheEntry{})
+	}
+
+	if len(cache.entries) != compileCacheCapacity || len(cache.keys) != compileCacheCapacity {
+		t.Fatalf("got %d entries, want %d", len(cache.entries), compileCacheCapacity)
+	}
+
+	if cache.get("a") != nil {
+		t.Error("oldest entry isn't evicted")
+	}
+}
+
+func TestCompileCacheSuperset(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		orders    = filepath.Join(dir, "orders.proto")
+		customers = filepath.Join(dir, "customers.proto")
+		common    = filepath.Join(dir, "common.proto")
+		sources   = map[string]string{
+			common:    "syntax = \"proto3\";\n\npackage common;\n\nmessage Money {}\n",
+			orders:    "syntax = \"proto3\";\n\npackage orders;\n\nimport \"" + common + "\";\n\nmessage Order {}\n",
+			customers: "syntax = \"proto3\";\n\npackage customers;\n\nmessage Customer {}\n",
+		}
+	)
+
+	for fileName, source := range sources {
+		if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err.Error())
+		}
+	}
+
+	var (
+		ctx      = context.Background()
+		cache    = NewCompileCache()
+		compiler = &protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+		}
+	)
+
+	all, err := cache.compile(ctx, compiler, []string{orders, customers})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	subset, err := cache.compile(ctx, compiler, []string{customers})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	if len(subset) != 1 || subset[0] != all[1] {
+		t.Error("file compiled along with others is compiled again")
+	}
+
+	if err = os.WriteFile(common, []byte(sources[common]+"\nmessage Currency {}\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	changed, err := cache.compile(ctx, compiler, []string{orders})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	if changed[0] == all[0] {
+		t.Error("file with a changed import isn't compiled again")
+	}
+}
@@ -0,0 +1,235 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// coverageRecorder records descriptors checks are evaluated on and descriptors they report findings for.
+// All methods are no-ops on a nil receiver, so recording can be disabled by not creating it.
+type coverageRecorder struct {
+	evaluated map[string]map[string]struct{}
+	failed    map[string]map[string]struct{}
+	// running holds the evaluations in progress, the innermost one is the last.
+	running []*coverageEvaluation
+}
+
+// coverageEvaluation identifies an evaluation of a check on a descriptor.
+type coverageEvaluation struct {
+	check string
+	key   string
+}
+
+func newCoverageRecorder() *coverageRecorder {
+	return &coverageRecorder{
+		evaluated: make(map[string]map[string]struct{}),
+		failed:    make(map[string]map[string]struct{}),
+	}
+}
+
+// CollectCoverage compiles the files and returns, per check, the numbers of descriptors
+// it's evaluated on with all exclusions removed, the ones excluded by the configuration,
+// and the ones passing and failing it.
+func (c *ProtoChecker) CollectCoverage(ctx context.Context, files ...string) (*CoverageReport, error) {
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := compileCacheFromContext(ctx).compile(ctx, c.compiler, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	var (
+		index = newDescriptorIndex(parsedFiles)
+		// Checks are evaluated once as configured and once with exclusions removed to find out what they hide.
+		configuredChecker = &ProtoChecker{
+			compiler: c.compiler,
+			config:   c.config,
+			coverage: newCoverageRecorder(),
+		}
+		unrestrictedChecker = &ProtoChecker{
+			compiler: c.compiler,
+			config:   c.config.WithoutExclusions(),
+			coverage: newCoverageRecorder(),
+		}
+	)
+
+	for _, parsedFile := range parsedFiles {
+		configuredChecker.checkFile(parsedFile, index)
+		unrestrictedChecker.checkFile(parsedFile, index)
+	}
+
+	return newCoverageReport(configuredChecker.coverage, unrestrictedChecker.coverage), nil
+}
+
+// newCoverageReport compares evaluations of checks as configured with evaluations without exclusions.
+// Every known check is listed, even if it's evaluated on no descriptors.
+func newCoverageReport(configured, unrestricted *coverageRecorder) *CoverageReport {
+	report := &CoverageReport{
+		Rules: make([]*RuleCoverage, 0, len(registeredRules)),
+		Total: new(RuleCoverage),
+	}
+
+	for _, rule := range Rules() {
+		var (
+			linted   = configured.evaluated[rule.Name]
+			eligible = len(linted)
+			failing  int
+		)
+
+		for key := range unrestricted.evaluated[rule.Name] {
+			if _, ok := linted[key]; !ok {
+				eligible++
+			}
+		}
+
+		for key := range configured.failed[rule.Name] {
+			if _, ok := linted[key]; ok {
+				failing++
+			}
+		}
+
+		coverage := &RuleCoverage{
+			Check:    rule.Name,
+			Eligible: eligible,
+			Excluded: eligible - len(linted),
+			Passing:  len(linted) - failing,
+			Failing:  failing,
+		}
+		coverage.Coverage = calculateCoverage(coverage)
+
+		report.Rules = append(report.Rules, coverage)
+		report.Total.Eligible += coverage.Eligible
+		report.Total.Excluded += coverage.Excluded
+		report.Total.Passing += coverage.Passing
+		report.Total.Failing += coverage.Failing
+	}
+
+	report.Total.Coverage = calculateCoverage(report.Total)
+
+	return report
+}
+
+// calculateCoverage returns the percentage of eligible descriptors the check is actually evaluated on,
+// 100 if there are no eligible descriptors.
+func calculateCoverage(coverage *RuleCoverage) float64 {
+	if coverage.Eligible == 0 {
+		return maxScore
+	}
+
+	coverageRatio := float64(coverage.Eligible-coverage.Excluded) / float64(coverage.Eligible)
+
+	return math.Round(maxScore*coverageRatio*100) / 100 //nolint: gomnd // Two decimal places.
+}
+
+// WriteText writes the coverage of checks to the writer as a table.
+func (r *CoverageReport) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+
+	fmt.Fprintln(writer, "CHECK\tELIGIBLE\tEXCLUDED\tPASSING\tFAILING\tCOVERAGE")
+
+	for _, coverage := range append(r.Rules, r.Total) {
+		check := coverage.Check
+		if coverage == r.Total {
+			check = "TOTAL"
+		}
+
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t%.2f%%\n",
+			check,
+			coverage.Eligible,
+			coverage.Excluded,
+			coverage.Passing,
+			coverage.Failing,
+			coverage.Coverage)
+	}
+
+	return writer.Flush()
+}
+
+// WriteJSON writes the coverage of checks to the writer in JSON format.
+func (r *CoverageReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode coverage report: %w", err)
+	}
+
+	return nil
+}
+
+// startRule records the beginning of an evaluation of the check on the descriptor
+// for tracing and coverage, the returned function records its end.
+func (c *ProtoChecker) startRule(check string, desc protoreflect.Descriptor) func() {
+	var (
+		finishCoverage = c.coverage.start(c.config, check, desc)
+		finishTracing  = c.tracer.start(check, desc)
+	)
+
+	return func() {
+		finishTracing()
+		finishCoverage()
+	}
+}
+
+// start records the evaluation of the check on the descriptor unless the check is excluded
+// for the descriptor by overrides of the configuration, the returned function records its end.
+func (r *coverageRecorder) start(cfg *config.Config, check string, desc protoreflect.Descriptor) func() {
+	if r == nil {
+		return func() {}
+	}
+
+	var (
+		path     = desc.ParentFile().Path()
+		fullName = string(desc.FullName())
+	)
+
+	if cfg.ForScope(path, fullName).IsCheckExcluded(check) {
+		return func() {}
+	}
+
+	evaluation := &coverageEvaluation{
+		check: check,
+		key:   strings.Join([]string{path, fullName}, ":"),
+	}
+
+	addCoverageKey(r.evaluated, check, evaluation.key)
+
+	r.running = append(r.running, evaluation)
+
+	return func() {
+		r.running = r.running[:len(r.running)-1]
+	}
+}
+
+// fail records that the innermost running evaluation of the check reported a finding.
+func (r *coverageRecorder) fail(check string) {
+	if r == nil {
+		return
+	}
+
+	for i := len(r.running) - 1; i >= 0; i-- {
+		if r.running[i].check == check {
+			addCoverageKey(r.failed, check, r.running[i].key)
+
+			return
+		}
+	}
+}
+
+func addCoverageKey(keys map[string]map[string]struct{}, check, key string) {
+	checkKeys, ok := keys[check]
+	if !ok {
+		checkKeys = make(map[string]struct{})
+		keys[check] = checkKeys
+	}
+
+	checkKeys[key] = struct{}{}
+}
@@ -0,0 +1,49 @@
+package checker
+
+import "testing"
+
+func TestNewCoverageReport(t *testing.T) {
+	var (
+		configured   = newCoverageRecorder()
+		unrestricted = newCoverageRecorder()
+	)
+
+	// Three methods are eligible, one of them is excluded and one of the checked ones fails.
+	for _, key := range []string{"a.proto:shop.v1.S.GetV1", "a.proto:shop.v1.S.List", "a.proto:shop.v1.S.Legacy"} {
+		addCoverageKey(unrestricted.evaluated, MethodHasVersion, key)
+	}
+
+	addCoverageKey(configured.evaluated, MethodHasVersion, "a.proto:shop.v1.S.GetV1")
+	addCoverageKey(configured.evaluated, MethodHasVersion, "a.proto:shop.v1.S.List")
+	addCoverageKey(configured.failed, MethodHasVersion, "a.proto:shop.v1.S.List")
+
+	// The check is excluded entirely.
+	addCoverageKey(unrestricted.evaluated, MessageNotEmpty, "a.proto:shop.v1.Empty")
+
+	report := newCoverageReport(configured, unrestricted)
+	if len(report.Rules) != len(registeredRules) {
+		t.Fatalf("expected coverage of all %d checks, got %d", len(registeredRules), len(report.Rules))
+	}
+
+	coverages := make(map[string]*RuleCoverage, len(report.Rules))
+	for _, coverage := range report.Rules {
+		coverages[coverage.Check] = coverage
+	}
+
+	expected := map[string]RuleCoverage{
+		MethodHasVersion:      {Check: MethodHasVersion, Eligible: 3, Excluded: 1, Passing: 1, Failing: 1, Coverage: 66.67},
+		MessageNotEmpty:       {Check: MessageNotEmpty, Eligible: 1, Excluded: 1, Coverage: 0},
+		FieldHasNoDescription: {Check: FieldHasNoDescription, Coverage: 100},
+	}
+
+	for check, coverage := range expected {
+		if actual := coverages[check]; actual == nil || *actual != coverage {
+			t.Errorf("unexpected coverage of %s: %+v, expected %+v", check, actual, coverage)
+		}
+	}
+
+	total := RuleCoverage{Eligible: 4, Excluded: 2, Passing: 1, Failing: 1, Coverage: 50}
+	if *report.Total != total {
+		t.Errorf("unexpected total coverage: %+v, expected %+v", report.Total, total)
+	}
+}
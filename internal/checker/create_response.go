@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	createVerb    = "Create"
+	emptyFullName = "google.protobuf.Empty"
+)
+
+var methodVersionSuffixRegexp = regexp.MustCompile(`V\d+$`)
+
+// checkCreateResponse checks that a method named like CreateOrderV1 returns the resource it creates:
+// the Order message itself or, unless the strictness requires the resource itself, a message having an Order field.
+func (c *ProtoChecker) checkCreateResponse(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	methodName := string(method.Name())
+	if !hasVerbPrefix(methodName, createVerb) {
+		return
+	}
+
+	resourceName := methodVersionSuffixRegexp.ReplaceAllString(strings.TrimPrefix(methodName, createVerb), "")
+	if resourceName == "" {
+		return
+	}
+
+	c.runRule(MethodCreateReturnsResource, method, func() {
+		output := method.Output()
+
+		switch {
+		case output.FullName() == emptyFullName:
+			result.AddFindingf(
+				MethodCreateReturnsResource,
+				method,
+				"Method %s returns %s instead of the created %s",
+				methodLogName,
+				emptyFullName,
+				resourceName)
+		case string(output.Name()) == resourceName:
+		case c.config.GetCreateResponseStrictness() == config.CreateResponseResource:
+			result.AddFindingf(
+				MethodCreateReturnsResource,
+				method,
+				"Method %s returns %s instead of the created %s",
+				methodLogName,
+				output.FullName(),
+				resourceName)
+		case !hasFieldOfMessage(output, resourceName):
+			result.AddFindingf(
+				MethodCreateReturnsResource,
+				method,
+				"Response %s of method %s has no field of the created %s",
+				output.FullName(),
+				methodLogName,
+				resourceName)
+		}
+	})
+}
+
+// hasFieldOfMessage returns true if the message has a singular field of a message with the name.
+func hasFieldOfMessage(message protoreflect.MessageDescriptor, name string) bool {
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		if !field.IsList() && !field.IsMap() && field.Message() != nil && string(field.Message().Name()) == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const cyclePathSeparator = " -> "
+
+// findShortestCycle returns the shortest chain of messages leading from the message back to itself
+// through message-typed fields, including map values, or nil if the message isn't recursive.
+// The chain starts and ends with the message, map entries are omitted.
+func findShortestCycle(message protoreflect.MessageDescriptor) []protoreflect.MessageDescriptor {
+	var (
+		start   = message.FullName()
+		parents = map[protoreflect.FullName]protoreflect.MessageDescriptor{}
+		queue   = []protoreflect.MessageDescriptor{message}
+	)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		fields := current.Fields()
+		for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
+			next := fields.Get(fieldIndex).Message()
+			if next == nil {
+				continue
+			}
+
+			if next.FullName() == start {
+				return buildCyclePath(message, current, parents)
+			}
+
+			if _, ok := parents[next.FullName()]; ok {
+				continue
+			}
+
+			parents[next.FullName()] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func buildCyclePath(
+	start, last protoreflect.MessageDescriptor,
+	parents map[protoreflect.FullName]protoreflect.MessageDescriptor,
+) []protoreflect.MessageDescriptor {
+	result := []protoreflect.MessageDescriptor{start}
+
+	for current := last; current.FullName() != start.FullName(); current = parents[current.FullName()] {
+		if !current.IsMapEntry() {
+			result = append(result, current)
+		}
+	}
+
+	result = append(result, start)
+
+	// The chain was collected backwards.
+	for i, j := 1, len(result)-2; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return result
+}
+
+func formatCyclePath(path []protoreflect.MessageDescriptor) string {
+	names := make([]string, 0, len(path))
+	for _, message := range path {
+		names = append(names, string(message.Name()))
+	}
+
+	return strings.Join(names, cyclePathSeparator)
+}
@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkDeprecatedImports checks that the file doesn't import deprecated packages or files,
+// nor references their types made available by public imports.
+// Files deprecated by the same rule may depend on each other.
+func (c *ProtoChecker) checkDeprecatedImports(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(NoDeprecatedImports) || len(c.config.GetDeprecatedImports()) == 0 {
+		return
+	}
+
+	defer c.startRule(NoDeprecatedImports, parsedFile)()
+
+	var (
+		ownRule         = c.config.FindDeprecatedImport(string(parsedFile.Package()), parsedFile.Path())
+		imports         = parsedFile.Imports()
+		importedFiles   = make(map[string]struct{}, imports.Len())
+		sourceLocations = parsedFile.SourceLocations()
+	)
+
+	for i := 0; i < imports.Len(); i++ {
+		imported := imports.Get(i)
+		importedFiles[imported.Path()] = struct{}{}
+
+		deprecated := c.config.FindDeprecatedImport(string(imported.Package()), imported.Path())
+		if deprecated == nil || deprecated == ownRule {
+			continue
+		}
+
+		location := sourceLocations.ByPath(protoreflect.SourcePath{dependencyFieldNumber, int32(i)})
+		if deprecated.Replacement == "" {
+			result.AddFindingAtf(
+				NoDeprecatedImports,
+				nil,
+				location,
+				"Import of deprecated %s of package %s",
+				imported.Path(),
+				imported.Package())
+
+			continue
+		}
+
+		result.AddFindingAtf(
+			NoDeprecatedImports,
+			nil,
+			location,
+			"Import of deprecated %s of package %s, use %s instead",
+			imported.Path(),
+			imported.Package(),
+			deprecated.Replacement)
+	}
+
+	// Types of directly imported files are already reported with the import.
+	walkTypeReferences(parsedFile, func(desc, referenced protoreflect.Descriptor) {
+		referencedFile := referenced.ParentFile()
+		if _, ok := importedFiles[referencedFile.Path()]; ok || referencedFile.Path() == parsedFile.Path() {
+			return
+		}
+
+		deprecated := c.config.FindDeprecatedImport(string(referencedFile.Package()), referencedFile.Path())
+		if deprecated == nil || deprecated == ownRule {
+			return
+		}
+
+		reportDeprecatedReference(result, desc, referenced, deprecated)
+	})
+}
+
+// reportDeprecatedReference reports a reference to a type of a deprecated package or file.
+func reportDeprecatedReference(
+	result *CheckResult,
+	desc protoreflect.Descriptor,
+	referenced protoreflect.Descriptor,
+	deprecated *config.DeprecatedImport,
+) {
+	if deprecated.Replacement == "" {
+		result.AddFindingf(
+			NoDeprecatedImports,
+			desc,
+			"Reference to deprecated %s of %s",
+			referenced.FullName(),
+			referenced.ParentFile().Path())
+
+		return
+	}
+
+	result.AddFindingf(
+		NoDeprecatedImports,
+		desc,
+		"Reference to deprecated %s of %s, use %s instead",
+		referenced.FullName(),
+		referenced.ParentFile().Path(),
+		deprecated.Replacement)
+}
@@ -0,0 +1,255 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+// maxSuggestionDistance is the maximum number of edits turning an unknown check name into a suggested one.
+const maxSuggestionDistance = 3
+
+// loadConfig loads the configuration and replaces the former names of checks with the current ones,
+// warning about every mention of a renamed or retired check.
+func loadConfig(ctx context.Context, configPath string) (*config.Config, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, problem := range append(findDeprecatedChecks(cfg), findDeprecatedOptions(cfg)...) {
+		logger.Warn(ctx, problem.Message)
+	}
+
+	cfg.RenameChecks(getCheckNewNames())
+
+	return cfg, nil
+}
+
+// renameDeprecatedChecks returns the list of checks with the former names replaced by the current ones,
+// warning about every renamed check.
+func renameDeprecatedChecks(ctx context.Context, checks []string) []string {
+	var (
+		newNames = getCheckNewNames()
+		result   = make([]string, 0, len(checks))
+	)
+
+	for _, check := range checks {
+		if newName, ok := newNames[check]; ok {
+			logger.Warnf(ctx, "Check %s is renamed to %s", check, newName)
+
+			check = newName
+		}
+
+		result = append(result, check)
+	}
+
+	return result
+}
+
+// ValidateConfig returns the problems of the check names mentioned in the configuration:
+// unknown checks are errors, renamed and retired checks are warnings suggesting replacements.
+// Score weights of unknown categories are errors as well.
+func ValidateConfig(cfg *config.Config) []*ConfigProblem {
+	var (
+		result       = append(findDeprecatedChecks(cfg), findDeprecatedOptions(cfg)...)
+		knownChecks  = make(map[string]struct{}, len(registeredRules))
+		newNames     = getCheckNewNames()
+		retiredNames = getRetiredRules()
+	)
+
+	for _, rule := range registeredRules {
+		knownChecks[rule.Name] = struct{}{}
+	}
+
+	for _, reference := range cfg.GetCheckReferences() {
+		if _, ok := knownChecks[reference.Check]; ok {
+			continue
+		}
+
+		if _, ok := newNames[reference.Check]; ok {
+			continue
+		}
+
+		if _, ok := retiredNames[reference.Check]; ok {
+			continue
+		}
+
+		message := fmt.Sprintf("%s: unknown check %s", reference.Key, reference.Check)
+		if suggestion := suggestCheckName(reference.Check); suggestion != "" {
+			message = fmt.Sprintf("%s, did you mean %s?", message, suggestion)
+		}
+
+		result = append(result, &ConfigProblem{
+			Severity: config.SeverityError,
+			Message:  message,
+		})
+	}
+
+	return append(result, findUnknownCategories(cfg)...)
+}
+
+// findUnknownCategories returns the errors about score weights of categories no check belongs to.
+func findUnknownCategories(cfg *config.Config) []*ConfigProblem {
+	if cfg == nil {
+		return nil
+	}
+
+	knownCategories := make(map[string]struct{})
+	for _, rule := range registeredRules {
+		knownCategories[rule.Category] = struct{}{}
+	}
+
+	categories := make([]string, 0, len(cfg.Score.CategoryWeights))
+	for category := range cfg.Score.CategoryWeights {
+		categories = append(categories, category)
+	}
+
+	sort.Strings(categories)
+
+	var result []*ConfigProblem
+
+	for _, category := range categories {
+		if _, ok := knownCategories[category]; ok {
+			continue
+		}
+
+		result = append(result, &ConfigProblem{
+			Severity: config.SeverityError,
+			Message:  fmt.Sprintf("score.category_weights: unknown category %s", category),
+		})
+	}
+
+	return result
+}
+
+// findDeprecatedChecks returns the warnings about mentions of renamed and retired checks.
+func findDeprecatedChecks(cfg *config.Config) []*ConfigProblem {
+	var (
+		result       []*ConfigProblem
+		newNames     = getCheckNewNames()
+		retiredNames = getRetiredRules()
+	)
+
+	for _, reference := range cfg.GetCheckReferences() {
+		if newName, ok := newNames[reference.Check]; ok {
+			result = append(result, &ConfigProblem{
+				Severity: config.SeverityWarning,
+				Message: fmt.Sprintf("%s: check %s is deprecated, use %s instead",
+					reference.Key,
+					reference.Check,
+					newName),
+			})
+
+			continue
+		}
+
+		retiredRule, ok := retiredNames[reference.Check]
+		if !ok {
+			continue
+		}
+
+		message := fmt.Sprintf("%s: check %s is retired: %s", reference.Key, reference.Check, retiredRule.Reason)
+		if retiredRule.Replacement != "" {
+			message = fmt.Sprintf("%s, use %s instead", message, retiredRule.Replacement)
+		}
+
+		result = append(result, &ConfigProblem{
+			Severity: config.SeverityWarning,
+			Message:  message,
+		})
+	}
+
+	return result
+}
+
+// findDeprecatedOptions returns the warnings about deprecated options set in the configuration.
+func findDeprecatedOptions(cfg *config.Config) []*ConfigProblem {
+	var result []*ConfigProblem
+
+	for _, option := range cfg.GetDeprecatedOptions() {
+		result = append(result, &ConfigProblem{
+			Severity: config.SeverityWarning,
+			Message:  fmt.Sprintf("%s: option is deprecated, %s", option.Key, option.Replacement),
+		})
+	}
+
+	return result
+}
+
+// getCheckNewNames maps the former names of checks to the current ones.
+func getCheckNewNames() map[string]string {
+	result := make(map[string]string)
+
+	for _, rule := range registeredRules {
+		for _, alias := range rule.Aliases {
+			result[alias] = rule.Name
+		}
+	}
+
+	return result
+}
+
+func getRetiredRules() map[string]*RetiredRule {
+	result := make(map[string]*RetiredRule, len(retiredRules))
+	for _, rule := range retiredRules {
+		result[rule.Name] = rule
+	}
+
+	return result
+}
+
+// suggestCheckName returns the name of the check closest to the unknown one,
+// or an empty string if no check is close enough.
+func suggestCheckName(name string) string {
+	var (
+		result       string
+		bestDistance = maxSuggestionDistance + 1
+	)
+
+	for _, rule := range registeredRules {
+		if distance := getEditDistance(name, rule.Name); distance < bestDistance {
+			result = rule.Name
+			bestDistance = distance
+		}
+	}
+
+	return result
+}
+
+// getEditDistance returns the Levenshtein distance between the strings.
+func getEditDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,90 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestValidateConfig(t *testing.T) {
+	rule := registeredRules[0]
+	previousAliases, previousRetiredRules := rule.Aliases, retiredRules
+
+	t.Cleanup(func() {
+		rule.Aliases, retiredRules = previousAliases, previousRetiredRules
+	})
+
+	rule.Aliases = []string{"former_check"}
+	retiredRules = []*RetiredRule{
+		{
+			Name:        "retired_check",
+			Reason:      "it duplicated another check",
+			Replacement: rule.Name,
+		},
+	}
+
+	misspelledName := rule.Name[:len(rule.Name)-1]
+
+	cfg := &config.Config{
+		ExcludedChecks: []string{"former_check", "retired_check", misspelledName, rule.Name},
+	}
+
+	problems := ValidateConfig(cfg)
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got %d", len(problems))
+	}
+
+	expected := []struct {
+		severity config.Severity
+		contains string
+	}{
+		{config.SeverityWarning, "use " + rule.Name},
+		{config.SeverityWarning, "retired: it duplicated another check"},
+		{config.SeverityError, "did you mean " + rule.Name + "?"},
+	}
+
+	for i, e := range expected {
+		if problems[i].Severity != e.severity || !strings.Contains(problems[i].Message, e.contains) {
+			t.Errorf("expected %s problem containing %q, got %s problem %q",
+				e.severity,
+				e.contains,
+				problems[i].Severity,
+				problems[i].Message)
+		}
+	}
+
+	cfg.RenameChecks(getCheckNewNames())
+
+	if cfg.ExcludedChecks[0] != rule.Name {
+		t.Errorf("expected former name to be replaced with %s, got %s", rule.Name, cfg.ExcludedChecks[0])
+	}
+}
+
+func TestSuggestCheckName(t *testing.T) {
+	if suggestion := suggestCheckName("message_not_empt"); suggestion != MessageNotEmpty {
+		t.Errorf("expected suggestion %s, got %q", MessageNotEmpty, suggestion)
+	}
+
+	if suggestion := suggestCheckName("something_completely_different"); suggestion != "" {
+		t.Errorf("expected no suggestion, got %s", suggestion)
+	}
+}
+
+func TestValidateConfigDeprecatedOptions(t *testing.T) {
+	failAfterDate := false
+
+	cfg := &config.Config{
+		RemovalNote: config.RemovalNoteOptions{FailAfterDate: &failAfterDate},
+	}
+
+	problems := ValidateConfig(cfg)
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %d", len(problems))
+	}
+
+	if problems[0].Severity != config.SeverityWarning ||
+		!strings.Contains(problems[0].Message, "deprecated_field_has_removal_note.fail_after_date") {
+		t.Errorf("expected warning about fail_after_date, got %s problem %q", problems[0].Severity, problems[0].Message)
+	}
+}
@@ -0,0 +1,277 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// directiveName is the name of comment directives suppressing findings.
+	directiveName = "protolinter:disable"
+	// directiveReasonSeparator separates the list of checks of a directive from the explanation.
+	directiveReasonSeparator = "--"
+)
+
+// directiveRegexp matches suppression directives like "protolinter:disable field_has_no_description -- legacy"
+// in comments, capturing the rest of the line.
+var directiveRegexp = regexp.MustCompile(regexp.QuoteMeta(directiveName) + `\b([^\n]*)`)
+
+// directive is a suppression comment of a descriptor disabling the listed checks for the descriptor
+// and the descriptors nested in it.
+type directive struct {
+	desc protoreflect.Descriptor
+	// names are the names of checks as written in the comment.
+	names []string
+	// checks map the current names of the listed checks to whether they suppressed a finding.
+	checks map[string]bool
+}
+
+// collectDirectives returns the suppression directives of the leading, trailing and detached comments
+// of the descriptors declared in the file, indexed by the full names of the descriptors.
+func collectDirectives(parsedFile linker.File) map[protoreflect.FullName][]*directive {
+	var (
+		result    = make(map[protoreflect.FullName][]*directive)
+		newNames  = getCheckNewNames()
+		locations = parsedFile.SourceLocations()
+	)
+
+	rangeFileDescriptors(parsedFile, func(desc protoreflect.Descriptor) {
+		location := locations.ByDescriptor(desc)
+
+		comments := append([]string{location.LeadingComments, location.TrailingComments},
+			location.LeadingDetachedComments...)
+
+		for _, comment := range comments {
+			for _, match := range directiveRegexp.FindAllStringSubmatch(comment, -1) {
+				text, _, _ := strings.Cut(match[1], directiveReasonSeparator)
+
+				names := strings.FieldsFunc(text, func(r rune) bool {
+					return r == ',' || unicode.IsSpace(r)
+				})
+
+				checks := make(map[string]bool, len(names))
+				for _, name := range names {
+					if newName, ok := newNames[name]; ok {
+						name = newName
+					}
+
+					checks[name] = false
+				}
+
+				result[desc.FullName()] = append(result[desc.FullName()], &directive{
+					desc:   desc,
+					names:  names,
+					checks: checks,
+				})
+			}
+		}
+	})
+
+	return result
+}
+
+// suppresses returns true if a directive of the descriptor or of a descriptor it's nested in
+// disables the check, marking the directive as used.
+func (c *CheckResult) suppresses(check string, desc protoreflect.Descriptor) bool {
+	if len(c.directives) == 0 || desc == nil || check == DirectiveIsValid {
+		return false
+	}
+
+	for parent := desc; parent != nil; parent = parent.Parent() {
+		if _, ok := parent.(protoreflect.FileDescriptor); ok {
+			return false
+		}
+
+		for _, d := range c.directives[parent.FullName()] {
+			if _, ok := d.checks[check]; ok {
+				d.checks[check] = true
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkDirectives checks that suppression directives of the file name known checks
+// and suppress at least one finding of every check that isn't excluded for the descriptor.
+// It must run after all other checks of the file.
+func (c *ProtoChecker) checkDirectives(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(DirectiveIsValid) || len(result.directives) == 0 {
+		return
+	}
+
+	defer c.startRule(DirectiveIsValid, parsedFile)()
+
+	var (
+		knownChecks  = make(map[string]struct{}, len(registeredRules))
+		newNames     = getCheckNewNames()
+		retiredNames = getRetiredRules()
+	)
+
+	for _, rule := range registeredRules {
+		knownChecks[rule.Name] = struct{}{}
+	}
+
+	rangeFileDescriptors(parsedFile, func(desc protoreflect.Descriptor) {
+		for _, d := range result.directives[desc.FullName()] {
+			c.checkDirective(d, result, knownChecks, newNames, retiredNames)
+		}
+	})
+}
+
+// checkDirective reports the problems of a single suppression directive.
+func (c *ProtoChecker) checkDirective(
+	d *directive,
+	result *CheckResult,
+	knownChecks map[string]struct{},
+	newNames map[string]string,
+	retiredNames map[string]*RetiredRule,
+) {
+	descName := string(d.desc.FullName())
+
+	if len(d.names) == 0 {
+		result.AddFindingf(
+			DirectiveIsValid,
+			d.desc,
+			"Directive %s of %s names no checks",
+			directiveName,
+			descName)
+
+		return
+	}
+
+	scopeConfig := c.config.ForScope(d.desc.ParentFile().Path(), descName)
+
+	for _, name := range d.names {
+		check := name
+		if newName, ok := newNames[name]; ok {
+			check = newName
+
+			result.AddFindingf(
+				DirectiveIsValid,
+				d.desc,
+				"Directive %s of %s names check %s, which is renamed to %s",
+				directiveName,
+				descName,
+				name,
+				newName)
+		}
+
+		if _, ok := retiredNames[check]; ok {
+			result.AddFindingf(
+				DirectiveIsValid,
+				d.desc,
+				"Directive %s of %s names retired check %s",
+				directiveName,
+				descName,
+				check)
+
+			continue
+		}
+
+		if _, ok := knownChecks[check]; !ok {
+			c.reportUnknownDirectiveCheck(d, result, check)
+
+			continue
+		}
+
+		if !d.checks[check] && !scopeConfig.IsCheckExcluded(check) {
+			result.AddFindingf(
+				DirectiveIsValid,
+				d.desc,
+				"Directive %s of %s doesn't suppress any finding of check %s",
+				directiveName,
+				descName,
+				check)
+		}
+	}
+}
+
+// reportUnknownDirectiveCheck reports a directive naming an unknown check, suggesting the closest known one.
+func (c *ProtoChecker) reportUnknownDirectiveCheck(d *directive, result *CheckResult, check string) {
+	if suggestion := suggestCheckName(check); suggestion != "" {
+		result.AddFindingf(
+			DirectiveIsValid,
+			d.desc,
+			"Directive %s of %s names unknown check %s, did you mean %s?",
+			directiveName,
+			d.desc.FullName(),
+			check,
+			suggestion)
+
+		return
+	}
+
+	result.AddFindingf(
+		DirectiveIsValid,
+		d.desc,
+		"Directive %s of %s names unknown check %s",
+		directiveName,
+		d.desc.FullName(),
+		check)
+}
+
+// rangeFileDescriptors calls the function for every descriptor declared in the file in the order of declaration
+// within its kind: services and their methods, messages with their fields, oneofs and nested types,
+// enums with their values and extensions.
+func rangeFileDescriptors(parsedFile linker.File, f func(desc protoreflect.Descriptor)) {
+	services := parsedFile.Services()
+	for serviceIndex := 0; serviceIndex < services.Len(); serviceIndex++ {
+		service := services.Get(serviceIndex)
+		f(service)
+
+		methods := service.Methods()
+		for methodIndex := 0; methodIndex < methods.Len(); methodIndex++ {
+			f(methods.Get(methodIndex))
+		}
+	}
+
+	rangeMessageDescriptors(parsedFile.Messages(), f)
+	rangeEnumDescriptors(parsedFile.Enums(), f)
+	rangeExtensionDescriptors(parsedFile.Extensions(), f)
+}
+
+func rangeMessageDescriptors(messages protoreflect.MessageDescriptors, f func(desc protoreflect.Descriptor)) {
+	for messageIndex := 0; messageIndex < messages.Len(); messageIndex++ {
+		message := messages.Get(messageIndex)
+		f(message)
+
+		fields := message.Fields()
+		for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
+			f(fields.Get(fieldIndex))
+		}
+
+		oneofs := message.Oneofs()
+		for oneofIndex := 0; oneofIndex < oneofs.Len(); oneofIndex++ {
+			f(oneofs.Get(oneofIndex))
+		}
+
+		rangeMessageDescriptors(message.Messages(), f)
+		rangeEnumDescriptors(message.Enums(), f)
+		rangeExtensionDescriptors(message.Extensions(), f)
+	}
+}
+
+func rangeEnumDescriptors(enums protoreflect.EnumDescriptors, f func(desc protoreflect.Descriptor)) {
+	for enumIndex := 0; enumIndex < enums.Len(); enumIndex++ {
+		enum := enums.Get(enumIndex)
+		f(enum)
+
+		values := enum.Values()
+		for valueIndex := 0; valueIndex < values.Len(); valueIndex++ {
+			f(values.Get(valueIndex))
+		}
+	}
+}
+
+func rangeExtensionDescriptors(extensions protoreflect.ExtensionDescriptors, f func(desc protoreflect.Descriptor)) {
+	for extensionIndex := 0; extensionIndex < extensions.Len(); extensionIndex++ {
+		f(extensions.Get(extensionIndex))
+	}
+}
@@ -0,0 +1,216 @@
+package checker
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+const bufModuleFileName = "buf.yaml"
+
+// mimirFileNames is a list of file names recognized as mimir files during auto-discovery.
+var mimirFileNames = map[string]struct{}{
+	"mimir.yaml":  {},
+	"mimir.yml":   {},
+	".mimir.yaml": {},
+	".mimir.yml":  {},
+}
+
+// skippedDirectoryNames is a list of directories never descended into during auto-discovery.
+var skippedDirectoryNames = map[string]struct{}{
+	".git":         {},
+	"vendor":       {},
+	"node_modules": {},
+}
+
+type discoveredManifests struct {
+	mimirFiles []string
+	bufModules []string
+	configDirs map[string]string
+	protoFiles []string
+}
+
+// discoverCheckScopes walks the directory tree looking for mimir files, buf modules
+// and configuration files, and turns them into check scopes.
+// Files listed in mimir files take precedence over buf modules,
+// which take precedence over plain directories having a configuration file.
+// Each scope is checked with the configuration file closest to it,
+// falling back to the default configuration.
+func discoverCheckScopes(root, defaultConfigPath string) ([]*checkScope, []*DiscoveryError, error) {
+	manifests, discoveryErrors, err := walkManifests(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		result       []*checkScope
+		claimedFiles = make(map[string]struct{})
+		scopesMap    = make(map[string]*checkScope)
+	)
+
+	for _, mimirFile := range manifests.mimirFiles {
+		mimirConfig, err := readMimirConfig(mimirFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Files excluded by a mimir file must not be picked up by other scopes.
+		for _, file := range manifests.protoFiles {
+			if isPathMatched(file, mimirConfig.ExcludePaths) {
+				claimedFiles[file] = struct{}{}
+			}
+		}
+
+		configPath := manifests.findNearestConfig(filepath.Dir(mimirFile), defaultConfigPath)
+
+		scopes, mimirErrors, err := extractScopesFromMimir([]string{mimirFile}, configPath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		discoveryErrors = append(discoveryErrors, mimirErrors...)
+
+		for _, scope := range scopes {
+			for _, sourcePath := range scope.sourcePaths {
+				claimedFiles[filepath.Clean(sourcePath)] = struct{}{}
+			}
+		}
+
+		result = append(result, scopes...)
+	}
+
+	addFile := func(file, configPath string, importRoots []string) {
+		var (
+			scopeKey     = strings.Join(append([]string{configPath}, importRoots...), "\x00")
+			compiledName = trimImportRoot(file, importRoots)
+		)
+
+		scope, ok := scopesMap[scopeKey]
+		if !ok {
+			scope = &checkScope{
+				configPath:  configPath,
+				importRoots: importRoots,
+			}
+
+			scopesMap[scopeKey] = scope
+			result = append(result, scope)
+		}
+
+		claimedFiles[file] = struct{}{}
+		scope.files = append(scope.files, compiledName)
+		scope.sourcePaths = append(scope.sourcePaths, file)
+	}
+
+	for _, bufModule := range manifests.bufModules {
+		configPath := manifests.findNearestConfig(bufModule, defaultConfigPath)
+
+		for _, file := range manifests.protoFiles {
+			if _, ok := claimedFiles[file]; ok || !isPathMatched(file, []string{bufModule}) {
+				continue
+			}
+
+			addFile(file, configPath, []string{bufModule})
+		}
+	}
+
+	for _, file := range manifests.protoFiles {
+		if _, ok := claimedFiles[file]; ok {
+			continue
+		}
+
+		configPath := manifests.findNearestConfig(filepath.Dir(file), "")
+		if configPath == "" {
+			continue
+		}
+
+		addFile(file, configPath, nil)
+	}
+
+	return result, discoveryErrors, nil
+}
+
+func walkManifests(root string) (*discoveredManifests, []*DiscoveryError, error) {
+	var (
+		result = &discoveredManifests{
+			configDirs: make(map[string]string),
+		}
+		discoveryErrors []*DiscoveryError
+	)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The root itself must be readable, broken entries below it are skipped.
+			if path == root {
+				return err
+			}
+
+			discoveryErrors = append(discoveryErrors, &DiscoveryError{
+				Pattern: root,
+				Path:    path,
+				Err:     err,
+			})
+
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		name := d.Name()
+
+		if d.IsDir() {
+			if _, ok := skippedDirectoryNames[name]; ok && path != root {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+
+		switch {
+		case name == config.DefaultConfigName:
+			result.configDirs[dir] = path
+		case name == bufModuleFileName:
+			result.bufModules = append(result.bufModules, dir)
+		case filepath.Ext(name) == protoFileExtension:
+			result.protoFiles = append(result.protoFiles, path)
+		default:
+			if _, ok := mimirFileNames[name]; ok {
+				result.mimirFiles = append(result.mimirFiles, path)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nested buf modules must claim their files before the enclosing ones.
+	sort.Slice(result.bufModules, func(i, j int) bool {
+		return len(result.bufModules[i]) > len(result.bufModules[j])
+	})
+
+	return result, discoveryErrors, nil
+}
+
+// findNearestConfig returns the configuration file located in the directory or the closest of its parents.
+func (m *discoveredManifests) findNearestConfig(dir, defaultConfigPath string) string {
+	for {
+		if configPath, ok := m.configDirs[dir]; ok {
+			return configPath
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return defaultConfigPath
+		}
+
+		dir = parent
+	}
+}
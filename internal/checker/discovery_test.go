@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverCheckScopes(t *testing.T) {
+	const (
+		syntax       = "syntax = \"proto3\";\n"
+		mimirContent = `proto_paths:
+  - services/orders/*.proto
+exclude_paths:
+  - services/orders/legacy
+`
+	)
+
+	chdirForTest(t, t.TempDir())
+
+	for file, content := range map[string]string{
+		// The mimir file claims its files before the buf module enclosing them,
+		// and its excluded files aren't picked up by the buf module either.
+		"mimir.yaml":                          mimirContent,
+		"services/orders/buf.yaml":            "version: v1\n",
+		"services/orders/.protolinter.yaml":   "verbose_mode: false\n",
+		"services/orders/orders.proto":        syntax,
+		"services/orders/legacy/legacy.proto": syntax,
+		"services/orders/v2/orders.proto":     syntax,
+		// The nested buf module claims its files before the enclosing one,
+		// both are checked with the default configuration, having no configuration file of their own.
+		"libs/buf.yaml":          "version: v1\n",
+		"libs/common.proto":      syntax,
+		"libs/inner/buf.yaml":    "version: v1\n",
+		"libs/inner/inner.proto": syntax,
+		// Plain directories are checked with the nearest configuration file of their parents.
+		"teams/payments/.protolinter.yaml": "verbose_mode: false\n",
+		"teams/payments/v1/payments.proto": syntax,
+		// Files without a configuration file outside of mimir files and buf modules are skipped.
+		"tools/tools.proto": syntax,
+		// Vendored files are never discovered.
+		"vendor/buf.yaml":       "version: v1\n",
+		"vendor/vendored.proto": syntax,
+	} {
+		writeTestFileWithDirs(t, file, content)
+	}
+
+	scopes, discoveryErrors, err := discoverCheckScopes(".", "default.yaml")
+	if err != nil {
+		t.Fatalf("failed to discover check scopes: %s", err.Error())
+	}
+
+	if len(discoveryErrors) > 0 {
+		t.Fatalf("unexpected discovery errors: %v", discoveryErrors)
+	}
+
+	expected := []*checkScope{
+		{
+			configPath:  "default.yaml",
+			files:       []string{"services/orders/orders.proto"},
+			sourcePaths: []string{"services/orders/orders.proto"},
+		},
+		{
+			configPath:  "services/orders/.protolinter.yaml",
+			importRoots: []string{"services/orders"},
+			files:       []string{"v2/orders.proto"},
+			sourcePaths: []string{"services/orders/v2/orders.proto"},
+		},
+		{
+			configPath:  "default.yaml",
+			importRoots: []string{"libs/inner"},
+			files:       []string{"inner.proto"},
+			sourcePaths: []string{"libs/inner/inner.proto"},
+		},
+		{
+			configPath:  "default.yaml",
+			importRoots: []string{"libs"},
+			files:       []string{"common.proto"},
+			sourcePaths: []string{"libs/common.proto"},
+		},
+		{
+			configPath:  "teams/payments/.protolinter.yaml",
+			files:       []string{"teams/payments/v1/payments.proto"},
+			sourcePaths: []string{"teams/payments/v1/payments.proto"},
+		},
+	}
+
+	if len(scopes) != len(expected) {
+		t.Fatalf("expected %d scopes, got %d: %v", len(expected), len(scopes), describeScopes(scopes))
+	}
+
+	for i, scope := range scopes {
+		if !reflect.DeepEqual(scope, expected[i]) {
+			t.Errorf("expected scope %+v, got %+v", expected[i], scope)
+		}
+	}
+}
+
+func TestFindNearestConfig(t *testing.T) {
+	manifests := &discoveredManifests{
+		configDirs: map[string]string{
+			".":           ".protolinter.yaml",
+			"api/orders":  "api/orders/.protolinter.yaml",
+			"/repo/teams": "/repo/teams/.protolinter.yaml",
+		},
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{dir: "api/orders", expected: "api/orders/.protolinter.yaml"},
+		{dir: "api/orders/v1/internal", expected: "api/orders/.protolinter.yaml"},
+		{dir: "api/payments", expected: ".protolinter.yaml"},
+		{dir: "/repo/teams/payments", expected: "/repo/teams/.protolinter.yaml"},
+		{dir: "/repo/tools", expected: "default.yaml"},
+	}
+
+	for _, test := range tests {
+		if actual := manifests.findNearestConfig(test.dir, "default.yaml"); actual != test.expected {
+			t.Errorf("expected configuration %s for directory %s, got %s", test.expected, test.dir, actual)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/oshokin/protolinter/internal/common"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+// downloadRetryDelay is the delay before the first retry of a failed download,
+// it doubles with every next attempt and is extended by a random jitter of up to the same duration.
+var downloadRetryDelay = 500 * time.Millisecond
+
+// errRetryableDownload marks failures of downloads worth another attempt,
+// such as network errors and server errors of flaky mirrors.
+var errRetryableDownload = errors.New("retryable download failure")
+
+// newDependencyRegistry creates a registry of downloaded dependencies
+// limiting the number of concurrent downloads as configured.
+func newDependencyRegistry(cfg *config.Config) *dependencyRegistry {
+	return &dependencyRegistry{
+		slots: make(chan struct{}, cfg.GetDownloadMaxConcurrency()),
+	}
+}
+
+// fetchDependency downloads the resource, retrying failed attempts with an exponential backoff and a jitter.
+// An attempt interrupted while reading the body is resumed from the received bytes if the server supports ranges.
+func fetchDependency(
+	ctx context.Context,
+	cfg *config.Config,
+	dependencies *dependencyRegistry,
+	path string,
+	resource string,
+) ([]byte, error) {
+	if err := checkDependencyHost(cfg, path, resource); err != nil {
+		return nil, err
+	}
+
+	release := dependencies.acquire()
+	defer release()
+
+	var (
+		maxAttempts = cfg.GetDownloadMaxAttempts()
+		delay       = downloadRetryDelay
+		attempt     = 1
+		body        []byte
+		err         error
+	)
+
+	for ; ; attempt++ {
+		body, err = fetchDependencyAttempt(ctx, cfg, path, resource, body)
+		if err == nil || !errors.Is(err, errRetryableDownload) || attempt == maxAttempts {
+			break
+		}
+
+		logger.Warnf(ctx, "Failed to download proto dependency, retrying, %s: %s, %s: %s, attempt: %d/%d",
+			common.URLTag, resource,
+			common.ErrorTag, err.Error(),
+			attempt, maxAttempts)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay + time.Duration(rand.Int63n(int64(delay)+1))): //nolint: gosec // Jitter isn't security-sensitive.
+		}
+
+		delay *= 2
+	}
+
+	if errors.Is(err, errRetryableDownload) {
+		return nil, fmt.Errorf("failed to download %s after %d attempts: %w", path, attempt, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies.addFetched(len(body))
+
+	if cfg.GetVerboseMode() {
+		logger.Infof(ctx, "Downloaded proto dependency, %s: %s, %s: %s, bytes: %d",
+			common.FileNameTag, path,
+			common.URLTag, resource,
+			len(body))
+	}
+
+	return body, nil
+}
+
+// checkDependencyHost returns an error if the host of the resource isn't allowed to be contacted,
+// so restricted environments fail fast instead of waiting for timeouts.
+func checkDependencyHost(cfg *config.Config, path, resource string) error {
+	resourceURL, err := url.Parse(resource)
+	if err != nil || resourceURL.Hostname() == "" {
+		return fmt.Errorf("failed to download %s: %s isn't a valid URL", path, resource)
+	}
+
+	if !cfg.IsHostAllowed(resourceURL.Hostname()) {
+		return fmt.Errorf("failed to download %s: host %s isn't allowed, add it to allowed_hosts to download from it",
+			path,
+			resourceURL.Hostname())
+	}
+
+	return nil
+}
+
+// fetchDependencyAttempt makes a single attempt to download the resource, resuming from the received bytes if any.
+// It returns the bytes received so far along with an error wrapping errRetryableDownload
+// if the attempt is worth repeating.
+func fetchDependencyAttempt(
+	ctx context.Context,
+	cfg *config.Config,
+	path string,
+	resource string,
+	received []byte,
+) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, resource, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(received) > 0 {
+		request.Header.Set("Range", "bytes="+strconv.Itoa(len(received))+"-")
+	}
+
+	response, err := cfg.GetHTTPClient().Do(request)
+	if errors.Is(err, config.ErrHostNotAllowed) {
+		return received, fmt.Errorf("failed to download %s: %w", path, err)
+	}
+
+	if err != nil {
+		return received, fmt.Errorf("%w: %s", errRetryableDownload, err.Error())
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusPartialContent && len(received) > 0:
+	case response.StatusCode == http.StatusOK:
+		// The server ignored the range, so the download starts over.
+		received = nil
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError:
+		return received, fmt.Errorf("%w: server responded with status %d", errRetryableDownload, response.StatusCode)
+	default:
+		return nil, fmt.Errorf("failed to download %s: server responded with status %d", path, response.StatusCode)
+	}
+
+	if err = validateContentType(cfg, path, response.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+
+	// One byte over the limit is read to tell a file of the maximum size from a larger one.
+	rest, err := io.ReadAll(io.LimitReader(response.Body, cfg.GetDownloadMaxSize()+1-int64(len(received))))
+	received = append(received, rest...)
+
+	if err != nil {
+		return received, fmt.Errorf("%w: %s", errRetryableDownload, err.Error())
+	}
+
+	return received, nil
+}
+
+// acquire waits for a free download slot and returns the function releasing it.
+func (r *dependencyRegistry) acquire() func() {
+	if r == nil || r.slots == nil {
+		return func() {}
+	}
+
+	r.slots <- struct{}{}
+
+	return func() { <-r.slots }
+}
+
+// addFetched counts a dependency downloaded from its origin rather than taken from a cache.
+func (r *dependencyRegistry) addFetched(size int) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fetchedCount++
+	r.fetchedBytes += int64(size)
+}
+
+// logSummary logs the number and the total size of dependencies downloaded from their origins, if any.
+func (r *dependencyRegistry) logSummary(ctx context.Context, cfg *config.Config) {
+	if r == nil || !cfg.GetVerboseMode() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fetchedCount > 0 {
+		logger.Infof(ctx, "Downloaded %d proto dependencies, bytes: %d", r.fetchedCount, r.fetchedBytes)
+	}
+}
@@ -0,0 +1,135 @@
+package checker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestDownloadDependencyLimits(t *testing.T) {
+	const contents = `syntax = "proto3";`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok.proto":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(contents))
+		case "/page.proto":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html>Sign in</html>"))
+		case "/big.proto":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(strings.Repeat("/", 100)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checksum := sha256.Sum256([]byte(contents))
+
+	testCases := []struct {
+		path    string
+		cfg     *config.Config
+		isValid bool
+	}{
+		{"/ok.proto", newLocalConfig(config.DownloadsOptions{}), true},
+		{"/page.proto", newLocalConfig(config.DownloadsOptions{}), false},
+		{"/page.proto", newLocalConfig(config.DownloadsOptions{AllowedContentTypes: []string{}}), true},
+		{"/big.proto", newLocalConfig(config.DownloadsOptions{MaxSize: 99}), false},
+		{"/big.proto", newLocalConfig(config.DownloadsOptions{MaxSize: 100}), true},
+		{"/missing.proto", newLocalConfig(config.DownloadsOptions{}), false},
+		{"/ok.proto", newLocalConfig(newChecksumOptions(server.URL+"/ok.proto", hex.EncodeToString(checksum[:]))), true},
+		{"/ok.proto", newLocalConfig(newChecksumOptions(server.URL+"/ok.proto", strings.Repeat("0", 64))), false},
+		{"/ok.proto", &config.Config{}, false},
+	}
+
+	for _, tc := range testCases {
+		_, err := downloadDependency(context.Background(), tc.cfg, nil, server.URL+tc.path)
+		if (err == nil) != tc.isValid {
+			t.Errorf("%s with %+v: expected valid %t, got error %v", tc.path, tc.cfg.Downloads, tc.isValid, err)
+		}
+	}
+}
+
+// newLocalConfig returns the configuration allowing downloads from test servers.
+func newLocalConfig(downloads config.DownloadsOptions) *config.Config {
+	return &config.Config{
+		AllowedHosts: []string{"127.0.0.1"},
+		Downloads:    downloads,
+	}
+}
+
+func newChecksumOptions(path, checksum string) config.DownloadsOptions {
+	return config.DownloadsOptions{
+		Checksums: []*config.DependencyChecksum{{Path: path, SHA256: checksum}},
+	}
+}
+
+func TestDownloadDependencyRetries(t *testing.T) {
+	const contents = `syntax = "proto3"; package orders.v1;`
+
+	defer func(delay time.Duration) { downloadRetryDelay = delay }(downloadRetryDelay)
+	downloadRetryDelay = time.Millisecond
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := attempts.Add(1)
+
+		w.Header().Set("Content-Type", "text/plain")
+
+		switch {
+		case r.URL.Path == "/flaky.proto" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/flaky.proto":
+			_, _ = w.Write([]byte(contents))
+		case r.URL.Path == "/interrupted.proto" && r.Header.Get("Range") == "":
+			// The connection is closed after a half of the declared length.
+			w.Header().Set("Content-Length", strconv.Itoa(len(contents)))
+			_, _ = w.Write([]byte(contents[:10]))
+		case r.URL.Path == "/interrupted.proto" && r.Header.Get("Range") == "bytes=10-":
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(contents[10:]))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	cfg := newLocalConfig(config.DownloadsOptions{})
+
+	for _, path := range []string{"/flaky.proto", "/interrupted.proto"} {
+		attempts.Store(0)
+
+		dependencies := newDependencyRegistry(nil)
+
+		body, err := downloadDependency(context.Background(), cfg, dependencies, server.URL+path)
+		if err != nil || string(body) != contents {
+			t.Errorf("%s: expected the contents after a retry, got %q, %v", path, body, err)
+		}
+
+		if dependencies.fetchedCount != 1 || dependencies.fetchedBytes != int64(len(contents)) {
+			t.Errorf("%s: unexpected summary of fetched dependencies: %d, %d bytes",
+				path,
+				dependencies.fetchedCount,
+				dependencies.fetchedBytes)
+		}
+	}
+
+	attempts.Store(0)
+
+	if _, err := downloadDependency(context.Background(), cfg, nil, server.URL+"/down.proto"); err == nil ||
+		attempts.Load() != config.DefaultDownloadMaxAttempts {
+		t.Errorf("expected %d failed attempts, got %d, %v", config.DefaultDownloadMaxAttempts, attempts.Load(), err)
+	}
+}
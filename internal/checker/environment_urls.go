@@ -0,0 +1,103 @@
+package checker
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fileOptionsFieldNumber is the number of the options field of google.protobuf.FileDescriptorProto.
+const fileOptionsFieldNumber = 8
+
+// checkEnvironmentURLs checks that string values of options of the descriptor, including nested values
+// of message options like swagger external_docs, don't match the configured environment-specific patterns.
+// The kind is the human-readable kind of the descriptor used in messages, e.g. "Service".
+// Findings about file options point to the option itself, since files have no declaration to point to.
+func (c *ProtoChecker) checkEnvironmentURLs(
+	desc protoreflect.Descriptor,
+	result *CheckResult,
+	kind string,
+	logName string,
+) {
+	c.runRule(OptionNoEnvironmentURLs, desc, func() {
+		patterns := c.config.GetEnvironmentURLPatterns()
+		file, isFile := desc.(protoreflect.FileDescriptor)
+
+		desc.Options().ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			rangeStringOptionValues(fd, v, "", func(option, value string) {
+				for _, pattern := range patterns {
+					if !pattern.MatchString(value) {
+						continue
+					}
+
+					format := "%s %s has option %s with environment-specific value %q matching %s"
+					args := []any{result.localize(kind), logName, option, value, pattern.String()}
+
+					if isFile {
+						location := file.SourceLocations().ByPath(
+							protoreflect.SourcePath{fileOptionsFieldNumber, int32(fd.Number())})
+
+						result.AddFindingAtf(OptionNoEnvironmentURLs, nil, location, format, args...)
+					} else {
+						result.AddFindingf(OptionNoEnvironmentURLs, desc, format, args...)
+					}
+
+					return
+				}
+			})
+
+			return true
+		})
+	})
+}
+
+// rangeStringOptionValues calls the function for every string value of the field set to the value,
+// recursing into nested messages, lists and maps. The option is the dot-separated path to the value
+// prefixed with the prefix, extensions are named by their full names.
+func rangeStringOptionValues(
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+	prefix string,
+	f func(option, value string),
+) {
+	option := string(fd.Name())
+	if fd.IsExtension() {
+		option = string(fd.FullName())
+	}
+
+	if prefix != "" {
+		option = strings.Join([]string{prefix, option}, ".")
+	}
+
+	switch {
+	case fd.IsList():
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			rangeStringValue(fd, list.Get(i), option, f)
+		}
+	case fd.IsMap():
+		v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+			rangeStringValue(fd.MapValue(), value, option, f)
+
+			return true
+		})
+	default:
+		rangeStringValue(fd, v, option, f)
+	}
+}
+
+// rangeStringValue calls the function for the single value of the field if it's a string,
+// or for string values of its fields if it's a message.
+func rangeStringValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, option string, f func(option, value string)) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		f(option, v.String())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		v.Message().Range(func(nestedFD protoreflect.FieldDescriptor, nestedValue protoreflect.Value) bool {
+			rangeStringOptionValues(nestedFD, nestedValue, option, f)
+
+			return true
+		})
+	default:
+	}
+}
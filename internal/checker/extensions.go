@@ -0,0 +1,125 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// extensionRangeFieldNumber is the number of the extension_range field in google.protobuf.DescriptorProto,
+// used to build source paths of extension range declarations.
+const extensionRangeFieldNumber = 5
+
+// Extension runs the checks of the extension.
+func (v *checkVisitor) Extension(extension protoreflect.ExtensionDescriptor) {
+	var (
+		c                 = v.checker
+		result            = v.result
+		extensionFullName = string(extension.FullName())
+		extensionLogName  = c.getNameForLogs(string(v.file.FullName()), "", 0, extensionFullName)
+	)
+
+	if !c.config.IsDescriptorOnTargetPath(extensionFullName) {
+		return
+	}
+
+	if c.shouldDescriptorBeSkipped(extensionFullName) {
+		result.AddMessagef("Extension %s is skipped", extensionLogName)
+
+		return
+	}
+
+	c.runRule(NoExtensions, extension, func() {
+		if c.config.GetExtensionPolicy() == config.ExtensionPolicyForbid {
+			result.AddFindingf(
+				NoExtensions,
+				extension,
+				"Extension %s of message %s is forbidden",
+				extensionLogName,
+				extension.ContainingMessage().FullName())
+		}
+	})
+
+	c.checkCommentStyle(extension, result, "Extension", extensionLogName)
+}
+
+// checkExtensionRanges checks every "extensions" statement of the message,
+// a single statement may declare several ranges.
+func (c *ProtoChecker) checkExtensionRanges(
+	message protoreflect.MessageDescriptor,
+	result *CheckResult,
+	parsedFile linker.File,
+	messageLogName string,
+) {
+	if message.ExtensionRanges().Len() == 0 {
+		return
+	}
+
+	var (
+		isForbidden      = !c.config.IsCheckExcluded(NoExtensions) && c.config.GetExtensionPolicy() == config.ExtensionPolicyForbid
+		mustBeDocumented = !c.config.IsCheckExcluded(ExtensionRangeDocumented)
+	)
+
+	if !isForbidden && !mustBeDocumented {
+		return
+	}
+
+	sourceLocations := parsedFile.SourceLocations()
+
+	messagePath := sourceLocations.ByDescriptor(message).Path
+	if messagePath == nil {
+		return
+	}
+
+	for i := 0; i < sourceLocations.Len(); i++ {
+		sl := sourceLocations.Get(i)
+		if !isExtensionRangeStatement(sl.Path, messagePath) {
+			continue
+		}
+
+		if isForbidden {
+			done := c.startRule(NoExtensions, message)
+
+			result.AddFindingAtf(
+				NoExtensions,
+				message,
+				sl,
+				"Extension ranges of message %s are forbidden",
+				messageLogName)
+
+			done()
+		}
+
+		if mustBeDocumented {
+			done := c.startRule(ExtensionRangeDocumented, message)
+
+			if strings.TrimSpace(sl.LeadingComments) == "" {
+				result.AddFindingAtf(
+					ExtensionRangeDocumented,
+					message,
+					sl,
+					"Extension range of message %s has no leading comments",
+					messageLogName)
+			}
+
+			done()
+		}
+	}
+}
+
+// isExtensionRangeStatement returns true if the path points to an "extensions" statement of the message.
+func isExtensionRangeStatement(path, messagePath protoreflect.SourcePath) bool {
+	if len(path) != len(messagePath)+1 || path[len(messagePath)] != extensionRangeFieldNumber {
+		return false
+	}
+
+	for i := range messagePath {
+		if path[i] != messagePath[i] {
+			return false
+		}
+	}
+
+	return true
+}
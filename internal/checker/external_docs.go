@@ -0,0 +1,69 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	swaggerExternalDocsKey    = "externalDocs"
+	swaggerExternalDocsURLKey = "externalDocs.url"
+)
+
+// checkSwaggerExternalDocs checks that the external_docs.url of the openapiv2_operation option of the method
+// is an absolute HTTPS URL on one of the allowed domains, if any are configured.
+// Methods without external_docs aren't checked.
+func (c *ProtoChecker) checkSwaggerExternalDocs(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+	parsedOptions *parser.OptionValues,
+) {
+	if !parsedOptions.Has(swaggerExternalDocsKey) {
+		return
+	}
+
+	c.runRule(SwaggerExternalDocsValidURL, method, func() {
+		docsURL := strings.TrimSpace(parsedOptions.GetString(swaggerExternalDocsURLKey))
+
+		parsedURL, err := url.Parse(docsURL)
+		if err != nil || parsedURL.Scheme != "https" || parsedURL.Hostname() == "" {
+			result.AddFindingf(
+				SwaggerExternalDocsValidURL,
+				method,
+				"External docs URL %q of method %s must be an absolute HTTPS URL",
+				docsURL,
+				methodLogName)
+
+			return
+		}
+
+		allowedDomains := c.config.GetExternalDocsAllowedDomains()
+		if len(allowedDomains) > 0 && !isDomainAllowed(parsedURL.Hostname(), allowedDomains) {
+			result.AddFindingf(
+				SwaggerExternalDocsValidURL,
+				method,
+				"External docs URL %q of method %s isn't on an allowed domain: %s",
+				docsURL,
+				methodLogName,
+				strings.Join(allowedDomains, ", "))
+		}
+	})
+}
+
+// isDomainAllowed returns true if the host is one of the domains or their subdomains.
+func isDomainAllowed(host string, domains []string) bool {
+	host = strings.ToLower(host)
+
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package checker
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+const (
+	fieldBehaviorOptionName = "google.api.field_behavior"
+
+	fieldBehaviorInputOnly  = "INPUT_ONLY"
+	fieldBehaviorImmutable  = "IMMUTABLE"
+	fieldBehaviorOutputOnly = "OUTPUT_ONLY"
+)
+
+// checkFieldBehaviors checks that fields of a message reachable from responses aren't marked
+// as input only or immutable, and fields of a message reachable from requests aren't marked as output only.
+func (c *ProtoChecker) checkFieldBehaviors(
+	message protoreflect.MessageDescriptor,
+	result *CheckResult,
+	index *descriptorIndex,
+	messageLogName string,
+) {
+	var (
+		requestMethod  = index.getRequestMethod(message)
+		responseMethod = index.getResponseMethod(message)
+		fields         = message.Fields()
+	)
+
+	if requestMethod == nil && responseMethod == nil {
+		return
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		c.runRule(ResponseNoInputOnlyFields, field, func() {
+			for _, behavior := range getFieldBehaviors(field) {
+				switch {
+				case responseMethod != nil &&
+					(behavior == fieldBehaviorInputOnly || behavior == fieldBehaviorImmutable):
+					result.AddFindingf(
+						ResponseNoInputOnlyFields,
+						field,
+						"Field %s of message %s is %s, but the message is reachable from the response of method %s",
+						field.Name(),
+						messageLogName,
+						behavior,
+						responseMethod.FullName())
+				case requestMethod != nil && behavior == fieldBehaviorOutputOnly:
+					result.AddFindingf(
+						ResponseNoInputOnlyFields,
+						field,
+						"Field %s of message %s is %s, but the message is reachable from the request of method %s",
+						field.Name(),
+						messageLogName,
+						behavior,
+						requestMethod.FullName())
+				}
+			}
+		})
+	}
+}
+
+// getFieldBehaviors returns the names of the values of the google.api.field_behavior option of the field.
+func getFieldBehaviors(field protoreflect.FieldDescriptor) []string {
+	var result []string
+
+	field.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if string(fd.FullName()) != fieldBehaviorOptionName || !fd.IsList() || fd.Enum() == nil {
+				return true
+			}
+
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				number := list.Get(i).Enum()
+
+				if value := fd.Enum().Values().ByNumber(number); value != nil {
+					result = append(result, string(value.Name()))
+				}
+			}
+
+			return false
+		})
+
+	return result
+}
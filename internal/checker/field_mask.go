@@ -0,0 +1,37 @@
+package checker
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+const (
+	updateVerb        = "Update"
+	httpVerbPatch     = "patch"
+	updateMaskName    = "update_mask"
+	fieldMaskFullName = "google.protobuf.FieldMask"
+)
+
+// checkUpdateFieldMask checks that the request of a method named Update* or bound to HTTP PATCH
+// has a google.protobuf.FieldMask update_mask field, so partial updates are explicit, as AIP-134 suggests.
+func (c *ProtoChecker) checkUpdateFieldMask(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+	httpVerb string,
+) {
+	if !hasVerbPrefix(string(method.Name()), updateVerb) && httpVerb != httpVerbPatch {
+		return
+	}
+
+	c.runRule(MethodUpdateHasFieldMask, method, func() {
+		field := method.Input().Fields().ByName(updateMaskName)
+		if field != nil && !field.IsList() && field.Message() != nil && field.Message().FullName() == fieldMaskFullName {
+			return
+		}
+
+		result.AddFindingf(
+			MethodUpdateHasFieldMask,
+			method,
+			"Request %s of method %s doesn't have field google.protobuf.FieldMask update_mask",
+			method.Input().FullName(),
+			methodLogName)
+	})
+}
@@ -0,0 +1,127 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fileElementRank is the position of a kind of file elements in the canonical order.
+// The syntax statement isn't ranked, since the compiler requires it to be the first statement.
+type fileElementRank int
+
+const (
+	fileElementPackage fileElementRank = iota + 1
+	fileElementImport
+	fileElementOption
+	fileElementDefinition
+)
+
+// checkFileElementOrder checks that the elements of the file follow the canonical order:
+// the package, sorted imports, options and then definitions of messages, enums, services and extensions.
+func (c *ProtoChecker) checkFileElementOrder(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(FileElementOrder) {
+		return
+	}
+
+	res, ok := parsedFile.(linker.Result)
+	if !ok || res.AST() == nil {
+		return
+	}
+
+	defer c.startRule(FileElementOrder, parsedFile)()
+
+	var (
+		fileNode    = res.AST()
+		fileLogName = parsedFile.Path()
+		maxRank     fileElementRank
+		lastImport  string
+	)
+
+	for _, decl := range fileNode.Decls {
+		var rank fileElementRank
+
+		switch node := decl.(type) {
+		case *ast.PackageNode:
+			if maxRank > fileElementPackage {
+				result.AddFindingAtf(
+					FileElementOrder,
+					nil,
+					getNodeLocation(fileNode, node),
+					"Package of file %s must be declared before imports, options and definitions",
+					fileLogName)
+			}
+
+			rank = fileElementPackage
+		case *ast.ImportNode:
+			importPath := node.Name.AsString()
+
+			switch {
+			case maxRank > fileElementImport:
+				result.AddFindingAtf(
+					FileElementOrder,
+					nil,
+					getNodeLocation(fileNode, node),
+					"Import %s of file %s must precede options and definitions",
+					importPath,
+					fileLogName)
+			case importPath < lastImport:
+				result.AddFindingAtf(
+					FileElementOrder,
+					nil,
+					getNodeLocation(fileNode, node),
+					"Import %s of file %s must precede import %s to keep imports sorted",
+					importPath,
+					fileLogName,
+					lastImport)
+			}
+
+			lastImport = importPath
+			rank = fileElementImport
+		case *ast.OptionNode:
+			if maxRank > fileElementOption {
+				result.AddFindingAtf(
+					FileElementOrder,
+					nil,
+					getNodeLocation(fileNode, node),
+					"Option %s of file %s must precede definitions",
+					getOptionName(node),
+					fileLogName)
+			}
+
+			rank = fileElementOption
+		case *ast.MessageNode, *ast.EnumNode, *ast.ServiceNode, *ast.ExtendNode:
+			rank = fileElementDefinition
+		}
+
+		// An element out of order doesn't lower the rank, so the elements following it are still checked.
+		if rank > maxRank {
+			maxRank = rank
+		}
+	}
+}
+
+// getNodeLocation returns the source location of the start of the AST node,
+// used for elements that have no source path of their own.
+func getNodeLocation(fileNode *ast.FileNode, node ast.Node) protoreflect.SourceLocation {
+	start := fileNode.NodeInfo(node).Start()
+
+	// Source locations are zero-based, while AST positions count from one.
+	return protoreflect.SourceLocation{
+		Path:        protoreflect.SourcePath{},
+		StartLine:   start.Line - 1,
+		StartColumn: start.Col - 1,
+	}
+}
+
+// getOptionName returns the name of the option as written in the source, like (google.api.http).body.
+func getOptionName(node *ast.OptionNode) string {
+	parts := make([]string, 0, len(node.Name.Parts))
+	for _, part := range node.Name.Parts {
+		parts = append(parts, part.Value())
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,124 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const blockCommentSuffix = "*/"
+
+// checkFileHeader checks that the first comment block of the file starts with the configured header.
+// The check is skipped unless a header template is configured.
+func (c *ProtoChecker) checkFileHeader(parsedFile linker.File, result *CheckResult) {
+	patterns := c.config.GetFileHeaderPatterns()
+	if c.config.IsCheckExcluded(FileHasHeader) || len(patterns) == 0 {
+		return
+	}
+
+	res, ok := parsedFile.(linker.Result)
+	if !ok || res.AST() == nil {
+		return
+	}
+
+	defer c.startRule(FileHasHeader, parsedFile)()
+
+	// The header is reported at the beginning of the file.
+	location := protoreflect.SourceLocation{Path: protoreflect.SourcePath{}}
+
+	switch header := getFileHeaderLines(res.AST()); {
+	case len(header) == 0:
+		result.AddFindingAtf(
+			FileHasHeader,
+			nil,
+			location,
+			"File %s doesn't start with a header comment",
+			parsedFile.Path())
+	case !isFileHeaderMatching(header, patterns):
+		result.AddFindingAtf(
+			FileHasHeader,
+			nil,
+			location,
+			"Header of file %s doesn't match the template",
+			parsedFile.Path())
+	}
+}
+
+// isFileHeaderMatching returns true if the lines of the header start with the lines matching the patterns.
+func isFileHeaderMatching(header []string, patterns []*regexp.Regexp) bool {
+	if len(header) < len(patterns) {
+		return false
+	}
+
+	for i, pattern := range patterns {
+		if !pattern.MatchString(header[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getFileHeaderLines returns the text lines of the first block of consecutive comments of the file
+// without comment markers and trailing spaces, empty lines around the text are omitted.
+// Comments not preceding the first declaration aren't considered a header.
+func getFileHeaderLines(fileNode *ast.FileNode) []string {
+	children := fileNode.Children()
+	if len(children) == 0 {
+		return nil
+	}
+
+	var (
+		result   []string
+		comments = fileNode.NodeInfo(children[0]).LeadingComments()
+	)
+
+	for i := 0; i < comments.Len(); i++ {
+		comment := comments.Index(i)
+
+		// A blank line ends the block.
+		if i > 0 && comment.Start().Line > comments.Index(i-1).End().Line+1 {
+			break
+		}
+
+		result = append(result, getCommentLines(comment.RawText())...)
+	}
+
+	for len(result) > 0 && result[0] == "" {
+		result = result[1:]
+	}
+
+	for len(result) > 0 && result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+
+	return result
+}
+
+// getCommentLines returns the text lines of a line or block comment without comment markers,
+// the leading asterisks of block comment lines are omitted as well.
+func getCommentLines(rawText string) []string {
+	if strings.HasPrefix(rawText, lineCommentPrefix) {
+		text := strings.TrimPrefix(strings.TrimPrefix(rawText, lineCommentPrefix), " ")
+
+		return []string{strings.TrimRight(text, " \t\r\n")}
+	}
+
+	var (
+		text   = strings.TrimSuffix(strings.TrimPrefix(rawText, blockCommentPrefix), blockCommentSuffix)
+		lines  = strings.Split(text, "\n")
+		result = make([]string, 0, len(lines))
+	)
+
+	for _, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "*"), " ")
+
+		result = append(result, strings.TrimRight(line, " \t\r"))
+	}
+
+	return result
+}
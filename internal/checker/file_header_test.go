@@ -0,0 +1,27 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetCommentLines(t *testing.T) {
+	tests := []struct {
+		rawText  string
+		expected []string
+	}{
+		{"// Copyright 2024 Acme Corp.  ", []string{"Copyright 2024 Acme Corp."}},
+		{"//Copyright", []string{"Copyright"}},
+		{"/* Copyright 2024 Acme Corp. */", []string{"Copyright 2024 Acme Corp."}},
+		{
+			"/*\n * Copyright 2024 Acme Corp.\n *\n * Licensed under MIT.\n */",
+			[]string{"", "Copyright 2024 Acme Corp.", "", "Licensed under MIT.", ""},
+		},
+	}
+
+	for _, test := range tests {
+		if actual := getCommentLines(test.rawText); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("getCommentLines(%q) = %q, expected %q", test.rawText, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+const stdinFileName = "-"
+
+// Error returns the description of the discovery error.
+func (e *DiscoveryError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("pattern %s: %s", e.Pattern, e.Err.Error())
+	}
+
+	return fmt.Sprintf("pattern %s: %s: %s", e.Pattern, e.Path, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *DiscoveryError) Unwrap() error {
+	return e.Err
+}
+
+// extractFilesFromPatterns returns the files matching the glob patterns,
+// skipping directories and files with other extensions if the extension is set.
+// Invalid patterns and entries that can't be accessed (for example, dangling symbolic links
+// or files in directories without permissions) are skipped and returned as discovery errors.
+func extractFilesFromPatterns(patterns []string, extension string) ([]string, []*DiscoveryError) {
+	var (
+		alreadyAddedFiles = make(map[string]struct{}, len(patterns))
+		result            = make([]string, 0, len(patterns))
+		canonicalizer     = newPathCanonicalizer()
+		discoveryErrors   []*DiscoveryError
+	)
+
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			discoveryErrors = append(discoveryErrors, &DiscoveryError{
+				Pattern: pattern,
+				Err:     err,
+			})
+
+			continue
+		}
+
+		for _, file := range files {
+			// The same file may be reached via symbolic links or differently cased paths.
+			fileKey := canonicalizer.key(file)
+			if _, ok := alreadyAddedFiles[fileKey]; ok {
+				continue
+			}
+
+			alreadyAddedFiles[fileKey] = struct{}{}
+
+			fi, err := os.Stat(file)
+			if err != nil {
+				discoveryErrors = append(discoveryErrors, &DiscoveryError{
+					Pattern: pattern,
+					Path:    file,
+					Err:     err,
+				})
+
+				continue
+			}
+
+			if fi.IsDir() {
+				continue
+			}
+
+			if extension != "" && filepath.Ext(file) != extension {
+				continue
+			}
+
+			result = append(result, file)
+		}
+	}
+
+	return result, discoveryErrors
+}
+
+// readFileList reads the paths of files listed one per line, skipping blank lines.
+// The "-" file name stands for stdin, so the list can be piped from other commands like git diff.
+func readFileList(fileName string) ([]string, error) {
+	var input io.Reader = os.Stdin
+
+	if fileName != stdinFileName {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		defer file.Close()
+
+		input = file
+	}
+
+	var (
+		result  []string
+		scanner = bufio.NewScanner(input)
+	)
+
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func logDiscoveryErrors(ctx context.Context, discoveryErrors []*DiscoveryError) {
+	for _, err := range discoveryErrors {
+		logger.Warnf(ctx, "Skipping inaccessible entry, %s", err.Error())
+	}
+}
@@ -0,0 +1,121 @@
+package checker
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtractFilesFromPatterns(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, "a.proto"))
+	writeTestFile(t, filepath.Join(dir, "b.txt"))
+
+	if err := os.Symlink(filepath.Join(dir, "a.proto"), filepath.Join(dir, "link.proto")); err != nil {
+		t.Skipf("symbolic links are not supported: %s", err.Error())
+	}
+
+	if err := os.Symlink(filepath.Join(dir, "missing.proto"), filepath.Join(dir, "dangling.proto")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, discoveryErrors := extractFilesFromPatterns(
+		[]string{
+			filepath.Join(dir, "a.proto"),
+			filepath.Join(dir, "*.proto"),
+			filepath.Join(dir, "[.proto"),
+		},
+		protoFileExtension)
+
+	expectedFiles := []string{filepath.Join(dir, "a.proto")}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Errorf("expected files %v, got %v", expectedFiles, files)
+	}
+
+	if len(discoveryErrors) != 2 {
+		t.Fatalf("expected 2 discovery errors, got %d: %v", len(discoveryErrors), discoveryErrors)
+	}
+
+	if discoveryErrors[0].Path != filepath.Join(dir, "dangling.proto") ||
+		!errors.Is(discoveryErrors[0], fs.ErrNotExist) {
+		t.Errorf("expected dangling symbolic link error, got %s", discoveryErrors[0].Error())
+	}
+
+	if discoveryErrors[1].Path != "" ||
+		!errors.Is(discoveryErrors[1], filepath.ErrBadPattern) {
+		t.Errorf("expected invalid pattern error, got %s", discoveryErrors[1].Error())
+	}
+}
+
+func TestExtractFilesFromPatternsPermissionDenied(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for the root user")
+	}
+
+	var (
+		dir           = t.TempDir()
+		restrictedDir = filepath.Join(dir, "restricted")
+	)
+
+	if err := os.Mkdir(restrictedDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, filepath.Join(restrictedDir, "a.proto"))
+	writeTestFile(t, filepath.Join(dir, "b.proto"))
+
+	// Listing the directory is allowed, accessing its entries isn't.
+	if err := os.Chmod(restrictedDir, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = os.Chmod(restrictedDir, 0o755)
+	})
+
+	files, discoveryErrors := extractFilesFromPatterns(
+		[]string{
+			filepath.Join(restrictedDir, "*.proto"),
+			filepath.Join(dir, "*.proto"),
+		},
+		protoFileExtension)
+
+	expectedFiles := []string{filepath.Join(dir, "b.proto")}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Errorf("expected files %v, got %v", expectedFiles, files)
+	}
+
+	if len(discoveryErrors) != 1 || !errors.Is(discoveryErrors[0], fs.ErrPermission) {
+		t.Errorf("expected a single permission error, got %v", discoveryErrors)
+	}
+}
+
+func TestReadFileList(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "files.txt")
+
+	if err := os.WriteFile(fileName, []byte("a.proto\n\n  dir/b.proto\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := readFileList(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFiles := []string{"a.proto", "dir/b.proto"}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Errorf("expected files %v, got %v", expectedFiles, files)
+	}
+}
+
+func writeTestFile(t *testing.T, fileName string) {
+	t.Helper()
+
+	if err := os.WriteFile(fileName, []byte("syntax = \"proto3\";\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
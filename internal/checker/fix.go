@@ -0,0 +1,70 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// FixFormat is the fix of findings fixed by formatting the file with "protolinter format".
+const FixFormat = "format"
+
+// readCheckReport reads the JSON report written by the "check" subcommand.
+func readCheckReport(fileName string) (*CheckReport, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", fileName, err)
+	}
+
+	var report CheckReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to decode report %s: %w", fileName, err)
+	}
+
+	return &report, nil
+}
+
+// findings returns the findings of the report, whether they are grouped or not.
+func (r *CheckReport) findings() []*Finding {
+	var result []*Finding
+
+	for _, file := range r.Files {
+		result = append(result, file.Findings...)
+	}
+
+	for _, group := range r.Groups {
+		result = append(result, group.Findings...)
+	}
+
+	return result
+}
+
+// getFilesToFormat returns the sorted paths of the files having findings fixed by formatting,
+// and the findings whose fixes aren't supported, e.g. recorded by a newer version.
+func getFilesToFormat(findings []*Finding) ([]string, []*Finding) {
+	var (
+		files       []string
+		unsupported []*Finding
+		seen        = make(map[string]struct{})
+	)
+
+	for _, finding := range findings {
+		switch finding.Fix {
+		case "":
+		case FixFormat:
+			if _, ok := seen[finding.Path]; ok {
+				continue
+			}
+
+			seen[finding.Path] = struct{}{}
+			files = append(files, finding.Path)
+		default:
+			unsupported = append(unsupported, finding)
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, unsupported
+}
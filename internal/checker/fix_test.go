@@ -0,0 +1,49 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetFilesToFormat(t *testing.T) {
+	const report = `{
+  "groups": [
+    {
+      "key": "team-a",
+      "findings": [
+        {"check": "file_element_order", "path": "b.proto", "fix": "format"},
+        {"check": "message_not_empty", "path": "c.proto"}
+      ]
+    },
+    {
+      "key": "team-b",
+      "findings": [
+        {"check": "style_indentation", "path": "a.proto", "fix": "format"},
+        {"check": "file_element_order", "path": "b.proto", "fix": "format"},
+        {"check": "future_check", "path": "d.proto", "fix": "rename"}
+      ]
+    }
+  ]
+}`
+
+	fileName := filepath.Join(t.TempDir(), "findings.json")
+	if err := os.WriteFile(fileName, []byte(report), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	checkReport, err := readCheckReport(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, unsupported := getFilesToFormat(checkReport.findings())
+	if expected := []string{"a.proto", "b.proto"}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+
+	if len(unsupported) != 1 || unsupported[0].Path != "d.proto" {
+		t.Errorf("expected the finding of d.proto to be unsupported, got %v", unsupported)
+	}
+}
@@ -0,0 +1,136 @@
+package checker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// generateConfig returns the configuration file with the descriptors having findings appended
+// to the excluded_descriptors section, so the current findings are accepted as a baseline.
+// Every appended descriptor is annotated with the checks failed there, their severities
+// and whether formatting fixes them, so reviewers can decide to fix the descriptor instead of excluding it.
+// Findings of files can't be excluded by descriptor, they are listed in the comment of the section.
+func generateConfig(cfg *config.Config, configPath string, results []*CheckResult) ([]byte, error) {
+	var (
+		descriptors      []string
+		descriptorChecks = make(map[string]map[string]*failedCheck)
+		files            []string
+		fileChecks       = make(map[string]map[string]*failedCheck)
+	)
+
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			if finding.descriptor == nil {
+				files = addFailedCheck(fileChecks, files, finding.Path, cfg, finding)
+
+				continue
+			}
+
+			name := string(finding.descriptor.FullName())
+			descriptors = addFailedCheck(descriptorChecks, descriptors, name, cfg, finding)
+		}
+	}
+
+	sort.Strings(descriptors)
+	sort.Strings(files)
+
+	comments := make(map[string][]string, len(descriptors))
+	for _, name := range descriptors {
+		comments[name] = formatFailedChecks(descriptorChecks[name])
+	}
+
+	var note []string
+
+	if len(files) > 0 {
+		note = append(note, "Findings of files can't be excluded by descriptor:")
+
+		for _, path := range files {
+			for _, line := range formatFailedChecks(fileChecks[path]) {
+				note = append(note, fmt.Sprintf("%s: %s", path, line))
+			}
+		}
+	}
+
+	return config.GenerateConfig(configPath, descriptors, comments, note)
+}
+
+// addFailedCheck records the finding in the checks failed on the key
+// and returns the keys with the key appended if it's seen for the first time.
+func addFailedCheck(
+	failedChecks map[string]map[string]*failedCheck,
+	keys []string,
+	key string,
+	cfg *config.Config,
+	finding *Finding,
+) []string {
+	checks, ok := failedChecks[key]
+	if !ok {
+		checks = make(map[string]*failedCheck)
+		failedChecks[key] = checks
+		keys = append(keys, key)
+	}
+
+	check, ok := checks[finding.Check]
+	if !ok {
+		check = &failedCheck{
+			name:      finding.Check,
+			severity:  finding.Severity,
+			isFixable: isFixedByFormatting(cfg, finding.Check),
+		}
+		checks[finding.Check] = check
+	}
+
+	if finding.Severity == config.SeverityError {
+		check.severity = config.SeverityError
+	}
+
+	check.count++
+
+	return keys
+}
+
+// formatFailedChecks returns the lines describing the failed checks, errors first.
+// If formatting fixes all of several checks, the last line suggests formatting instead of excluding.
+func formatFailedChecks(checks map[string]*failedCheck) []string {
+	sorted := make([]*failedCheck, 0, len(checks))
+	for _, check := range checks {
+		sorted = append(sorted, check)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		isErrorI := sorted[i].severity == config.SeverityError
+		if isErrorJ := sorted[j].severity == config.SeverityError; isErrorI != isErrorJ {
+			return isErrorI
+		}
+
+		return sorted[i].name < sorted[j].name
+	})
+
+	var (
+		result    = make([]string, 0, len(sorted)+1)
+		isFixable = true
+	)
+
+	for _, check := range sorted {
+		findings := "findings"
+		if check.count == 1 {
+			findings = "finding"
+		}
+
+		line := fmt.Sprintf("%s: %s, %d %s", check.name, check.severity, check.count, findings)
+		if check.isFixable {
+			line += `, fixed by "protolinter format"`
+		}
+
+		result = append(result, line)
+		isFixable = isFixable && check.isFixable
+	}
+
+	if isFixable && len(sorted) > 1 {
+		result = append(result, `all of them are fixed by "protolinter format", consider fixing instead of excluding`)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestGenerateConfig(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+message Order {
+  string id = 1;
+}
+`
+
+	var (
+		dir        = t.TempDir()
+		path       = filepath.Join(dir, "shop.proto")
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+		file       = compileTriageFile(t, path, source)
+		order      = file.Messages().ByName("Order")
+		id         = order.Fields().ByName("id")
+	)
+
+	if err := os.WriteFile(configPath, []byte("verbose_mode: true\n"), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %s", configPath, err.Error())
+	}
+
+	result := &CheckResult{
+		File: file,
+		Findings: []*Finding{
+			{Check: FieldHasNoDescription, Severity: config.SeverityWarning, Path: path, descriptor: id},
+			{Check: DescriptorIsReferenced, Severity: config.SeverityError, Path: path, descriptor: order},
+			{Check: MessageNotEmpty, Severity: config.SeverityError, Path: path, descriptor: order},
+			{Check: MessageNotEmpty, Severity: config.SeverityWarning, Path: path, descriptor: order},
+			{Check: FileElementOrder, Severity: config.SeverityWarning, Path: path},
+			{Check: StyleIndentation, Severity: config.SeverityWarning, Path: path},
+		},
+	}
+
+	data, err := generateConfig(nil, configPath, []*CheckResult{result})
+	if err != nil {
+		t.Fatalf("failed to generate configuration: %s", err.Error())
+	}
+
+	expected := `verbose_mode: true
+# Findings of files can't be excluded by descriptor:
+# ` + path + `: file_element_order: warning, 1 finding, fixed by "protolinter format"
+# ` + path + `: style_indentation: warning, 1 finding, fixed by "protolinter format"
+# ` + path + `: all of them are fixed by "protolinter format", consider fixing instead of excluding
+excluded_descriptors:
+  # descriptor_is_referenced: error, 1 finding
+  # message_not_empty: error, 2 findings
+  - shop.Order
+  # field_has_no_description: warning, 1 finding
+  - shop.Order.id
+`
+
+	if string(data) != expected {
+		t.Errorf("got configuration:\n%s\nwant:\n%s", data, expected)
+	}
+}
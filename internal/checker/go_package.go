@@ -0,0 +1,120 @@
+package checker
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// goPackageFieldNumber is the number of the go_package field of google.protobuf.FileOptions.
+	goPackageFieldNumber = 11
+	goPackageSeparator   = ";"
+	goTestPackageSuffix  = "_test"
+)
+
+// checkGoPackage checks that the go_package option of the file points to an existing directory
+// of a known Go module, and that Go files already in the directory declare the same package.
+// Import paths outside known modules, like the ones of other repositories, aren't checked.
+func (c *ProtoChecker) checkGoPackage(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(GoPackageMatchesModule) || !c.config.AreGoPackageDirectoriesRequired() {
+		return
+	}
+
+	options, ok := parsedFile.Options().(*descriptorpb.FileOptions)
+	if !ok || options.GetGoPackage() == "" {
+		return
+	}
+
+	defer c.startRule(GoPackageMatchesModule, parsedFile)()
+
+	var (
+		goPackage               = options.GetGoPackage()
+		importPath, packageName = parseGoPackage(goPackage)
+		dir, isInKnownModule    = c.modules.resolve(importPath)
+		location                = parsedFile.SourceLocations().ByPath(
+			protoreflect.SourcePath{fileOptionsFieldNumber, goPackageFieldNumber})
+	)
+
+	if !isInKnownModule {
+		return
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		result.AddFindingAtf(
+			GoPackageMatchesModule,
+			nil,
+			location,
+			"Option go_package %s of file %s points to directory %s, which doesn't exist in the module",
+			goPackage,
+			parsedFile.Path(),
+			dir)
+
+		return
+	}
+
+	if existingName := readGoPackageName(dir); existingName != "" && existingName != packageName {
+		result.AddFindingAtf(
+			GoPackageMatchesModule,
+			nil,
+			location,
+			"Option go_package %s of file %s declares package %s, but directory %s contains package %s",
+			goPackage,
+			parsedFile.Path(),
+			packageName,
+			dir,
+			existingName)
+	}
+}
+
+// parseGoPackage returns the import path and the package name of the go_package option value.
+// If the name isn't set explicitly after a semicolon, it's derived from the last element of the path
+// the same way protoc-gen-go does.
+func parseGoPackage(goPackage string) (string, string) {
+	importPath, packageName, ok := strings.Cut(goPackage, goPackageSeparator)
+	if ok && packageName != "" {
+		return importPath, packageName
+	}
+
+	packageName = strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+
+		return r
+	}, path.Base(importPath))
+
+	return importPath, packageName
+}
+
+// readGoPackageName returns the package declared by the first non-test Go file of the directory
+// having a parseable package clause, or an empty string if there is none.
+func readGoPackageName(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, goTestPackageSuffix+".go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+
+		return strings.TrimSuffix(file.Name.Name, goTestPackageSuffix)
+	}
+
+	return ""
+}
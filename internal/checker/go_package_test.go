@@ -0,0 +1,55 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoPackage(t *testing.T) {
+	testCases := []struct {
+		goPackage   string
+		importPath  string
+		packageName string
+	}{
+		{"example.com/shop/gen/orders/v1;ordersv1", "example.com/shop/gen/orders/v1", "ordersv1"},
+		{"example.com/shop/gen/orders/v1", "example.com/shop/gen/orders/v1", "v1"},
+		{"example.com/shop/gen/order-service.v1", "example.com/shop/gen/order-service.v1", "order_service_v1"},
+		{"example.com/shop/gen/orders;", "example.com/shop/gen/orders", "orders"},
+	}
+
+	for _, tc := range testCases {
+		importPath, packageName := parseGoPackage(tc.goPackage)
+		if importPath != tc.importPath || packageName != tc.packageName {
+			t.Errorf("%s: expected %s and %s, got %s and %s",
+				tc.goPackage,
+				tc.importPath,
+				tc.packageName,
+				importPath,
+				packageName)
+		}
+	}
+}
+
+func TestReadGoPackageName(t *testing.T) {
+	dir := t.TempDir()
+
+	if name := readGoPackageName(dir); name != "" {
+		t.Errorf("expected no package in the empty directory, got %s", name)
+	}
+
+	files := map[string]string{
+		"orders_test.go": "package orders_test\n",
+		"orders.pb.go":   "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage ordersv1\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err.Error())
+		}
+	}
+
+	if name := readGoPackageName(dir); name != "ordersv1" {
+		t.Errorf("expected package ordersv1, got %s", name)
+	}
+}
@@ -0,0 +1,152 @@
+package checker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+const goldenFileMode = 0o644
+
+// formatGoldenFindings returns the sorted list of findings formatted as lines of a golden file.
+func formatGoldenFindings(results []*CheckResult) []string {
+	var result []string
+
+	for _, cr := range results {
+		for _, finding := range cr.Findings {
+			result = append(result, finding.goldenLine())
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// goldenLine formats the finding with its location, severity and check name,
+// so any change of them is considered a difference.
+func (f *Finding) goldenLine() string {
+	return fmt.Sprintf("%s:%d:%d: %s [%s] %s", f.Path, f.Line, f.Column, f.Severity, f.Check, f.Message)
+}
+
+// readGoldenFile reads the findings from the golden file.
+// A missing file is an error rather than an empty list, so a mistyped path can't make a run pass.
+func readGoldenFile(fileName string) ([]string, error) {
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("golden file %s doesn't exist, create it with --update-expect", fileName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden file: %w", err)
+	}
+
+	var (
+		result  []string
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read golden file: %w", err)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func writeGoldenFile(fileName string, lines []string) error {
+	var content string
+	if len(lines) > 0 {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+
+	if err := os.WriteFile(fileName, []byte(content), goldenFileMode); err != nil {
+		return fmt.Errorf("failed to write golden file: %w", err)
+	}
+
+	return nil
+}
+
+// diffGoldenFindings returns the lines present only in the expected list and only in the actual list.
+// Both lists must be sorted.
+func diffGoldenFindings(expected, actual []string) ([]string, []string) {
+	var (
+		missing    []string
+		unexpected []string
+		i, j       int
+	)
+
+	for i < len(expected) && j < len(actual) {
+		switch {
+		case expected[i] == actual[j]:
+			i++
+			j++
+		case expected[i] < actual[j]:
+			missing = append(missing, expected[i])
+			i++
+		default:
+			unexpected = append(unexpected, actual[j])
+			j++
+		}
+	}
+
+	missing = append(missing, expected[i:]...)
+	unexpected = append(unexpected, actual[j:]...)
+
+	return missing, unexpected
+}
+
+// compareWithGoldenFile compares the findings with the golden file, writing the differences to the writer,
+// and returns true if they match exactly.
+// If update is set, the golden file is overwritten with the current findings instead.
+func compareWithGoldenFile(ctx context.Context, w io.Writer, results []*CheckResult, fileName string, update bool) (bool, error) {
+	actual := formatGoldenFindings(results)
+
+	if update {
+		if err := writeGoldenFile(fileName, actual); err != nil {
+			return false, err
+		}
+
+		logger.Infof(ctx, "Golden file %s is updated with %d findings", fileName, len(actual))
+
+		return true, nil
+	}
+
+	expected, err := readGoldenFile(fileName)
+	if err != nil {
+		return false, err
+	}
+
+	missing, unexpected := diffGoldenFindings(expected, actual)
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return true, nil
+	}
+
+	logger.Errorf(ctx, "Findings differ from golden file %s", fileName)
+
+	for _, line := range missing {
+		_, _ = fmt.Fprintln(w, strings.Join([]string{"- ", line}, ""))
+	}
+
+	for _, line := range unexpected {
+		_, _ = fmt.Fprintln(w, strings.Join([]string{"+ ", line}, ""))
+	}
+
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestCompareWithGoldenFile(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		fileName = filepath.Join(t.TempDir(), "findings.golden")
+		first    = &Finding{
+			Check:    MessageNotEmpty,
+			Severity: config.SeverityError,
+			Message:  "Message orders.v1.Order is empty",
+			Path:     "orders.proto",
+			Line:     5,
+			Column:   1,
+		}
+		second = &Finding{
+			Check:    FieldHasNoDescription,
+			Severity: config.SeverityWarning,
+			Message:  "Field orders.v1.Item.id has no description",
+			Path:     "orders.proto",
+			Line:     8,
+			Column:   3,
+		}
+	)
+
+	newResults := func(findings ...*Finding) []*CheckResult {
+		return []*CheckResult{{Findings: findings}}
+	}
+
+	var output bytes.Buffer
+
+	// Without --update-expect a missing golden file must not pass as an empty one.
+	if _, err := compareWithGoldenFile(ctx, &output, newResults(), fileName, false); err == nil {
+		t.Fatal("expected error for missing golden file")
+	}
+
+	isMatched, err := compareWithGoldenFile(ctx, &output, newResults(second, first), fileName, true)
+	if err != nil || !isMatched {
+		t.Fatalf("failed to update golden file: %v", err)
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %s", err.Error())
+	}
+
+	expectedContent := first.goldenLine() + "\n" + second.goldenLine() + "\n"
+	if string(data) != expectedContent {
+		t.Errorf("expected golden file %q, got %q", expectedContent, string(data))
+	}
+
+	tests := []struct {
+		name           string
+		findings       []*Finding
+		expectedMatch  bool
+		expectedOutput string
+	}{
+		{
+			name:          "exact match in another order",
+			findings:      []*Finding{second, first},
+			expectedMatch: true,
+		},
+		{
+			name: "added finding",
+			findings: []*Finding{first, second, {
+				Check:    MessageNotEmpty,
+				Severity: config.SeverityError,
+				Message:  "Message orders.v1.Item is empty",
+				Path:     "orders.proto",
+				Line:     12,
+				Column:   1,
+			}},
+			expectedOutput: "+ orders.proto:12:1: error [message_not_empty] Message orders.v1.Item is empty\n",
+		},
+		{
+			name:           "removed finding",
+			findings:       []*Finding{second},
+			expectedOutput: "- " + first.goldenLine() + "\n",
+		},
+		{
+			name: "changed severity",
+			findings: []*Finding{first, {
+				Check:    second.Check,
+				Severity: config.SeverityError,
+				Message:  second.Message,
+				Path:     second.Path,
+				Line:     second.Line,
+				Column:   second.Column,
+			}},
+			expectedOutput: "- " + second.goldenLine() + "\n" +
+				"+ orders.proto:8:3: error [field_has_no_description] Field orders.v1.Item.id has no description\n",
+		},
+	}
+
+	for _, test := range tests {
+		output.Reset()
+
+		isMatched, err = compareWithGoldenFile(ctx, &output, newResults(test.findings...), fileName, false)
+		if err != nil {
+			t.Fatalf("%s: failed to compare with golden file: %s", test.name, err.Error())
+		}
+
+		if isMatched != test.expectedMatch {
+			t.Errorf("%s: expected match %t, got %t", test.name, test.expectedMatch, isMatched)
+		}
+
+		if output.String() != test.expectedOutput {
+			t.Errorf("%s: expected output %q, got %q", test.name, test.expectedOutput, output.String())
+		}
+	}
+}
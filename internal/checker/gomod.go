@@ -0,0 +1,138 @@
+package checker
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const goModFileName = "go.mod"
+
+// moduleRegistry maps Go module names to their directories,
+// so imports prefixed with a module name are resolved locally instead of being downloaded.
+type moduleRegistry struct {
+	mu      sync.RWMutex
+	modules map[string]string
+	visited map[string]struct{}
+}
+
+func newModuleRegistry(overriddenModuleName string) *moduleRegistry {
+	result := &moduleRegistry{
+		modules: make(map[string]string),
+		visited: make(map[string]struct{}),
+	}
+
+	if overriddenModuleName != "" {
+		result.modules[overriddenModuleName] = "."
+	}
+
+	return result
+}
+
+// addForFiles registers the nearest Go module of every file, walking up the directory tree.
+func (r *moduleRegistry) addForFiles(files []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, file := range files {
+		dir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			continue
+		}
+
+		r.addNearestModule(dir)
+	}
+}
+
+func (r *moduleRegistry) addNearestModule(dir string) {
+	for {
+		if _, ok := r.visited[dir]; ok {
+			return
+		}
+
+		r.visited[dir] = struct{}{}
+
+		moduleName, err := readModuleName(filepath.Join(dir, goModFileName))
+		if err == nil && moduleName != "" {
+			if _, ok := r.modules[moduleName]; !ok {
+				r.modules[moduleName] = dir
+			}
+
+			return
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+
+		dir = parent
+	}
+}
+
+// resolve returns the local path of the import if it's prefixed with a known module name.
+// The longest matching module name wins, so nested modules take precedence.
+func (r *moduleRegistry) resolve(path string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.modules))
+	for name := range r.modules {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	for _, name := range names {
+		relativePath, ok := strings.CutPrefix(path, strings.Join([]string{name, "/"}, ""))
+		if !ok {
+			continue
+		}
+
+		return filepath.Join(r.modules[name], filepath.FromSlash(relativePath)), true
+	}
+
+	return "", false
+}
+
+// readModuleName returns the module path declared in the go.mod file.
+func readModuleName(goModPath string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		moduleName, ok := strings.CutPrefix(line, "module")
+		if !ok || moduleName == "" || (moduleName[0] != ' ' && moduleName[0] != '\t') {
+			continue
+		}
+
+		moduleName = strings.TrimSpace(moduleName)
+		if commentIndex := strings.Index(moduleName, "//"); commentIndex >= 0 {
+			moduleName = strings.TrimSpace(moduleName[:commentIndex])
+		}
+
+		if unquoted, err := strconv.Unquote(moduleName); err == nil {
+			moduleName = unquoted
+		}
+
+		return moduleName, nil
+	}
+
+	return "", scanner.Err()
+}
@@ -0,0 +1,107 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModuleRegistryResolve(t *testing.T) {
+	var (
+		root        = t.TempDir()
+		ordersFile  = filepath.Join(root, "api", "orders", "v1", "orders.proto")
+		toolsFile   = filepath.Join(root, "api", "tools", "gen", "gen.proto")
+		outsideFile = filepath.Join(root, "other", "other.proto")
+	)
+
+	writeTestFileWithDirs(t, filepath.Join(root, "api", goModFileName), "module github.com/company/api\n\ngo 1.20\n")
+	writeTestFileWithDirs(t, filepath.Join(root, "api", "tools", goModFileName),
+		"// Tools of the API.\nmodule \"github.com/company/api/tools\" // Nested module.\n")
+
+	for _, file := range []string{ordersFile, toolsFile, outsideFile} {
+		writeTestFileWithDirs(t, file, "syntax = \"proto3\";\n")
+	}
+
+	tests := []struct {
+		name         string
+		moduleName   string
+		importPath   string
+		expectedPath string
+	}{
+		{
+			name:         "nearest module",
+			importPath:   "github.com/company/api/orders/v1/orders.proto",
+			expectedPath: ordersFile,
+		},
+		{
+			name:         "nested module",
+			importPath:   "github.com/company/api/tools/gen/gen.proto",
+			expectedPath: toolsFile,
+		},
+		{
+			name:       "module name prefix without separator",
+			importPath: "github.com/company/apis/orders.proto",
+		},
+		{
+			name:       "import outside of modules",
+			importPath: "google/api/annotations.proto",
+		},
+		{
+			name:         "overridden module name",
+			moduleName:   "github.com/company/protos",
+			importPath:   "github.com/company/protos/orders/v1/orders.proto",
+			expectedPath: filepath.Join("orders", "v1", "orders.proto"),
+		},
+		{
+			name:         "overridden module name takes precedence over go.mod",
+			moduleName:   "github.com/company/api",
+			importPath:   "github.com/company/api/orders/v1/orders.proto",
+			expectedPath: filepath.Join("orders", "v1", "orders.proto"),
+		},
+	}
+
+	for _, test := range tests {
+		registry := newModuleRegistry(test.moduleName)
+		// Files outside of any module are skipped silently.
+		registry.addForFiles([]string{ordersFile, toolsFile, outsideFile})
+
+		actual, ok := registry.resolve(test.importPath)
+		if ok != (test.expectedPath != "") || actual != test.expectedPath {
+			t.Errorf("%s: expected path %q, got %q resolved %t", test.name, test.expectedPath, actual, ok)
+		}
+	}
+
+	if _, ok := (*moduleRegistry)(nil).resolve("github.com/company/api/orders.proto"); ok {
+		t.Error("expected nil registry to resolve nothing")
+	}
+}
+
+func TestReadModuleName(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{content: "module github.com/company/api\n", expected: "github.com/company/api"},
+		{content: "// Header.\n\nmodule\tgithub.com/company/api // Comment.\n", expected: "github.com/company/api"},
+		{content: "module \"github.com/company/api\"\n", expected: "github.com/company/api"},
+		{content: "modules github.com/company/api\ngo 1.20\n", expected: ""},
+		{content: "go 1.20\n", expected: ""},
+	}
+
+	goModPath := filepath.Join(t.TempDir(), goModFileName)
+
+	for _, test := range tests {
+		if err := os.WriteFile(goModPath, []byte(test.content), 0o600); err != nil {
+			t.Fatalf("failed to write go.mod: %s", err.Error())
+		}
+
+		actual, err := readModuleName(goModPath)
+		if err != nil {
+			t.Fatalf("failed to read module name: %s", err.Error())
+		}
+
+		if actual != test.expected {
+			t.Errorf("expected module name %q of %q, got %q", test.expected, test.content, actual)
+		}
+	}
+}
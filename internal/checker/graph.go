@@ -0,0 +1,158 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OutputFormatDOT is the Graphviz output format of the import graph.
+const OutputFormatDOT = "dot"
+
+// BuildImportGraph compiles the provided protobuf files and returns the graph of their imports,
+// including standard imports and dependencies downloaded while compiling.
+func (c *ProtoChecker) BuildImportGraph(ctx context.Context, files ...string) (*ImportGraph, error) {
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := c.compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	var (
+		result = new(ImportGraph)
+		nodes  = make(map[string]*ImportGraphNode)
+	)
+
+	var addFile func(file protoreflect.FileDescriptor) *ImportGraphNode
+
+	addFile = func(file protoreflect.FileDescriptor) *ImportGraphNode {
+		if node, ok := nodes[file.Path()]; ok {
+			return node
+		}
+
+		node := &ImportGraphNode{
+			Path:    file.Path(),
+			Package: string(file.Package()),
+		}
+
+		nodes[file.Path()] = node
+		result.Nodes = append(result.Nodes, node)
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			imported := imports.Get(i)
+
+			addFile(imported.FileDescriptor)
+
+			result.Edges = append(result.Edges, &ImportGraphEdge{
+				From:     file.Path(),
+				To:       imported.Path(),
+				IsPublic: imported.IsPublic,
+				IsWeak:   imported.IsWeak,
+			})
+		}
+
+		return node
+	}
+
+	for _, parsedFile := range parsedFiles {
+		addFile(parsedFile).IsChecked = true
+	}
+
+	// Dependencies are registered under the import paths rewritten by the resolver.
+	var (
+		rewrites    = getImportRewrites(c.config)
+		downloadURL = make(map[string]string)
+	)
+
+	for _, dependency := range c.dependencies.list() {
+		downloadURL[dependency.Path] = dependency.URL
+	}
+
+	for _, node := range result.Nodes {
+		if url, ok := downloadURL[rewriteImportPath(node.Path, rewrites)]; ok {
+			node.URL = url
+		}
+	}
+
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		return result.Nodes[i].Path < result.Nodes[j].Path
+	})
+
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i].From != result.Edges[j].From {
+			return result.Edges[i].From < result.Edges[j].From
+		}
+
+		return result.Edges[i].To < result.Edges[j].To
+	})
+
+	return result, nil
+}
+
+// WriteJSON writes the import graph to the writer as indented JSON.
+func (g *ImportGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(g); err != nil {
+		return fmt.Errorf("failed to encode graph: %w", err)
+	}
+
+	return nil
+}
+
+// WriteDOT writes the import graph to the writer in Graphviz format.
+// Checked files are drawn bold, downloaded dependencies are drawn dashed,
+// public imports are labeled.
+func (g *ImportGraph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("digraph imports {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		var attributes []string
+
+		label := node.Path
+		if node.Package != "" {
+			label = strings.Join([]string{node.Path, node.Package}, "\n")
+		}
+
+		attributes = append(attributes, fmt.Sprintf("label=%q", label))
+
+		switch {
+		case node.IsChecked:
+			attributes = append(attributes, "style=bold")
+		case node.URL != "":
+			attributes = append(attributes, "style=dashed")
+		}
+
+		fmt.Fprintf(&sb, "  %q [%s];\n", node.Path, strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		switch {
+		case edge.IsPublic:
+			fmt.Fprintf(&sb, "  %q -> %q [label=\"public\"];\n", edge.From, edge.To)
+		case edge.IsWeak:
+			fmt.Fprintf(&sb, "  %q -> %q [label=\"weak\", style=dotted];\n", edge.From, edge.To)
+		default:
+			fmt.Fprintf(&sb, "  %q -> %q;\n", edge.From, edge.To)
+		}
+	}
+
+	sb.WriteString("}\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+
+	return nil
+}
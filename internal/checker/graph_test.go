@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestBuildImportGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/money.proto" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("syntax = \"proto3\";\n\npackage company;\n\nmessage Money {}\n"))
+	}))
+	defer server.Close()
+
+	var (
+		ctx        = context.Background()
+		dir        = t.TempDir()
+		ordersFile = filepath.Join(dir, "orders.proto")
+		commonFile = filepath.Join(dir, "common.proto")
+		cfg        = newLocalConfig(config.DownloadsOptions{})
+	)
+
+	cfg.ImportRewrites = []*config.ImportRewrite{{Prefix: "company/", Replacement: server.URL + "/company/"}}
+
+	commonSource := "syntax = \"proto3\";\n\npackage orders;\n\nimport public \"company/money.proto\";\n\n" +
+		"message Item {}\n"
+	if err := os.WriteFile(commonFile, []byte(commonSource), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	ordersSource := "syntax = \"proto3\";\n\npackage orders;\n\nimport \"" + commonFile + "\";\n" +
+		"import \"google/protobuf/empty.proto\";\n\n" +
+		"message Order {\n  Item item = 1;\n  company.Money total = 2;\n  google.protobuf.Empty details = 3;\n}\n"
+	if err := os.WriteFile(ordersFile, []byte(ordersSource), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	graph, err := NewProtoChecker(ctx, cfg).BuildImportGraph(ctx, ordersFile)
+	if err != nil {
+		t.Fatalf("failed to build import graph: %s", err.Error())
+	}
+
+	expected := &ImportGraph{
+		Nodes: []*ImportGraphNode{
+			{Path: commonFile, Package: "orders"},
+			{Path: ordersFile, Package: "orders", IsChecked: true},
+			{Path: "company/money.proto", Package: "company", URL: server.URL + "/company/money.proto"},
+			{Path: "google/protobuf/empty.proto", Package: "google.protobuf"},
+		},
+		Edges: []*ImportGraphEdge{
+			{From: commonFile, To: "company/money.proto", IsPublic: true},
+			{From: ordersFile, To: commonFile},
+			{From: ordersFile, To: "google/protobuf/empty.proto"},
+		},
+	}
+
+	// Temporary directories start with a slash, so local files are sorted before the imports.
+	if !reflect.DeepEqual(graph, expected) {
+		var actual bytes.Buffer
+
+		_ = graph.WriteJSON(&actual)
+
+		t.Fatalf("unexpected graph:\n%s", actual.String())
+	}
+
+	var buf bytes.Buffer
+	if err = graph.WriteJSON(&buf); err != nil {
+		t.Fatalf("failed to write JSON: %s", err.Error())
+	}
+
+	decoded := new(ImportGraph)
+	if err = json.Unmarshal(buf.Bytes(), decoded); err != nil || !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected JSON to round-trip the graph, got %s", buf.String())
+	}
+}
+
+func TestImportGraphWriteDOT(t *testing.T) {
+	graph := &ImportGraph{
+		Nodes: []*ImportGraphNode{
+			{Path: "company/money.proto", Package: "company", URL: "https://example.com/company/money.proto"},
+			{Path: "orders.proto", Package: "orders", IsChecked: true},
+			{Path: "weak.proto"},
+		},
+		Edges: []*ImportGraphEdge{
+			{From: "orders.proto", To: "company/money.proto", IsPublic: true},
+			{From: "orders.proto", To: "weak.proto", IsWeak: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := graph.WriteDOT(&buf); err != nil {
+		t.Fatalf("failed to write DOT: %s", err.Error())
+	}
+
+	expected := `digraph imports {
+  node [shape=box];
+  "company/money.proto" [label="company/money.proto\ncompany", style=dashed];
+  "orders.proto" [label="orders.proto\norders", style=bold];
+  "weak.proto" [label="weak.proto"];
+  "orders.proto" -> "company/money.proto" [label="public"];
+  "orders.proto" -> "weak.proto" [label="weak", style=dotted];
+}
+`
+
+	if buf.String() != expected {
+		t.Errorf("expected DOT:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,88 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	gitPreCommitHookPath = "hooks/pre-commit"
+	preCommitHookMarker  = "# Installed by 'protolinter hook install'."
+)
+
+// buildPreCommitHook returns the git pre-commit hook script linting the staged protobuf files.
+// Deleted files are omitted from the list, since there is nothing to lint.
+func buildPreCommitHook(configPath string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# Lints the staged protobuf files, run 'git commit --no-verify' to skip it.
+
+files=$(git diff --cached --name-only --diff-filter=ACMR -- '*.proto')
+if [ -z "$files" ]; then
+	exit 0
+fi
+
+printf '%%s\n' "$files" | %s --files-from -
+`,
+		preCommitHookMarker,
+		buildHookCheckCommand(configPath))
+}
+
+// buildPreCommitConfig returns the stanza of the .pre-commit-config.yaml file of the pre-commit framework,
+// which passes the staged protobuf files as arguments.
+func buildPreCommitConfig(configPath string) string {
+	return fmt.Sprintf(`repos:
+  - repo: local
+    hooks:
+      - id: protolinter
+        name: protolinter
+        entry: %s
+        language: system
+        files: \.proto$
+`,
+		buildHookCheckCommand(configPath))
+}
+
+func buildHookCheckCommand(configPath string) string {
+	command := "protolinter check --quiet --fail-fast"
+	if configPath != "" {
+		command += " --config " + quoteShellArgument(configPath)
+	}
+
+	return command
+}
+
+// quoteShellArgument wraps the argument in single quotes unless it consists of safe characters only.
+func quoteShellArgument(arg string) string {
+	isSafe := arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' ||
+			r >= 'A' && r <= 'Z' ||
+			r >= '0' && r <= '9' ||
+			strings.ContainsRune("-_./=", r))
+	}) < 0
+	if isSafe {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// locateGitPreCommitHook returns the path to the pre-commit hook of the current repository,
+// respecting worktrees and the core.hooksPath setting.
+func locateGitPreCommitHook() (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", gitPreCommitHookPath)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate git hooks directory: %w: %s",
+			err,
+			strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
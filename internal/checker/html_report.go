@@ -0,0 +1,223 @@
+package checker
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// htmlSnippetContext is the number of lines shown before and after the line of a finding.
+const htmlSnippetContext = 2
+
+// htmlReportTemplate renders a standalone page without external resources,
+// so the report can be shared as a single file.
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Protolinter report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+h1 { margin-bottom: 0.2em; }
+.summary { color: #59636e; margin-bottom: 1em; }
+.filters { display: flex; gap: 1em; margin-bottom: 1.5em; }
+.filters label { display: flex; flex-direction: column; font-size: 0.85em; color: #59636e; }
+section.file { border: 1px solid #d1d9e0; border-radius: 6px; margin-bottom: 1.5em; }
+section.file h2 { font-size: 1em; margin: 0; padding: 0.6em 1em; background: #f6f8fa; border-bottom: 1px solid #d1d9e0; }
+section.file h2 .package { color: #59636e; font-weight: normal; margin-left: 0.5em; }
+.finding { padding: 0.6em 1em; border-bottom: 1px solid #eaeef2; }
+.finding:last-child { border-bottom: none; }
+.severity { display: inline-block; padding: 0 0.5em; border-radius: 1em; font-size: 0.8em; color: #fff; }
+.severity-error { background: #cf222e; }
+.severity-warning { background: #9a6700; }
+.check { color: #59636e; font-family: monospace; }
+.location { color: #59636e; font-family: monospace; font-size: 0.85em; }
+pre { background: #f6f8fa; padding: 0.5em 0; margin: 0.5em 0 0; overflow-x: auto; }
+pre span { display: block; padding: 0 1em; }
+pre span.highlighted { background: #fff8c5; }
+pre .number { display: inline-block; width: 3em; color: #8c959f; user-select: none; }
+</style>
+</head>
+<body>
+<h1>Protolinter report</h1>
+<div class="summary">
+{{ len .Files }} files with findings, {{ .Errors }} errors, {{ .Warnings }} warnings,
+<span id="shown">{{ .Total }}</span> findings shown
+</div>
+<div class="filters">
+<label>Check
+<select data-filter="check">
+<option value="">All</option>
+{{ range .Checks }}<option>{{ . }}</option>
+{{ end }}</select>
+</label>
+<label>Severity
+<select data-filter="severity">
+<option value="">All</option>
+<option>error</option>
+<option>warning</option>
+</select>
+</label>
+<label>Package
+<select data-filter="package">
+<option value="">All</option>
+{{ range .Packages }}<option>{{ . }}</option>
+{{ end }}</select>
+</label>
+</div>
+{{ range .Files }}{{ $package := .Package }}<section class="file">
+<h2>{{ .Path }}<span class="package">{{ .Package }}</span></h2>
+{{ range .Findings }}<div class="finding" data-check="{{ .Check }}" data-severity="{{ .Severity }}" data-package="{{ $package }}">
+<span class="severity severity-{{ .Severity }}">{{ .Severity }}</span>
+{{ .Message }} <span class="check">[{{ .Check }}]</span>
+<div class="location">{{ .Text }}</div>
+{{ if .Snippet }}<pre>{{ range .Snippet }}<span{{ if .IsHighlighted }} class="highlighted"{{ end }}><span class="number">{{ .Number }}</span>{{ .Text }}</span>{{ end }}</pre>
+{{ end }}</div>
+{{ end }}</section>
+{{ end }}<script>
+const filters = document.querySelectorAll("select[data-filter]");
+
+function applyFilters() {
+  let shown = 0;
+
+  document.querySelectorAll("section.file").forEach((section) => {
+    let visible = 0;
+
+    section.querySelectorAll("div.finding").forEach((finding) => {
+      const matches = Array.from(filters).every((filter) =>
+        filter.value === "" || finding.dataset[filter.dataset.filter] === filter.value);
+
+      finding.hidden = !matches;
+
+      if (matches) {
+        visible++;
+      }
+    });
+
+    section.hidden = visible === 0;
+    shown += visible;
+  });
+
+  document.getElementById("shown").textContent = shown;
+}
+
+filters.forEach((filter) => filter.addEventListener("change", applyFilters));
+</script>
+</body>
+</html>
+`))
+
+// NewHTMLReport creates the HTML report of the files having findings, with snippets of their sources.
+func NewHTMLReport(results []*CheckResult) *HTMLReport {
+	var (
+		report   = &HTMLReport{}
+		checks   = make(map[string]struct{})
+		packages = make(map[string]struct{})
+	)
+
+	for _, cr := range results {
+		if len(cr.Findings) == 0 {
+			continue
+		}
+
+		fileReport := &HTMLFileReport{
+			Path:     cr.File.Path(),
+			Package:  string(cr.File.Package()),
+			Findings: make([]*HTMLFinding, 0, len(cr.Findings)),
+		}
+
+		lines := getResultSourceLines(cr.File)
+
+		for _, finding := range cr.Findings {
+			report.Total++
+
+			switch finding.Severity {
+			case config.SeverityError:
+				report.Errors++
+			case config.SeverityWarning:
+				report.Warnings++
+			}
+
+			checks[finding.Check] = struct{}{}
+
+			fileReport.Findings = append(fileReport.Findings, &HTMLFinding{
+				Finding: finding,
+				Text:    formatFindingText(finding),
+				Snippet: getSourceSnippet(lines, finding.Line),
+			})
+		}
+
+		packages[fileReport.Package] = struct{}{}
+		report.Files = append(report.Files, fileReport)
+	}
+
+	report.Checks = getSortedKeys(checks)
+	report.Packages = getSortedKeys(packages)
+
+	return report
+}
+
+// WriteHTML writes the report to the writer as a standalone HTML page.
+func (r *HTMLReport) WriteHTML(w io.Writer) error {
+	if err := htmlReportTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	return nil
+}
+
+// getResultSourceLines returns the lines of the source of the file, if its syntax tree is available.
+func getResultSourceLines(file linker.File) []string {
+	res, ok := file.(linker.Result)
+	if !ok || res.AST() == nil {
+		return nil
+	}
+
+	lines, _ := getSourceLines(res.AST())
+
+	return lines
+}
+
+// getSourceSnippet returns the one-based line along with the lines around it.
+func getSourceSnippet(lines []string, line int) []*SourceLine {
+	if line <= 0 || line > len(lines) {
+		return nil
+	}
+
+	first, last := line-htmlSnippetContext, line+htmlSnippetContext
+	if first < 1 {
+		first = 1
+	}
+
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	result := make([]*SourceLine, 0, last-first+1)
+
+	for number := first; number <= last; number++ {
+		result = append(result, &SourceLine{
+			Number:        number,
+			Text:          lines[number-1],
+			IsHighlighted: number == line,
+		})
+	}
+
+	return result
+}
+
+// getSortedKeys returns the keys of the set in ascending order.
+func getSortedKeys(set map[string]struct{}) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
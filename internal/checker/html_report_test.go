@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestGetSourceSnippet(t *testing.T) {
+	lines := []string{"a", "b", "c", "d", "e"}
+
+	expected := []*SourceLine{
+		{Number: 1, Text: "a"},
+		{Number: 2, Text: "b", IsHighlighted: true},
+		{Number: 3, Text: "c"},
+		{Number: 4, Text: "d"},
+	}
+
+	if actual := getSourceSnippet(lines, 2); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected snippet %+v", actual)
+	}
+
+	if actual := getSourceSnippet(lines, 0); actual != nil {
+		t.Errorf("expected no snippet for an unknown line, got %+v", actual)
+	}
+}
+
+func TestHTMLReport(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+message Order {
+  string id = 1;
+}
+`
+
+	compiler := &protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{
+				"shop.proto":  source,
+				"empty.proto": "syntax = \"proto3\";\n\npackage empty;\n",
+			}),
+		},
+		RetainASTs: true,
+	}
+
+	files, err := compiler.Compile(context.Background(), "shop.proto", "empty.proto")
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	results := []*CheckResult{
+		{
+			File: files[0],
+			Findings: []*Finding{
+				{
+					Check:    FieldHasNoDescription,
+					Severity: config.SeverityWarning,
+					Message:  "Field <id> has no description",
+					Path:     "shop.proto",
+					Line:     6,
+					Column:   3,
+				},
+			},
+		},
+		{File: files[1]},
+	}
+
+	report := NewHTMLReport(results)
+	if len(report.Files) != 1 || report.Total != 1 || report.Warnings != 1 ||
+		!reflect.DeepEqual(report.Packages, []string{"shop"}) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	var output bytes.Buffer
+	if err = report.WriteHTML(&output); err != nil {
+		t.Fatalf("failed to write report: %s", err.Error())
+	}
+
+	for _, expected := range []string{
+		`<option>field_has_no_description</option>`,
+		`data-package="shop"`,
+		`Field &lt;id&gt; has no description`,
+		`<span class="highlighted"><span class="number">6</span>  string id = 1;</span>`,
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("expected the report to contain %s, got:\n%s", expected, output.String())
+		}
+	}
+}
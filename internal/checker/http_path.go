@@ -0,0 +1,205 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	httpPathSeparator    = "/"
+	httpPathVerbPrefix   = ':'
+	httpPathVariableOpen = '{'
+	httpPathVariableEnd  = '}'
+	httpPathVariableBind = "="
+)
+
+var httpPathVersionRegexp = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+
+// httpPathSegment is a segment of an HTTP path template.
+// Patterns of variables like {name=shops/*/orders/*} are expanded into several segments.
+type httpPathSegment struct {
+	value      string
+	isVariable bool
+}
+
+// checkHTTPPath checks the depth of an HTTP path and that it alternates
+// collections and resource identifiers, as REST resource naming guidelines suggest.
+func (c *ProtoChecker) checkHTTPPath(
+	method protoreflect.Descriptor,
+	result *CheckResult,
+	methodLogName string,
+	path string,
+) {
+	isMaxDepthChecked := !c.config.IsCheckExcluded(HTTPPathMaxDepth)
+	isResourceStyleChecked := !c.config.IsCheckExcluded(HTTPPathResourceStyle)
+
+	if !isMaxDepthChecked && !isResourceStyleChecked {
+		return
+	}
+
+	segments, err := parseHTTPPathTemplate(path)
+	if err != nil {
+		result.AddMessagef("Failed to parse path %s of method %s: %s", path, methodLogName, err.Error())
+
+		return
+	}
+
+	// The version prefix is neither a collection nor a resource.
+	if len(segments) > 0 && !segments[0].isVariable && httpPathVersionRegexp.MatchString(segments[0].value) {
+		segments = segments[1:]
+	}
+
+	c.runRule(HTTPPathMaxDepth, method, func() {
+		if maxSegments := c.config.GetHTTPPathMaxSegments(); len(segments) > maxSegments {
+			result.AddFindingf(
+				HTTPPathMaxDepth,
+				method,
+				"Path %s of method %s has %d segments, the maximum is %d",
+				path,
+				methodLogName,
+				len(segments),
+				maxSegments)
+		}
+	})
+
+	c.runRule(HTTPPathResourceStyle, method, func() {
+		c.checkHTTPPathResourceStyle(method, result, methodLogName, path, segments)
+	})
+}
+
+// checkHTTPPathResourceStyle reports the first segment breaking the alternation
+// of collections and resource identifiers.
+func (c *ProtoChecker) checkHTTPPathResourceStyle(
+	method protoreflect.Descriptor,
+	result *CheckResult,
+	methodLogName string,
+	path string,
+	segments []*httpPathSegment,
+) {
+	for i, segment := range segments {
+		// Collections are at even positions, resource identifiers are at odd ones.
+		isVariableExpected := i%2 == 1
+		if segment.isVariable == isVariableExpected {
+			continue
+		}
+
+		if i == 0 {
+			result.AddFindingf(
+				HTTPPathResourceStyle,
+				method,
+				"Path %s of method %s must start with a collection instead of a variable",
+				path,
+				methodLogName)
+
+			return
+		}
+
+		expectedKind := "a collection"
+		if isVariableExpected {
+			expectedKind = "a resource identifier variable"
+		}
+
+		result.AddFindingf(
+			HTTPPathResourceStyle,
+			method,
+			"Path %s of method %s must have %s after %s instead of %s",
+			path,
+			methodLogName,
+			result.localize(expectedKind),
+			segments[i-1].value,
+			segment.value)
+
+		return
+	}
+}
+
+// parseHTTPPathTemplate splits an HTTP path template into segments,
+// omitting the custom verb of the last segment.
+func parseHTTPPathTemplate(path string) ([]*httpPathSegment, error) {
+	if !strings.HasPrefix(path, httpPathSeparator) {
+		return nil, errors.New("path must start with /")
+	}
+
+	var (
+		result  []*httpPathSegment
+		tokens  []string
+		current strings.Builder
+		depth   int
+	)
+
+	for _, r := range path[1:] {
+		switch {
+		case r == httpPathVariableOpen:
+			depth++
+		case r == httpPathVariableEnd:
+			depth--
+		case r == httpPathVerbPrefix && depth == 0:
+			// The custom verb ends the path.
+			tokens = append(tokens, current.String())
+			current.Reset()
+
+			return appendHTTPPathSegments(result, tokens)
+		case string(r) == httpPathSeparator && depth == 0:
+			tokens = append(tokens, current.String())
+			current.Reset()
+
+			continue
+		}
+
+		if depth < 0 || depth > 1 {
+			return nil, errors.New("unbalanced braces")
+		}
+
+		current.WriteRune(r)
+	}
+
+	if depth != 0 {
+		return nil, errors.New("unbalanced braces")
+	}
+
+	return appendHTTPPathSegments(result, append(tokens, current.String()))
+}
+
+func appendHTTPPathSegments(result []*httpPathSegment, tokens []string) ([]*httpPathSegment, error) {
+	for _, token := range tokens {
+		if token == "" {
+			return nil, errors.New("path must not have empty segments")
+		}
+
+		if token[0] != httpPathVariableOpen {
+			result = append(result, &httpPathSegment{
+				value:      token,
+				isVariable: token == "*" || token == "**",
+			})
+
+			continue
+		}
+
+		if token[len(token)-1] != httpPathVariableEnd {
+			return nil, fmt.Errorf("malformed variable %s", token)
+		}
+
+		_, pattern, hasPattern := strings.Cut(token[1:len(token)-1], httpPathVariableBind)
+		if !hasPattern {
+			result = append(result, &httpPathSegment{
+				value:      token,
+				isVariable: true,
+			})
+
+			continue
+		}
+
+		patternSegments, err := appendHTTPPathSegments(nil, strings.Split(pattern, httpPathSeparator))
+		if err != nil {
+			return nil, fmt.Errorf("malformed variable %s: %w", token, err)
+		}
+
+		result = append(result, patternSegments...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHTTPPathTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []httpPathSegment
+		isError  bool
+	}{
+		{
+			path: "/v1/orders",
+			expected: []httpPathSegment{
+				{value: "v1"},
+				{value: "orders"},
+			},
+		},
+		{
+			path: "/v1/orders/{order_id}:cancel",
+			expected: []httpPathSegment{
+				{value: "v1"},
+				{value: "orders"},
+				{value: "{order_id}", isVariable: true},
+			},
+		},
+		{
+			path: "/v1/{name=shops/*/orders/*}/items",
+			expected: []httpPathSegment{
+				{value: "v1"},
+				{value: "shops"},
+				{value: "*", isVariable: true},
+				{value: "orders"},
+				{value: "*", isVariable: true},
+				{value: "items"},
+			},
+		},
+		{
+			path:    "v1/orders",
+			isError: true,
+		},
+		{
+			path:    "/v1//orders",
+			isError: true,
+		},
+		{
+			path:    "/v1/{order_id",
+			isError: true,
+		},
+		{
+			path:    "/v1/{order_{id}}",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		segments, err := parseHTTPPathTemplate(test.path)
+		if test.isError {
+			if err == nil {
+				t.Errorf("expected an error for path %s", test.path)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("unexpected error for path %s: %s", test.path, err.Error())
+
+			continue
+		}
+
+		actual := make([]httpPathSegment, 0, len(segments))
+		for _, segment := range segments {
+			actual = append(actual, *segment)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("expected segments %v for path %s, got %v", test.expected, test.path, actual)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package checker
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	idempotencyLevelOption = "google.protobuf.MethodOptions.idempotency_level"
+	httpVerbGet            = "get"
+)
+
+// checkIdempotencyLevel checks that the idempotency_level option is set,
+// and that methods mapped to HTTP GET are declared as having no side effects.
+func (c *ProtoChecker) checkIdempotencyLevel(
+	method protoreflect.Descriptor,
+	result *CheckResult,
+	methodLogName string,
+	httpVerb string,
+	idempotencyLevel descriptorpb.MethodOptions_IdempotencyLevel,
+) {
+	if c.config.IsCheckExcluded(MethodHasIdempotencyLevel) {
+		return
+	}
+
+	defer c.startRule(MethodHasIdempotencyLevel, method)()
+
+	switch {
+	case idempotencyLevel == descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN:
+		result.AddFindingf(
+			MethodHasIdempotencyLevel,
+			method,
+			"Method %s doesn't have option idempotency_level",
+			methodLogName)
+	case httpVerb == httpVerbGet && idempotencyLevel != descriptorpb.MethodOptions_NO_SIDE_EFFECTS:
+		result.AddFindingf(
+			MethodHasIdempotencyLevel,
+			method,
+			"Method %s is mapped to HTTP GET, its idempotency_level must be %s instead of %s",
+			methodLogName,
+			descriptorpb.MethodOptions_NO_SIDE_EFFECTS,
+			idempotencyLevel)
+	}
+}
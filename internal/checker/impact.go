@@ -0,0 +1,130 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// NewImpactReport compares the findings of the same files checked under the current and the candidate
+// configurations and groups the difference by checks. Findings are matched by their check, location and severity,
+// so a finding changing its severity is both removed and added.
+func NewImpactReport(current, candidate []*CheckResult) *ImpactReport {
+	var (
+		currentFindings   = indexImpactFindings(current)
+		candidateFindings = indexImpactFindings(candidate)
+		index             = make(map[string]*RuleImpact)
+	)
+
+	getRuleImpact := func(check string) *RuleImpact {
+		impact, ok := index[check]
+		if !ok {
+			impact = &RuleImpact{Check: check}
+			index[check] = impact
+		}
+
+		return impact
+	}
+
+	for key, finding := range candidateFindings {
+		if _, ok := currentFindings[key]; !ok {
+			impact := getRuleImpact(finding.Check)
+			impact.Added = append(impact.Added, finding)
+		}
+	}
+
+	for key, finding := range currentFindings {
+		if _, ok := candidateFindings[key]; !ok {
+			impact := getRuleImpact(finding.Check)
+			impact.Removed = append(impact.Removed, finding)
+		}
+	}
+
+	rules := make([]*RuleImpact, 0, len(index))
+	for _, impact := range index {
+		sortImpactFindings(impact.Added)
+		sortImpactFindings(impact.Removed)
+
+		rules = append(rules, impact)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Check < rules[j].Check
+	})
+
+	return &ImpactReport{
+		Rules: rules,
+	}
+}
+
+// IsEmpty returns true if the candidate configuration doesn't change any finding.
+func (r *ImpactReport) IsEmpty() bool {
+	return len(r.Rules) == 0
+}
+
+// WriteText writes the number of added and removed findings per check to the writer as a table,
+// followed by the added and removed findings themselves.
+func (r *ImpactReport) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+
+	fmt.Fprintln(writer, "CHECK\tADDED\tREMOVED")
+
+	for _, impact := range r.Rules {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", impact.Check, len(impact.Added), len(impact.Removed))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	for _, impact := range r.Rules {
+		for _, finding := range impact.Added {
+			if _, err := fmt.Fprintf(w, "+ %s\n", finding.goldenLine()); err != nil {
+				return err
+			}
+		}
+
+		for _, finding := range impact.Removed {
+			if _, err := fmt.Fprintf(w, "- %s\n", finding.goldenLine()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the difference in findings per check to the writer in JSON format.
+func (r *ImpactReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode impact report: %w", err)
+	}
+
+	return nil
+}
+
+// indexImpactFindings returns the findings of the results by their check, location and severity.
+func indexImpactFindings(results []*CheckResult) map[string]*Finding {
+	result := make(map[string]*Finding)
+
+	for _, cr := range results {
+		for _, finding := range cr.Findings {
+			key := fmt.Sprintf("%s:%d:%d: %s [%s]", finding.Path, finding.Line, finding.Column, finding.Severity, finding.Check)
+			result[key] = finding
+		}
+	}
+
+	return result
+}
+
+// sortImpactFindings sorts the findings by their location.
+func sortImpactFindings(findings []*Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].goldenLine() < findings[j].goldenLine()
+	})
+}
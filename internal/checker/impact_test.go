@@ -0,0 +1,45 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestNewImpactReport(t *testing.T) {
+	var (
+		kept = &Finding{Check: MethodHasVersion, Severity: config.SeverityError, Path: "a.proto", Line: 3, Column: 1}
+		// The same finding under the candidate configuration, only its message differs.
+		keptAgain = &Finding{Check: MethodHasVersion, Severity: config.SeverityError, Path: "a.proto", Line: 3, Column: 1,
+			Message: "translated"}
+		escalatedFrom = &Finding{Check: FieldHasNoDescription, Severity: config.SeverityWarning, Path: "a.proto", Line: 7}
+		escalatedTo   = &Finding{Check: FieldHasNoDescription, Severity: config.SeverityError, Path: "a.proto", Line: 7}
+		added         = &Finding{Check: FieldHasNoDescription, Severity: config.SeverityError, Path: "a.proto", Line: 5}
+		removed       = &Finding{Check: MessageNoCycles, Severity: config.SeverityError, Path: "b.proto", Line: 2}
+	)
+
+	report := NewImpactReport(
+		[]*CheckResult{{Findings: []*Finding{kept, escalatedFrom, removed}}},
+		[]*CheckResult{{Findings: []*Finding{keptAgain, escalatedTo, added}}})
+
+	if len(report.Rules) != 2 {
+		t.Fatalf("expected 2 checks with changed findings, got %d", len(report.Rules))
+	}
+
+	fieldImpact, cycleImpact := report.Rules[0], report.Rules[1]
+
+	if fieldImpact.Check != FieldHasNoDescription ||
+		len(fieldImpact.Added) != 2 || fieldImpact.Added[0] != added || fieldImpact.Added[1] != escalatedTo ||
+		len(fieldImpact.Removed) != 1 || fieldImpact.Removed[0] != escalatedFrom {
+		t.Errorf("unexpected impact of %s: %+v", FieldHasNoDescription, fieldImpact)
+	}
+
+	if cycleImpact.Check != MessageNoCycles || len(cycleImpact.Added) != 0 ||
+		len(cycleImpact.Removed) != 1 || cycleImpact.Removed[0] != removed {
+		t.Errorf("unexpected impact of %s: %+v", MessageNoCycles, cycleImpact)
+	}
+
+	if !NewImpactReport(nil, nil).IsEmpty() {
+		t.Error("expected no impact without findings")
+	}
+}
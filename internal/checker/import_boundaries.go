@@ -0,0 +1,137 @@
+package checker
+
+import (
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// dependencyFieldNumber is the number of the dependency field in google.protobuf.FileDescriptorProto,
+// used to build source paths of import statements.
+const dependencyFieldNumber = 3
+
+// checkImportBoundaries checks that the file doesn't import files of packages
+// forbidden for its package, nor references types of such packages made available by public imports.
+func (c *ProtoChecker) checkImportBoundaries(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(ImportBoundaries) || len(c.config.GetImportBoundaries()) == 0 {
+		return
+	}
+
+	defer c.startRule(ImportBoundaries, parsedFile)()
+
+	var (
+		filePackage     = string(parsedFile.Package())
+		imports         = parsedFile.Imports()
+		importedFiles   = make(map[string]struct{}, imports.Len())
+		sourceLocations = parsedFile.SourceLocations()
+	)
+
+	for i := 0; i < imports.Len(); i++ {
+		imported := imports.Get(i)
+		importedFiles[imported.Path()] = struct{}{}
+
+		importedPackage := string(imported.Package())
+
+		pattern := c.config.FindImportBoundary(filePackage, importedPackage)
+		if pattern == "" {
+			continue
+		}
+
+		result.AddFindingAtf(
+			ImportBoundaries,
+			nil,
+			sourceLocations.ByPath(protoreflect.SourcePath{dependencyFieldNumber, int32(i)}),
+			"Package %s must not import %s of package %s matching %s",
+			filePackage,
+			imported.Path(),
+			importedPackage,
+			pattern)
+	}
+
+	// Types of directly imported files are already reported with the import.
+	walkTypeReferences(parsedFile, func(desc, referenced protoreflect.Descriptor) {
+		referencedFile := referenced.ParentFile()
+		if _, ok := importedFiles[referencedFile.Path()]; ok || referencedFile.Path() == parsedFile.Path() {
+			return
+		}
+
+		referencedPackage := string(referencedFile.Package())
+
+		pattern := c.config.FindImportBoundary(filePackage, referencedPackage)
+		if pattern == "" {
+			return
+		}
+
+		result.AddFindingf(
+			ImportBoundaries,
+			desc,
+			"Package %s must not reference %s of package %s matching %s",
+			filePackage,
+			referenced.FullName(),
+			referencedPackage,
+			pattern)
+	})
+}
+
+// walkTypeReferences calls the function for every message or enum referenced by a field,
+// a method or an extension declared in the file.
+func walkTypeReferences(file protoreflect.FileDescriptor, fn func(desc, referenced protoreflect.Descriptor)) {
+	walkFieldReferences := func(field protoreflect.FieldDescriptor) {
+		if field.Message() != nil && !field.Message().IsMapEntry() {
+			fn(field, field.Message())
+		}
+
+		if field.Enum() != nil {
+			fn(field, field.Enum())
+		}
+
+		if field.IsExtension() {
+			fn(field, field.ContainingMessage())
+		}
+
+		// Types of map keys and values are referenced by the field itself.
+		if field.IsMap() {
+			if value := field.MapValue(); value.Message() != nil {
+				fn(field, value.Message())
+			} else if value.Enum() != nil {
+				fn(field, value.Enum())
+			}
+		}
+	}
+
+	var walkMessages func(messages protoreflect.MessageDescriptors)
+
+	walkMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			if message.IsMapEntry() {
+				continue
+			}
+
+			for j := 0; j < message.Fields().Len(); j++ {
+				walkFieldReferences(message.Fields().Get(j))
+			}
+
+			for j := 0; j < message.Extensions().Len(); j++ {
+				walkFieldReferences(message.Extensions().Get(j))
+			}
+
+			walkMessages(message.Messages())
+		}
+	}
+
+	walkMessages(file.Messages())
+
+	for i := 0; i < file.Extensions().Len(); i++ {
+		walkFieldReferences(file.Extensions().Get(i))
+	}
+
+	for i := 0; i < file.Services().Len(); i++ {
+		methods := file.Services().Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			method := methods.Get(j)
+
+			fn(method, method.Input())
+			fn(method, method.Output())
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const emptyMessageNameSuffix = "Empty"
+
+// newDescriptorIndex collects facts about descriptors declared in the compiled files.
+func newDescriptorIndex(files linker.Files) *descriptorIndex {
+	result := &descriptorIndex{
+		rpcMessages:     make(map[protoreflect.FullName]struct{}),
+		referenced:      make(map[protoreflect.FullName]struct{}),
+		requestMethods:  make(map[protoreflect.FullName]protoreflect.MethodDescriptor),
+		responseMethods: make(map[protoreflect.FullName]protoreflect.MethodDescriptor),
+		packageServices: make(map[protoreflect.FullName]int),
+	}
+
+	for _, file := range files {
+		Walk(file, &indexVisitor{
+			index: result,
+		})
+	}
+
+	return result
+}
+
+// EnterService counts the service in its package.
+func (v *indexVisitor) EnterService(service protoreflect.ServiceDescriptor) bool {
+	v.index.packageServices[service.ParentFile().Package()]++
+
+	return true
+}
+
+// Method records the input and output of the method as used by RPCs and the messages reachable from them.
+func (v *indexVisitor) Method(method protoreflect.MethodDescriptor) {
+	i := v.index
+
+	i.rpcMessages[method.Input().FullName()] = struct{}{}
+	i.rpcMessages[method.Output().FullName()] = struct{}{}
+	i.referenced[method.Input().FullName()] = struct{}{}
+	i.referenced[method.Output().FullName()] = struct{}{}
+
+	addReachableMessages(i.requestMethods, method.Input(), method)
+	addReachableMessages(i.responseMethods, method.Output(), method)
+}
+
+// Field records the message or enum the field refers to.
+func (v *indexVisitor) Field(field protoreflect.FieldDescriptor) {
+	v.index.addFieldReferences(field)
+}
+
+// Extension records the message the extension extends and the message or enum it refers to.
+func (v *indexVisitor) Extension(extension protoreflect.ExtensionDescriptor) {
+	v.index.addFieldReferences(extension)
+}
+
+// addReachableMessages adds the message and the messages reachable from it through fields,
+// including map values, to the map unless they are already reached by another method.
+func addReachableMessages(
+	reached map[protoreflect.FullName]protoreflect.MethodDescriptor,
+	message protoreflect.MessageDescriptor,
+	method protoreflect.MethodDescriptor,
+) {
+	queue := []protoreflect.MessageDescriptor{message}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := reached[current.FullName()]; ok {
+			continue
+		}
+
+		reached[current.FullName()] = method
+
+		fields := current.Fields()
+		for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
+			if next := fields.Get(fieldIndex).Message(); next != nil {
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+func (i *descriptorIndex) addFieldReferences(field protoreflect.FieldDescriptor) {
+	if field.IsExtension() {
+		i.referenced[field.ContainingMessage().FullName()] = struct{}{}
+	}
+
+	if message := field.Message(); message != nil {
+		i.referenced[message.FullName()] = struct{}{}
+	}
+
+	if enum := field.Enum(); enum != nil {
+		i.referenced[enum.FullName()] = struct{}{}
+	}
+}
+
+// isReferenced returns true if the message or enum is referenced by a field, a method or an extension.
+func (i *descriptorIndex) isReferenced(desc protoreflect.Descriptor) bool {
+	if i == nil {
+		return true
+	}
+
+	_, ok := i.referenced[desc.FullName()]
+
+	return ok
+}
+
+// isUsedByRPC returns true if the message is used as an input or output of a method.
+func (i *descriptorIndex) isUsedByRPC(message protoreflect.MessageDescriptor) bool {
+	if i == nil {
+		return false
+	}
+
+	_, ok := i.rpcMessages[message.FullName()]
+
+	return ok
+}
+
+// getRequestMethod returns the method whose input the message is reachable from, or nil.
+func (i *descriptorIndex) getRequestMethod(message protoreflect.MessageDescriptor) protoreflect.MethodDescriptor {
+	if i == nil {
+		return nil
+	}
+
+	return i.requestMethods[message.FullName()]
+}
+
+// getResponseMethod returns the method whose output the message is reachable from, or nil.
+func (i *descriptorIndex) getResponseMethod(message protoreflect.MessageDescriptor) protoreflect.MethodDescriptor {
+	if i == nil {
+		return nil
+	}
+
+	return i.responseMethods[message.FullName()]
+}
+
+// isPlaceholderMessage returns true if the message declares nothing and isn't meant to be empty:
+// it's neither used by a method nor named like *Empty.
+// Messages having only nested declarations are namespaces rather than placeholders.
+func isPlaceholderMessage(message protoreflect.MessageDescriptor, index *descriptorIndex) bool {
+	return message.Fields().Len() == 0 &&
+		message.Messages().Len() == 0 &&
+		message.Enums().Len() == 0 &&
+		message.Extensions().Len() == 0 &&
+		message.ExtensionRanges().Len() == 0 &&
+		!strings.HasSuffix(string(message.Name()), emptyMessageNameSuffix) &&
+		!index.isUsedByRPC(message)
+}
@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const jsonNameOption = "json_name"
+
+var lowerCamelCaseRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// hasExplicitJSONName returns true if the json_name option is set in the declaration of the field.
+// The compiler fills in JSON names of all fields, so the descriptor can't tell them apart.
+func hasExplicitJSONName(field protoreflect.FieldDescriptor) bool {
+	_, node := findDescriptorNode(field)
+
+	fieldNode, ok := node.(ast.FieldDeclNode)
+	if !ok || fieldNode.GetOptions() == nil {
+		return false
+	}
+
+	for _, option := range fieldNode.GetOptions().Options {
+		parts := option.Name.Parts
+		if len(parts) == 1 && !parts[0].IsExtension() && parts[0].Name.AsIdentifier() == jsonNameOption {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findJSONNameCollision returns another field of the message whose JSON name or name
+// matches the JSON name of the field ignoring case, or nil if there is none.
+// JSON parsers accept both names of a field and some of them ignore case,
+// so such fields can't be told apart in payloads.
+func findJSONNameCollision(field protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	message, ok := field.Parent().(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil
+	}
+
+	var (
+		jsonName = field.JSONName()
+		fields   = message.Fields()
+	)
+
+	for i := 0; i < fields.Len(); i++ {
+		other := fields.Get(i)
+		if other == field {
+			continue
+		}
+
+		if strings.EqualFold(jsonName, other.JSONName()) || strings.EqualFold(jsonName, string(other.Name())) {
+			return other
+		}
+	}
+
+	return nil
+}
+
+// checkJSONNameStyle checks that an explicitly set JSON name of the field is lowerCamelCase
+// and doesn't collide with other fields of the message.
+// The check contradicts field_has_correct_json_name, so it runs only where that check is excluded.
+func (c *ProtoChecker) checkJSONNameStyle(
+	field protoreflect.FieldDescriptor,
+	result *CheckResult,
+	fieldLogName string,
+) {
+	scopeConfig := c.config.ForScope(field.ParentFile().Path(), string(field.FullName()))
+	if !scopeConfig.IsCheckExcluded(FieldHasCorrectJSONName) {
+		return
+	}
+
+	c.runRule(JSONNameIsLowerCamel, field, func() {
+		if !hasExplicitJSONName(field) {
+			return
+		}
+
+		fieldJSONName := field.JSONName()
+		if !lowerCamelCaseRegexp.MatchString(fieldJSONName) {
+			result.AddFindingf(
+				JSONNameIsLowerCamel,
+				field,
+				"JSON name %s of field %s isn't lowerCamelCase",
+				fieldJSONName,
+				fieldLogName)
+		}
+
+		if other := findJSONNameCollision(field); other != nil {
+			result.AddFindingf(
+				JSONNameIsLowerCamel,
+				field,
+				"JSON name %s of field %s collides with the name or JSON name of field %s",
+				fieldJSONName,
+				fieldLogName,
+				other.Name())
+		}
+	})
+}
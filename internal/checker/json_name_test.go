@@ -0,0 +1,85 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestJSONNameChecksTogether(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package orders.v1;
+
+message Order {
+  string order_id = 1 [json_name = "orderId"];
+  string customer_id = 2 [json_name = "customer_id"];
+}
+`
+
+	var (
+		ctx        = context.Background()
+		dir        = t.TempDir()
+		fileName   = filepath.Join(dir, "orders.proto")
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+	)
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	tests := []struct {
+		name     string
+		config   string
+		expected map[string][]int
+	}{
+		{
+			name:   "both checks enabled",
+			config: "verbose_mode: false\n",
+			expected: map[string][]int{
+				FieldHasCorrectJSONName: {6},
+			},
+		},
+		{
+			name:   "field_has_correct_json_name excluded",
+			config: "excluded_checks:\n  - field_has_correct_json_name\n",
+			expected: map[string][]int{
+				JSONNameIsLowerCamel: {7},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if err := os.WriteFile(configPath, []byte(test.config), 0o600); err != nil {
+			t.Fatalf("failed to write configuration: %s", err.Error())
+		}
+
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("failed to load configuration: %s", err.Error())
+		}
+
+		results, err := NewProtoChecker(ctx, cfg).CheckFiles(ctx, fileName)
+		if err != nil {
+			t.Fatalf("failed to check file: %s", err.Error())
+		}
+
+		actual := make(map[string][]int)
+
+		for _, cr := range results {
+			for _, finding := range cr.Findings {
+				if finding.Check == FieldHasCorrectJSONName || finding.Check == JSONNameIsLowerCamel {
+					actual[finding.Check] = append(actual[finding.Check], finding.Line)
+				}
+			}
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: got findings %v, want %v", test.name, actual, test.expected)
+		}
+	}
+}
@@ -12,6 +12,8 @@ import (
 // a list of CheckResult instances, each containing the checking results for a single file.
 // It uses the compiler and parser associated with the ProtoChecker instance.
 func (c *ProtoChecker) ListFullNamesFromFiles(ctx context.Context, files ...string) ([]*ListResult, error) {
+	c.modules.addForFiles(files)
+
 	parsedFiles, err := c.compiler.Compile(ctx, files...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
@@ -28,74 +30,47 @@ func (c *ProtoChecker) ListFullNamesFromFiles(ctx context.Context, files ...stri
 
 func (c *ProtoChecker) listFullNamesFromFile(parsedFile linker.File) *ListResult {
 	result := NewListResult(parsedFile, c.config)
-	parsedFileFullName := string(parsedFile.FullName())
-
-	result.AddMessagef("Package: %s", parsedFileFullName)
-
-	services := parsedFile.Services()
-	servicesCount := services.Len()
-
-	for serviceIndex := 0; serviceIndex < servicesCount; serviceIndex++ {
-		service := services.Get(serviceIndex)
-		serviceFullName := string(service.FullName())
-
-		result.AddMessagef("Service: %s", serviceFullName)
-
-		methods := service.Methods()
-		for methodIndex := 0; methodIndex < methods.Len(); methodIndex++ {
-			method := methods.Get(methodIndex)
-			methodFullName := string(method.FullName())
-
-			result.AddMessagef("Method: %s", methodFullName)
-		}
-	}
+	result.AddMessagef("Package: %s", parsedFile.FullName())
 
-	c.listMessagesFullNames(parsedFile.Messages(), result)
-	c.listEnumsFullNames(parsedFile.Enums(), result)
+	Walk(parsedFile, &listVisitor{
+		result: result,
+	})
 
 	return result
 }
 
-func (c *ProtoChecker) listMessagesFullNames(
-	messages protoreflect.MessageDescriptors,
-	result *ListResult,
-) {
-	for messageIndex := 0; messageIndex < messages.Len(); messageIndex++ {
-		message := messages.Get(messageIndex)
-		messageFullName := string(message.FullName())
+// EnterService lists the service.
+func (v *listVisitor) EnterService(service protoreflect.ServiceDescriptor) bool {
+	v.result.AddMessagef("Service: %s", service.FullName())
 
-		result.AddMessagef("Message: %s", messageFullName)
+	return true
+}
 
-		fields := message.Fields()
-		for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
-			field := fields.Get(fieldIndex)
-			fieldFullName := string(field.FullName())
+// Method lists the method.
+func (v *listVisitor) Method(method protoreflect.MethodDescriptor) {
+	v.result.AddMessagef("Method: %s", method.FullName())
+}
 
-			result.AddMessagef("Field: %s", fieldFullName)
-		}
+// EnterMessage lists the message.
+func (v *listVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	v.result.AddMessagef("Message: %s", message.FullName())
 
-		c.listMessagesFullNames(message.Messages(), result)
-		c.listEnumsFullNames(message.Enums(), result)
-	}
+	return true
 }
 
-func (c *ProtoChecker) listEnumsFullNames(
-	enums protoreflect.EnumDescriptors,
-	result *ListResult,
-) {
-	for enumIndex := 0; enumIndex < enums.Len(); enumIndex++ {
-		enum := enums.Get(enumIndex)
-		enumFullName := string(enum.FullName())
-
-		result.AddMessagef("Enum: %s", enumFullName)
+// Field lists the field.
+func (v *listVisitor) Field(field protoreflect.FieldDescriptor) {
+	v.result.AddMessagef("Field: %s", field.FullName())
+}
 
-		enumValues := enum.Values()
+// EnterEnum lists the enum.
+func (v *listVisitor) EnterEnum(enum protoreflect.EnumDescriptor) bool {
+	v.result.AddMessagef("Enum: %s", enum.FullName())
 
-		for enumValueIndex := 0; enumValueIndex < enumValues.Len(); enumValueIndex++ {
-			enumValue := enumValues.Get(enumValueIndex)
-			enumValueFullName := string(enumValue.FullName())
+	return true
+}
 
-			result.AddMessagef("Enum value: %s", enumValueFullName)
-		}
-	}
+// EnumValue lists the enum value.
+func (v *listVisitor) EnumValue(enumValue protoreflect.EnumValueDescriptor) {
+	v.result.AddMessagef("Enum value: %s", enumValue.FullName())
 }
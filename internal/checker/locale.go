@@ -0,0 +1,150 @@
+package checker
+
+import "github.com/oshokin/protolinter/internal/config"
+
+// messageCatalogs maps locales to the translations of diagnostic messages.
+// Messages are keyed by their English format strings, so the English locale needs no catalog,
+// and a message missing from a catalog is emitted in English.
+// Words substituted into messages, like kinds of descriptors, are translated the same way.
+var messageCatalogs = map[string]map[string]string{ //nolint: lll // Translations are easier to review on one line.
+	config.LocaleRussian: {
+		// Kinds of descriptors and other words substituted into messages.
+		"Service":                        "Сервис",
+		"Method":                         "Метод",
+		"Message":                        "Сообщение",
+		"Field":                          "Поле",
+		"Enum":                           "Перечисление",
+		"Enum value":                     "Значение перечисления",
+		"File":                           "Файл",
+		"Extension":                      "Расширение",
+		"Input":                          "Входное сообщение",
+		"Output":                         "Выходное сообщение",
+		"a map":                          "словарём",
+		"a repeated message":             "повторяющимся сообщением",
+		"a message":                      "сообщением",
+		"a collection":                   "коллекция",
+		"a resource identifier variable": "переменная идентификатора ресурса",
+
+		// Skipped descriptors and other informational messages.
+		"Package %s is skipped":                         "Пакет %s пропущен",
+		"Service %s is skipped":                         "Сервис %s пропущен",
+		"Method %s is skipped":                          "Метод %s пропущен",
+		"Message %s is skipped":                         "Сообщение %s пропущено",
+		"Field %s is skipped":                           "Поле %s пропущено",
+		"Enum %s is skipped":                            "Перечисление %s пропущено",
+		"Enum value %s is skipped":                      "Значение перечисления %s пропущено",
+		"Extension %s is skipped":                       "Расширение %s пропущено",
+		"Failed to parse path %s of method %s: %s":      "Не удалось разобрать путь %s метода %s: %s",
+		"%s (will be escalated from %s to %s after %s)": "%s (уровень будет повышен с %s до %s после %s)",
+
+		// Findings.
+		"%s %s must be documented with %s comments":                                                       "%s %s: документация должна быть оформлена комментариями %s",
+		"%s %s has a trailing comment, documentation must precede the declaration":                        "%s %s: комментарий в конце строки, документация должна предшествовать объявлению",
+		"%s %s of method %s is defined in package %s instead of %s":                                       "%s %s метода %s объявлено в пакете %s вместо %s",
+		"Service %s doesn't have option %s":                                                               "У сервиса %s нет опции %s",
+		"Option %s of service %s must be a host name without scheme and path, got %q":                     "Опция %s сервиса %s должна быть именем хоста без схемы и пути, получено %q",
+		"Option %s of service %s must be a comma-separated list of HTTPS URLs, got %q":                    "Опция %s сервиса %s должна быть списком HTTPS URL через запятую, получено %q",
+		"Name of method %s doesn't match regular expression: %s":                                          "Имя метода %s не соответствует регулярному выражению: %s",
+		"Input of method %s should be named as %s":                                                        "Входное сообщение метода %s должно называться %s",
+		"Methods of service %s have different versions: V%s":                                              "Методы сервиса %s имеют разные версии: V%s",
+		"Version V%s of method %s doesn't match version v%s of package %s":                                "Версия V%s метода %s не совпадает с версией v%s пакета %s",
+		"Path of method %s is not specified":                                                              "Путь метода %s не указан",
+		"Method %s doesn't have body tag or body is not equal to *":                                       "У метода %s нет тега body или body не равен *",
+		"Method %s has no swagger tags":                                                                   "У метода %s нет тегов swagger",
+		"Method %s has no swagger summary":                                                                "У метода %s нет краткого описания swagger",
+		"Method %s has no swagger description":                                                            "У метода %s нет описания swagger",
+		"External docs URL %q of method %s must be an absolute HTTPS URL":                                 "URL внешней документации %q метода %s должен быть абсолютным HTTPS URL",
+		"External docs URL %q of method %s isn't on an allowed domain: %s":                                "URL внешней документации %q метода %s не относится к разрешенным доменам: %s",
+		"Method %s doesn't have option idempotency_level":                                                 "У метода %s нет опции idempotency_level",
+		"Method %s is mapped to HTTP GET, its idempotency_level must be %s instead of %s":                 "Метод %s отображён на HTTP GET, его idempotency_level должен быть %s вместо %s",
+		"Field %s of request of GET method %s is %s and can't be bound from query parameters":             "Поле %s запроса GET-метода %s является %s и не может быть заполнено из параметров запроса",
+		"Request of GET method %s has oneof %s, which can't be reliably bound from query parameters":      "Запрос GET-метода %s содержит oneof %s, который нельзя надежно заполнить из параметров запроса",
+		"Key type %s of map field %s is discouraged, JSON gateways stringify such keys inconsistently":    "Тип ключа %s map-поля %s не рекомендуется, JSON-шлюзы непоследовательно преобразуют такие ключи в строки",
+		"Key type %s of map field %s isn't allowed":                                                       "Тип ключа %s map-поля %s не разрешен",
+		"Deprecated field %s has no removal note like \"Remove after: %s\"":                               "У устаревшего поля %s нет заметки об удалении вида \"Remove after: %s\"",
+		"Removal note of deprecated field %s has invalid date %s, expected a date like %s":                "Заметка об удалении устаревшего поля %s содержит некорректную дату %s, ожидается дата вида %s",
+		"%s %s is deprecated and was due to be removed after %s":                                          "%s %s: устаревший элемент должен был быть удалён после %s",
+		"Field %s of message %s is %s, but the message is reachable from the response of method %s":       "Поле %s сообщения %s помечено как %s, но сообщение достижимо из ответа метода %s",
+		"Field %s of message %s is %s, but the message is reachable from the request of method %s":        "Поле %s сообщения %s помечено как %s, но сообщение достижимо из запроса метода %s",
+		"Field %s looks like it holds sensitive data, but isn't marked by any of the options: %s":         "Поле %s похоже на чувствительные данные, но не помечено ни одной из опций: %s",
+		"Field %s holds personal data, but isn't marked by debug_redact":                                  "Поле %s содержит персональные данные, но не помечено debug_redact",
+		"Field %s is marked by debug_redact, but can't hold personal data":                                "Поле %s помечено debug_redact, но не может содержать персональные данные",
+		"Method %s starts with %s, but is bound to HTTP %s instead of %s":                                 "Метод %s начинается с %s, но привязан к HTTP %s вместо %s",
+		"Request %s of method %s doesn't have field google.protobuf.FieldMask update_mask":                "Запрос %s метода %s не содержит поле google.protobuf.FieldMask update_mask",
+		"Method %s returns %s instead of the created %s":                                                  "Метод %s возвращает %s вместо созданного %s",
+		"Response %s of method %s has no field of the created %s":                                         "Ответ %s метода %s не содержит поля созданного %s",
+		"Request %s of method %s doesn't have repeated field %s":                                          "Запрос %s метода %s не содержит повторяющееся поле %s",
+		"Field %s of request %s of method %s has validation rules, but doesn't limit the number of items": "Поле %s запроса %s метода %s имеет правила валидации, но не ограничивает число элементов",
+		"Response %s of method %s doesn't have repeated message field %s":                                 "Ответ %s метода %s не содержит повторяющееся поле-сообщение %s",
+		"Method %s returns %s, but doesn't have option %s":                                                "Метод %s возвращает %s, но не имеет опции %s",
+		"Option %s of method %s doesn't specify %s":                                                       "Опция %s метода %s не задаёт %s",
+		"Type %s in %s of method %s isn't a message found in the file or its imports":                     "Тип %s в %s метода %s не является сообщением из файла или его импортов",
+		"%s %s has option with unknown field number %d, checks can't read its value":                      "%s %s имеет опцию с неизвестным номером поля %d, проверки не могут прочитать её значение",
+		"%s %s has option %s unknown to its file, checks can't read its value until %s is imported":       "%s %s имеет опцию %s, неизвестную её файлу, проверки не могут прочитать её значение, пока не импортирован %s",
+		"Service %s has %d methods, the maximum is %d":                                                    "Сервис %s содержит методов: %d, максимум: %d",
+		"Package %s has %d services, the maximum is %d":                                                   "Пакет %s содержит сервисов: %d, максимум: %d",
+		"%s %s must be named %s to spell acronyms in %s style":                                            "%s %s должен называться %s, чтобы аббревиатуры были написаны в стиле %s",
+		"%s %s of method %s is defined in %s instead of %s":                                               "%s %s метода %s объявлено в %s вместо %s",
+		"%s %s of method %s is defined in %s outside the directory of %s":                                 "%s %s метода %s объявлено в %s за пределами каталога %s",
+		"%s %s of method %s is defined in %s instead of %s or %s":                                         "%s %s метода %s объявлено в %s вместо %s или %s",
+		"Import of deprecated %s of package %s":                                                           "Импорт устаревшего файла %s пакета %s",
+		"Import of deprecated %s of package %s, use %s instead":                                           "Импорт устаревшего файла %s пакета %s, используйте %s",
+		"Reference to deprecated %s of %s":                                                                "Ссылка на устаревший тип %s из %s",
+		"Reference to deprecated %s of %s, use %s instead":                                                "Ссылка на устаревший тип %s из %s, используйте %s",
+		"File %s has %d lines, the maximum is %d":                                                         "Файл %s содержит строк: %d, максимум: %d",
+		"File %s declares %d messages, the maximum is %d":                                                 "Файл %s объявляет сообщений: %d, максимум: %d",
+		"Directive %s of %s names no checks":                                                              "Директива %s элемента %s не называет ни одной проверки",
+		"Directive %s of %s names check %s, which is renamed to %s":                                       "Директива %s элемента %s называет проверку %s, которая переименована в %s",
+		"Directive %s of %s names retired check %s":                                                       "Директива %s элемента %s называет выведенную из использования проверку %s",
+		"Directive %s of %s names unknown check %s, did you mean %s?":                                     "Директива %s элемента %s называет неизвестную проверку %s, возможно, имелась в виду %s?",
+		"Directive %s of %s names unknown check %s":                                                       "Директива %s элемента %s называет неизвестную проверку %s",
+		"Directive %s of %s doesn't suppress any finding of check %s":                                     "Директива %s элемента %s не подавляет ни одной находки проверки %s",
+		"%s %s has option %s with environment-specific value %q matching %s":                              "%s %s: опция %s содержит значение %q, зависящее от окружения и совпадающее с %s",
+		"Path %s of method %s has %d segments, the maximum is %d":                                         "Путь %s метода %s содержит сегментов: %d, максимум: %d",
+		"Path %s of method %s must start with a collection instead of a variable":                         "Путь %s метода %s должен начинаться с коллекции, а не с переменной",
+		"Path %s of method %s must have %s after %s instead of %s":                                        "В пути %[1]s метода %[2]s после %[4]s должна быть %[3]s вместо %[5]s",
+		"Message %s has no fields and isn't used by any method":                                           "Сообщение %s не содержит полей и не используется ни одним методом",
+		"Message %s isn't referenced by any field, method or extension":                                   "На сообщение %s не ссылается ни одно поле, метод или расширение",
+		"Message %s references itself: %s":                                                                "Сообщение %s ссылается само на себя: %s",
+		"Field %s has incorrect json_name tag":                                                            "У поля %s неверный тег json_name",
+		"Comment of field %s merely restates its name":                                                    "Комментарий поля %s лишь повторяет его имя",
+		"Field %s in doesn't have description":                                                            "У поля %s нет описания",
+		"Description of field %s doesn't start with capital letter":                                       "Описание поля %s не начинается с заглавной буквы",
+		"Description of field %s is shorter than %d characters":                                           "Описание поля %s короче %d символов",
+		"Description of field %s is longer than %d characters":                                            "Описание поля %s длиннее %d символов",
+		"Description of field %s must end with dot":                                                       "Описание поля %s должно заканчиваться точкой",
+		"Enum %s isn't referenced by any field":                                                           "На перечисление %s не ссылается ни одно поле",
+		"Enum value %s has no leading comments":                                                           "У значения перечисления %s нет комментария перед ним",
+		"Comment of enum value %s merely restates its name":                                               "Комментарий значения перечисления %s лишь повторяет его имя",
+		"Extension %s of message %s is forbidden":                                                         "Расширение %s сообщения %s запрещено",
+		"Extension ranges of message %s are forbidden":                                                    "Диапазоны расширений сообщения %s запрещены",
+		"Extension range of message %s has no leading comments":                                           "У диапазона расширений сообщения %s нет комментария перед ним",
+		"Package %s must not import %s of package %s matching %s":                                         "Пакет %s не должен импортировать %s пакета %s, подпадающего под %s",
+		"Package %s must not reference %s of package %s matching %s":                                      "Пакет %s не должен ссылаться на %s пакета %s, подпадающего под %s",
+		"File %s doesn't start with a header comment":                                                     "Файл %s не начинается с комментария-заголовка",
+		"Header of file %s doesn't match the template":                                                    "Заголовок файла %s не соответствует шаблону",
+		"Package of file %s must be declared before imports, options and definitions":                     "Пакет файла %s должен быть объявлен до импортов, опций и определений",
+		"Import %s of file %s must precede options and definitions":                                       "Импорт %s файла %s должен предшествовать опциям и определениям",
+		"Import %s of file %s must precede import %s to keep imports sorted":                              "Импорт %s файла %s должен предшествовать импорту %s, чтобы импорты были отсортированы",
+		"Option go_package %s of file %s points to directory %s, which doesn't exist in the module":       "Опция go_package %s файла %s указывает на каталог %s, которого нет в модуле",
+		"Option go_package %s of file %s declares package %s, but directory %s contains package %s":       "Опция go_package %s файла %s объявляет пакет %s, но каталог %s содержит пакет %s",
+		"Option %s of file %s must precede definitions":                                                   "Опция %s файла %s должна предшествовать определениям",
+		"Line %d of file %s is %d characters long, exceeding the limit of %d":                             "Строка %d файла %s длиной %d символов превышает ограничение в %d",
+		"Line %d of file %s is indented with tabs instead of spaces":                                      "Строка %d файла %s имеет отступ табуляциями вместо пробелов",
+		"Line %d of file %s is indented with spaces instead of tabs":                                      "Строка %d файла %s имеет отступ пробелами вместо табуляций",
+		"Line %d of file %s is indented by %d spaces, which is not a multiple of %d":                      "Отступ строки %d файла %s в %d пробелов не кратен %d",
+		"JSON name %s of field %s isn't lowerCamelCase":                                                   "JSON-имя %s поля %s не в стиле lowerCamelCase",
+		"JSON name %s of field %s collides with the name or JSON name of field %s":                        "JSON-имя %s поля %s совпадает с именем или JSON-именем поля %s",
+		"Field %s of message %s serializes to JSON key %s already used by field %s":                       "Поле %s сообщения %s сериализуется в JSON-ключ %s, уже занятый полем %s",
+	},
+}
+
+// localize returns the translation of the message or its format to the locale,
+// or the message itself if there is no translation.
+func localize(locale, v string) string {
+	if translation, ok := messageCatalogs[locale][v]; ok {
+		return translation
+	}
+
+	return v
+}
@@ -0,0 +1,141 @@
+package checker
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var formatVerbRegexp = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+// TestMessageCatalogsAreComplete checks that every format passed to the methods of CheckResult
+// is translated and that translations consume the same arguments as the original formats.
+func TestMessageCatalogsAreComplete(t *testing.T) {
+	formats := findMessageFormats(t)
+
+	for locale, catalog := range messageCatalogs {
+		for _, format := range formats {
+			if _, ok := catalog[format]; !ok {
+				t.Errorf("locale %s: missing translation of %q", locale, format)
+			}
+		}
+
+		for format, translation := range catalog {
+			expected, actual := getFormatVerbs(format), getFormatVerbs(translation)
+			if strings.Join(expected, " ") != strings.Join(actual, " ") {
+				t.Errorf("locale %s: translation of %q has verbs %v, expected %v", locale, format, actual, expected)
+			}
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	if actual := localize("ru", "Path of method %s is not specified"); actual != "Путь метода %s не указан" {
+		t.Errorf("unexpected translation: %s", actual)
+	}
+
+	if actual := localize("en", "Path of method %s is not specified"); actual != "Path of method %s is not specified" {
+		t.Errorf("expected English message to be kept, got %s", actual)
+	}
+
+	if actual := localize("ru", "Unknown message"); actual != "Unknown message" {
+		t.Errorf("expected message without translation to be kept, got %s", actual)
+	}
+}
+
+// findMessageFormats returns the literal formats passed to the formatting methods of CheckResult.
+// Listings of descriptors aren't diagnostics, so the lister isn't translated.
+func findMessageFormats(t *testing.T) []string {
+	t.Helper()
+
+	formatArgumentIndexes := map[string]int{
+		"AddMessagef":   0,
+		"AddFindingf":   2,
+		"AddFindingAtf": 3,
+		"AddWarningf":   2,
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("failed to list source files: %s", err.Error())
+	}
+
+	var (
+		result  []string
+		fileSet = token.NewFileSet()
+	)
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || file == "lister.go" {
+			continue
+		}
+
+		parsedFile, err := parser.ParseFile(fileSet, file, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", file, err.Error())
+		}
+
+		ast.Inspect(parsedFile, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			index, ok := formatArgumentIndexes[selector.Sel.Name]
+			if !ok || len(call.Args) <= index {
+				return true
+			}
+
+			literal, ok := call.Args[index].(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+
+			format, err := strconv.Unquote(literal.Value)
+			if err != nil {
+				t.Fatalf("failed to unquote %s: %s", literal.Value, err.Error())
+			}
+
+			result = append(result, format)
+
+			return true
+		})
+	}
+
+	return result
+}
+
+// getFormatVerbs returns the verbs of the format ordered by the arguments they consume.
+func getFormatVerbs(format string) []string {
+	var (
+		result   []string
+		argument int
+	)
+
+	for _, match := range formatVerbRegexp.FindAllStringSubmatch(format, -1) {
+		verb := match[0][len(match[0])-1:]
+
+		if match[1] != "" {
+			argument, _ = strconv.Atoi(strings.Trim(match[1], "[]"))
+		} else {
+			argument++
+		}
+
+		result = append(result, strconv.Itoa(argument)+verb)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
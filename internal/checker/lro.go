@@ -0,0 +1,114 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	operationFullName          = "google.longrunning.Operation"
+	operationInfoOptionName    = "google.longrunning.operation_info"
+	operationInfoResponseField = "response_type"
+	operationInfoMetadataField = "metadata_type"
+)
+
+// checkLongRunningOperation checks that a method returning google.longrunning.Operation declares
+// the types of the response and the metadata of the operation in the operation_info option,
+// and that the types are messages found in the file of the method or in its imports, as AIP-151 suggests.
+func (c *ProtoChecker) checkLongRunningOperation(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	if method.Output().FullName() != operationFullName {
+		return
+	}
+
+	c.runRule(MethodLROConventions, method, func() {
+		operationInfo := getOperationInfo(method)
+		if operationInfo == nil {
+			result.AddFindingf(
+				MethodLROConventions,
+				method,
+				"Method %s returns %s, but doesn't have option %s",
+				methodLogName,
+				operationFullName,
+				operationInfoOptionName)
+
+			return
+		}
+
+		for _, fieldName := range []string{operationInfoResponseField, operationInfoMetadataField} {
+			typeName := operationInfo.GetString(fieldName)
+			if typeName == "" {
+				result.AddFindingf(
+					MethodLROConventions,
+					method,
+					"Option %s of method %s doesn't specify %s",
+					operationInfoOptionName,
+					methodLogName,
+					fieldName)
+
+				continue
+			}
+
+			if !isMessageResolvable(method, typeName) {
+				result.AddFindingf(
+					MethodLROConventions,
+					method,
+					"Type %s in %s of method %s isn't a message found in the file or its imports",
+					typeName,
+					fieldName,
+					methodLogName)
+			}
+		}
+	})
+}
+
+// getOperationInfo returns the values of the operation_info option of the method, nil if the option isn't set.
+func getOperationInfo(method protoreflect.MethodDescriptor) *parser.OptionValues {
+	var result *parser.OptionValues
+
+	method.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if string(fd.FullName()) != operationInfoOptionName || fd.Message() == nil {
+				return true
+			}
+
+			result = parser.NewOptionValues(v.Message())
+
+			return false
+		})
+
+	return result
+}
+
+// isMessageResolvable returns true if the type name refers to a message of the file of the method
+// or of the files it imports. Names are looked up relative to the package of the file first,
+// then as fully qualified names. If the file can't be searched, the type is considered resolvable.
+func isMessageResolvable(method protoreflect.MethodDescriptor, typeName string) bool {
+	file, ok := method.ParentFile().(linker.File)
+	if !ok {
+		return true
+	}
+
+	var (
+		resolver   = linker.ResolverFromFile(file)
+		candidates = []string{strings.TrimPrefix(typeName, ".")}
+	)
+
+	if packageName := file.Package(); packageName != "" && !strings.HasPrefix(typeName, ".") {
+		candidates = append([]string{string(packageName) + "." + typeName}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := resolver.FindMessageByName(protoreflect.FullName(candidate)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/oshokin/protolinter/internal/common"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+const manifestFileMode = 0o644
+
+// NewRunManifest creates a run manifest describing the configuration file,
+// the checked files and the downloaded dependencies.
+func NewRunManifest(configPath string, files []string, dependencies []*RemoteDependency) (*RunManifest, error) {
+	result := &RunManifest{
+		Version:            common.Version(),
+		CreatedAt:          time.Now().UTC(),
+		Files:              make([]*ManifestFile, 0, len(files)),
+		RemoteDependencies: dependencies,
+	}
+
+	if configPath == "" {
+		configPath = config.DefaultConfigName
+	}
+
+	configChecksum, _, err := getFileChecksum(configPath)
+
+	switch {
+	case err == nil:
+		result.ConfigFile = configPath
+		result.ConfigSHA256 = configChecksum
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	for _, file := range files {
+		checksum, size, err := getFileChecksum(file)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Files = append(result.Files, &ManifestFile{
+			Path:   file,
+			SHA256: checksum,
+			Size:   size,
+		})
+	}
+
+	if result.RemoteDependencies == nil {
+		result.RemoteDependencies = []*RemoteDependency{}
+	}
+
+	return result, nil
+}
+
+// WriteToFile writes the run manifest to the specified file in JSON format.
+func (m *RunManifest) WriteToFile(fileName string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err = os.WriteFile(fileName, data, manifestFileMode); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", fileName, err)
+	}
+
+	return nil
+}
+
+func getFileChecksum(fileName string) (string, int64, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", 0, err
+	}
+
+	checksum := sha256.Sum256(data)
+
+	return hex.EncodeToString(checksum[:]), int64(len(data)), nil
+}
@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewRunManifest(t *testing.T) {
+	var (
+		dir          = t.TempDir()
+		configPath   = filepath.Join(dir, ".protolinter.yaml")
+		fileName     = filepath.Join(dir, "orders.proto")
+		dependencies = []*RemoteDependency{
+			{
+				Path:   "google/api/annotations.proto",
+				URL:    "https://raw.githubusercontent.com/googleapis/googleapis/master/google/api/annotations.proto",
+				SHA256: "0123456789abcdef",
+				Size:   1024,
+			},
+		}
+	)
+
+	writeTestFileWithDirs(t, configPath, "verbose_mode: false\n")
+	writeTestFileWithDirs(t, fileName, "syntax = \"proto3\";\n")
+
+	// The manifest is written without its creation time, so runs on the same inputs can be compared.
+	writeManifest := func() ([]byte, *RunManifest) {
+		t.Helper()
+
+		manifest, err := NewRunManifest(configPath, []string{fileName}, dependencies)
+		if err != nil {
+			t.Fatalf("failed to create manifest: %s", err.Error())
+		}
+
+		manifest.CreatedAt = time.Time{}
+
+		manifestPath := filepath.Join(dir, "manifest.json")
+		if err = manifest.WriteToFile(manifestPath); err != nil {
+			t.Fatalf("failed to write manifest: %s", err.Error())
+		}
+
+		data, err := os.ReadFile(manifestPath)
+		if err != nil {
+			t.Fatalf("failed to read manifest: %s", err.Error())
+		}
+
+		return data, manifest
+	}
+
+	first, manifest := writeManifest()
+
+	// SHA-256 checksums of "verbose_mode: false\n" and "syntax = \"proto3\";\n".
+	const (
+		expectedConfigChecksum = "eefe4a32a37f85f641592211e7093d7a5d7521e921f163e1ccc64bee5112f0e1"
+		expectedFileChecksum   = "26695965cd692d9dce08efe0b2e1f745c3be7c19631b56873c8956b8d486cf17"
+	)
+
+	if manifest.ConfigFile != configPath || manifest.ConfigSHA256 != expectedConfigChecksum {
+		t.Errorf("expected configuration %s with checksum %s, got %s with %s",
+			configPath,
+			expectedConfigChecksum,
+			manifest.ConfigFile,
+			manifest.ConfigSHA256)
+	}
+
+	if len(manifest.Files) != 1 || manifest.Files[0].SHA256 != expectedFileChecksum || manifest.Files[0].Size != 19 {
+		t.Errorf("expected file with checksum %s and size 19, got %+v", expectedFileChecksum, manifest.Files)
+	}
+
+	if second, _ := writeManifest(); !bytes.Equal(first, second) {
+		t.Errorf("expected the same manifest for the same inputs, got:\n%s\n%s", first, second)
+	}
+
+	writeTestFileWithDirs(t, fileName, "syntax = \"proto3\";\n\npackage orders;\n")
+
+	changedFile, manifest := writeManifest()
+	if bytes.Equal(first, changedFile) || manifest.Files[0].SHA256 == expectedFileChecksum ||
+		manifest.ConfigSHA256 != expectedConfigChecksum {
+		t.Errorf("expected only the checksum of the changed file to change, got %+v", manifest)
+	}
+
+	writeTestFileWithDirs(t, configPath, "verbose_mode: true\n")
+
+	if _, manifest = writeManifest(); manifest.ConfigSHA256 == expectedConfigChecksum {
+		t.Error("expected the checksum of the changed configuration to change")
+	}
+}
+
+func TestNewRunManifestWithoutConfig(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		fileName = filepath.Join(dir, "orders.proto")
+	)
+
+	writeTestFileWithDirs(t, fileName, "syntax = \"proto3\";\n")
+
+	manifest, err := NewRunManifest(filepath.Join(dir, "missing.yaml"), []string{fileName}, nil)
+	if err != nil {
+		t.Fatalf("failed to create manifest: %s", err.Error())
+	}
+
+	if manifest.ConfigFile != "" || manifest.ConfigSHA256 != "" || manifest.RemoteDependencies == nil {
+		t.Errorf("expected manifest without configuration and with empty dependencies, got %+v", manifest)
+	}
+
+	if _, err = NewRunManifest("", []string{filepath.Join(dir, "missing.proto")}, nil); err == nil {
+		t.Error("expected error for missing checked file")
+	}
+}
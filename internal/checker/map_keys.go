@@ -0,0 +1,53 @@
+package checker
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkMapKeyType checks that the key type of the map field is allowed.
+// Key types listed as warning types are reported as warnings regardless of the severity of the check.
+func (c *ProtoChecker) checkMapKeyType(
+	field protoreflect.FieldDescriptor,
+	result *CheckResult,
+	fieldLogName string,
+) {
+	if !field.IsMap() {
+		return
+	}
+
+	c.runRule(MapKeyTypeAllowed, field, func() {
+		var (
+			allowedTypes, warningTypes = c.config.GetMapKeyTypes()
+			keyType                    = field.MapKey().Kind().String()
+		)
+
+		switch {
+		case containsString(allowedTypes, keyType):
+		case containsString(warningTypes, keyType):
+			result.AddWarningf(
+				MapKeyTypeAllowed,
+				field,
+				"Key type %s of map field %s is discouraged, JSON gateways stringify such keys inconsistently",
+				keyType,
+				fieldLogName)
+		default:
+			result.AddFindingf(
+				MapKeyTypeAllowed,
+				field,
+				"Key type %s of map field %s isn't allowed",
+				keyType,
+				fieldLogName)
+		}
+	})
+}
+
+// containsString returns true if the value is present in the list.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
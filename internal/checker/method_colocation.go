@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"path"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkMethodColocation checks that the input and output of a method are defined
+// where the colocation policy expects them. Messages of other packages are left
+// to the method_io_same_package check.
+func (c *ProtoChecker) checkMethodColocation(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	if c.config.IsCheckExcluded(MethodIOColocated) {
+		return
+	}
+
+	defer c.startRule(MethodIOColocated, method)()
+
+	var (
+		serviceFile = method.ParentFile()
+		policy      = c.config.GetMethodIOColocationPolicy()
+	)
+
+	for _, message := range []struct {
+		kind       string
+		descriptor protoreflect.MessageDescriptor
+	}{
+		{kind: "Input", descriptor: method.Input()},
+		{kind: "Output", descriptor: method.Output()},
+	} {
+		messageFile := message.descriptor.ParentFile()
+		if messageFile.Package() != serviceFile.Package() ||
+			isColocated(policy, serviceFile.Path(), messageFile.Path()) {
+			continue
+		}
+
+		switch policy {
+		case config.ColocationSameDirectory:
+			result.AddFindingf(
+				MethodIOColocated,
+				method,
+				"%s %s of method %s is defined in %s outside the directory of %s",
+				result.localize(message.kind),
+				message.descriptor.FullName(),
+				methodLogName,
+				messageFile.Path(),
+				serviceFile.Path())
+		case config.ColocationMessagesFile:
+			result.AddFindingf(
+				MethodIOColocated,
+				method,
+				"%s %s of method %s is defined in %s instead of %s or %s",
+				result.localize(message.kind),
+				message.descriptor.FullName(),
+				methodLogName,
+				messageFile.Path(),
+				serviceFile.Path(),
+				messagesFilePath(serviceFile.Path()))
+		default:
+			result.AddFindingf(
+				MethodIOColocated,
+				method,
+				"%s %s of method %s is defined in %s instead of %s",
+				result.localize(message.kind),
+				message.descriptor.FullName(),
+				methodLogName,
+				messageFile.Path(),
+				serviceFile.Path())
+		}
+	}
+}
+
+// isColocated returns true if a message file is placed where the policy expects it
+// relative to the service file.
+func isColocated(policy, serviceFile, messageFile string) bool {
+	if serviceFile == messageFile {
+		return true
+	}
+
+	switch policy {
+	case config.ColocationSameDirectory:
+		return path.Dir(serviceFile) == path.Dir(messageFile)
+	case config.ColocationMessagesFile:
+		return messageFile == messagesFilePath(serviceFile)
+	default:
+		return false
+	}
+}
+
+// messagesFilePath returns the path of the messages file paired with a service file,
+// e.g. orders/v1/orders_messages.proto for orders/v1/orders.proto.
+func messagesFilePath(serviceFile string) string {
+	return strings.TrimSuffix(serviceFile, path.Ext(serviceFile)) + config.MessagesFileSuffix
+}
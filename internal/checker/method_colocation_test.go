@@ -0,0 +1,29 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestIsColocated(t *testing.T) {
+	tests := []struct {
+		policy      string
+		messageFile string
+		expected    bool
+	}{
+		{policy: config.ColocationSameFile, messageFile: "orders/v1/orders.proto", expected: true},
+		{policy: config.ColocationSameFile, messageFile: "orders/v1/orders_messages.proto"},
+		{policy: config.ColocationSameDirectory, messageFile: "orders/v1/common.proto", expected: true},
+		{policy: config.ColocationSameDirectory, messageFile: "orders/v1/internal/common.proto"},
+		{policy: config.ColocationMessagesFile, messageFile: "orders/v1/orders_messages.proto", expected: true},
+		{policy: config.ColocationMessagesFile, messageFile: "orders/v1/common.proto"},
+		{policy: config.ColocationMessagesFile, messageFile: "orders/v2/orders_messages.proto"},
+	}
+
+	for _, test := range tests {
+		if actual := isColocated(test.policy, "orders/v1/orders.proto", test.messageFile); actual != test.expected {
+			t.Errorf("isColocated(%q, %q) = %v, expected %v", test.policy, test.messageFile, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package checker
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wellKnownTypesPackage is the package of well-known types any method may use.
+const wellKnownTypesPackage = "google.protobuf"
+
+// checkMethodPackages checks that the input and output of a method are defined
+// in the package of its service, unless they are shared types from allowed packages.
+func (c *ProtoChecker) checkMethodPackages(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	if c.config.IsCheckExcluded(MethodIOSamePackage) {
+		return
+	}
+
+	defer c.startRule(MethodIOSamePackage, method)()
+
+	servicePackage := method.ParentFile().Package()
+
+	for _, message := range []struct {
+		kind       string
+		descriptor protoreflect.MessageDescriptor
+	}{
+		{kind: "Input", descriptor: method.Input()},
+		{kind: "Output", descriptor: method.Output()},
+	} {
+		messagePackage := message.descriptor.ParentFile().Package()
+		if messagePackage == servicePackage || c.isSharedPackage(string(messagePackage)) {
+			continue
+		}
+
+		result.AddFindingf(
+			MethodIOSamePackage,
+			method,
+			"%s %s of method %s is defined in package %s instead of %s",
+			result.localize(message.kind),
+			message.descriptor.FullName(),
+			methodLogName,
+			messagePackage,
+			servicePackage)
+	}
+}
+
+// isSharedPackage returns true if messages of the package may be used by methods of any package.
+func (c *ProtoChecker) isSharedPackage(packageName string) bool {
+	for _, prefix := range append([]string{wellKnownTypesPackage}, c.config.GetMethodIOAllowedPackages()...) {
+		if packageName == prefix || strings.HasPrefix(packageName, strings.Join([]string{prefix, "."}, "")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkMethodVerb checks that the method is bound to one of the HTTP methods allowed
+// for the verb its name starts with. Methods with custom HTTP methods or without a known verb are skipped.
+func (c *ProtoChecker) checkMethodVerb(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+	httpVerb string,
+) {
+	if httpVerb == "" {
+		return
+	}
+
+	c.runRule(MethodVerbMatchesHTTPMethod, method, func() {
+		verb := getMethodVerb(string(method.Name()), c.config.GetMethodVerbs())
+		if verb == nil {
+			return
+		}
+
+		for _, httpMethod := range verb.HTTPMethods {
+			if strings.EqualFold(httpMethod, httpVerb) {
+				return
+			}
+		}
+
+		allowed := make([]string, 0, len(verb.HTTPMethods))
+		for _, httpMethod := range verb.HTTPMethods {
+			allowed = append(allowed, strings.ToUpper(httpMethod))
+		}
+
+		result.AddFindingf(
+			MethodVerbMatchesHTTPMethod,
+			method,
+			"Method %s starts with %s, but is bound to HTTP %s instead of %s",
+			methodLogName,
+			verb.Prefix,
+			strings.ToUpper(httpVerb),
+			strings.Join(allowed, " or "))
+	})
+}
+
+// getMethodVerb returns the longest verb the method name starts with as a separate word,
+// so List matches ListOrdersV1, but not ListenV1.
+func getMethodVerb(methodName string, verbs []*config.MethodVerb) *config.MethodVerb {
+	var result *config.MethodVerb
+
+	for _, verb := range verbs {
+		if !hasVerbPrefix(methodName, verb.Prefix) {
+			continue
+		}
+
+		if result == nil || len(verb.Prefix) > len(result.Prefix) {
+			result = verb
+		}
+	}
+
+	return result
+}
+
+// hasVerbPrefix returns true if the method name starts with the verb followed by an upper case letter,
+// a digit or the end of the name.
+func hasVerbPrefix(methodName, verb string) bool {
+	if !strings.HasPrefix(methodName, verb) {
+		return false
+	}
+
+	next, _ := utf8.DecodeRuneInString(methodName[len(verb):])
+
+	return next == utf8.RuneError || unicode.IsUpper(next) || unicode.IsDigit(next)
+}
@@ -3,16 +3,113 @@ package checker
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// MimirConfig defines the structure of the mimir file.
-type MimirConfig struct {
-	ProtoPaths []string `yaml:"proto_paths"`
+const protoFileExtension = ".proto"
+
+type (
+	// MimirConfig defines the structure of the mimir file.
+	MimirConfig struct {
+		// ProtoPaths is a list of glob patterns of protobuf files to check.
+		ProtoPaths []string `yaml:"proto_paths"`
+		// ExcludePaths is a list of glob patterns or directory prefixes of files excluded from checking.
+		ExcludePaths []string `yaml:"exclude_paths"`
+		// ImportRoots is a list of directories imports are resolved against.
+		ImportRoots []string `yaml:"import_roots"`
+		// Overrides is a list of configuration files applied to files under the specified paths.
+		Overrides []*MimirOverride `yaml:"overrides"`
+	}
+
+	// MimirOverride assigns a configuration file to the files under the specified path.
+	MimirOverride struct {
+		// Path is a directory prefix or a glob pattern of files the override applies to.
+		Path string `yaml:"path"`
+		// Config is the path to the configuration file used for the matching files.
+		Config string `yaml:"config"`
+	}
+
+	// checkScope is a set of files checked with the same configuration and import roots.
+	checkScope struct {
+		configPath  string
+		importRoots []string
+		files       []string // Names the files are compiled under.
+		sourcePaths []string // Paths to the files relative to the working directory.
+	}
+)
+
+// extractScopesFromMimir reads mimir files matching the patterns
+// and groups the files they describe into check scopes.
+// Files without a matching override are checked with the default configuration.
+func extractScopesFromMimir(
+	patterns []string,
+	defaultConfigPath string,
+) ([]*checkScope, []*DiscoveryError, error) {
+	mimirFiles, discoveryErrors := extractFilesFromPatterns(patterns, "")
+
+	var (
+		scopesMap         = make(map[string]*checkScope)
+		alreadyAddedFiles = make(map[string]struct{})
+	)
+
+	for _, mimirFile := range mimirFiles {
+		cfg, err := readMimirConfig(mimirFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files, protoPathsErrors := extractFilesFromPatterns(cfg.ProtoPaths, protoFileExtension)
+		discoveryErrors = append(discoveryErrors, protoPathsErrors...)
+
+		for _, file := range files {
+			if isPathMatched(file, cfg.ExcludePaths) {
+				continue
+			}
+
+			var (
+				configPath = cfg.getConfigPath(file, defaultConfigPath)
+				scopeKey   = strings.Join(append([]string{configPath}, cfg.ImportRoots...), "\x00")
+			)
+
+			compiledName := trimImportRoot(file, cfg.ImportRoots)
+			if _, ok := alreadyAddedFiles[compiledName]; ok {
+				continue
+			}
+
+			alreadyAddedFiles[compiledName] = struct{}{}
+
+			scope, ok := scopesMap[scopeKey]
+			if !ok {
+				scope = &checkScope{
+					configPath:  configPath,
+					importRoots: cfg.ImportRoots,
+				}
+
+				scopesMap[scopeKey] = scope
+			}
+
+			scope.files = append(scope.files, compiledName)
+			scope.sourcePaths = append(scope.sourcePaths, file)
+		}
+	}
+
+	result := make([]*checkScope, 0, len(scopesMap))
+	for _, scope := range scopesMap {
+		result = append(result, scope)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].files[0] < result[j].files[0]
+	})
+
+	return result, discoveryErrors, nil
 }
 
-func extractFilesFromMimir(file string) ([]string, error) {
+func readMimirConfig(file string) (*MimirConfig, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read mimir file: %w", err)
@@ -20,13 +117,62 @@ func extractFilesFromMimir(file string) ([]string, error) {
 
 	var cfg MimirConfig
 	if err = yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal mimir file: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal mimir file %s: %w", file, err)
 	}
 
-	files, err := extractFilesFromPatterns(cfg.ProtoPaths, "proto")
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract files from \"proto_paths\" section: %w", err)
+	return &cfg, nil
+}
+
+// getConfigPath returns the configuration file of the most specific override matching the file.
+func (cfg *MimirConfig) getConfigPath(file, defaultConfigPath string) string {
+	var (
+		result        = defaultConfigPath
+		longestLength = -1
+	)
+
+	for _, override := range cfg.Overrides {
+		if !isPathMatched(file, []string{override.Path}) {
+			continue
+		}
+
+		if len(override.Path) > longestLength {
+			result = override.Config
+			longestLength = len(override.Path)
+		}
+	}
+
+	return result
+}
+
+// isPathMatched returns true if the file matches any of the glob patterns or lies under any of the directories.
+func isPathMatched(file string, patterns []string) bool {
+	file = filepath.Clean(file)
+
+	for _, pattern := range patterns {
+		if isMatched, _ := filepath.Match(pattern, file); isMatched {
+			return true
+		}
+
+		dir := filepath.Clean(pattern)
+		if file == dir || strings.HasPrefix(file, strings.Join([]string{dir, string(filepath.Separator)}, "")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimImportRoot makes the file path relative to the first import root containing it,
+// so the file is compiled under the same name it is imported by.
+func trimImportRoot(file string, importRoots []string) string {
+	for _, root := range importRoots {
+		relativePath, err := filepath.Rel(root, file)
+		if err != nil || strings.HasPrefix(relativePath, "..") {
+			continue
+		}
+
+		return filepath.ToSlash(relativePath)
 	}
 
-	return files, nil
+	return file
 }
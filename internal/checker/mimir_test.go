@@ -0,0 +1,211 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractScopesFromMimir(t *testing.T) {
+	const (
+		mimirContent = `proto_paths:
+  - api/*/v1/*.proto
+  - api/*/v1/*/*.proto
+exclude_paths:
+  - api/generated
+  - api/orders/v1/legacy
+  - api/*/v1/*_test.proto
+import_roots:
+  - api
+overrides:
+  - path: api/orders
+    config: orders.yaml
+  - path: api/orders/v1/internal
+    config: internal.yaml
+  - path: api/*/v1/payments.proto
+    config: payments.yaml
+`
+		vendorMimirContent = `proto_paths:
+  - vendor/*.proto
+`
+	)
+
+	chdirForTest(t, t.TempDir())
+
+	for _, file := range []string{
+		"api/common/v1/common.proto",
+		"api/generated/v1/generated.proto",
+		"api/orders/v1/orders.proto",
+		"api/orders/v1/internal/admin.proto",
+		"api/orders/v1/legacy/legacy.proto",
+		"api/payments/v1/payments.proto",
+		"api/payments/v1/payments_test.proto",
+		"vendor/common.proto",
+	} {
+		writeTestFileWithDirs(t, file, "syntax = \"proto3\";\n")
+	}
+
+	writeTestFileWithDirs(t, "mimir.yaml", mimirContent)
+	writeTestFileWithDirs(t, "vendor/mimir.yaml", vendorMimirContent)
+
+	// The last pattern matches the first mimir file again, it's read once.
+	scopes, discoveryErrors, err := extractScopesFromMimir([]string{"mimir.yaml", "*/mimir.yaml", "*.yaml"},
+		".protolinter.yaml")
+	if err != nil {
+		t.Fatalf("failed to extract scopes: %s", err.Error())
+	}
+
+	if len(discoveryErrors) > 0 {
+		t.Fatalf("unexpected discovery errors: %v", discoveryErrors)
+	}
+
+	// Excluded paths win over overrides covering them, the most specific override is applied,
+	// and files are compiled under their paths relative to the import root.
+	expected := []*checkScope{
+		{
+			configPath:  ".protolinter.yaml",
+			importRoots: []string{"api"},
+			files:       []string{"common/v1/common.proto"},
+			sourcePaths: []string{"api/common/v1/common.proto"},
+		},
+		{
+			configPath:  "internal.yaml",
+			importRoots: []string{"api"},
+			files:       []string{"orders/v1/internal/admin.proto"},
+			sourcePaths: []string{"api/orders/v1/internal/admin.proto"},
+		},
+		{
+			configPath:  "orders.yaml",
+			importRoots: []string{"api"},
+			files:       []string{"orders/v1/orders.proto"},
+			sourcePaths: []string{"api/orders/v1/orders.proto"},
+		},
+		{
+			configPath:  "payments.yaml",
+			importRoots: []string{"api"},
+			files:       []string{"payments/v1/payments.proto"},
+			sourcePaths: []string{"api/payments/v1/payments.proto"},
+		},
+		{
+			configPath:  ".protolinter.yaml",
+			files:       []string{"vendor/common.proto"},
+			sourcePaths: []string{"vendor/common.proto"},
+		},
+	}
+
+	if len(scopes) != len(expected) {
+		t.Fatalf("expected %d scopes, got %d: %v", len(expected), len(scopes), describeScopes(scopes))
+	}
+
+	for i, scope := range scopes {
+		if !reflect.DeepEqual(scope, expected[i]) {
+			t.Errorf("expected scope %+v, got %+v", expected[i], scope)
+		}
+	}
+}
+
+func TestExtractScopesFromInvalidMimir(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+	writeTestFileWithDirs(t, "mimir.yaml", "proto_paths: api/*.proto\n")
+
+	if _, _, err := extractScopesFromMimir([]string{"mimir.yaml"}, ""); err == nil {
+		t.Error("expected error for invalid mimir file")
+	}
+}
+
+func TestTrimImportRoot(t *testing.T) {
+	tests := []struct {
+		file        string
+		importRoots []string
+		expected    string
+	}{
+		{file: "api/orders/v1/orders.proto", importRoots: []string{"api"}, expected: "orders/v1/orders.proto"},
+		{file: "api/orders/v1/orders.proto", importRoots: []string{"api/orders", "api"}, expected: "v1/orders.proto"},
+		{file: "third_party/google/type/date.proto", importRoots: []string{"api", "third_party"},
+			expected: "google/type/date.proto"},
+		{file: "apis/orders.proto", importRoots: []string{"api"}, expected: "apis/orders.proto"},
+		{file: "api/orders.proto", importRoots: []string{"./api/"}, expected: "orders.proto"},
+		{file: "api/orders.proto", importRoots: []string{"."}, expected: "api/orders.proto"},
+		{file: "api/orders.proto", importRoots: nil, expected: "api/orders.proto"},
+		{file: "/repo/api/orders.proto", importRoots: []string{"api"}, expected: "/repo/api/orders.proto"},
+	}
+
+	for _, test := range tests {
+		if actual := trimImportRoot(test.file, test.importRoots); actual != test.expected {
+			t.Errorf("expected %s for file %s and import roots %v, got %s",
+				test.expected,
+				test.file,
+				test.importRoots,
+				actual)
+		}
+	}
+}
+
+func TestIsPathMatched(t *testing.T) {
+	tests := []struct {
+		file     string
+		patterns []string
+		expected bool
+	}{
+		{file: "api/generated/orders.proto", patterns: []string{"api/generated"}, expected: true},
+		{file: "api/generated/orders.proto", patterns: []string{"api/generated/"}, expected: true},
+		{file: "api/generated_v2/orders.proto", patterns: []string{"api/generated"}, expected: false},
+		{file: "api/orders/orders_test.proto", patterns: []string{"api/*/*_test.proto"}, expected: true},
+		{file: "api/orders/v1/orders_test.proto", patterns: []string{"api/*/*_test.proto"}, expected: false},
+		{file: "./api/orders.proto", patterns: []string{"api/orders.proto"}, expected: true},
+		{file: "api/orders.proto", patterns: nil, expected: false},
+	}
+
+	for _, test := range tests {
+		if actual := isPathMatched(test.file, test.patterns); actual != test.expected {
+			t.Errorf("expected %t for file %s and patterns %v, got %t",
+				test.expected,
+				test.file,
+				test.patterns,
+				actual)
+		}
+	}
+}
+
+// chdirForTest changes the working directory for the duration of the test.
+func chdirForTest(t *testing.T, dir string) {
+	t.Helper()
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %s", err.Error())
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change working directory: %s", err.Error())
+	}
+
+	t.Cleanup(func() {
+		_ = os.Chdir(workingDirectory)
+	})
+}
+
+// writeTestFileWithDirs writes the file, creating its parent directories.
+func writeTestFileWithDirs(t *testing.T, fileName, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o700); err != nil {
+		t.Fatalf("failed to create directory: %s", err.Error())
+	}
+
+	if err := os.WriteFile(fileName, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+}
+
+// describeScopes returns the files of the scopes with their configurations for failure messages.
+func describeScopes(scopes []*checkScope) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, scope.configPath+": "+strings.Join(scope.sourcePaths, ", "))
+	}
+
+	return result
+}
@@ -1,25 +1,452 @@
 package checker
 
 import (
+	"io"
+	"sync"
+	"time"
+
 	"github.com/bufbuild/protocompile"
 	"github.com/bufbuild/protocompile/linker"
+	"github.com/bufbuild/protocompile/reporter"
 	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type (
 	// ProtoChecker represents a structure that
 	// wraps the compiler and parser for protobuf files.
 	ProtoChecker struct {
-		compiler *protocompile.Compiler
-		config   *config.Config
+		compiler     *protocompile.Compiler
+		config       *config.Config
+		dependencies *dependencyRegistry
+		modules      *moduleRegistry
+		progress     *progressReporter
+		tracer       *ruleTracer
+		coverage     *coverageRecorder
+		// optionExtensions holds the extensions unknown options are named by, loaded on the first check.
+		optionExtensions *optionExtensionIndex
+		failFast         bool
+	}
+
+	// CheckOptions holds the parameters of the "check" subcommand.
+	CheckOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		IsMimirFile  bool   // Whether the patterns are mimir files.
+		IsAuto       bool   // Whether check scopes are discovered automatically.
+		FilesFrom    string // Path to the file listing the files to check one per line, "-" stands for stdin.
+		ManifestPath string // Path to the run manifest file, if empty, the manifest is not written.
+		OutputFormat string // Format of the results: text or json.
+		OutputPath   string // Path to the file the results are written to, if empty, stdout is used.
+		ReportDir    string // Path to the directory per-file reports are written to, if empty, they are not written.
+		GroupBy      string // Key to group findings by, if empty, findings are grouped by file.
+		Locale       string // Language of diagnostic messages, if empty, the configured one is used.
+		ExpectPath   string // Path to the golden file the findings must match exactly.
+		UpdateExpect bool   // Whether to overwrite the golden file with the current findings.
+		ShowProgress bool   // Whether to show the progress of compiling and checking files.
+		DebugRules   bool   // Whether to log every evaluation of a check and the time spent per check.
+		FailFast     bool   // Whether to stop checking at the first file with errors.
+		Quiet        bool   // Whether to write nothing if the run passes.
+		// OnlyDescriptors is a list of full name prefixes of descriptors findings are reported for,
+		// if empty, findings of all descriptors are reported.
+		OnlyDescriptors []string
+		// OnlyChecks is a list of checks to run regardless of the configuration,
+		// if empty, checks are run as configured.
+		OnlyChecks []string
+		// Output is the writer the results are written to if the output file isn't specified,
+		// if nil, stdout is used.
+		Output io.Writer
 	}
 
 	// CheckResult holds the results of checking a single protobuf file.
 	CheckResult struct {
 		File     linker.File // Checked file.
 		Messages []string    // List of informational messages related to the file.
-		Errors   []string    // List of errors. If empty, the check is considered successful.
+		Findings []*Finding  // List of failed checks. If there are no errors, the check is considered successful.
 		config   *config.Config
+		coverage *coverageRecorder
+		// directives are the suppression directives of the file indexed by the full names of their descriptors.
+		directives map[protoreflect.FullName][]*directive
+	}
+
+	// Finding describes a single failed check.
+	Finding struct {
+		Check    string          `json:"check"`            // Name of the failed check.
+		Severity config.Severity `json:"severity"`         // Severity of the failed check.
+		Message  string          `json:"message"`          // Human-readable description of the problem.
+		Path     string          `json:"path"`             // Path to the file containing the descriptor.
+		Line     int             `json:"line,omitempty"`   // One-based line of the descriptor, zero if unknown.
+		Column   int             `json:"column,omitempty"` // One-based column of the descriptor, zero if unknown.
+		Owners   []string        `json:"owners,omitempty"` // Owners of the file according to the ownership rules.
+		Fix      string          `json:"fix,omitempty"`    // Fix available for the finding, FixFormat or empty if none.
+
+		descriptor protoreflect.Descriptor // Descriptor the finding belongs to, nil for findings of the file.
+	}
+
+	// FileReport holds the results of checking a single file in structured output.
+	FileReport struct {
+		Path     string             `json:"path"`               // Path to the checked file.
+		Messages []string           `json:"messages,omitempty"` // Informational messages related to the file.
+		Findings []*Finding         `json:"findings"`           // Failed checks.
+		Metrics  *DescriptorMetrics `json:"metrics,omitempty"`  // Numbers of descriptors declared in the file.
+	}
+
+	// HTMLReport holds the results of the "check" subcommand rendered as a standalone HTML page.
+	HTMLReport struct {
+		Files    []*HTMLFileReport // Files having findings.
+		Checks   []string          // Sorted names of the reported checks, used for filtering.
+		Packages []string          // Sorted packages of the files having findings, used for filtering.
+		Total    int               // Number of findings.
+		Errors   int               // Number of findings with the error severity.
+		Warnings int               // Number of findings with the warning severity.
+	}
+
+	// HTMLFileReport holds the findings of a single file in the HTML report.
+	HTMLFileReport struct {
+		Path     string         // Path to the checked file.
+		Package  string         // Package of the checked file.
+		Findings []*HTMLFinding // Findings of the file.
+	}
+
+	// HTMLFinding is a finding along with the lines of the source around it.
+	HTMLFinding struct {
+		*Finding
+		Text    string        // Finding formatted like in the text output.
+		Snippet []*SourceLine // Lines of the source around the finding, empty if its line is unknown.
+	}
+
+	// SourceLine is a line of a source snippet.
+	SourceLine struct {
+		Number        int    // One-based number of the line.
+		Text          string // Text of the line.
+		IsHighlighted bool   // Whether the finding points to the line.
+	}
+
+	// ReportIndexEntry describes the report of a single file written to the report directory.
+	ReportIndexEntry struct {
+		Path     string `json:"path"`     // Path to the checked file.
+		Report   string `json:"report"`   // Path to the report relative to the report directory.
+		Errors   int    `json:"errors"`   // Number of findings with the error severity.
+		Warnings int    `json:"warnings"` // Number of findings with the warning severity.
+	}
+
+	// ReportIndex lists the per-file reports written to the report directory.
+	ReportIndex struct {
+		Files []*ReportIndexEntry `json:"files"` // Reports in the order the files were checked.
+	}
+
+	// DescriptorMetrics holds the numbers of descriptors declared in a file, including nested ones,
+	// so coverage ratios like "fields with descriptions / total fields" can be computed from the findings.
+	// Synthetic map entry messages and their fields aren't counted.
+	DescriptorMetrics struct {
+		Services   int `json:"services"`    // Number of services.
+		Methods    int `json:"methods"`     // Number of methods of all services.
+		Messages   int `json:"messages"`    // Number of messages.
+		Fields     int `json:"fields"`      // Number of fields of all messages, extensions aren't counted.
+		Enums      int `json:"enums"`       // Number of enums.
+		EnumValues int `json:"enum_values"` // Number of values of all enums.
+	}
+
+	// FindingGroup holds findings sharing the same value of the grouping key.
+	FindingGroup struct {
+		Key      string     `json:"key"`      // Value of the grouping key, empty if not set.
+		Findings []*Finding `json:"findings"` // Findings of the group.
+	}
+
+	// CheckReport holds the results of the "check" subcommand in structured output.
+	CheckReport struct {
+		Files  []*FileReport   `json:"files,omitempty"`  // Results per file, if findings aren't grouped.
+		Groups []*FindingGroup `json:"groups,omitempty"` // Grouped findings, if grouping is requested.
+	}
+
+	// CompileOptions holds the parameters of the "compile" subcommand.
+	CompileOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the results: text or json.
+		OutputPath   string // Path to the file the results are written to, if empty, stdout is used.
+	}
+
+	// FormatOptions holds the parameters of the "format" subcommand.
+	FormatOptions struct {
+		Check bool // Whether the files that aren't formatted are listed instead of being printed.
+		Write bool // Whether the formatted content is written back to the files instead of stdout.
+	}
+
+	// FixOptions holds the parameters of the "fix" subcommand.
+	FixOptions struct {
+		ReportPath string // Path to the JSON report of a previous run of the "check" subcommand.
+	}
+
+	// ListOptions holds the parameters of the "list" subcommand.
+	ListOptions struct {
+		OutputPath string // Path to the file the results are written to, if empty, stdout is used.
+	}
+
+	// ScoreOptions holds the parameters of the "score" subcommand.
+	ScoreOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the scores: text or json.
+		OutputPath   string // Path to the file the scores are written to, if empty, stdout is used.
+	}
+
+	// PackageScore holds the score of a package in the "score" subcommand.
+	PackageScore struct {
+		Rank        int            `json:"rank"`        // Position of the package, starting from the best score.
+		Package     string         `json:"package"`     // Name of the package.
+		Score       float64        `json:"score"`       // Score of the package from 0 to 100.
+		Descriptors int            `json:"descriptors"` // Number of descriptors declared in the package.
+		Errors      int            `json:"errors"`      // Number of findings with the error severity.
+		Warnings    int            `json:"warnings"`    // Number of findings with the warning severity.
+		Penalty     float64        `json:"penalty"`     // Sum of the weights of the findings.
+		Categories  map[string]int `json:"categories"`  // Numbers of findings per category of checks.
+	}
+
+	// ScoreReport holds the results of the "score" subcommand.
+	ScoreReport struct {
+		Packages []*PackageScore `json:"packages"` // Packages ranked from the best score to the worst.
+	}
+
+	// ConfigImpactOptions holds the parameters of the "config impact" subcommand.
+	ConfigImpactOptions struct {
+		ConfigPath    string // Path to the current configuration file.
+		CandidatePath string // Path to the candidate configuration file compared with the current one.
+		OutputFormat  string // Format of the report: text or json.
+		OutputPath    string // Path to the file the report is written to, if empty, stdout is used.
+	}
+
+	// RuleImpact holds the difference in findings of a check between two configurations.
+	RuleImpact struct {
+		Check   string     `json:"check"`             // Name of the check.
+		Added   []*Finding `json:"added,omitempty"`   // Findings reported only under the candidate configuration.
+		Removed []*Finding `json:"removed,omitempty"` // Findings reported only under the current configuration.
+	}
+
+	// ImpactReport holds the results of the "config impact" subcommand.
+	ImpactReport struct {
+		Rules []*RuleImpact `json:"rules"` // Checks whose findings differ, sorted by name.
+	}
+
+	// CoverageOptions holds the parameters of the "coverage" subcommand.
+	CoverageOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the report: text or json.
+		OutputPath   string // Path to the file the report is written to, if empty, stdout is used.
+	}
+
+	// RuleCoverage holds the numbers of descriptors a check applies to in the "coverage" subcommand.
+	RuleCoverage struct {
+		Check    string  `json:"check,omitempty"` // Name of the check, empty for the total.
+		Eligible int     `json:"eligible"`        // Number of descriptors the check applies to without exclusions.
+		Excluded int     `json:"excluded"`        // Number of eligible descriptors the configuration excludes.
+		Passing  int     `json:"passing"`         // Number of checked descriptors without findings of the check.
+		Failing  int     `json:"failing"`         // Number of checked descriptors with findings of the check.
+		Coverage float64 `json:"coverage"`        // Percentage of eligible descriptors that are checked.
+	}
+
+	// CoverageReport holds the results of the "coverage" subcommand.
+	CoverageReport struct {
+		Rules []*RuleCoverage `json:"rules"` // Coverage of every known check in the order they are documented.
+		Total *RuleCoverage   `json:"total"` // Sums of the numbers of all checks.
+	}
+
+	// GraphOptions holds the parameters of the "graph" subcommand.
+	GraphOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the graph: dot or json.
+		OutputPath   string // Path to the file the graph is written to, if empty, stdout is used.
+	}
+
+	// WhereOptions holds the parameters of the "where" subcommand.
+	WhereOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the symbols: text or json.
+		OutputPath   string // Path to the file the symbols are written to, if empty, stdout is used.
+	}
+
+	// GrepOptions holds the parameters of the "grep" subcommand.
+	GrepOptions struct {
+		ConfigPath   string   // Path to the custom configuration file.
+		Regex        string   // Regular expression full names of the symbols must match.
+		Kinds        []string // Kinds of the symbols to list, if empty, symbols of all kinds are listed.
+		OutputFormat string   // Format of the symbols: text or json.
+		OutputPath   string   // Path to the file the symbols are written to, if empty, stdout is used.
+	}
+
+	// HookInstallOptions holds the parameters of the "hook install" subcommand.
+	HookInstallOptions struct {
+		ConfigPath      string // Path to the custom configuration file passed to the hook.
+		PreCommitConfig bool   // Whether to print a pre-commit framework stanza instead of writing a git hook.
+		Force           bool   // Whether to overwrite an existing pre-commit hook not installed by protolinter.
+	}
+
+	// AnnotateOptions holds the parameters of the "annotate" subcommand.
+	AnnotateOptions struct {
+		ConfigPath  string // Path to the custom configuration file.
+		Provider    string // Code hosting platform: github or gitlab, if empty, it's detected from the environment.
+		Repository  string // GitHub repository or GitLab project, if empty, it's taken from the environment.
+		PullRequest int    // Number of the pull request or merge request.
+	}
+
+	// TriageOptions holds the parameters of the "triage" subcommand.
+	TriageOptions struct {
+		ConfigPath string // Path to the configuration file exclusions are added to.
+	}
+
+	// TriageSummary holds the numbers of findings handled in the "triage" subcommand.
+	TriageSummary struct {
+		Fixed      int // Number of findings fixed by formatting the file.
+		Suppressed int // Number of findings suppressed by directives.
+		Excluded   int // Number of findings whose descriptors were excluded in the configuration.
+		Skipped    int // Number of findings left as is.
+	}
+
+	// VersionOptions holds the parameters of the "version" subcommand.
+	VersionOptions struct {
+		IsJSON      bool // Whether to write the version information as JSON.
+		CheckUpdate bool // Whether to query the releases API for a newer version.
+	}
+
+	// VersionInfo describes the build of the linter.
+	VersionInfo struct {
+		Version           string `json:"version"`                    // Version of the linter.
+		Commit            string `json:"commit,omitempty"`           // Revision the linter was built from.
+		GoVersion         string `json:"go_version"`                 // Version of Go the linter was built with.
+		RuleCount         int    `json:"rule_count"`                 // Number of checks.
+		LatestVersion     string `json:"latest_version,omitempty"`   // Latest released version, set by --check-update.
+		IsUpdateAvailable bool   `json:"update_available,omitempty"` // Whether the latest version is newer.
+	}
+
+	// ImportGraph holds the import graph of the compiled files and their dependencies.
+	ImportGraph struct {
+		Nodes []*ImportGraphNode `json:"nodes"` // Files sorted by path.
+		Edges []*ImportGraphEdge `json:"edges"` // Imports sorted by importing and imported file paths.
+	}
+
+	// SymbolReport holds the symbols found by the "where" subcommand.
+	SymbolReport struct {
+		Symbols []*SymbolLocation `json:"symbols"` // Symbols sorted by full name and file path.
+	}
+
+	// Visitor receives the descriptors visited by Walk.
+	// Embed BaseVisitor to implement only the methods of interest.
+	Visitor interface {
+		// EnterService is called before the methods of the service, they are skipped if it returns false.
+		EnterService(service protoreflect.ServiceDescriptor) bool
+		// Method is called for every method of an entered service.
+		Method(method protoreflect.MethodDescriptor)
+		// EnterMessage is called before the descendants of the message, including map entries,
+		// they are skipped along with LeaveMessage if it returns false.
+		EnterMessage(message protoreflect.MessageDescriptor) bool
+		// LeaveMessage is called after the descendants of an entered message.
+		LeaveMessage(message protoreflect.MessageDescriptor)
+		// Field is called for every field of an entered message.
+		Field(field protoreflect.FieldDescriptor)
+		// Oneof is called for every oneof of an entered message, including synthetic ones of optional fields.
+		Oneof(oneof protoreflect.OneofDescriptor)
+		// Extension is called for every extension declared in the file or in an entered message.
+		Extension(extension protoreflect.ExtensionDescriptor)
+		// EnterEnum is called before the values of the enum, they are skipped if it returns false.
+		EnterEnum(enum protoreflect.EnumDescriptor) bool
+		// EnumValue is called for every value of an entered enum.
+		EnumValue(value protoreflect.EnumValueDescriptor)
+	}
+
+	// BaseVisitor implements Visitor entering every descriptor and doing nothing else.
+	BaseVisitor struct{}
+
+	// listVisitor lists the full names of the descriptors of a file visited by Walk.
+	listVisitor struct {
+		BaseVisitor
+		result *ListResult
+	}
+
+	// metricsVisitor counts the descriptors of a file visited by Walk.
+	metricsVisitor struct {
+		BaseVisitor
+		metrics *DescriptorMetrics
+	}
+
+	// indexVisitor collects the facts about the descriptors of a file visited by Walk into the index.
+	indexVisitor struct {
+		BaseVisitor
+		index *descriptorIndex
+	}
+
+	// symbolVisitor collects the symbols of a file visited by Walk matching the function.
+	symbolVisitor struct {
+		BaseVisitor
+		file    protoreflect.FileDescriptor
+		match   func(symbol *SymbolLocation) bool
+		symbols []*SymbolLocation
+	}
+
+	// checkVisitor runs the checks of the descriptors of a file visited by Walk.
+	checkVisitor struct {
+		BaseVisitor
+		checker *ProtoChecker
+		result  *CheckResult
+		file    linker.File
+		index   *descriptorIndex
+	}
+
+	// optionExtensionIndex finds option extensions defined in the configured extension sources.
+	optionExtensionIndex struct {
+		resolvers []linker.Resolver // Resolvers of the compiled sources, covering their imports as well.
+	}
+
+	// RunOptions holds the parameters of the "run" subcommand.
+	RunOptions struct {
+		ConfigPath   string // Path to the custom configuration file.
+		OutputFormat string // Format of the combined report: text or json.
+		OutputPath   string // Path to the file the report is written to, if empty, stdout is used.
+	}
+
+	// RunReport holds the combined report of the tasks executed by the "run" subcommand.
+	RunReport struct {
+		Tasks []*TaskReport `json:"tasks"` // Reports of the tasks in the order they are executed.
+	}
+
+	// TaskReport holds the outcome of a task executed by the "run" subcommand.
+	TaskReport struct {
+		Name   string     `json:"name"`             // Name of the task, e.g. compile or lint.
+		Status string     `json:"status"`           // Status of the task: passed, failed or skipped.
+		Report taskResult `json:"report,omitempty"` // Report of the task, nil if the task is skipped.
+	}
+
+	// OpenAPIReport holds the outcome of the generator executed by the openapi task.
+	OpenAPIReport struct {
+		Command  []string `json:"command"`          // Generator command with the placeholders replaced.
+		ExitCode int      `json:"exit_code"`        // Exit code of the generator.
+		Output   string   `json:"output,omitempty"` // Combined stdout and stderr of the generator.
+	}
+
+	// taskResult is the report of a task, written the same way as the report of the matching subcommand.
+	taskResult interface {
+		WriteText(w io.Writer) error
+		WriteJSON(w io.Writer) error
+	}
+
+	// SymbolLocation describes where a symbol is defined.
+	SymbolLocation struct {
+		Name string `json:"name"`           // Full name of the symbol.
+		Kind string `json:"kind"`           // Kind of the symbol, e.g. message or value (of an enum).
+		Path string `json:"path"`           // Import path of the file defining the symbol.
+		Line int    `json:"line,omitempty"` // One-based line of the definition, zero if the file has no source info.
+	}
+
+	// ImportGraphNode describes a file of the import graph.
+	ImportGraphNode struct {
+		Path      string `json:"path"`              // Import path of the file.
+		Package   string `json:"package,omitempty"` // Package of the file.
+		IsChecked bool   `json:"is_checked"`        // Whether the file was passed as an argument.
+		URL       string `json:"url,omitempty"`     // URL the file was downloaded from, empty for local files.
+	}
+
+	// ImportGraphEdge describes an import statement of the import graph.
+	ImportGraphEdge struct {
+		From     string `json:"from"`      // Path of the importing file.
+		To       string `json:"to"`        // Path of the imported file.
+		IsPublic bool   `json:"is_public"` // Whether the import is public.
+		IsWeak   bool   `json:"is_weak"`   // Whether the import is weak.
 	}
 
 	// ListResult holds the results of listing full protobuf element names.
@@ -28,4 +455,168 @@ type (
 		Messages []string    // List of full protobuf element names found in the file.
 		config   *config.Config
 	}
+
+	// Rule holds the structured metadata of a single check,
+	// used to generate user-facing documentation.
+	Rule struct {
+		Name        string       // Name of the check as used in the configuration file.
+		Description string       // Short description of what the check verifies.
+		Category    string       // Category of the check, like documentation or naming.
+		Rationale   string       // Explanation of why the check exists.
+		GoodExample string       // Protobuf snippet that passes the check.
+		BadExample  string       // Protobuf snippet that fails the check.
+		Options     []RuleOption // Configuration options affecting the check.
+		Aliases     []string     // Former names of the check, accepted in configurations with a deprecation warning.
+	}
+
+	// RetiredRule describes a check removed from the linter.
+	// Configurations mentioning it keep working, the mentions are reported as deprecated.
+	RetiredRule struct {
+		Name        string // Name of the check as used in the configuration file.
+		Reason      string // Explanation of why the check was removed.
+		Replacement string // Name of the check superseding the removed one, empty if there is none.
+	}
+
+	// ConfigProblem describes a problem of the configuration found by the "config validate" subcommand.
+	ConfigProblem struct {
+		Severity config.Severity // Severity of the problem, only errors fail the validation.
+		Message  string          // Human-readable description of the problem.
+	}
+
+	// RuleOption describes a configuration option affecting a check.
+	RuleOption struct {
+		Name        string // Name of the option as used in the configuration file.
+		Description string // Description of the option.
+	}
+
+	// failedCheck summarizes the findings of a check on a descriptor suggested for exclusion.
+	failedCheck struct {
+		name      string          // Name of the check.
+		severity  config.Severity // Highest severity of the findings.
+		count     int             // Number of the findings.
+		isFixable bool            // Whether formatting the file fixes the findings.
+	}
+
+	// BudgetBreach describes a package having more error findings than max_findings_per_package allows.
+	BudgetBreach struct {
+		Package  string // Name of the package.
+		Findings int    // Number of error findings in the files of the package.
+		Budget   int    // Number of error findings the package may have.
+	}
+
+	// UnusedExclusions holds the entries of the configuration having no effect on the checked files.
+	UnusedExclusions struct {
+		Checks      []string // Excluded checks that wouldn't report anything if enabled.
+		Descriptors []string // Excluded descriptors not matching any descriptor.
+	}
+
+	// ConfigValidateOptions holds the parameters of the "config validate" subcommand.
+	ConfigValidateOptions struct {
+		ConfigPath string // Path to the custom configuration file.
+	}
+
+	// ConfigGenerateOptions holds the parameters of the "config generate" subcommand.
+	ConfigGenerateOptions struct {
+		ConfigPath string // Path to the custom configuration file.
+		OutputPath string // Path to the file the configuration is written to, stdout if empty.
+	}
+
+	// ConfigPruneOptions holds the parameters of the "config prune" subcommand.
+	ConfigPruneOptions struct {
+		ConfigPath string // Path to the custom configuration file.
+		Write      bool   // Whether to remove the unused entries from the configuration file.
+	}
+
+	// DiscoveryError describes a problem with a single entry found while locating files.
+	DiscoveryError struct {
+		Pattern string // Pattern or directory the entry was found by.
+		Path    string // Path to the problematic entry, empty if the pattern itself is invalid.
+		Err     error  // Underlying error.
+	}
+
+	// RunManifest records the exact inputs of a run for audit trails.
+	RunManifest struct {
+		Version            string              `json:"version"`                 // Version of the linter.
+		CreatedAt          time.Time           `json:"created_at"`              // Time the manifest was created.
+		ConfigFile         string              `json:"config_file,omitempty"`   // Path to the configuration file.
+		ConfigSHA256       string              `json:"config_sha256,omitempty"` // Checksum of the configuration file.
+		Files              []*ManifestFile     `json:"files"`                   // Checked files.
+		RemoteDependencies []*RemoteDependency `json:"remote_dependencies"`     // Downloaded dependencies.
+	}
+
+	// ManifestFile describes a single checked file in the run manifest.
+	ManifestFile struct {
+		Path   string `json:"path"`   // Path to the file.
+		SHA256 string `json:"sha256"` // Checksum of the file contents.
+		Size   int64  `json:"size"`   // Size of the file in bytes.
+	}
+
+	// RemoteDependency describes a proto dependency downloaded during compilation.
+	RemoteDependency struct {
+		Path   string `json:"path"`   // Import path of the dependency.
+		URL    string `json:"url"`    // URL the dependency was downloaded from.
+		SHA256 string `json:"sha256"` // Checksum of the downloaded contents.
+		Size   int    `json:"size"`   // Size of the downloaded contents in bytes.
+	}
+
+	dependencyRegistry struct {
+		mu           sync.Mutex
+		items        []*RemoteDependency
+		slots        chan struct{} // Slots of concurrent downloads, nil means no limit.
+		fetchedCount int           // Number of dependencies downloaded from their origins.
+		fetchedBytes int64         // Total size of dependencies downloaded from their origins.
+	}
+
+	// downloadCache keeps the contents of downloaded dependencies by URL across runs within a process,
+	// so a persistent worker downloads every dependency once.
+	downloadCache struct {
+		mu    sync.Mutex
+		items map[string][]byte
+	}
+
+	downloadCacheKey struct{}
+
+	// CompileCache keeps compiled files across runs within a process, so the files
+	// are compiled again only if one of the sources read while compiling them has changed.
+	// It is safe for concurrent use.
+	CompileCache struct {
+		mu      sync.Mutex
+		entries map[string]*compileCacheEntry
+		keys    []string // Keys of the entries from the oldest to the newest.
+	}
+
+	compileCacheEntry struct {
+		files    linker.Files
+		warnings []reporter.ErrorWithPos // Warnings reported while compiling the files.
+		// checksums map the paths of the sources read while compiling the files to the checksums of their contents.
+		checksums map[string]string
+	}
+
+	compileCacheKey struct{}
+
+	// checksumResolver records the checksums of the sources resolved by the wrapped resolver.
+	checksumResolver struct {
+		protocompile.Resolver
+		mu        sync.Mutex
+		checksums map[string]string
+	}
+
+	// descriptorIndex holds facts about descriptors collected across all compiled files,
+	// needed by checks looking beyond a single file.
+	descriptorIndex struct {
+		rpcMessages map[protoreflect.FullName]struct{} // Messages used as method inputs or outputs.
+		referenced  map[protoreflect.FullName]struct{} // Messages and enums referenced by fields, methods or extensions.
+		// requestMethods map messages reachable from method inputs through fields to the first method reaching them.
+		requestMethods map[protoreflect.FullName]protoreflect.MethodDescriptor
+		// responseMethods map messages reachable from method outputs through fields to the first method reaching them.
+		responseMethods map[protoreflect.FullName]protoreflect.MethodDescriptor
+		packageServices map[protoreflect.FullName]int // Packages mapped to the numbers of services they declare.
+	}
+
+	// diffLine is a line of a unified diff: kept (' '), removed ('-') or added ('+').
+	// The text includes the line terminator unless it's the last line of a file not ending with one.
+	diffLine struct {
+		kind byte
+		text string
+	}
 )
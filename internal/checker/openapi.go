@@ -0,0 +1,164 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// GenerateOpenAPI runs the configured generator of OpenAPI documents on the provided protobuf files.
+// The generator reads the compiled files from a descriptor set including their imports and source info,
+// so it doesn't have to resolve dependencies again. A generator exiting with a non-zero code is reported
+// in the returned report, failing to start it is returned as an error.
+func (c *ProtoChecker) GenerateOpenAPI(ctx context.Context, files ...string) (*OpenAPIReport, error) {
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := compileCacheFromContext(ctx).compile(ctx, c.compiler, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	descriptorSet, err := os.CreateTemp("", "protolinter-*.binpb")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create descriptor set: %w", err)
+	}
+
+	defer os.Remove(descriptorSet.Name())
+
+	data, err := proto.Marshal(newDescriptorSet(parsedFiles))
+	if err != nil {
+		_ = descriptorSet.Close()
+
+		return nil, fmt.Errorf("failed to encode descriptor set: %w", err)
+	}
+
+	if _, err = descriptorSet.Write(data); err != nil {
+		_ = descriptorSet.Close()
+
+		return nil, fmt.Errorf("failed to write descriptor set: %w", err)
+	}
+
+	if err = descriptorSet.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write descriptor set: %w", err)
+	}
+
+	paths := make([]string, 0, len(parsedFiles))
+	for _, parsedFile := range parsedFiles {
+		paths = append(paths, parsedFile.Path())
+	}
+
+	var (
+		command = getOpenAPICommand(c.config.GetOpenAPICommand(), descriptorSet.Name(), paths)
+		result  = &OpenAPIReport{
+			Command: command,
+		}
+	)
+
+	//nolint: gosec // The command is taken from the configuration on purpose.
+	output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput()
+	result.Output = string(output)
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return nil, fmt.Errorf("failed to run OpenAPI generator %s: %w", command[0], err)
+	}
+
+	return result, nil
+}
+
+// IsPassed returns true if the generator exited successfully.
+func (r *OpenAPIReport) IsPassed() bool {
+	return r.ExitCode == 0
+}
+
+// WriteText writes the output of the generator to the writer, followed by its exit code if it failed.
+func (r *OpenAPIReport) WriteText(w io.Writer) error {
+	if _, err := io.WriteString(w, r.Output); err != nil {
+		return err
+	}
+
+	if r.IsPassed() {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "%s exited with code %d\n", strings.Join(r.Command, " "), r.ExitCode)
+
+	return err
+}
+
+// WriteJSON writes the outcome of the generator to the writer in JSON format.
+func (r *OpenAPIReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode OpenAPI report: %w", err)
+	}
+
+	return nil
+}
+
+// getOpenAPICommand replaces the placeholders in the arguments of the generator command:
+// the descriptor set one by the path to the descriptor set, and the argument equal to the files one
+// by the paths to the files.
+func getOpenAPICommand(command []string, descriptorSetPath string, paths []string) []string {
+	result := make([]string, 0, len(command)+len(paths))
+
+	for _, arg := range command {
+		if arg == config.FilesPlaceholder {
+			result = append(result, paths...)
+
+			continue
+		}
+
+		result = append(result, strings.ReplaceAll(arg, config.DescriptorSetPlaceholder, descriptorSetPath))
+	}
+
+	return result
+}
+
+// newDescriptorSet returns the descriptor set of the files and their imports,
+// each file preceded by its imports, as protoc and buf write them.
+func newDescriptorSet(files linker.Files) *descriptorpb.FileDescriptorSet {
+	var (
+		result  = new(descriptorpb.FileDescriptorSet)
+		visited = make(map[string]struct{})
+		add     func(file protoreflect.FileDescriptor)
+	)
+
+	add = func(file protoreflect.FileDescriptor) {
+		if _, ok := visited[file.Path()]; ok {
+			return
+		}
+
+		visited[file.Path()] = struct{}{}
+
+		for i := 0; i < file.Imports().Len(); i++ {
+			add(file.Imports().Get(i).FileDescriptor)
+		}
+
+		result.File = append(result.File, protodesc.ToFileDescriptorProto(file))
+	}
+
+	for _, file := range files {
+		add(file)
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestGetOpenAPICommand(t *testing.T) {
+	var (
+		command = []string{"protoc", "--descriptor_set_in=" + config.DescriptorSetPlaceholder, config.FilesPlaceholder}
+		actual  = getOpenAPICommand(command, "/tmp/set.binpb", []string{"a.proto", "b.proto"})
+	)
+
+	expected := []string{"protoc", "--descriptor_set_in=/tmp/set.binpb", "a.proto", "b.proto"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected command %v, got %v", expected, actual)
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh isn't available")
+	}
+
+	var (
+		ctx      = context.Background()
+		dir      = t.TempDir()
+		fileName = filepath.Join(dir, "orders.proto")
+	)
+
+	source := "syntax = \"proto3\";\n\npackage orders;\n\nimport \"google/protobuf/empty.proto\";\n\n" +
+		"message Order {\n  google.protobuf.Empty details = 1;\n}\n"
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	// The generator prints the size of the descriptor set and the files it's given.
+	cfg := &config.Config{
+		OpenAPI: config.OpenAPIOptions{
+			Command: []string{"sh", "-c", `test -s "$0" && echo "$@"`, config.DescriptorSetPlaceholder, config.FilesPlaceholder},
+		},
+	}
+
+	report, err := NewProtoChecker(ctx, cfg).GenerateOpenAPI(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to generate OpenAPI documents: %s", err.Error())
+	}
+
+	if !report.IsPassed() || strings.TrimSpace(report.Output) != fileName {
+		t.Errorf("expected generator to pass with files %s, got code %d and output %q",
+			fileName,
+			report.ExitCode,
+			report.Output)
+	}
+
+	cfg.OpenAPI.Command = []string{"sh", "-c", "echo failed; exit 3"}
+
+	report, err = NewProtoChecker(ctx, cfg).GenerateOpenAPI(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to generate OpenAPI documents: %s", err.Error())
+	}
+
+	var text strings.Builder
+	if err = report.WriteText(&text); err != nil {
+		t.Fatalf("failed to write report: %s", err.Error())
+	}
+
+	if report.IsPassed() || text.String() != "failed\nsh -c echo failed; exit 3 exited with code 3\n" {
+		t.Errorf("expected generator to fail with code 3, got code %d and report %q", report.ExitCode, text.String())
+	}
+}
+
+func TestNewDescriptorSet(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		fileName = filepath.Join(t.TempDir(), "orders.proto")
+	)
+
+	source := "syntax = \"proto3\";\n\npackage orders;\n\nimport \"google/protobuf/empty.proto\";\n\n" +
+		"message Order {\n  google.protobuf.Empty details = 1;\n}\n"
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	files, err := NewProtoChecker(ctx, nil).compiler.Compile(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to compile file: %s", err.Error())
+	}
+
+	set := newDescriptorSet(files)
+
+	// Imports precede the files importing them.
+	names := make([]string, 0, len(set.File))
+	for _, file := range set.File {
+		names = append(names, file.GetName())
+	}
+
+	expected := []string{"google/protobuf/empty.proto", fileName}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected files %v, got %v", expected, names)
+	}
+
+	if set.File[1].GetSourceCodeInfo() == nil {
+		t.Error("expected source info of the compiled file")
+	}
+}
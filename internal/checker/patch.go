@@ -0,0 +1,243 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/formatter"
+)
+
+// diffContextLines is the number of unchanged lines shown around changes in a unified diff.
+const diffContextLines = 3
+
+// maxDiffTableCells limits the size of the table of common subsequence lengths,
+// changes larger than that, e.g. a whole file rewritten by the formatter, are diffed as a single replacement.
+const maxDiffTableCells = 1 << 20
+
+// writeFixPatch writes a unified diff of the fixes available for the findings without applying them,
+// i.e. the formatting of files having findings fixed by "protolinter format".
+// Paths are prefixed with a/ and b/, so the patch can be applied by git apply.
+func writeFixPatch(w io.Writer, results []*CheckResult) error {
+	var (
+		files   []string
+		fixable = make(map[string]struct{})
+	)
+
+	for _, cr := range results {
+		for _, finding := range cr.Findings {
+			if _, ok := fixable[finding.Path]; ok || finding.Fix != FixFormat {
+				continue
+			}
+
+			fixable[finding.Path] = struct{}{}
+			files = append(files, finding.Path)
+		}
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+
+		formatted, err := formatter.Format(file, data)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, formatted) {
+			continue
+		}
+
+		if _, err = io.WriteString(w, formatUnifiedDiff(file, string(data), string(formatted))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatUnifiedDiff returns the unified diff turning the old content of the file into the new one.
+func formatUnifiedDiff(file, oldContent, newContent string) string {
+	var (
+		text  strings.Builder
+		lines = diffLines(splitLinesKeepingEnds(oldContent), splitLinesKeepingEnds(newContent))
+	)
+
+	fmt.Fprintf(&text, "--- a/%s\n+++ b/%s\n", file, file)
+
+	// oldBefore and newBefore hold the numbers of old and new lines preceding each diff line.
+	oldBefore := make([]int, len(lines)+1)
+	newBefore := make([]int, len(lines)+1)
+
+	for i, line := range lines {
+		oldBefore[i+1], newBefore[i+1] = oldBefore[i], newBefore[i]
+
+		if line.kind != '+' {
+			oldBefore[i+1]++
+		}
+
+		if line.kind != '-' {
+			newBefore[i+1]++
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+
+			continue
+		}
+
+		// Changes separated by less than two contexts are joined into a single hunk.
+		lastChange := i
+		for j := i; j < len(lines) && j-lastChange <= 2*diffContextLines; j++ {
+			if lines[j].kind != ' ' {
+				lastChange = j
+			}
+		}
+
+		start, end := i-diffContextLines, lastChange+diffContextLines+1
+		if start < 0 {
+			start = 0
+		}
+
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		fmt.Fprintf(&text, "@@ -%s +%s @@\n",
+			formatHunkRange(oldBefore[start], oldBefore[end]-oldBefore[start]),
+			formatHunkRange(newBefore[start], newBefore[end]-newBefore[start]))
+
+		for _, line := range lines[start:end] {
+			text.WriteByte(line.kind)
+			text.WriteString(line.text)
+
+			if !strings.HasSuffix(line.text, "\n") {
+				text.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+
+	return text.String()
+}
+
+// formatHunkRange formats the range of lines of a hunk header.
+// An empty range refers to the line preceding it, as diff does.
+func formatHunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// diffLines returns the shortest edit turning the old lines into the new ones,
+// based on their longest common subsequence.
+// If the changed part is too large for the table, all its old lines are removed and all its new lines are added.
+func diffLines(oldLines, newLines []string) []*diffLine {
+	// Common prefix and suffix are kept as is, so the table covers only the changed middle.
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	var (
+		oldMiddle = oldLines[prefix : len(oldLines)-suffix]
+		newMiddle = newLines[prefix : len(newLines)-suffix]
+		result    = make([]*diffLine, 0, len(oldLines)+len(newLines))
+	)
+
+	for _, line := range oldLines[:prefix] {
+		result = append(result, &diffLine{kind: ' ', text: line})
+	}
+
+	if (len(oldMiddle)+1)*(len(newMiddle)+1) > maxDiffTableCells {
+		for _, line := range oldMiddle {
+			result = append(result, &diffLine{kind: '-', text: line})
+		}
+
+		for _, line := range newMiddle {
+			result = append(result, &diffLine{kind: '+', text: line})
+		}
+
+		for _, line := range oldLines[len(oldLines)-suffix:] {
+			result = append(result, &diffLine{kind: ' ', text: line})
+		}
+
+		return result
+	}
+
+	// common[i][j] is the length of the longest common subsequence of oldMiddle[i:] and newMiddle[j:].
+	common := make([][]int, len(oldMiddle)+1)
+	for i := range common {
+		common[i] = make([]int, len(newMiddle)+1)
+	}
+
+	for i := len(oldMiddle) - 1; i >= 0; i-- {
+		for j := len(newMiddle) - 1; j >= 0; j-- {
+			switch {
+			case oldMiddle[i] == newMiddle[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(oldMiddle) || j < len(newMiddle) {
+		switch {
+		case i < len(oldMiddle) && j < len(newMiddle) && oldMiddle[i] == newMiddle[j]:
+			result = append(result, &diffLine{kind: ' ', text: oldMiddle[i]})
+			i++
+			j++
+		case j == len(newMiddle) || i < len(oldMiddle) && common[i+1][j] >= common[i][j+1]:
+			result = append(result, &diffLine{kind: '-', text: oldMiddle[i]})
+			i++
+		default:
+			result = append(result, &diffLine{kind: '+', text: newMiddle[j]})
+			j++
+		}
+	}
+
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		result = append(result, &diffLine{kind: ' ', text: line})
+	}
+
+	return result
+}
+
+// splitLinesKeepingEnds splits the content into lines keeping their terminators.
+func splitLinesKeepingEnds(content string) []string {
+	var result []string
+
+	for content != "" {
+		end := strings.IndexByte(content, '\n') + 1
+		if end == 0 {
+			end = len(content)
+		}
+
+		result = append(result, content[:end])
+		content = content[end:]
+	}
+
+	return result
+}
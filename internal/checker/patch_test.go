@@ -0,0 +1,72 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldContent string
+		newContent string
+		expected   string
+	}{
+		{
+			name:       "changed line",
+			oldContent: "a\nb\nc\n",
+			newContent: "a\nB\nc\n",
+			expected:   "--- a/x.proto\n+++ b/x.proto\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:       "distant changes",
+			oldContent: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			newContent: "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			expected: "--- a/x.proto\n+++ b/x.proto\n" +
+				"@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n" +
+				"@@ -7,4 +8,3 @@\n 7\n 8\n 9\n-10\n",
+		},
+		{
+			name:       "missing final newline",
+			oldContent: "a\nb",
+			newContent: "a\nb\n",
+			expected:   "--- a/x.proto\n+++ b/x.proto\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := formatUnifiedDiff("x.proto", test.oldContent, test.newContent); actual != test.expected {
+			t.Errorf("%s: expected diff\n%s\ngot\n%s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestDiffLinesOfLargeChange(t *testing.T) {
+	oldLines := []string{"header\n"}
+	newLines := []string{"header\n"}
+
+	for i := 0; i < 2000; i++ {
+		oldLines = append(oldLines, "old "+strings.Repeat("x", i%7)+"\n")
+		newLines = append(newLines, "new "+strings.Repeat("x", i%7)+"\n")
+	}
+
+	lines := diffLines(append(oldLines, "footer\n"), append(newLines, "footer\n"))
+	if len(lines) != 4002 {
+		t.Fatalf("expected 4002 diff lines, got %d", len(lines))
+	}
+
+	if lines[0].kind != ' ' || lines[len(lines)-1].kind != ' ' {
+		t.Error("expected the common prefix and suffix to be kept")
+	}
+
+	for i, line := range lines[1 : len(lines)-1] {
+		expectedKind := byte('-')
+		if i >= 2000 {
+			expectedKind = '+'
+		}
+
+		if line.kind != expectedKind {
+			t.Fatalf("expected line %d to be %c, got %c", i+1, expectedKind, line.kind)
+		}
+	}
+}
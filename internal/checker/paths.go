@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// pathCanonicalizer builds keys identifying files regardless of the path they are reached by:
+// symbolic links are resolved, paths are made absolute, and on case-insensitive filesystems
+// the keys are lower-cased.
+type pathCanonicalizer struct {
+	caseInsensitiveDirs map[string]bool
+}
+
+func newPathCanonicalizer() *pathCanonicalizer {
+	return &pathCanonicalizer{
+		caseInsensitiveDirs: make(map[string]bool),
+	}
+}
+
+// key returns the canonical key of the file.
+// If the path can't be resolved, the cleaned absolute path is used.
+func (c *pathCanonicalizer) key(file string) string {
+	resolvedPath, err := filepath.EvalSymlinks(file)
+	if err != nil {
+		resolvedPath = file
+	}
+
+	absolutePath, err := filepath.Abs(resolvedPath)
+	if err != nil {
+		absolutePath = filepath.Clean(resolvedPath)
+	}
+
+	if c.isCaseInsensitive(filepath.Dir(absolutePath)) {
+		return strings.ToLower(absolutePath)
+	}
+
+	return absolutePath
+}
+
+// isCaseInsensitive detects whether the filesystem of the directory ignores case
+// by checking if the directory is reachable under a name with swapped letter case.
+func (c *pathCanonicalizer) isCaseInsensitive(dir string) bool {
+	if result, ok := c.caseInsensitiveDirs[dir]; ok {
+		return result
+	}
+
+	var (
+		result      bool
+		swappedPath = swapCase(dir)
+	)
+
+	if swappedPath != dir {
+		originalInfo, originalErr := os.Stat(dir)
+		swappedInfo, swappedErr := os.Stat(swappedPath)
+		result = originalErr == nil && swappedErr == nil && os.SameFile(originalInfo, swappedInfo)
+	}
+
+	c.caseInsensitiveDirs[dir] = result
+
+	return result
+}
+
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r):
+			return unicode.ToLower(r)
+		case unicode.IsLower(r):
+			return unicode.ToUpper(r)
+		default:
+			return r
+		}
+	}, s)
+}
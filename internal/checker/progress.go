@@ -0,0 +1,189 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+const (
+	progressStageCompiling = "compiling"
+	progressStageChecking  = "checking"
+
+	progressBarWidth    = 30
+	progressLogInterval = 10 * time.Second
+)
+
+// progressReporter shows how many files are compiled and checked, the current file and ETA.
+// On a terminal it redraws a progress bar, otherwise it writes periodic log lines.
+// All methods are no-ops on a nil receiver, so progress can be disabled by not creating it.
+type progressReporter struct {
+	mu        sync.Mutex
+	ctx       context.Context //nolint: containedctx // Used to write periodic log lines.
+	output    io.Writer
+	isTTY     bool
+	now       func() time.Time
+	total     int
+	done      int
+	startedAt time.Time
+	loggedAt  time.Time
+}
+
+// progressResolver notifies the progress reporter when the compiler opens one of the checked files.
+type progressResolver struct {
+	protocompile.Resolver
+	progress *progressReporter
+	files    map[string]struct{}
+}
+
+// newProgressReporter creates a progress reporter for the specified number of files,
+// each of them is compiled and checked.
+func newProgressReporter(ctx context.Context, filesCount int) *progressReporter {
+	return newProgressReporterTo(ctx, os.Stderr, isTerminal(os.Stderr), time.Now, filesCount)
+}
+
+// newProgressReporterTo creates a progress reporter drawing the progress bar to the output if it's a terminal,
+// the clock is used to measure the elapsed time and ETA.
+func newProgressReporterTo(
+	ctx context.Context,
+	output io.Writer,
+	isTTY bool,
+	clock func() time.Time,
+	filesCount int,
+) *progressReporter {
+	now := clock()
+
+	return &progressReporter{
+		ctx:       ctx,
+		output:    output,
+		isTTY:     isTTY,
+		now:       clock,
+		total:     filesCount * 2, //nolint: gomnd // Every file is compiled and then checked.
+		startedAt: now,
+		loggedAt:  now,
+	}
+}
+
+// advance marks one more step as done, the file is the one the step is performed on.
+func (p *progressReporter) advance(stage, file string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done < p.total {
+		p.done++
+	}
+
+	now := p.now()
+
+	if p.isTTY {
+		fmt.Fprintf(p.output, "\r\033[K%s %d/%d %s %s, ETA %s",
+			p.bar(),
+			p.done,
+			p.total,
+			stage,
+			file,
+			p.eta(now))
+
+		return
+	}
+
+	if now.Sub(p.loggedAt) < progressLogInterval {
+		return
+	}
+
+	p.loggedAt = now
+
+	logger.Infof(p.ctx, "Progress: %d/%d steps done, %s %s, ETA %s",
+		p.done,
+		p.total,
+		stage,
+		file,
+		p.eta(now))
+}
+
+// finish completes the progress bar, so the following output starts on a new line.
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isTTY {
+		fmt.Fprintln(p.output)
+	}
+
+	logger.Infof(p.ctx, "Processed %d files in %s",
+		p.total/2, //nolint: gomnd // Every file is compiled and then checked.
+		p.now().Sub(p.startedAt).Round(time.Millisecond))
+}
+
+func (p *progressReporter) bar() string {
+	filled := progressBarWidth * p.done / p.total
+
+	return strings.Join([]string{
+		"[",
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", progressBarWidth-filled),
+		"]",
+	}, "")
+}
+
+func (p *progressReporter) eta(now time.Time) time.Duration {
+	if p.done == 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(p.startedAt)
+
+	return (elapsed / time.Duration(p.done) * time.Duration(p.total-p.done)).Round(time.Second)
+}
+
+// wrapResolver returns the resolver reporting the progress of compiling the files.
+func (p *progressReporter) wrapResolver(resolver protocompile.Resolver, files []string) protocompile.Resolver {
+	if p == nil {
+		return resolver
+	}
+
+	filesMap := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		filesMap[file] = struct{}{}
+	}
+
+	return &progressResolver{
+		Resolver: resolver,
+		progress: p,
+		files:    filesMap,
+	}
+}
+
+// FindFileByPath reports the progress when one of the checked files is resolved for the first time.
+func (r *progressResolver) FindFileByPath(path string) (protocompile.SearchResult, error) {
+	if _, ok := r.files[path]; ok {
+		delete(r.files, path)
+		r.progress.advance(progressStageCompiling, path)
+	}
+
+	return r.Resolver.FindFileByPath(path)
+}
+
+func isTerminal(file *os.File) bool {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fileInfo.Mode()&os.ModeCharDevice != 0
+}
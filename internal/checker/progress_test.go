@@ -0,0 +1,130 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oshokin/protolinter/internal/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// testClock is a clock advanced by tests.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newObservedContext() (context.Context, *observer.ObservedLogs) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	return logger.ToContext(context.Background(), zap.New(core).Sugar()), logs
+}
+
+func getLogMessages(logs *observer.ObservedLogs) []string {
+	var result []string
+	for _, entry := range logs.TakeAll() {
+		result = append(result, entry.Message)
+	}
+
+	return result
+}
+
+func TestProgressReporterTerminal(t *testing.T) {
+	var (
+		ctx, logs = newObservedContext()
+		output    bytes.Buffer
+		clock     = &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+		progress  = newProgressReporterTo(ctx, &output, true, clock.Now, 2)
+	)
+
+	clock.advance(10 * time.Second)
+	progress.advance(progressStageCompiling, "a.proto")
+
+	// One of four steps took 10 seconds, so the remaining three are expected to take 30.
+	expected := "\r\033[K[=======                       ] 1/4 compiling a.proto, ETA 30s"
+	if output.String() != expected {
+		t.Errorf("expected progress %q, got %q", expected, output.String())
+	}
+
+	for _, file := range []string{"b.proto", "a.proto", "b.proto", "c.proto"} {
+		clock.advance(10 * time.Second)
+		output.Reset()
+		progress.advance(progressStageChecking, file)
+	}
+
+	// Steps beyond the total don't overflow the bar.
+	expected = "\r\033[K[==============================] 4/4 checking c.proto, ETA 0s"
+	if output.String() != expected {
+		t.Errorf("expected progress %q, got %q", expected, output.String())
+	}
+
+	output.Reset()
+	progress.finish()
+
+	if output.String() != "\n" {
+		t.Errorf("expected progress bar to be completed by a new line, got %q", output.String())
+	}
+
+	if messages := getLogMessages(logs); !reflect.DeepEqual(messages, []string{"Processed 2 files in 50s"}) {
+		t.Errorf("expected only the summary to be logged, got %v", messages)
+	}
+}
+
+func TestProgressReporterLogFallback(t *testing.T) {
+	var (
+		ctx, logs = newObservedContext()
+		output    bytes.Buffer
+		clock     = &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+		progress  = newProgressReporterTo(ctx, &output, false, clock.Now, 3)
+	)
+
+	// Steps are logged at most once per interval.
+	clock.advance(progressLogInterval / 2)
+	progress.advance(progressStageCompiling, "a.proto")
+
+	clock.advance(progressLogInterval / 2)
+	progress.advance(progressStageCompiling, "b.proto")
+
+	clock.advance(time.Second)
+	progress.advance(progressStageCompiling, "c.proto")
+
+	clock.advance(progressLogInterval)
+	progress.advance(progressStageChecking, "a.proto")
+	progress.finish()
+
+	expected := []string{
+		"Progress: 2/6 steps done, compiling b.proto, ETA 20s",
+		"Progress: 4/6 steps done, checking a.proto, ETA 11s",
+		"Processed 3 files in 21s",
+	}
+
+	if messages := getLogMessages(logs); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected log messages %v, got %v", expected, messages)
+	}
+
+	if output.Len() > 0 {
+		t.Errorf("expected nothing to be drawn outside of a terminal, got %q", output.String())
+	}
+}
+
+func TestProgressReporterNil(t *testing.T) {
+	var progress *progressReporter
+
+	progress.advance(progressStageCompiling, "a.proto")
+	progress.finish()
+
+	if resolver := progress.wrapResolver(nil, []string{"a.proto"}); resolver != nil {
+		t.Errorf("expected resolver to be left intact, got %v", resolver)
+	}
+}
@@ -0,0 +1,126 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FindUnusedExclusions compiles the files and returns the entries of the configuration
+// that have no effect on them: excluded descriptors not matching any descriptor
+// and excluded checks that wouldn't report anything if they were enabled.
+func (c *ProtoChecker) FindUnusedExclusions(ctx context.Context, files ...string) (*UnusedExclusions, error) {
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := c.compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	var (
+		index           = newDescriptorIndex(parsedFiles)
+		descriptorNames []string
+		firedChecks     = make(map[string]struct{})
+		// All checks are enabled on all descriptors to find out which of the excluded ones would fire,
+		// so a check firing only on excluded descriptors isn't reported as unused.
+		unrestrictedChecker = &ProtoChecker{
+			compiler: c.compiler,
+			config:   c.config.WithoutExclusions(),
+		}
+	)
+
+	for _, parsedFile := range parsedFiles {
+		descriptorNames = append(descriptorNames, collectDescriptorNames(parsedFile)...)
+
+		for _, finding := range unrestrictedChecker.checkFile(parsedFile, index).Findings {
+			firedChecks[finding.Check] = struct{}{}
+		}
+	}
+
+	result := new(UnusedExclusions)
+
+	// Imported descriptors are maintained by the tooling producing their files, so only inline ones are reported.
+	for _, exception := range c.config.GetInlineExcludedDescriptors() {
+		if !hasNameWithPrefix(descriptorNames, exception) {
+			result.Descriptors = append(result.Descriptors, exception)
+		}
+	}
+
+	for _, check := range c.config.GetExcludedChecks() {
+		if _, ok := firedChecks[check]; !ok {
+			result.Checks = append(result.Checks, check)
+		}
+	}
+
+	return result, nil
+}
+
+// IsEmpty returns true if no unused exclusions are found.
+func (u *UnusedExclusions) IsEmpty() bool {
+	return len(u.Checks) == 0 && len(u.Descriptors) == 0
+}
+
+func hasNameWithPrefix(names []string, prefix string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectDescriptorNames returns the full names of the file and all descriptors declared in it.
+func collectDescriptorNames(parsedFile linker.File) []string {
+	result := []string{string(parsedFile.FullName())}
+
+	services := parsedFile.Services()
+	for serviceIndex := 0; serviceIndex < services.Len(); serviceIndex++ {
+		service := services.Get(serviceIndex)
+		result = append(result, string(service.FullName()))
+
+		methods := service.Methods()
+		for methodIndex := 0; methodIndex < methods.Len(); methodIndex++ {
+			result = append(result, string(methods.Get(methodIndex).FullName()))
+		}
+	}
+
+	result = appendMessageNames(result, parsedFile.Messages())
+	result = appendEnumNames(result, parsedFile.Enums())
+
+	return result
+}
+
+func appendMessageNames(result []string, messages protoreflect.MessageDescriptors) []string {
+	for messageIndex := 0; messageIndex < messages.Len(); messageIndex++ {
+		message := messages.Get(messageIndex)
+		result = append(result, string(message.FullName()))
+
+		fields := message.Fields()
+		for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
+			result = append(result, string(fields.Get(fieldIndex).FullName()))
+		}
+
+		result = appendMessageNames(result, message.Messages())
+		result = appendEnumNames(result, message.Enums())
+	}
+
+	return result
+}
+
+func appendEnumNames(result []string, enums protoreflect.EnumDescriptors) []string {
+	for enumIndex := 0; enumIndex < enums.Len(); enumIndex++ {
+		enum := enums.Get(enumIndex)
+		result = append(result, string(enum.FullName()))
+
+		values := enum.Values()
+		for valueIndex := 0; valueIndex < values.Len(); valueIndex++ {
+			result = append(result, string(values.Get(valueIndex).FullName()))
+		}
+	}
+
+	return result
+}
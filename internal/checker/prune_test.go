@@ -0,0 +1,73 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestFindUnusedExclusions(t *testing.T) {
+	const (
+		source = `syntax = "proto3";
+
+package orders.v1;
+
+// Order is an order.
+message Order {
+  // ID of the order.
+  string order_id = 1 [json_name = "order_id"];
+}
+
+// LegacyOrder is an order of the old API.
+message LegacyOrder {
+  // ID of the order.
+  string order_id = 1 [json_name = "orderId"];
+}
+`
+		configContent = `excluded_checks:
+  - field_has_correct_json_name
+  - method_io_colocated
+excluded_descriptors:
+  - orders.v1.LegacyOrder
+  - orders.v1.Missing
+`
+	)
+
+	var (
+		ctx        = context.Background()
+		dir        = t.TempDir()
+		fileName   = filepath.Join(dir, "orders.proto")
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+	)
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	unused, err := NewProtoChecker(ctx, cfg).FindUnusedExclusions(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to find unused exclusions: %s", err.Error())
+	}
+
+	// field_has_correct_json_name fires only on the excluded LegacyOrder, so it's still in use.
+	expected := &UnusedExclusions{
+		Checks:      []string{MethodIOColocated},
+		Descriptors: []string{"orders.v1.Missing"},
+	}
+	if !reflect.DeepEqual(unused, expected) {
+		t.Errorf("got unused exclusions %+v, want %+v", unused, expected)
+	}
+}
@@ -0,0 +1,104 @@
+package checker
+
+import (
+	"regexp"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// queryParamMessages is a list of well-known messages grpc-gateway parses from a single query parameter.
+var queryParamMessages = map[protoreflect.FullName]struct{}{
+	"google.protobuf.Timestamp":   {},
+	"google.protobuf.Duration":    {},
+	"google.protobuf.FieldMask":   {},
+	"google.protobuf.DoubleValue": {},
+	"google.protobuf.FloatValue":  {},
+	"google.protobuf.Int64Value":  {},
+	"google.protobuf.UInt64Value": {},
+	"google.protobuf.Int32Value":  {},
+	"google.protobuf.UInt32Value": {},
+	"google.protobuf.BoolValue":   {},
+	"google.protobuf.StringValue": {},
+	"google.protobuf.BytesValue":  {},
+}
+
+var httpPathVariableNameRegexp = regexp.MustCompile(`\{([^}=.]+)`)
+
+// checkGetRequestFields checks that fields of the request of a GET method
+// that aren't bound to the path can be bound from query parameters by grpc-gateway.
+func (c *ProtoChecker) checkGetRequestFields(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+	path string,
+) {
+	if c.config.IsCheckExcluded(MethodGetRequestFieldsBindable) {
+		return
+	}
+
+	defer c.startRule(MethodGetRequestFieldsBindable, method)()
+
+	pathFields := make(map[protoreflect.Name]struct{})
+	for _, match := range httpPathVariableNameRegexp.FindAllStringSubmatch(path, -1) {
+		pathFields[protoreflect.Name(match[1])] = struct{}{}
+	}
+
+	fields := method.Input().Fields()
+	for fieldIndex := 0; fieldIndex < fields.Len(); fieldIndex++ {
+		field := fields.Get(fieldIndex)
+		if _, ok := pathFields[field.Name()]; ok {
+			continue
+		}
+
+		var reason string
+
+		switch {
+		case field.IsMap():
+			reason = "a map"
+		case field.Message() == nil:
+			continue
+		case field.IsList():
+			reason = "a repeated message"
+		default:
+			if _, ok := queryParamMessages[field.Message().FullName()]; ok {
+				continue
+			}
+
+			reason = "a message"
+		}
+
+		result.AddFindingf(
+			MethodGetRequestFieldsBindable,
+			method,
+			"Field %s of request of GET method %s is %s and can't be bound from query parameters",
+			field.Name(),
+			methodLogName,
+			result.localize(reason))
+	}
+}
+
+// checkGetRequestOneofs checks that the request of a GET method doesn't contain oneofs,
+// since grpc-gateway doesn't define which field of a oneof is set when several are passed in the query.
+// Synthetic oneofs of proto3 optional fields hold a single field, so they are allowed.
+func (c *ProtoChecker) checkGetRequestOneofs(
+	method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	c.runRule(MethodGetRequestNoOneof, method, func() {
+		oneofs := method.Input().Oneofs()
+		for oneofIndex := 0; oneofIndex < oneofs.Len(); oneofIndex++ {
+			oneof := oneofs.Get(oneofIndex)
+			if oneof.IsSynthetic() {
+				continue
+			}
+
+			result.AddFindingf(
+				MethodGetRequestNoOneof,
+				method,
+				"Request of GET method %s has oneof %s, which can't be reliably bound from query parameters",
+				methodLogName,
+				oneof.Name())
+		}
+	})
+}
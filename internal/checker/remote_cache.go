@@ -0,0 +1,97 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/common"
+	"github.com/oshokin/protolinter/internal/config"
+	"github.com/oshokin/protolinter/internal/logger"
+)
+
+// remoteCacheDependenciesPrefix is the prefix of keys of downloaded dependencies in the remote cache.
+const remoteCacheDependenciesPrefix = "dependencies/"
+
+// getRemoteCacheEntry reads the contents of the resource from the remote cache, if it's configured.
+// Failures of the remote cache never fail the run, they are logged and considered misses.
+func getRemoteCacheEntry(ctx context.Context, cfg *config.Config, resource string) ([]byte, bool) {
+	entryURL := getRemoteCacheEntryURL(cfg, resource)
+	if entryURL == "" {
+		return nil, false
+	}
+
+	body, err := requestRemoteCache(ctx, cfg, http.MethodGet, entryURL, nil)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read from remote cache, %s: %s, %s: %s",
+			common.URLTag, resource,
+			common.ErrorTag, err.Error())
+
+		return nil, false
+	}
+
+	return body, body != nil
+}
+
+// putRemoteCacheEntry writes the contents of the resource to the remote cache,
+// if it's configured and not read-only.
+func putRemoteCacheEntry(ctx context.Context, cfg *config.Config, resource string, body []byte) {
+	entryURL := getRemoteCacheEntryURL(cfg, resource)
+	if entryURL == "" || cfg.IsRemoteCacheReadOnly() {
+		return
+	}
+
+	if _, err := requestRemoteCache(ctx, cfg, http.MethodPut, entryURL, body); err != nil {
+		logger.Warnf(ctx, "Failed to write to remote cache, %s: %s, %s: %s",
+			common.URLTag, resource,
+			common.ErrorTag, err.Error())
+	}
+}
+
+// getRemoteCacheEntryURL returns the URL of the resource in the remote cache,
+// named after the checksum of the resource, or an empty string if the remote cache isn't configured.
+func getRemoteCacheEntryURL(cfg *config.Config, resource string) string {
+	remoteURL := cfg.GetRemoteCacheURL()
+	if remoteURL == "" {
+		return ""
+	}
+
+	checksum := sha256.Sum256([]byte(resource))
+
+	return strings.TrimSuffix(remoteURL, "/") + "/" + remoteCacheDependenciesPrefix + hex.EncodeToString(checksum[:])
+}
+
+// requestRemoteCache sends the request to the remote cache and returns the body of the response,
+// or nil if the entry is missing.
+func requestRemoteCache(
+	ctx context.Context,
+	cfg *config.Config,
+	method string,
+	entryURL string,
+	body []byte,
+) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, entryURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := cfg.GetHTTPClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound && method == http.MethodGet:
+		return nil, nil
+	case response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices:
+		return nil, fmt.Errorf("remote cache responded with status %d", response.StatusCode)
+	}
+
+	return io.ReadAll(response.Body)
+}
@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestRemoteCache(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		entries = make(map[string][]byte)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			body, ok := entries[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = w.Write(body)
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			entries[r.URL.Path] = body
+		}
+	}))
+	defer server.Close()
+
+	var (
+		ctx      = context.Background()
+		resource = "https://raw.githubusercontent.com/googleapis/googleapis/master/google/api/http.proto"
+		cfg      = &config.Config{Cache: config.CacheOptions{RemoteURL: server.URL + "/protolinter/"}}
+	)
+
+	if _, ok := getRemoteCacheEntry(ctx, cfg, resource); ok {
+		t.Fatal("expected a miss in the empty remote cache")
+	}
+
+	putRemoteCacheEntry(ctx, cfg, resource, []byte("syntax = \"proto3\";"))
+
+	if body, ok := getRemoteCacheEntry(ctx, cfg, resource); !ok || string(body) != "syntax = \"proto3\";" {
+		t.Errorf("expected a hit in the remote cache, got %q", body)
+	}
+
+	cfg.Cache.ReadOnly = true
+	putRemoteCacheEntry(ctx, cfg, resource+"?v=2", []byte("changed"))
+
+	if _, ok := getRemoteCacheEntry(ctx, cfg, resource+"?v=2"); ok {
+		t.Error("expected read-only remote cache not to be written to")
+	}
+
+	if _, ok := getRemoteCacheEntry(ctx, nil, resource); ok {
+		t.Error("expected no hits without remote cache")
+	}
+}
@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// removalDateLayout is the layout of dates in removal notes.
+const removalDateLayout = "2006-01-02"
+
+// removalNoteRegexp matches removal notes like "Remove after: 2025-12-01" in comments.
+var removalNoteRegexp = regexp.MustCompile(`(?i)remove after:\s*(\S+)`)
+
+// checkRemovalNote checks that a deprecated field has a comment with the date it's going to be removed after.
+func (c *ProtoChecker) checkRemovalNote(
+	field protoreflect.FieldDescriptor,
+	result *CheckResult,
+	fieldLogName string,
+) {
+	if !isDeprecated(field) {
+		return
+	}
+
+	c.runRule(DeprecatedFieldHasRemovalNote, field, func() {
+		date, ok := findRemovalDate(field)
+		if !ok {
+			result.AddFindingf(
+				DeprecatedFieldHasRemovalNote,
+				field,
+				"Deprecated field %s has no removal note like \"Remove after: %s\"",
+				fieldLogName,
+				removalDateLayout)
+
+			return
+		}
+
+		if _, err := time.Parse(removalDateLayout, date); err != nil {
+			result.AddFindingf(
+				DeprecatedFieldHasRemovalNote,
+				field,
+				"Removal note of deprecated field %s has invalid date %s, expected a date like %s",
+				fieldLogName,
+				date,
+				removalDateLayout)
+		}
+	})
+}
+
+// checkDeprecationExpired checks that a deprecated descriptor isn't still declared after its removal date.
+// Descriptors without a removal note or with an invalid date are left to deprecated_field_has_removal_note.
+// The kind is the human-readable kind of the descriptor used in messages, e.g. "Field".
+func (c *ProtoChecker) checkDeprecationExpired(
+	desc protoreflect.Descriptor,
+	result *CheckResult,
+	kind string,
+	logName string,
+) {
+	if !isDeprecated(desc) {
+		return
+	}
+
+	c.runRule(DeprecationExpired, desc, func() {
+		date, ok := findRemovalDate(desc)
+		if !ok {
+			return
+		}
+
+		removalDate, err := time.Parse(removalDateLayout, date)
+		if err != nil || !time.Now().After(removalDate) {
+			return
+		}
+
+		result.AddFindingf(
+			DeprecationExpired,
+			desc,
+			"%s %s is deprecated and was due to be removed after %s",
+			result.localize(kind),
+			logName,
+			date)
+	})
+}
+
+// isDeprecated returns true if the descriptor has the deprecated option set to true.
+func isDeprecated(desc protoreflect.Descriptor) bool {
+	options := desc.Options().ProtoReflect()
+
+	deprecated := options.Descriptor().Fields().ByName("deprecated")
+	if deprecated == nil || deprecated.Kind() != protoreflect.BoolKind {
+		return false
+	}
+
+	return options.Get(deprecated).Bool()
+}
+
+// findRemovalDate returns the date from the removal note in the leading or trailing comment of the descriptor
+// and true, or false if there's no removal note.
+func findRemovalDate(desc protoreflect.Descriptor) (string, bool) {
+	var (
+		location = desc.ParentFile().SourceLocations().ByDescriptor(desc)
+		match    = removalNoteRegexp.FindStringSubmatch(location.LeadingComments + "\n" + location.TrailingComments)
+	)
+
+	if match == nil {
+		return "", false
+	}
+
+	// The note may continue the sentence, so punctuation after the date isn't part of it.
+	return strings.TrimRight(match[1], ".,;"), true
+}
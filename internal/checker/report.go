@@ -0,0 +1,268 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/ownership"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// OutputFormatText is the human-readable output format.
+	OutputFormatText = "text"
+	// OutputFormatJSON is the structured JSON output format.
+	OutputFormatJSON = "json"
+	// OutputFormatHTML is the standalone HTML page output format.
+	OutputFormatHTML = "html"
+	// OutputFormatPatch is the unified diff of the available fixes output format.
+	OutputFormatPatch = "patch"
+
+	// GroupByOwner groups findings by the owners of the checked files.
+	GroupByOwner = "owner"
+
+	noOwnerGroupName = "(no owner)"
+)
+
+// NewCheckReport creates a structured report from the check results,
+// grouping findings by the specified key if it's not empty.
+func NewCheckReport(results []*CheckResult, groupBy string) *CheckReport {
+	if groupBy == GroupByOwner {
+		return &CheckReport{
+			Groups: groupFindingsByOwner(results),
+		}
+	}
+
+	files := make([]*FileReport, 0, len(results))
+
+	for _, cr := range results {
+		findings := cr.Findings
+		if findings == nil {
+			findings = []*Finding{}
+		}
+
+		files = append(files, &FileReport{
+			Path:     cr.File.Path(),
+			Messages: cr.Messages,
+			Findings: findings,
+			Metrics:  newDescriptorMetrics(cr.File),
+		})
+	}
+
+	return &CheckReport{
+		Files: files,
+	}
+}
+
+// newFindingsReport creates a structured report from findings not tied to compiled files,
+// such as compilation errors, keeping the order of the findings.
+func newFindingsReport(findings []*Finding) *CheckReport {
+	var (
+		files      []*FileReport
+		filesIndex = make(map[string]*FileReport)
+	)
+
+	for _, finding := range findings {
+		file, ok := filesIndex[finding.Path]
+		if !ok {
+			file = &FileReport{
+				Path: finding.Path,
+			}
+
+			filesIndex[finding.Path] = file
+			files = append(files, file)
+		}
+
+		file.Findings = append(file.Findings, finding)
+	}
+
+	if files == nil {
+		files = []*FileReport{}
+	}
+
+	return &CheckReport{
+		Files: files,
+	}
+}
+
+// newDescriptorMetrics counts the descriptors declared in the file.
+func newDescriptorMetrics(file protoreflect.FileDescriptor) *DescriptorMetrics {
+	visitor := &metricsVisitor{
+		metrics: new(DescriptorMetrics),
+	}
+
+	Walk(file, visitor)
+
+	return visitor.metrics
+}
+
+// EnterService counts the service.
+func (v *metricsVisitor) EnterService(protoreflect.ServiceDescriptor) bool {
+	v.metrics.Services++
+
+	return true
+}
+
+// Method counts the method.
+func (v *metricsVisitor) Method(protoreflect.MethodDescriptor) {
+	v.metrics.Methods++
+}
+
+// EnterMessage counts the message, map entries are implementation details and aren't counted.
+func (v *metricsVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	if message.IsMapEntry() {
+		return false
+	}
+
+	v.metrics.Messages++
+
+	return true
+}
+
+// Field counts the field.
+func (v *metricsVisitor) Field(protoreflect.FieldDescriptor) {
+	v.metrics.Fields++
+}
+
+// EnterEnum counts the enum.
+func (v *metricsVisitor) EnterEnum(protoreflect.EnumDescriptor) bool {
+	v.metrics.Enums++
+
+	return true
+}
+
+// EnumValue counts the enum value.
+func (v *metricsVisitor) EnumValue(protoreflect.EnumValueDescriptor) {
+	v.metrics.EnumValues++
+}
+
+// WriteJSON writes the report to the writer in JSON format.
+func (r *CheckReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	return nil
+}
+
+// annotateOwners sets the owners of the findings.
+// Files are looked up by their paths relative to the working directory rather than the names they're compiled under,
+// since the latter are relative to import roots.
+func annotateOwners(results []*CheckResult, owners *ownership.Owners, scopes []*checkScope) {
+	if owners == nil {
+		return
+	}
+
+	sourcePaths := make(map[string]string)
+
+	for _, scope := range scopes {
+		for i, file := range scope.files {
+			sourcePaths[file] = scope.sourcePaths[i]
+		}
+	}
+
+	for _, cr := range results {
+		sourcePath, ok := sourcePaths[cr.File.Path()]
+		if !ok {
+			sourcePath = cr.File.Path()
+		}
+
+		fileOwners := owners.Find(sourcePath)
+		if len(fileOwners) == 0 {
+			continue
+		}
+
+		for _, finding := range cr.Findings {
+			finding.Owners = fileOwners
+		}
+	}
+}
+
+func groupFindingsByOwner(results []*CheckResult) []*FindingGroup {
+	groupsMap := make(map[string]*FindingGroup)
+
+	addToGroup := func(key string, finding *Finding) {
+		group, ok := groupsMap[key]
+		if !ok {
+			group = &FindingGroup{
+				Key: key,
+			}
+
+			groupsMap[key] = group
+		}
+
+		group.Findings = append(group.Findings, finding)
+	}
+
+	for _, cr := range results {
+		for _, finding := range cr.Findings {
+			if len(finding.Owners) == 0 {
+				addToGroup("", finding)
+
+				continue
+			}
+
+			for _, owner := range finding.Owners {
+				addToGroup(owner, finding)
+			}
+		}
+	}
+
+	result := make([]*FindingGroup, 0, len(groupsMap))
+	for _, group := range groupsMap {
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// WriteText writes the report to the writer in human-readable format, one finding per line.
+func (r *CheckReport) WriteText(w io.Writer) error {
+	for _, file := range r.Files {
+		for _, finding := range file.Findings {
+			if _, err := fmt.Fprintln(w, formatFindingText(finding)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range r.Groups {
+		groupName := group.Key
+		if groupName == "" {
+			groupName = noOwnerGroupName
+		}
+
+		if _, err := fmt.Fprintf(w, "Findings owned by %s:\n", groupName); err != nil {
+			return err
+		}
+
+		for _, finding := range group.Findings {
+			if _, err := fmt.Fprintf(w, "  %s\n", formatFindingText(finding)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatFindingText formats the finding like compilers do: location, severity, message and check name.
+func formatFindingText(finding *Finding) string {
+	text := fmt.Sprintf("%s: %s [%s]", finding.Severity, finding.Message, finding.Check)
+
+	if finding.Line > 0 && finding.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", finding.Path, finding.Line, finding.Column, text)
+	}
+
+	return strings.Join([]string{finding.Path, text}, ": ")
+}
@@ -0,0 +1,195 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+const (
+	// reportIndexName is the name of the index of the report directory without an extension.
+	reportIndexName = "index"
+	reportDirMode   = 0o755
+)
+
+// reportIndexTemplate renders the index of the report directory in the html output format.
+var reportIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Protolinter reports</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: 0.3em 1em; border-bottom: 1px solid #d1d9e0; }
+</style>
+</head>
+<body>
+<h1>Protolinter reports</h1>
+<table>
+<tr><th>File</th><th>Errors</th><th>Warnings</th></tr>
+{{ range .Files }}<tr><td><a href="{{ .Report }}">{{ .Path }}</a></td><td>{{ .Errors }}</td><td>{{ .Warnings }}</td></tr>
+{{ end }}</table>
+</body>
+</html>
+`))
+
+// writeReportDir writes a report per checked file in the output format to the directory,
+// mirroring the paths of the files, and an index listing the reports.
+// Findings in per-file reports are never grouped.
+func writeReportDir(results []*CheckResult, dir, outputFormat string) error {
+	extension := getReportExtension(outputFormat)
+	index := &ReportIndex{
+		Files: make([]*ReportIndexEntry, 0, len(results)),
+	}
+
+	for i, fileReport := range NewCheckReport(results, "").Files {
+		entry := &ReportIndexEntry{
+			Path:   fileReport.Path,
+			Report: getReportName(fileReport.Path, extension),
+		}
+
+		for _, finding := range fileReport.Findings {
+			switch finding.Severity {
+			case config.SeverityError:
+				entry.Errors++
+			case config.SeverityWarning:
+				entry.Warnings++
+			}
+		}
+
+		report := &CheckReport{Files: []*FileReport{fileReport}}
+
+		err := writeReportFile(filepath.Join(dir, filepath.FromSlash(entry.Report)), func(w io.Writer) error {
+			switch outputFormat {
+			case OutputFormatJSON:
+				return report.WriteJSON(w)
+			case OutputFormatHTML:
+				return NewHTMLReport(results[i : i+1]).WriteHTML(w)
+			default:
+				return report.WriteText(w)
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		index.Files = append(index.Files, entry)
+	}
+
+	return writeReportFile(filepath.Join(dir, reportIndexName+extension), func(w io.Writer) error {
+		switch outputFormat {
+		case OutputFormatJSON:
+			return index.WriteJSON(w)
+		case OutputFormatHTML:
+			return index.WriteHTML(w)
+		default:
+			return index.WriteText(w)
+		}
+	})
+}
+
+// WriteJSON writes the index to the writer in JSON format.
+func (r *ReportIndex) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode report index: %w", err)
+	}
+
+	return nil
+}
+
+// WriteText writes the index to the writer as a table.
+func (r *ReportIndex) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+
+	if _, err := fmt.Fprintln(writer, "FILE\tREPORT\tERRORS\tWARNINGS"); err != nil {
+		return err
+	}
+
+	for _, entry := range r.Files {
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t%d\t%d\n",
+			entry.Path,
+			entry.Report,
+			entry.Errors,
+			entry.Warnings); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// WriteHTML writes the index to the writer as an HTML page linking the reports.
+func (r *ReportIndex) WriteHTML(w io.Writer) error {
+	if err := reportIndexTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render report index: %w", err)
+	}
+
+	return nil
+}
+
+// getReportExtension returns the extension of report files in the output format.
+func getReportExtension(outputFormat string) string {
+	switch outputFormat {
+	case OutputFormatJSON:
+		return ".json"
+	case OutputFormatHTML:
+		return ".html"
+	default:
+		return ".txt"
+	}
+}
+
+// getReportName returns the slash-separated path of the report of the file relative to the report directory,
+// e.g. "api/orders.proto.json" for "api/orders.proto".
+// Absolute paths are made relative and parent directory elements are replaced,
+// so reports never leave the report directory.
+func getReportName(filePath, extension string) string {
+	name := path.Clean(filepath.ToSlash(filePath))
+	name = strings.TrimPrefix(name, filepath.ToSlash(filepath.VolumeName(filePath)))
+	name = strings.TrimLeft(name, "/")
+
+	elements := strings.Split(name, "/")
+	for i, element := range elements {
+		if element == ".." {
+			elements[i] = "__"
+		}
+	}
+
+	return strings.Join(elements, "/") + extension
+}
+
+// writeReportFile creates the file with its parent directories and writes the content to it.
+func writeReportFile(fileName string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), reportDirMode); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	if err = write(file); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write report file %s: %w", fileName, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to write report file %s: %w", fileName, err)
+	}
+
+	return nil
+}
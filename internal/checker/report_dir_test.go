@@ -0,0 +1,95 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestGetReportName(t *testing.T) {
+	testCases := []struct {
+		filePath string
+		expected string
+	}{
+		{"api/orders.proto", "api/orders.proto.json"},
+		{"./api/../orders.proto", "orders.proto.json"},
+		{"../shared/money.proto", "__/shared/money.proto.json"},
+		{"/abs/orders.proto", "abs/orders.proto.json"},
+	}
+
+	for _, tc := range testCases {
+		if actual := getReportName(tc.filePath, ".json"); actual != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.filePath, tc.expected, actual)
+		}
+	}
+}
+
+func TestWriteReportDir(t *testing.T) {
+	compiler := &protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{
+				"api/orders.proto": "syntax = \"proto3\";\n\npackage shop;\n",
+				"api/money.proto":  "syntax = \"proto3\";\n\npackage shop;\n",
+			}),
+		},
+	}
+
+	files, err := compiler.Compile(context.Background(), "api/orders.proto", "api/money.proto")
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	results := []*CheckResult{
+		{
+			File: files[0],
+			Findings: []*Finding{
+				{Check: FileHasHeader, Severity: config.SeverityError, Path: "api/orders.proto"},
+				{Check: FileElementOrder, Severity: config.SeverityWarning, Path: "api/orders.proto"},
+			},
+		},
+		{File: files[1]},
+	}
+
+	dir := t.TempDir()
+	if err = writeReportDir(results, dir, OutputFormatJSON); err != nil {
+		t.Fatalf("failed to write report directory: %s", err.Error())
+	}
+
+	var index ReportIndex
+	readReportFile(t, filepath.Join(dir, "index.json"), &index)
+
+	expected := []*ReportIndexEntry{
+		{Path: "api/orders.proto", Report: "api/orders.proto.json", Errors: 1, Warnings: 1},
+		{Path: "api/money.proto", Report: "api/money.proto.json"},
+	}
+
+	if !reflect.DeepEqual(index.Files, expected) {
+		t.Errorf("unexpected index %+v", index.Files)
+	}
+
+	var report CheckReport
+	readReportFile(t, filepath.Join(dir, "api", "orders.proto.json"), &report)
+
+	if len(report.Files) != 1 || report.Files[0].Path != "api/orders.proto" || len(report.Files[0].Findings) != 2 {
+		t.Errorf("unexpected report of api/orders.proto %+v", report.Files)
+	}
+}
+
+func readReportFile(t *testing.T, fileName string, v any) {
+	t.Helper()
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("failed to read %s: %s", fileName, err.Error())
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to parse %s: %s", fileName, err.Error())
+	}
+}
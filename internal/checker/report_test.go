@@ -0,0 +1,65 @@
+package checker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+)
+
+func TestNewDescriptorMetrics(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+service OrderService {
+  rpc GetOrder(Order) returns (Order);
+  rpc ListOrders(Order) returns (Order);
+}
+
+message Order {
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    STATUS_PAID = 1;
+  }
+
+  message Item {
+    string sku = 1;
+  }
+
+  string id = 1;
+  Status status = 2;
+  repeated Item items = 3;
+  map<string, string> labels = 4;
+}
+
+enum Currency {
+  CURRENCY_UNSPECIFIED = 0;
+}
+`
+
+	compiler := &protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{"shop.proto": source}),
+		},
+	}
+
+	files, err := compiler.Compile(context.Background(), "shop.proto")
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	expected := &DescriptorMetrics{
+		Services:   1,
+		Methods:    2,
+		Messages:   2,
+		Fields:     5,
+		Enums:      2,
+		EnumValues: 3,
+	}
+
+	if actual := newDescriptorMetrics(files[0]); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected metrics %+v, got %+v", expected, actual)
+	}
+}
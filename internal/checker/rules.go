@@ -0,0 +1,1032 @@
+package checker
+
+const (
+	// RuleCategoryDocumentation groups checks of comments and descriptions.
+	RuleCategoryDocumentation = "documentation"
+	// RuleCategoryNaming groups checks of names of descriptors.
+	RuleCategoryNaming = "naming"
+	// RuleCategoryHTTP groups checks of HTTP mappings and service options.
+	RuleCategoryHTTP = "http"
+	// RuleCategoryStructure groups checks of the shape of messages and their references.
+	RuleCategoryStructure = "structure"
+	// RuleCategoryDependencies groups checks of dependencies between packages.
+	RuleCategoryDependencies = "dependencies"
+)
+
+var registeredRules = []*Rule{
+	{
+		Name:        MethodHasVersion,
+		Category:    RuleCategoryNaming,
+		Description: "Checks whether a method specifies a version.",
+		Rationale: "Versioned method names allow introducing breaking changes " +
+			"as new methods while keeping the old ones available for existing clients.",
+		GoodExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);`,
+		BadExample:  `rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+	},
+	{
+		Name:        ServiceMethodsSingleVersion,
+		Category:    RuleCategoryNaming,
+		Description: "Checks if all versioned methods of a service share the same `V\\d+` suffix.",
+		Rationale: "A service mixing method versions is half-migrated, " +
+			"new versions of methods belong to a new version of the service.",
+		GoodExample: `service OrderService {
+  rpc GetOrderV2(GetOrderV2Request) returns (GetOrderV2Response);
+  rpc ListOrdersV2(ListOrdersV2Request) returns (ListOrdersV2Response);
+}`,
+		BadExample: `service OrderService {
+  rpc GetOrderV2(GetOrderV2Request) returns (GetOrderV2Response);
+  rpc ListOrdersV1(ListOrdersV1Request) returns (ListOrdersV1Response);
+}`,
+	},
+	{
+		Name:        PackageVersionMatchesMethods,
+		Category:    RuleCategoryNaming,
+		Description: "Checks if the version suffix of a method matches the `.vN` segment of its package.",
+		Rationale: "Clients pick the API version by the package, " +
+			"a method of another version in it breaks that promise.",
+		GoodExample: `package orders.v2;
+
+service OrderService {
+  rpc GetOrderV2(GetOrderV2Request) returns (GetOrderV2Response);
+}`,
+		BadExample: `package orders.v2;
+
+service OrderService {
+  rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);
+}`,
+	},
+	{
+		Name:        MethodHasCorrectInputName,
+		Category:    RuleCategoryNaming,
+		Description: "Checks if the method input is named correctly.",
+		Rationale: "Naming the request message after the method makes it obvious " +
+			"which method the message belongs to and prevents sharing requests between methods.",
+		GoodExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);`,
+		BadExample:  `rpc GetOrderV1(OrderFilter) returns (GetOrderV1Response);`,
+	},
+	{
+		Name:        MethodHasHTTPPath,
+		Category:    RuleCategoryHTTP,
+		Description: "Checks if an HTTP path is specified for the method.",
+		Rationale:   "Methods exposed through grpc-gateway are unreachable over HTTP without a path.",
+		GoodExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option (google.api.http) = {get: "/v1/orders/{id}"};
+}`,
+		BadExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option (google.api.http) = {get: ""};
+}`,
+	},
+	{
+		Name:        MethodHasBodyTag,
+		Category:    RuleCategoryHTTP,
+		Description: "Checks if methods with a required body have the correct body tag.",
+		Rationale: "POST and PUT methods without `body: \"*\"` silently ignore " +
+			"request fields that aren't bound to the path.",
+		GoodExample: `rpc CreateOrderV1(CreateOrderV1Request) returns (CreateOrderV1Response) {
+  option (google.api.http) = {post: "/v1/orders" body: "*"};
+}`,
+		BadExample: `rpc CreateOrderV1(CreateOrderV1Request) returns (CreateOrderV1Response) {
+  option (google.api.http) = {post: "/v1/orders"};
+}`,
+	},
+	{
+		Name:        MethodHasSwaggerTags,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a method has appropriate Swagger tags.",
+		Rationale:   "Tags group methods in the generated Swagger UI.",
+		GoodExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  tags: "Orders"
+};`,
+		BadExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  summary: "Returns an order"
+};`,
+	},
+	{
+		Name:        MethodHasSwaggerSummary,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a method has a valid Swagger summary.",
+		Rationale:   "The summary is the one-line caption of the method in the generated Swagger UI.",
+		GoodExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  summary: "Returns an order"
+};`,
+		BadExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  tags: "Orders"
+};`,
+	},
+	{
+		Name:        MethodHasSwaggerDescription,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a method has a valid Swagger description.",
+		Rationale:   "The description is the main documentation of the method for API consumers.",
+		GoodExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  description: "Returns an order by its identifier."
+};`,
+		BadExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  tags: "Orders"
+};`,
+	},
+	{
+		Name:     SwaggerExternalDocsValidURL,
+		Category: RuleCategoryDocumentation,
+		Description: "Checks if the `external_docs.url` of the openapiv2_operation option of a method " +
+			"is an absolute HTTPS URL, on one of the allowed domains if they are configured.",
+		Rationale: "Relative, plain HTTP or third-party links in published API documentation " +
+			"are broken, insecure or point consumers to the wrong place.",
+		GoodExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  external_docs: {url: "https://docs.example.com/orders"}
+};`,
+		BadExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+  external_docs: {url: "docs/orders.md"}
+};`,
+		Options: []RuleOption{
+			{
+				Name:        "swagger_external_docs_valid_url.allowed_domains",
+				Description: "Domains the URL must belong to, subdomains included; any domain is allowed if empty.",
+			},
+		},
+	},
+	{
+		Name:        FieldHasCorrectJSONName,
+		Category:    RuleCategoryNaming,
+		Description: "Checks if a field's JSON name tag is correct.",
+		Rationale:   "A json_name differing from the field name makes HTTP and gRPC payloads inconsistent.",
+		GoodExample: `string order_id = 1 [json_name = "order_id"];`,
+		BadExample:  `string order_id = 1 [json_name = "orderId"];`,
+	},
+	{
+		Name:     JSONNameIsLowerCamel,
+		Category: RuleCategoryNaming,
+		Description: "Checks if an explicitly set json_name is lowerCamelCase and doesn't match the name " +
+			"or JSON name of another field of the message ignoring case. Contradicts field_has_correct_json_name, " +
+			"so it runs only where that check is excluded.",
+		Rationale: "JSON APIs conventionally use lowerCamelCase keys, and JSON parsers accept both names of a field, " +
+			"some of them ignoring case, so colliding names make payloads ambiguous.",
+		GoodExample: `string order_id = 1 [json_name = "orderId"];`,
+		BadExample: `string order_id = 1 [json_name = "OrderID"];
+string external_id = 2 [json_name = "order_id"];`,
+	},
+	{
+		Name:        FieldHasNoDescription,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a field has no description.",
+		Rationale:   "Undocumented fields show up empty in the generated Swagger UI.",
+		GoodExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Identifier of the order."
+}];`,
+		BadExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  example: "\"42\""
+}];`,
+	},
+	{
+		Name:        FieldDescriptionStartsWithCapital,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a field's description starts with a capital letter.",
+		Rationale:   "Consistent capitalization keeps the generated documentation readable.",
+		GoodExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Identifier of the order."
+}];`,
+		BadExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "identifier of the order."
+}];`,
+	},
+	{
+		Name:        FieldDescriptionEndsWithDot,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a field's description ends with a dot.",
+		Rationale:   "Consistent punctuation keeps the generated documentation readable.",
+		GoodExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Identifier of the order."
+}];`,
+		BadExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Identifier of the order"
+}];`,
+	},
+	{
+		Name:        EnumValueHasComments,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if an enum value has leading comments.",
+		Rationale:   "Enum values are rarely self-explanatory for API consumers.",
+		GoodExample: `enum OrderStatus {
+  // Status is not specified.
+  ORDER_STATUS_UNSPECIFIED = 0;
+}`,
+		BadExample: `enum OrderStatus {
+  ORDER_STATUS_UNSPECIFIED = 0;
+}`,
+	},
+	{
+		Name:        FieldDescriptionLength,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a field's description length is within the configured bounds.",
+		Rationale: "One-word descriptions don't explain anything, " +
+			"while very long ones blow up the generated Swagger UI.",
+		GoodExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Identifier of the order."
+}];`,
+		BadExample: `string order_id = 1 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {
+  description: "Id."
+}];`,
+		Options: []RuleOption{
+			{
+				Name:        "field_description_length.min",
+				Description: "Minimum number of characters in a description, 10 by default.",
+			},
+			{
+				Name:        "field_description_length.max",
+				Description: "Maximum number of characters in a description, 500 by default.",
+			},
+		},
+	},
+	{
+		Name:        CommentStyleSyntax,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if documentation comments use the configured syntax.",
+		Rationale: "Mixing line and block comments makes documentation extraction " +
+			"by generators inconsistent.",
+		GoodExample: `// Identifier of the order.
+string order_id = 1;`,
+		BadExample: `/* Identifier of the order. */
+string order_id = 1;`,
+		Options: []RuleOption{
+			{
+				Name:        "comment_style.syntax",
+				Description: "`line` (default) requires `//` comments, `block` requires `/* */` comments.",
+			},
+		},
+	},
+	{
+		Name:        CommentStyleNoTrailing,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if descriptors aren't documented with trailing same-line comments.",
+		Rationale: "Documentation generators expect leading comments, " +
+			"trailing ones are easily lost or attached to the wrong descriptor.",
+		GoodExample: `// Identifier of the order.
+string order_id = 1;`,
+		BadExample: `string order_id = 1; // Identifier of the order.`,
+	},
+	{
+		Name:        MessageNotEmpty,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a message has fields unless it's used by a method or named like *Empty.",
+		Rationale:   "Empty messages are usually placeholders left behind after refactors.",
+		GoodExample: `message OrderEmpty {}
+
+rpc DeleteOrderV1(DeleteOrderV1Request) returns (DeleteOrderV1Response);
+message DeleteOrderV1Response {}`,
+		BadExample: `message OrderDetails {}`,
+	},
+	{
+		Name:        DescriptorIsReferenced,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a message or enum is referenced by a field, a method or an extension within the checked files.",
+		Rationale: "Unreferenced types are usually leftovers of refactors. " +
+			"Types published for other repositories can be excluded, or the check can be reported as a warning.",
+		GoodExample: `enum OrderStatus {
+  // Status is not specified.
+  ORDER_STATUS_UNSPECIFIED = 0;
+}
+
+message Order {
+  OrderStatus status = 1;
+}`,
+		BadExample: `enum OrderStatus {
+  // Status is not specified.
+  ORDER_STATUS_UNSPECIFIED = 0;
+}
+
+message Order {
+  int32 status = 1;
+}`,
+	},
+	{
+		Name:     MapKeyTypeAllowed,
+		Category: RuleCategoryStructure,
+		Description: "Checks if a map uses one of the allowed key types, " +
+			"key types listed as warning types are reported as warnings regardless of the severity of the check.",
+		Rationale: "JSON turns map keys into strings, and gateways convert bool and 32-bit integer keys " +
+			"inconsistently, so clients in other languages can't read such maps reliably.",
+		GoodExample: `map<string, Order> orders = 1;`,
+		BadExample:  `map<bool, Order> orders = 1;`,
+		Options: []RuleOption{
+			{
+				Name:        "map_key_type_allowed.allowed_types",
+				Description: "Key types maps may use. Default is `string` and `int64`.",
+			},
+			{
+				Name:        "map_key_type_allowed.warning_types",
+				Description: "Key types reported as warnings. Default is `int32`.",
+			},
+		},
+	},
+	{
+		Name:     DeprecatedFieldHasRemovalNote,
+		Category: RuleCategoryDocumentation,
+		Description: "Checks if a field marked with `deprecated = true` has a comment with its removal date " +
+			"like `// Remove after: 2025-12-01`.",
+		Rationale: "Deprecated fields without a removal plan stay in APIs forever, " +
+			"a date tells clients how long they have to migrate.",
+		GoodExample: `// Remove after: 2025-12-01, use customer_id instead.
+string user_id = 1 [deprecated = true];`,
+		BadExample: `string user_id = 1 [deprecated = true];`,
+	},
+	{
+		Name:     DeprecationExpired,
+		Category: RuleCategoryStructure,
+		Description: "Checks if a deprecated service, method, message, field, enum or enum value " +
+			"is still declared after the date of its `// Remove after: 2025-12-01` note.",
+		Rationale: "A removal date nobody enforces is just a wish, " +
+			"failing the build on the deadline turns deprecation into a plan.",
+		GoodExample: `// Remove after: 2999-12-01, use customer_id instead.
+string user_id = 1 [deprecated = true];`,
+		BadExample: `// Remove after: 2020-12-01, use customer_id instead.
+string user_id = 1 [deprecated = true];`,
+	},
+	{
+		Name:     DirectiveIsValid,
+		Category: RuleCategoryDocumentation,
+		Description: "Checks if `protolinter:disable` directives in comments name known checks " +
+			"and suppress at least one finding of every named check that isn't excluded.",
+		Rationale: "Misspelled directives silently suppress nothing, and stale ones hide " +
+			"future violations of descriptors that have already been fixed.",
+		GoodExample: `// protolinter:disable field_has_no_description -- generated from a legacy schema.
+message LegacyOrder {
+  string id = 1;
+}`,
+		BadExample: `// protolinter:disable field_has_no_descripton
+message LegacyOrder {
+  string id = 1;
+}`,
+	},
+	{
+		Name:        MessageNoCycles,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a message doesn't reference itself directly or through other messages.",
+		Rationale:   "Recursive messages break OpenAPI generation and some client code generators.",
+		GoodExample: `message Category {
+  string parent_id = 1;
+}`,
+		BadExample: `message Category {
+  Category parent = 1;
+}`,
+		Options: []RuleOption{
+			{
+				Name: "message_no_cycles.max_depth",
+				Description: "Maximum number of messages in an allowed cycle, " +
+					"0 (default) forbids cycles entirely, 1 allows messages referencing themselves directly.",
+			},
+		},
+	},
+	{
+		Name:     MessageNoJSONNameCollisions,
+		Category: RuleCategoryStructure,
+		Description: "Checks if no two fields of a message serialize to the same JSON key, " +
+			"taking explicitly set json_name and names derived from field names into account.",
+		Rationale: "The compiler only warns about colliding derived JSON names in proto2 files, " +
+			"while such messages silently lose one of the values in JSON payloads.",
+		GoodExample: `optional string order_id = 1;
+optional string external_order_id = 2;`,
+		BadExample: `optional string order_id = 1;
+optional string orderId = 2;`,
+	},
+	{
+		Name:     ResponseNoInputOnlyFields,
+		Category: RuleCategoryStructure,
+		Description: "Checks if fields marked `INPUT_ONLY` or `IMMUTABLE` by `google.api.field_behavior` " +
+			"aren't reachable from responses of methods, and fields marked `OUTPUT_ONLY` aren't reachable from requests.",
+		Rationale: "A field the server never returns or never accepts in that direction misleads clients " +
+			"and generated documentation, usually a sign of a message shared between requests and responses.",
+		GoodExample: `message CreateOrderV1Request {
+  string idempotency_key = 1 [(google.api.field_behavior) = INPUT_ONLY];
+}`,
+		BadExample: `message GetOrderV1Response {
+  string idempotency_key = 1 [(google.api.field_behavior) = INPUT_ONLY];
+}`,
+	},
+	{
+		Name:     FieldSensitiveDataAnnotation,
+		Category: RuleCategoryStructure,
+		Description: "Checks if fields named like sensitive data, such as passwords, tokens or card numbers, " +
+			"are marked by `debug_redact` or one of the configured sensitivity options.",
+		Rationale: "Marking sensitive fields keeps them out of logs and debug output, " +
+			"enforcing the privacy review at lint time rather than after a leak.",
+		GoodExample: `string password = 1 [debug_redact = true];`,
+		BadExample:  `string password = 1;`,
+		Options: []RuleOption{
+			{
+				Name: "field_sensitive_data_annotation.patterns",
+				Description: "Regular expressions of names of sensitive fields, matched case-insensitively. " +
+					"Default matches password, token, access_token and similar, ssn and card_number.",
+			},
+			{
+				Name:        "field_sensitive_data_annotation.options",
+				Description: "Full names of field options marking a field as sensitive, accepted along with `debug_redact`.",
+			},
+		},
+	},
+	{
+		Name:     FieldPIIDebugRedact,
+		Category: RuleCategoryStructure,
+		Description: "Checks if fields holding personal data, matched by name or by a configured annotation, " +
+			"are marked by `debug_redact`, and in the strict mode that fields which can't hold personal data aren't.",
+		Rationale: "Personal data printed by debug output ends up in logs and crash reports, " +
+			"while redacting everything makes debugging needlessly hard.",
+		GoodExample: `string email = 1 [debug_redact = true];
+bool verified = 2;`,
+		BadExample: `string email = 1;
+bool verified = 2 [debug_redact = true];`,
+		Options: []RuleOption{
+			{
+				Name: "field_pii_debug_redact.patterns",
+				Description: "Regular expressions of names of fields holding personal data, matched case-insensitively. " +
+					"Default matches email, phone, names, birth dates, addresses, passports and ssn.",
+			},
+			{
+				Name:        "field_pii_debug_redact.annotations",
+				Description: "Full names of field options marking a field as holding personal data.",
+			},
+			{
+				Name: "field_pii_debug_redact.strict",
+				Description: "Whether `debug_redact` is reported on boolean and enum fields " +
+					"and fields matched by `non_sensitive_patterns`.",
+			},
+			{
+				Name: "field_pii_debug_redact.non_sensitive_patterns",
+				Description: "Regular expressions of names of fields that can't hold personal data. " +
+					"Default matches id, timestamps like created_at, pagination fields, status and state.",
+			},
+		},
+	},
+	{
+		Name:     MethodVerbMatchesHTTPMethod,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if the verb a method name starts with agrees with the HTTP method of its google.api.http " +
+			"binding: Get and List are bound to GET, Create to POST, Update to PUT or PATCH and Delete to DELETE.",
+		Rationale: "Gateways, caches and clients rely on HTTP semantics, " +
+			"a reading method bound to POST or a deleting one bound to GET misleads all of them.",
+		GoodExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option (google.api.http) = {get: "/v1/orders/{id}"};
+}`,
+		BadExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option (google.api.http) = {post: "/v1/orders/{id}" body: "*"};
+}`,
+		Options: []RuleOption{
+			{
+				Name: "method_verb_matches_http_method.verbs",
+				Description: "Verbs of method names (`prefix`) along with the HTTP methods they may be bound to " +
+					"(`http_methods`), replacing the default ones. The longest matching verb applies.",
+			},
+		},
+	},
+	{
+		Name:     MethodUpdateHasFieldMask,
+		Category: RuleCategoryStructure,
+		Description: "Checks if the request of a method named Update* or bound to HTTP PATCH " +
+			"has a `google.protobuf.FieldMask update_mask` field, as AIP-134 suggests.",
+		Rationale: "Without a field mask, a server can't tell a field cleared by the client " +
+			"from a field the client doesn't know about, so partial updates silently wipe data.",
+		GoodExample: `message UpdateOrderV1Request {
+  Order order = 1;
+  google.protobuf.FieldMask update_mask = 2;
+}`,
+		BadExample: `message UpdateOrderV1Request {
+  Order order = 1;
+}`,
+	},
+	{
+		Name:     MethodCreateReturnsResource,
+		Category: RuleCategoryStructure,
+		Description: "Checks if a method named like CreateOrderV1 returns the created resource: " +
+			"the Order message or a response having an Order field, and never google.protobuf.Empty.",
+		Rationale: "Clients need the identifier and the fields filled in by the server, " +
+			"returning nothing forces them to make another call to read the resource back.",
+		GoodExample: `rpc CreateOrderV1(CreateOrderV1Request) returns (Order);`,
+		BadExample:  `rpc CreateOrderV1(CreateOrderV1Request) returns (google.protobuf.Empty);`,
+		Options: []RuleOption{
+			{
+				Name: "method_create_returns_resource.strictness",
+				Description: "`embedded` (default) also accepts responses having a field of the resource, " +
+					"`resource` requires the response to be the resource itself, as AIP-133 suggests.",
+			},
+		},
+	},
+	{
+		Name:     MethodBatchConventions,
+		Category: RuleCategoryStructure,
+		Description: "Checks if the request of a method named like BatchGetOrdersV1 has a repeated `names` or `ids` field " +
+			"and the request of a method named like BatchCreateOrdersV1 has a repeated `requests` field, " +
+			"if the responses of both have a repeated `orders` field, and if validation rules of the repeated " +
+			"request field, when present, set `max_items`, as AIP-231 and AIP-233 suggest.",
+		Rationale: "Uniform batch methods are predictable for clients and generators, " +
+			"and an unlimited batch lets a single call overload the server.",
+		GoodExample: `message BatchGetOrdersV1Request {
+  repeated string ids = 1 [(validate.rules).repeated.max_items = 100];
+}
+
+message BatchGetOrdersV1Response {
+  repeated Order orders = 1;
+}`,
+		BadExample: `message BatchGetOrdersV1Request {
+  repeated string order_ids = 1;
+}
+
+message BatchGetOrdersV1Response {
+  repeated Order items = 1;
+}`,
+	},
+	{
+		Name:     MethodLROConventions,
+		Category: RuleCategoryStructure,
+		Description: "Checks if a method returning `google.longrunning.Operation` has the " +
+			"`google.longrunning.operation_info` option with `response_type` and `metadata_type`, " +
+			"and if both are messages of the file of the method or of its imports, as AIP-151 suggests.",
+		Rationale: "An operation is an opaque envelope, without the declared types clients and generators " +
+			"can't tell what the operation eventually returns and reports while running.",
+		GoodExample: `rpc ExportOrdersV1(ExportOrdersV1Request) returns (google.longrunning.Operation) {
+  option (google.longrunning.operation_info) = {
+    response_type: "ExportOrdersV1Response"
+    metadata_type: "ExportOrdersV1Metadata"
+  };
+}`,
+		BadExample: `rpc ExportOrdersV1(ExportOrdersV1Request) returns (google.longrunning.Operation);`,
+	},
+	{
+		Name:     UnknownOptionDetected,
+		Category: RuleCategoryStructure,
+		Description: "Reports options set by extensions unknown to the linker, " +
+			"naming the extensions defined in the configured extension sources. " +
+			"Only files loaded from descriptor sets can have such options, since source files " +
+			"setting unknown extensions fail to compile. Named extensions aren't used to read the options, " +
+			"their values stay hidden from other checks until the defining file is imported.",
+		Rationale: "Checks of annotations can't read the values of unknown options, " +
+			"so a clean run doesn't mean the annotations were checked.",
+		GoodExample: `import "google/api/annotations.proto";
+
+rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option (google.api.http) = {get: "/v1/orders/{id}"};
+}`,
+		BadExample: `// Options of a descriptor loaded without google/api/annotations.proto
+// keep google.api.http as the unknown field 72295728.`,
+		Options: []RuleOption{
+			{
+				Name: "unknown_option_detected.extension_sources",
+				Description: "Import paths of files defining option extensions, " +
+					"used to name the extensions of unknown options.",
+			},
+		},
+	},
+	{
+		Name:        ServiceMaxMethods,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a service doesn't have more methods than configured.",
+		Rationale: "Monolithic services are hard to version and to split between teams, " +
+			"gateways and generated clients grow with every method.",
+		GoodExample: `service OrderService {
+  rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);
+}`,
+		BadExample: `service ShopService {
+  // 41 methods managing orders, customers, payments and deliveries.
+}`,
+		Options: []RuleOption{
+			{
+				Name:        "service_max_methods.max_methods",
+				Description: "Maximum number of methods in a service, 40 by default.",
+			},
+		},
+	},
+	{
+		Name:        PackageMaxServices,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a package doesn't declare more services than configured in the checked files.",
+		Rationale: "Packages are versioned as a whole, so a package with many services " +
+			"forces unrelated APIs to change versions together.",
+		GoodExample: `package shop.orders.v1;
+
+service OrderService {}`,
+		BadExample: `package shop.v1;
+
+// 11 services declared in the files of the package.
+service OrderService {}`,
+		Options: []RuleOption{
+			{
+				Name:        "package_max_services.max_services",
+				Description: "Maximum number of services in a package, 10 by default.",
+			},
+		},
+	},
+	{
+		Name:        FileMaxLines,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a file doesn't have more lines than configured.",
+		Rationale: "Enormous files are usually machine-generated or dumping grounds of unrelated definitions, " +
+			"they are hard to review and should be split.",
+		GoodExample: `// orders/v1/orders.proto of 300 lines.`,
+		BadExample:  `// orders/v1/all.proto of 5000 lines.`,
+		Options: []RuleOption{
+			{
+				Name:        "file_max_lines.max_lines",
+				Description: "Maximum number of lines in a file, 2000 by default.",
+			},
+		},
+	},
+	{
+		Name:        FileMaxMessages,
+		Category:    RuleCategoryStructure,
+		Description: "Checks if a file doesn't declare more top-level messages than configured.",
+		Rationale: "Files with hundreds of messages mix unrelated definitions, " +
+			"so every change touches everyone importing them.",
+		GoodExample: `// orders/v1/orders.proto declaring the messages of OrderService.`,
+		BadExample:  `// common/v1/models.proto declaring 150 messages of every domain.`,
+		Options: []RuleOption{
+			{
+				Name:        "file_max_messages.max_messages",
+				Description: "Maximum number of top-level messages in a file, 100 by default.",
+			},
+		},
+	},
+	{
+		Name:     IdentifierAcronymStyle,
+		Category: RuleCategoryNaming,
+		Description: "Checks if acronyms in names of services, methods, messages and enums " +
+			"are spelled in the configured style.",
+		Rationale: "Mixed spellings like GetHTTPURLV1 and GetHttpUrlV1 make names unpredictable " +
+			"and generated code inconsistent across languages.",
+		GoodExample: `rpc GetOrderUrlV1(GetOrderUrlV1Request) returns (GetOrderUrlV1Response);`,
+		BadExample:  `rpc GetOrderURLV1(GetOrderURLV1Request) returns (GetOrderURLV1Response);`,
+		Options: []RuleOption{
+			{
+				Name:        "identifier_acronym_style.acronyms",
+				Description: "Acronyms whose spelling is checked, ID, URL, HTTP and API by default.",
+			},
+			{
+				Name: "identifier_acronym_style.style",
+				Description: "Spelling of acronyms: capitalized (default) for names like GetHttpUrlV1 " +
+					"or upper for names like GetHTTPURLV1.",
+			},
+		},
+	},
+	{
+		Name:     MethodIOColocated,
+		Category: RuleCategoryDependencies,
+		Description: "Checks if method input and output messages of the package of the service " +
+			"are defined in the file of the service, its directory or its paired messages file.",
+		Rationale: "Request and response definitions scattered across files make reviews " +
+			"and breaking-change detection harder.",
+		GoodExample: `// orders/v1/orders.proto
+rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);
+
+message GetOrderV1Request {}`,
+		BadExample: `// orders/v1/orders.proto
+import "orders/v1/common.proto";
+
+rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);`,
+		Options: []RuleOption{
+			{
+				Name: "method_io_colocated.policy",
+				Description: "Where messages may be defined: same_file (default), same_directory " +
+					"or messages_file, which also allows the sibling file <service file>_messages.proto.",
+			},
+		},
+	},
+	{
+		Name:     NoExtensions,
+		Category: RuleCategoryStructure,
+		Description: "Checks if extensions and extension ranges are absent " +
+			"when the extension policy forbids them.",
+		Rationale: "Extensions are a proto2 feature poorly supported by JSON mappings " +
+			"and many code generators.",
+		GoodExample: `message Order {
+  map<string, string> labels = 1;
+}`,
+		BadExample: `extend Order {
+  optional string partner = 100;
+}`,
+		Options: []RuleOption{
+			{
+				Name:        "extension_policy",
+				Description: "The check reports only if the policy is `forbid`, the default policy is `document`.",
+			},
+		},
+	},
+	{
+		Name:        ExtensionRangeDocumented,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if extension ranges have leading comments.",
+		Rationale:   "Extension ranges are contracts with third parties, who need to know what the numbers are reserved for.",
+		GoodExample: `message Order {
+  // Reserved for partner extensions.
+  extensions 100 to 199;
+}`,
+		BadExample: `message Order {
+  extensions 100 to 199;
+}`,
+	},
+	{
+		Name:     ServiceHasDefaultHost,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if a service has valid google.api.default_host and google.api.oauth_scopes options " +
+			"when they are required.",
+		Rationale: "API gateways and generated Google-style clients take the host and OAuth scopes " +
+			"from these options.",
+		GoodExample: `service OrderService {
+  option (google.api.default_host) = "orders.example.com";
+  option (google.api.oauth_scopes) = "https://example.com/auth/orders";
+}`,
+		BadExample: `service OrderService {
+  option (google.api.default_host) = "https://orders.example.com/v1";
+}`,
+		Options: []RuleOption{
+			{
+				Name:        "google_api_service_options",
+				Description: "The check reports only if the value is `required`.",
+			},
+		},
+	},
+	{
+		Name:     OptionNoEnvironmentURLs,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if string option values, including nested values of options like swagger external_docs, " +
+			"don't match environment-specific patterns like `localhost` or `staging.`.",
+		Rationale: "Option values leak into published OpenAPI documents and generated clients, " +
+			"where staging and internal hosts are useless for consumers and expose the infrastructure.",
+		GoodExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_swagger) = {
+  host: "api.example.com"
+};`,
+		BadExample: `option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_swagger) = {
+  host: "staging.api.example.com"
+};`,
+		Options: []RuleOption{
+			{
+				Name: "option_no_environment_urls.patterns",
+				Description: "Regular expressions of forbidden values matched case-insensitively, " +
+					"`localhost`, `127.0.0.1`, `0.0.0.0`, `staging.`, `.internal` and `.local` by default.",
+			},
+		},
+	},
+	{
+		Name:        MethodHasIdempotencyLevel,
+		Category:    RuleCategoryHTTP,
+		Description: "Checks if a method has the idempotency_level option, NO_SIDE_EFFECTS for methods mapped to HTTP GET.",
+		Rationale: "Clients and proxies retry and cache calls based on the idempotency level, " +
+			"and a GET request must never change anything.",
+		GoodExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option idempotency_level = NO_SIDE_EFFECTS;
+  option (google.api.http) = {get: "/v1/orders/{id}"};
+}`,
+		BadExample: `rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response) {
+  option idempotency_level = IDEMPOTENT;
+  option (google.api.http) = {get: "/v1/orders/{id}"};
+}`,
+	},
+	{
+		Name:        HTTPPathMaxDepth,
+		Category:    RuleCategoryHTTP,
+		Description: "Checks if an HTTP path doesn't have more segments than configured, not counting the version prefix.",
+		Rationale: "Deeply nested resources are hard to address and usually mean " +
+			"that a part of the hierarchy should be a separate collection.",
+		GoodExample: `option (google.api.http) = {get: "/v1/orders/{order_id}/items/{item_id}"};`,
+		BadExample:  `option (google.api.http) = {get: "/v1/shops/{shop_id}/orders/{order_id}/items/{item_id}/taxes"};`,
+		Options: []RuleOption{
+			{
+				Name:        "http_path_max_depth.max_segments",
+				Description: "Maximum number of segments in a path, 6 by default.",
+			},
+		},
+	},
+	{
+		Name:     HTTPPathResourceStyle,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if an HTTP path alternates collections and resource identifier variables " +
+			"after the version prefix.",
+		Rationale: "Paths like /orders/{order_id}/items follow REST resource naming guidelines, " +
+			"while verbs and adjacent variables in paths make the API unpredictable.",
+		GoodExample: `option (google.api.http) = {get: "/v1/orders/{order_id}/items"};`,
+		BadExample:  `option (google.api.http) = {get: "/v1/orders/{order_id}/items/list"};`,
+	},
+	{
+		Name:     MethodGetRequestFieldsBindable,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if request fields of a method mapped to HTTP GET that aren't bound to the path " +
+			"can be bound from query parameters: maps, messages and repeated messages can't, " +
+			"except well-known types like google.protobuf.Timestamp.",
+		Rationale: "grpc-gateway can't fill such fields from the query string, " +
+			"so HTTP clients can't set them at all.",
+		GoodExample: `message ListOrdersV1Request {
+  repeated string statuses = 1;
+  google.protobuf.Timestamp created_after = 2;
+}`,
+		BadExample: `message ListOrdersV1Request {
+  map<string, string> labels = 1;
+  OrderFilter filter = 2;
+}`,
+	},
+	{
+		Name:     MethodGetRequestNoOneof,
+		Category: RuleCategoryHTTP,
+		Description: "Checks if the request of a method mapped to HTTP GET doesn't contain oneofs, " +
+			"proto3 optional fields are allowed.",
+		Rationale: "grpc-gateway doesn't define which field of a oneof wins when several of them are passed " +
+			"in the query string, so the behavior of such requests is undefined.",
+		GoodExample: `message ListOrdersV1Request {
+  optional string status = 1;
+}`,
+		BadExample: `message ListOrdersV1Request {
+  oneof filter {
+    string status = 1;
+    string customer_id = 2;
+  }
+}`,
+	},
+	{
+		Name:        MethodIOSamePackage,
+		Category:    RuleCategoryDependencies,
+		Description: "Checks if method input and output messages are defined in the package of the service.",
+		Rationale: "Messages owned by another package can be changed by another team without reviewing " +
+			"the methods using them. The check can be reported as a warning while such methods are migrated.",
+		GoodExample: `package orders.v1;
+
+rpc GetOrderV1(GetOrderV1Request) returns (GetOrderV1Response);`,
+		BadExample: `package orders.v1;
+
+rpc GetPaymentV1(GetPaymentV1Request) returns (payments.v1.GetPaymentV1Response);`,
+		Options: []RuleOption{
+			{
+				Name: "method_io_same_package.allowed_packages",
+				Description: "Package prefixes of shared types methods may use from any package, " +
+					"google.protobuf is always allowed.",
+			},
+		},
+	},
+	{
+		Name:     ImportBoundaries,
+		Category: RuleCategoryDependencies,
+		Description: "Checks if a file doesn't import files or reference types of packages " +
+			"forbidden for its package by the import boundaries.",
+		Rationale: "Import boundaries enforce architectural layering, " +
+			"e.g. keep internal packages of a domain from leaking into the contracts of other domains.",
+		GoodExample: `package payments.v1;
+
+import "orders/v1/order.proto";`,
+		BadExample: `package payments.v1;
+
+import "orders/internal/v1/audit.proto";`,
+		Options: []RuleOption{
+			{
+				Name: "import_boundaries",
+				Description: "List of rules with a `from` package pattern and `forbid` package patterns, " +
+					"a pattern ending with `.*` matches the package and all packages nested in it.",
+			},
+		},
+	},
+	{
+		Name:     NoDeprecatedImports,
+		Category: RuleCategoryDependencies,
+		Description: "Checks if a file doesn't import packages or files listed as deprecated, " +
+			"nor references their types.",
+		Rationale: "Dependencies on deprecated trees like v1beta packages block their removal, " +
+			"findings suggest the configured replacement.",
+		GoodExample: `import "orders/v1/order.proto";`,
+		BadExample:  `import "orders/v1beta/order.proto";`,
+		Options: []RuleOption{
+			{
+				Name: "deprecated_imports",
+				Description: "List of deprecated packages and files with a `package` pattern, " +
+					"a `path` prefix and an optional `replacement` suggested by findings.",
+			},
+		},
+	},
+	{
+		Name:     GoPackageMatchesModule,
+		Category: RuleCategoryDependencies,
+		Description: "Checks if the `go_package` option of a file pointing into a local Go module " +
+			"names an existing directory, and that Go files already there declare the same package.",
+		Rationale: "A go_package pointing to a non-existent or foreign directory makes protoc-gen-go " +
+			"write generated code where nothing imports it, or mix it with another package.",
+		GoodExample: `option go_package = "example.com/shop/gen/orders/v1;ordersv1";`,
+		BadExample:  `option go_package = "example.com/shop/gen/order/v1;ordersv1";`,
+		Options: []RuleOption{
+			{
+				Name: "go_package_directories",
+				Description: "The check reports only if the value is `required`, " +
+					"modules are detected from go.mod files or set by `module_name`.",
+			},
+		},
+	},
+	{
+		Name:        CommentNotTrivial,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if a field or enum value comment doesn't merely restate its name.",
+		Rationale: "Comments like \"Order id.\" on order_id satisfy documentation checks " +
+			"without telling API consumers anything new.",
+		GoodExample: `// Identifier of the order assigned by the billing system.
+string order_id = 1;`,
+		BadExample: `// Order id.
+string order_id = 1;`,
+		Options: []RuleOption{
+			{
+				Name: "comment_not_trivial.strictness",
+				Description: "`exact` (default) flags comments consisting of exactly the words of the name, " +
+					"optionally without the enum name prefix for enum values; " +
+					"`loose` flags comments having no words except the words of the name and articles.",
+			},
+		},
+	},
+	{
+		Name:        FileHasHeader,
+		Category:    RuleCategoryDocumentation,
+		Description: "Checks if the first comment block of a file starts with the configured header, such as a copyright notice.",
+		Rationale: "Corporate policies often require every source file to carry a copyright or license header, " +
+			"which is easy to forget in new files.",
+		GoodExample: `// Copyright 2024 Acme Corp. All rights reserved.
+
+syntax = "proto3";`,
+		BadExample: `syntax = "proto3";`,
+		Options: []RuleOption{
+			{
+				Name: "file_has_header.template",
+				Description: "Text the header must start with, `{year}` matches a year or a range of years " +
+					"like 2020-2024. The check is skipped unless the template is set.",
+			},
+		},
+	},
+	{
+		Name:     FileElementOrder,
+		Category: RuleCategoryStructure,
+		Description: "Checks if the elements of a file follow the canonical order: syntax, package, " +
+			"imports sorted by path, options and then definitions.",
+		Rationale: "A fixed layout makes files easy to scan and keeps diffs of imports and options small, " +
+			"the syntax statement is required to be the first one by the compiler itself.",
+		GoodExample: `syntax = "proto3";
+
+package orders.v1;
+
+import "google/protobuf/duration.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/orders/v1";
+
+message Order {}`,
+		BadExample: `syntax = "proto3";
+
+package orders.v1;
+
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/duration.proto";
+
+message Order {}
+
+option go_package = "example.com/orders/v1";`,
+	},
+	{
+		Name:     StyleMaxLineLength,
+		Category: RuleCategoryStructure,
+		Description: "Checks if lines of a file don't exceed the configured number of characters, " +
+			"the finding points to the first character beyond the limit.",
+		Rationale: "Long lines are hard to read in reviews and side-by-side diffs, " +
+			"a length limit is a basic style gate for teams not ready to adopt the formatter.",
+		GoodExample: `// Identifier of the order assigned by the billing system.
+string id = 1;`,
+		BadExample: `string id = 1; // Identifier of the order assigned by the billing system when the order is paid by the customer.`,
+		Options: []RuleOption{
+			{
+				Name:        "style_max_line_length.max_length",
+				Description: "Maximum number of characters in a line, tabs count as the indentation width. Default is 120.",
+			},
+		},
+	},
+	{
+		Name:     StyleIndentation,
+		Category: RuleCategoryStructure,
+		Description: "Checks if lines of a file are indented with the configured characters and, " +
+			"for spaces, by a multiple of the configured width. Continuation lines of block comments aren't checked.",
+		Rationale: "Mixed tabs and spaces render differently in editors and review tools, " +
+			"consistent indentation keeps the nesting of definitions readable.",
+		GoodExample: `message Order {
+  string id = 1;
+}`,
+		BadExample: `message Order {
+   string id = 1;
+}`,
+		Options: []RuleOption{
+			{
+				Name:        "style_indentation.style",
+				Description: "`spaces` (default) or `tabs`.",
+			},
+			{
+				Name:        "style_indentation.width",
+				Description: "Number of spaces in a level of indentation, also the width of a tab. Default is 2.",
+			},
+		},
+	},
+}
+
+// retiredRules is a list of checks removed from the linter, kept so configurations mentioning them
+// don't break and "config validate" can suggest replacements.
+var retiredRules = []*RetiredRule{}
+
+// Rules returns metadata of all checks known to the linter in the order they are documented.
+func Rules() []*Rule {
+	result := make([]*Rule, len(registeredRules))
+	copy(result, registeredRules)
+
+	return result
+}
@@ -0,0 +1,103 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	rulesDocsIndexName = "README.md"
+	rulesDocsFileMode  = 0o644
+	rulesDocsDirMode   = 0o755
+)
+
+var (
+	ruleDocTemplate = template.Must(template.New("rule").Parse(`# {{ .Name }}
+
+{{ .Description }}
+
+Category: ` + "`{{ .Category }}`" + `
+
+## Rationale
+
+{{ .Rationale }}
+
+## Examples
+
+Good:
+
+` + "```proto" + `
+{{ .GoodExample }}
+` + "```" + `
+
+Bad:
+
+` + "```proto" + `
+{{ .BadExample }}
+` + "```" + `
+
+## Configuration
+{{ if .Options }}
+{{ range .Options }}- ` + "`{{ .Name }}`" + `: {{ .Description }}
+{{ end }}{{ end }}
+The check can be disabled by adding it to the ` + "`excluded_checks`" + ` section of the configuration file:
+
+` + "```yaml" + `
+excluded_checks:
+  - {{ .Name }}
+` + "```" + `
+{{ if .Aliases }}
+Former names of the check, still accepted with a deprecation warning:
+{{ range .Aliases }}
+- ` + "`{{ . }}`" + `{{ end }}
+{{ end }}`))
+
+	rulesIndexTemplate = template.Must(template.New("index").Parse(`# Checks
+
+This documentation is generated by ` + "`protolinter rules docs`" + `, do not edit it manually.
+
+{{ range . }}- [{{ .Name }}]({{ .Name }}.md): {{ .Description }}
+{{ end }}`))
+)
+
+// generateRulesDocs writes one Markdown page per rule and an index page into the output directory.
+func generateRulesDocs(outputDir string, rules []*Rule) ([]string, error) {
+	if err := os.MkdirAll(outputDir, rulesDocsDirMode); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", outputDir, err)
+	}
+
+	result := make([]string, 0, len(rules)+1)
+
+	for _, rule := range rules {
+		fileName := filepath.Join(outputDir, strings.Join([]string{rule.Name, ".md"}, ""))
+		if err := renderTemplateToFile(fileName, ruleDocTemplate, rule); err != nil {
+			return nil, err
+		}
+
+		result = append(result, fileName)
+	}
+
+	indexFileName := filepath.Join(outputDir, rulesDocsIndexName)
+	if err := renderTemplateToFile(indexFileName, rulesIndexTemplate, rules); err != nil {
+		return nil, err
+	}
+
+	return append(result, indexFileName), nil
+}
+
+func renderTemplateToFile(fileName string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", fileName, err)
+	}
+
+	if err := os.WriteFile(fileName, buf.Bytes(), rulesDocsFileMode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateRulesDocs(t *testing.T) {
+	var (
+		outputDir = filepath.Join(t.TempDir(), "docs", "rules")
+		rules     = Rules()
+	)
+
+	files, err := generateRulesDocs(outputDir, rules)
+	if err != nil {
+		t.Fatalf("failed to generate docs: %s", err.Error())
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("failed to read docs directory: %s", err.Error())
+	}
+
+	// One page per registered rule and the index.
+	if len(files) != len(rules)+1 || len(entries) != len(rules)+1 {
+		t.Fatalf("expected %d pages, got %d reported and %d written", len(rules)+1, len(files), len(entries))
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, rulesDocsIndexName))
+	if err != nil {
+		t.Fatalf("failed to read index: %s", err.Error())
+	}
+
+	for _, rule := range rules {
+		data, err := os.ReadFile(filepath.Join(outputDir, rule.Name+".md"))
+		if err != nil {
+			t.Errorf("failed to read page of %s: %s", rule.Name, err.Error())
+
+			continue
+		}
+
+		var (
+			page     = string(data)
+			expected = []string{
+				"# " + rule.Name + "\n",
+				rule.Description,
+				"Category: `" + rule.Category + "`",
+				rule.GoodExample,
+				rule.BadExample,
+				"  - " + rule.Name + "\n",
+			}
+		)
+
+		for _, option := range rule.Options {
+			expected = append(expected, "- `"+option.Name+"`: "+option.Description)
+		}
+
+		for _, alias := range rule.Aliases {
+			expected = append(expected, "- `"+alias+"`")
+		}
+
+		for _, fragment := range expected {
+			if !strings.Contains(page, fragment) {
+				t.Errorf("expected page of %s to contain %q", rule.Name, fragment)
+			}
+		}
+
+		if link := "- [" + rule.Name + "](" + rule.Name + ".md)"; !strings.Contains(string(index), link) {
+			t.Errorf("expected index to link %s", rule.Name)
+		}
+	}
+}
+
+func TestGenerateRulesDocsPage(t *testing.T) {
+	var (
+		outputDir = t.TempDir()
+		rule      = &Rule{
+			Name:        "message_sample",
+			Description: "Checks samples.",
+			Category:    "naming",
+			Rationale:   "Samples matter.",
+			GoodExample: "message Sample {}",
+			BadExample:  "message sample {}",
+			Options:     []RuleOption{{Name: "message_sample.style", Description: "Style of samples."}},
+			Aliases:     []string{"sample_message"},
+		}
+	)
+
+	if _, err := generateRulesDocs(outputDir, []*Rule{rule}); err != nil {
+		t.Fatalf("failed to generate docs: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "message_sample.md"))
+	if err != nil {
+		t.Fatalf("failed to read page: %s", err.Error())
+	}
+
+	expected := strings.Join([]string{
+		"# message_sample",
+		"",
+		"Checks samples.",
+		"",
+		"Category: `naming`",
+		"",
+		"## Rationale",
+		"",
+		"Samples matter.",
+		"",
+		"## Examples",
+		"",
+		"Good:",
+		"",
+		"```proto",
+		"message Sample {}",
+		"```",
+		"",
+		"Bad:",
+		"",
+		"```proto",
+		"message sample {}",
+		"```",
+		"",
+		"## Configuration",
+		"",
+		"- `message_sample.style`: Style of samples.",
+		"",
+		"The check can be disabled by adding it to the `excluded_checks` section of the configuration file:",
+		"",
+		"```yaml",
+		"excluded_checks:",
+		"  - message_sample",
+		"```",
+		"",
+		"Former names of the check, still accepted with a deprecation warning:",
+		"",
+		"- `sample_message`",
+		"",
+	}, "\n")
+
+	if string(data) != expected {
+		t.Errorf("expected page:\n%s\ngot:\n%s", expected, string(data))
+	}
+}
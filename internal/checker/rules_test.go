@@ -0,0 +1,11 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/ruletest"
+)
+
+func TestRules(t *testing.T) {
+	ruletest.Run(t, "testdata")
+}
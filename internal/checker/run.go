@@ -0,0 +1,180 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// Statuses of the tasks executed by the "run" subcommand.
+const (
+	TaskStatusPassed  = "passed"
+	TaskStatusFailed  = "failed"
+	TaskStatusSkipped = "skipped"
+)
+
+// RunTasks executes the tasks on the provided protobuf files in order and returns their combined report.
+// The files are compiled once and shared by all tasks, the ones after a failed compilation are skipped.
+func (c *ProtoChecker) RunTasks(ctx context.Context, tasks []string, files ...string) (*RunReport, error) {
+	if compileCacheFromContext(ctx) == nil {
+		ctx = WithCompileCache(ctx, NewCompileCache())
+	}
+
+	var (
+		result       = new(RunReport)
+		checkResults []*CheckResult
+		isCompiled   = true
+	)
+
+	// Linting and scoring share the check results, so the checks are performed once.
+	checkFiles := func() ([]*CheckResult, error) {
+		if checkResults != nil {
+			return checkResults, nil
+		}
+
+		results, err := c.CheckFiles(ctx, files...)
+		if err != nil {
+			return nil, err
+		}
+
+		checkResults = results
+
+		return checkResults, nil
+	}
+
+	for _, task := range tasks {
+		taskReport := &TaskReport{
+			Name:   task,
+			Status: TaskStatusPassed,
+		}
+
+		result.Tasks = append(result.Tasks, taskReport)
+
+		if !isCompiled {
+			taskReport.Status = TaskStatusSkipped
+
+			continue
+		}
+
+		switch task {
+		case config.TaskCompile:
+			findings, err := c.CompileFiles(ctx, files...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile files: %w", err)
+			}
+
+			taskReport.Report = newFindingsReport(findings)
+
+			for _, finding := range findings {
+				if finding.Severity == config.SeverityError {
+					taskReport.Status = TaskStatusFailed
+					isCompiled = false
+
+					break
+				}
+			}
+		case config.TaskLint:
+			results, err := checkFiles()
+			if err != nil {
+				return nil, fmt.Errorf("failed to perform checks on files: %w", err)
+			}
+
+			taskReport.Report = NewCheckReport(results, "")
+
+			if isRunFailed(results) {
+				taskReport.Status = TaskStatusFailed
+			}
+		case config.TaskScore:
+			results, err := checkFiles()
+			if err != nil {
+				return nil, fmt.Errorf("failed to perform checks on files: %w", err)
+			}
+
+			taskReport.Report = NewScoreReport(results, c.config)
+		case config.TaskCoverage:
+			report, err := c.CollectCoverage(ctx, files...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to collect coverage of checks: %w", err)
+			}
+
+			taskReport.Report = report
+		case config.TaskBreaking:
+			findings, err := c.FindBreakingChanges(ctx, files...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find breaking changes: %w", err)
+			}
+
+			taskReport.Report = newFindingsReport(findings)
+
+			if len(findings) > 0 {
+				taskReport.Status = TaskStatusFailed
+			}
+		case config.TaskOpenAPI:
+			report, err := c.GenerateOpenAPI(ctx, files...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate OpenAPI documents: %w", err)
+			}
+
+			taskReport.Report = report
+
+			if !report.IsPassed() {
+				taskReport.Status = TaskStatusFailed
+			}
+		default:
+			return nil, fmt.Errorf("unknown task %s", task)
+		}
+	}
+
+	return result, nil
+}
+
+// IsPassed returns true if none of the tasks failed.
+func (r *RunReport) IsPassed() bool {
+	for _, task := range r.Tasks {
+		if task.Status == TaskStatusFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WriteText writes the report of every task to the writer, preceded by a header with its name and status.
+func (r *RunReport) WriteText(w io.Writer) error {
+	for i, task := range r.Tasks {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "== %s: %s ==\n", task.Name, task.Status); err != nil {
+			return err
+		}
+
+		if task.Report == nil {
+			continue
+		}
+
+		if err := task.Report.WriteText(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the report to the writer in JSON format.
+func (r *RunReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestRunTasks(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		validFile  = filepath.Join(dir, "orders.proto")
+		brokenFile = filepath.Join(dir, "broken.proto")
+	)
+
+	validSource := "syntax = \"proto3\";\n\npackage orders;\n\nmessage Order {}\n"
+	if err := os.WriteFile(validFile, []byte(validSource), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	if err := os.WriteFile(brokenFile, []byte("syntax = \"proto3\";\n\nmessage Order {\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	var (
+		ctx   = context.Background()
+		tasks = []string{config.TaskCompile, config.TaskLint, config.TaskScore, config.TaskCoverage}
+	)
+
+	report, err := NewProtoChecker(ctx, nil).RunTasks(ctx, tasks, validFile)
+	if err != nil {
+		t.Fatalf("failed to run tasks: %s", err.Error())
+	}
+
+	if len(report.Tasks) != len(tasks) {
+		t.Fatalf("got %d task reports, want %d", len(report.Tasks), len(tasks))
+	}
+
+	for i, task := range report.Tasks {
+		if task.Name != tasks[i] || task.Report == nil {
+			t.Errorf("task %d: got %s without report %t, want %s", i, task.Name, task.Report == nil, tasks[i])
+		}
+	}
+
+	if report.Tasks[0].Status != TaskStatusPassed {
+		t.Errorf("compile: got status %s, want %s", report.Tasks[0].Status, TaskStatusPassed)
+	}
+
+	report, err = NewProtoChecker(ctx, nil).RunTasks(ctx, tasks, brokenFile)
+	if err != nil {
+		t.Fatalf("failed to run tasks: %s", err.Error())
+	}
+
+	if report.IsPassed() {
+		t.Error("run with a broken file passed")
+	}
+
+	expectedStatuses := []string{TaskStatusFailed, TaskStatusSkipped, TaskStatusSkipped, TaskStatusSkipped}
+	for i, task := range report.Tasks {
+		if task.Status != expectedStatuses[i] {
+			t.Errorf("%s: got status %s, want %s", task.Name, task.Status, expectedStatuses[i])
+		}
+	}
+}
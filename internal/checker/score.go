@@ -0,0 +1,144 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+const (
+	maxScore           = 100
+	noPackageScoreName = "(no package)"
+)
+
+// NewScoreReport scores the packages of the checked files and ranks them from the best score to the worst.
+// The penalty of a finding is the product of the weights of its severity and the category of its check,
+// the score of a package is the share of its descriptors not covered by penalties, from 0 to 100.
+func NewScoreReport(results []*CheckResult, cfg *config.Config) *ScoreReport {
+	var (
+		packages   []*PackageScore
+		index      = make(map[string]*PackageScore)
+		categories = make(map[string]string, len(registeredRules))
+	)
+
+	for _, rule := range registeredRules {
+		categories[rule.Name] = rule.Category
+	}
+
+	for _, cr := range results {
+		packageName := string(cr.File.Package())
+		if packageName == "" {
+			packageName = noPackageScoreName
+		}
+
+		score, ok := index[packageName]
+		if !ok {
+			score = &PackageScore{
+				Package:    packageName,
+				Categories: make(map[string]int),
+			}
+
+			index[packageName] = score
+			packages = append(packages, score)
+		}
+
+		score.Descriptors += newDescriptorMetrics(cr.File).total()
+
+		for _, finding := range cr.Findings {
+			category := categories[finding.Check]
+
+			score.Categories[category]++
+			score.Penalty += cfg.GetSeverityWeight(finding.Severity) * cfg.GetCategoryWeight(category)
+
+			if finding.Severity == config.SeverityWarning {
+				score.Warnings++
+			} else {
+				score.Errors++
+			}
+		}
+	}
+
+	for _, score := range packages {
+		score.Score = calculateScore(score.Penalty, score.Descriptors)
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].Score != packages[j].Score {
+			return packages[i].Score > packages[j].Score
+		}
+
+		return packages[i].Package < packages[j].Package
+	})
+
+	for i, score := range packages {
+		score.Rank = i + 1
+	}
+
+	if packages == nil {
+		packages = []*PackageScore{}
+	}
+
+	return &ScoreReport{
+		Packages: packages,
+	}
+}
+
+// calculateScore returns the share of the descriptors not covered by the penalty, from 0 to 100,
+// rounded to two decimal places. A package without descriptors scores 100 only if it has no penalty.
+func calculateScore(penalty float64, descriptors int) float64 {
+	if descriptors == 0 {
+		if penalty > 0 {
+			return 0
+		}
+
+		return maxScore
+	}
+
+	score := maxScore * (1 - penalty/float64(descriptors))
+	if score < 0 {
+		return 0
+	}
+
+	return math.Round(score*100) / 100 //nolint: gomnd // Two decimal places.
+}
+
+// total returns the number of all counted descriptors.
+func (m *DescriptorMetrics) total() int {
+	return m.Services + m.Methods + m.Messages + m.Fields + m.Enums + m.EnumValues
+}
+
+// WriteText writes the ranked packages to the writer as a table.
+func (r *ScoreReport) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+
+	fmt.Fprintln(writer, "RANK\tPACKAGE\tSCORE\tDESCRIPTORS\tERRORS\tWARNINGS")
+
+	for _, score := range r.Packages {
+		fmt.Fprintf(writer, "%d\t%s\t%.2f\t%d\t%d\t%d\n",
+			score.Rank,
+			score.Package,
+			score.Score,
+			score.Descriptors,
+			score.Errors,
+			score.Warnings)
+	}
+
+	return writer.Flush()
+}
+
+// WriteJSON writes the ranked packages to the writer in JSON format.
+func (r *ScoreReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode score report: %w", err)
+	}
+
+	return nil
+}
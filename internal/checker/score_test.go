@@ -0,0 +1,54 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestCalculateScore(t *testing.T) {
+	testCases := []struct {
+		penalty     float64
+		descriptors int
+		expected    float64
+	}{
+		{0, 10, 100},
+		{1, 10, 90},
+		{0.5, 3, 83.33},
+		{12, 10, 0},
+		{0, 0, 100},
+		{1, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		if actual := calculateScore(tc.penalty, tc.descriptors); actual != tc.expected {
+			t.Errorf("penalty %g, descriptors %d: expected score %g, got %g",
+				tc.penalty,
+				tc.descriptors,
+				tc.expected,
+				actual)
+		}
+	}
+}
+
+func TestFindUnknownCategories(t *testing.T) {
+	cfg := &config.Config{
+		Score: config.ScoreOptions{
+			CategoryWeights: map[string]float64{
+				RuleCategoryDocumentation: 2,
+				"docs":                    1,
+			},
+		},
+	}
+
+	problems := findUnknownCategories(cfg)
+	if len(problems) != 1 || problems[0].Message != "score.category_weights: unknown category docs" {
+		t.Errorf("expected unknown category docs to be reported, got %v", problems)
+	}
+
+	for _, rule := range registeredRules {
+		if rule.Category == "" {
+			t.Errorf("check %s has no category", rule.Name)
+		}
+	}
+}
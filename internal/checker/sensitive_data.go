@@ -0,0 +1,134 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// debugRedactOptionName is the name of the standard field option redacting the field in debug output.
+const debugRedactOptionName = "debug_redact"
+
+// checkSensitiveData checks that a field whose name looks like it holds sensitive data
+// is marked by debug_redact or one of the configured sensitivity options.
+func (c *ProtoChecker) checkSensitiveData(
+	field protoreflect.FieldDescriptor,
+	result *CheckResult,
+	fieldLogName string,
+) {
+	c.runRule(FieldSensitiveDataAnnotation, field, func() {
+		sensitivityOptions := c.config.GetSensitiveDataOptions()
+
+		if !matchesAnyPattern(string(field.Name()), c.config.GetSensitiveDataPatterns()) ||
+			hasDebugRedact(field) ||
+			hasFieldOption(field, sensitivityOptions) {
+			return
+		}
+
+		options := []string{debugRedactOptionName}
+		for _, option := range sensitivityOptions {
+			options = append(options, strings.Trim(option, "()"))
+		}
+
+		result.AddFindingf(
+			FieldSensitiveDataAnnotation,
+			field,
+			"Field %s looks like it holds sensitive data, but isn't marked by any of the options: %s",
+			fieldLogName,
+			strings.Join(options, ", "))
+	})
+}
+
+// checkPIIDebugRedact checks that a field holding personal data, according to its name or annotations,
+// is marked by debug_redact, and in the strict mode that fields which can't hold personal data aren't.
+func (c *ProtoChecker) checkPIIDebugRedact(
+	field protoreflect.FieldDescriptor,
+	result *CheckResult,
+	fieldLogName string,
+) {
+	c.runRule(FieldPIIDebugRedact, field, func() {
+		var (
+			name           = string(field.Name())
+			isRedacted     = hasDebugRedact(field)
+			isPersonalData = matchesAnyPattern(name, c.config.GetPIIPatterns()) ||
+				hasFieldOption(field, c.config.GetPIIAnnotations())
+		)
+
+		switch {
+		case isPersonalData && !isRedacted:
+			result.AddFindingf(
+				FieldPIIDebugRedact,
+				field,
+				"Field %s holds personal data, but isn't marked by debug_redact",
+				fieldLogName)
+		case !isPersonalData && isRedacted && c.config.IsPIIDebugRedactStrict() &&
+			(field.Kind() == protoreflect.BoolKind ||
+				field.Kind() == protoreflect.EnumKind ||
+				matchesAnyPattern(name, c.config.GetNonSensitivePatterns())):
+			result.AddFindingf(
+				FieldPIIDebugRedact,
+				field,
+				"Field %s is marked by debug_redact, but can't hold personal data",
+				fieldLogName)
+		}
+	})
+}
+
+// matchesAnyPattern returns true if the name matches one of the patterns.
+func matchesAnyPattern(name string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasDebugRedact returns true if the debug_redact option of the field is set to true.
+func hasDebugRedact(field protoreflect.FieldDescriptor) bool {
+	var result bool
+
+	field.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if !fd.IsExtension() && fd.Name() == debugRedactOptionName {
+				result = v.Bool()
+
+				return false
+			}
+
+			return true
+		})
+
+	return result
+}
+
+// hasFieldOption returns true if one of the options, specified by full names optionally in parentheses,
+// is set on the field. Boolean options set to false don't count.
+func hasFieldOption(field protoreflect.FieldDescriptor, options []string) bool {
+	if len(options) == 0 {
+		return false
+	}
+
+	var result bool
+
+	field.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			for _, option := range options {
+				if string(fd.FullName()) != strings.Trim(option, "()") {
+					continue
+				}
+
+				if fd.Kind() != protoreflect.BoolKind || v.Bool() {
+					result = true
+
+					return false
+				}
+			}
+
+			return true
+		})
+
+	return result
+}
@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	googleAPIDefaultHostOption = "google.api.default_host"
+	googleAPIOAuthScopesOption = "google.api.oauth_scopes"
+	oauthScopesSeparator       = ","
+)
+
+// checkServiceOptions checks google.api.default_host and google.api.oauth_scopes service options
+// if they are required by the configuration.
+func (c *ProtoChecker) checkServiceOptions(
+	service protoreflect.ServiceDescriptor,
+	result *CheckResult,
+	serviceName string,
+) {
+	if c.config.IsCheckExcluded(ServiceHasDefaultHost) || !c.config.AreGoogleAPIServiceOptionsRequired() {
+		return
+	}
+
+	defer c.startRule(ServiceHasDefaultHost, service)()
+
+	var defaultHost, oauthScopes string
+
+	service.Options().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			switch string(fd.FullName()) {
+			case googleAPIDefaultHostOption:
+				defaultHost = strings.TrimSpace(v.String())
+			case googleAPIOAuthScopesOption:
+				oauthScopes = strings.TrimSpace(v.String())
+			}
+
+			return true
+		})
+
+	switch {
+	case defaultHost == "":
+		result.AddFindingf(
+			ServiceHasDefaultHost,
+			service,
+			"Service %s doesn't have option %s",
+			serviceName,
+			googleAPIDefaultHostOption)
+	case !isValidHost(defaultHost):
+		result.AddFindingf(
+			ServiceHasDefaultHost,
+			service,
+			"Option %s of service %s must be a host name without scheme and path, got %q",
+			googleAPIDefaultHostOption,
+			serviceName,
+			defaultHost)
+	}
+
+	if oauthScopes == "" {
+		result.AddFindingf(
+			ServiceHasDefaultHost,
+			service,
+			"Service %s doesn't have option %s",
+			serviceName,
+			googleAPIOAuthScopesOption)
+
+		return
+	}
+
+	for _, scope := range strings.Split(oauthScopes, oauthScopesSeparator) {
+		scope = strings.TrimSpace(scope)
+
+		parsedScope, err := url.Parse(scope)
+		if err != nil || parsedScope.Scheme != "https" || parsedScope.Host == "" {
+			result.AddFindingf(
+				ServiceHasDefaultHost,
+				service,
+				"Option %s of service %s must be a comma-separated list of HTTPS URLs, got %q",
+				googleAPIOAuthScopesOption,
+				serviceName,
+				scope)
+		}
+	}
+}
+
+// isValidHost returns true if the value is a host name, optionally with a port.
+func isValidHost(value string) bool {
+	parsedURL, err := url.Parse(strings.Join([]string{"https://", value}, ""))
+
+	return err == nil &&
+		parsedURL.Host == value &&
+		parsedURL.Hostname() != "" &&
+		!strings.ContainsAny(value, "/ ")
+}
@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkServiceMaxMethods checks that the service doesn't have more methods than configured,
+// since monolithic services are hard to version for gateways and clients.
+func (c *ProtoChecker) checkServiceMaxMethods(
+	service protoreflect.ServiceDescriptor,
+	result *CheckResult,
+	serviceName string,
+) {
+	c.runRule(ServiceMaxMethods, service, func() {
+		if count, maxMethods := service.Methods().Len(), c.config.GetServiceMaxMethods(); count > maxMethods {
+			result.AddFindingf(
+				ServiceMaxMethods,
+				service,
+				"Service %s has %d methods, the maximum is %d",
+				serviceName,
+				count,
+				maxMethods)
+		}
+	})
+}
+
+// checkPackageMaxServices checks that the package of the file doesn't declare more services than configured
+// in the checked files. Every file of the package declaring services is reported at its package statement.
+func (c *ProtoChecker) checkPackageMaxServices(parsedFile linker.File, result *CheckResult, index *descriptorIndex) {
+	if parsedFile.Services().Len() == 0 {
+		return
+	}
+
+	c.runRule(PackageMaxServices, parsedFile, func() {
+		var (
+			packageName = parsedFile.Package()
+			count       = index.packageServices[packageName]
+			maxServices = c.config.GetPackageMaxServices()
+		)
+
+		if count <= maxServices {
+			return
+		}
+
+		result.AddFindingAtf(
+			PackageMaxServices,
+			nil,
+			parsedFile.SourceLocations().ByPath(protoreflect.SourcePath{packageFieldNumber}),
+			"Package %s has %d services, the maximum is %d",
+			packageName,
+			count,
+			maxServices)
+	})
+}
+
+// checkFileMaxLines checks that the file doesn't have more lines than configured,
+// since enormous files are usually machine-generated or dumping grounds that should be split.
+func (c *ProtoChecker) checkFileMaxLines(parsedFile linker.File, result *CheckResult) {
+	res, ok := parsedFile.(linker.Result)
+	if !ok || res.AST() == nil {
+		return
+	}
+
+	c.runRule(FileMaxLines, parsedFile, func() {
+		lines, _ := getSourceLines(res.AST())
+		if count, maxLines := len(lines), c.config.GetFileMaxLines(); count > maxLines {
+			// The file is reported at its beginning.
+			result.AddFindingAtf(
+				FileMaxLines,
+				nil,
+				protoreflect.SourceLocation{Path: protoreflect.SourcePath{}},
+				"File %s has %d lines, the maximum is %d",
+				parsedFile.Path(),
+				count,
+				maxLines)
+		}
+	})
+}
+
+// checkFileMaxMessages checks that the file doesn't declare more top-level messages than configured.
+func (c *ProtoChecker) checkFileMaxMessages(parsedFile linker.File, result *CheckResult) {
+	c.runRule(FileMaxMessages, parsedFile, func() {
+		if count, maxMessages := parsedFile.Messages().Len(), c.config.GetFileMaxMessages(); count > maxMessages {
+			// The file is reported at its beginning.
+			result.AddFindingAtf(
+				FileMaxMessages,
+				nil,
+				protoreflect.SourceLocation{Path: protoreflect.SourcePath{}},
+				"File %s declares %d messages, the maximum is %d",
+				parsedFile.Path(),
+				count,
+				maxMessages)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkStyleMaxLineLength checks that lines of the file don't exceed the configured number of characters.
+// The finding points to the first character beyond the limit.
+func (c *ProtoChecker) checkStyleMaxLineLength(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(StyleMaxLineLength) {
+		return
+	}
+
+	res, ok := parsedFile.(linker.Result)
+	if !ok || res.AST() == nil {
+		return
+	}
+
+	defer c.startRule(StyleMaxLineLength, parsedFile)()
+
+	var (
+		maxLength   = c.config.GetMaxLineLength()
+		_, tabWidth = c.config.GetIndentation()
+		lines, _    = getSourceLines(res.AST())
+	)
+
+	for i, line := range lines {
+		length, column := getLineLength(line, tabWidth, maxLength)
+		if length <= maxLength {
+			continue
+		}
+
+		result.AddFindingAtf(
+			StyleMaxLineLength,
+			nil,
+			getLineLocation(i, column),
+			"Line %d of file %s is %d characters long, exceeding the limit of %d",
+			i+1,
+			parsedFile.Path(),
+			length,
+			maxLength)
+	}
+}
+
+// checkStyleIndentation checks that lines of the file are indented with the configured characters,
+// and that indentation with spaces is a multiple of the configured width.
+// Continuation lines of block comments are aligned freely, so they aren't checked.
+func (c *ProtoChecker) checkStyleIndentation(parsedFile linker.File, result *CheckResult) {
+	if c.config.IsCheckExcluded(StyleIndentation) {
+		return
+	}
+
+	res, ok := parsedFile.(linker.Result)
+	if !ok || res.AST() == nil {
+		return
+	}
+
+	defer c.startRule(StyleIndentation, parsedFile)()
+
+	var (
+		style, width                     = c.config.GetIndentation()
+		lines, blockCommentContinuations = getSourceLines(res.AST())
+		fileLogName                      = parsedFile.Path()
+	)
+
+	for i, line := range lines {
+		if _, ok := blockCommentContinuations[i]; ok {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if indent == "" || indent == line {
+			continue
+		}
+
+		switch {
+		case style == config.IndentationTabs && strings.Contains(indent, " "):
+			result.AddFindingAtf(
+				StyleIndentation,
+				nil,
+				getLineLocation(i, strings.Index(indent, " ")),
+				"Line %d of file %s is indented with spaces instead of tabs",
+				i+1,
+				fileLogName)
+		case style == config.IndentationSpaces && strings.Contains(indent, "\t"):
+			result.AddFindingAtf(
+				StyleIndentation,
+				nil,
+				getLineLocation(i, strings.Index(indent, "\t")),
+				"Line %d of file %s is indented with tabs instead of spaces",
+				i+1,
+				fileLogName)
+		case style == config.IndentationSpaces && len(indent)%width != 0:
+			result.AddFindingAtf(
+				StyleIndentation,
+				nil,
+				getLineLocation(i, len(indent)),
+				"Line %d of file %s is indented by %d spaces, which is not a multiple of %d",
+				i+1,
+				fileLogName,
+				len(indent),
+				width)
+		}
+	}
+}
+
+// getSourceLines restores the source text of the file from its tokens and comments and returns its lines
+// along with the zero-based numbers of lines continuing multi-line block comments.
+func getSourceLines(fileNode *ast.FileNode) ([]string, map[int]struct{}) {
+	var (
+		text                      strings.Builder
+		blockCommentContinuations = make(map[int]struct{})
+		items                     = fileNode.Items()
+	)
+
+	for item, ok := items.First(); ok; item, ok = items.Next(item) {
+		info := fileNode.ItemInfo(item)
+
+		text.WriteString(info.LeadingWhitespace())
+		text.WriteString(info.RawText())
+
+		_, comment := fileNode.GetItem(item)
+		if comment.IsValid() && strings.HasPrefix(comment.RawText(), blockCommentPrefix) {
+			// Lines of source positions count from one, so the next line has the index of the current one.
+			for line := info.Start().Line; line < info.End().Line; line++ {
+				blockCommentContinuations[line] = struct{}{}
+			}
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(text.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return lines, blockCommentContinuations
+}
+
+// getLineLength returns the number of characters in the line with tabs counted as the tab width,
+// and the zero-based column of the first character beyond the limit, or -1 if the line fits.
+func getLineLength(line string, tabWidth, limit int) (int, int) {
+	var (
+		length int
+		column = -1
+	)
+
+	for i, char := range []rune(line) {
+		if char == '\t' {
+			length += tabWidth
+		} else {
+			length++
+		}
+
+		if length > limit && column < 0 {
+			column = i
+		}
+	}
+
+	return length, column
+}
+
+// getLineLocation returns the source location of the zero-based line and column,
+// used for findings about the text of the file rather than its elements.
+func getLineLocation(line, column int) protoreflect.SourceLocation {
+	return protoreflect.SourceLocation{
+		Path:        protoreflect.SourcePath{},
+		StartLine:   line,
+		StartColumn: column,
+	}
+}
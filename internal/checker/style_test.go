@@ -0,0 +1,27 @@
+package checker
+
+import (
+	"testing"
+)
+
+func TestGetLineLength(t *testing.T) {
+	tests := []struct {
+		line           string
+		limit          int
+		expectedLength int
+		expectedColumn int
+	}{
+		{"string id = 1;", 20, 14, -1},
+		{"string id = 1;", 10, 14, 10},
+		{"\tstring id = 1;", 16, 18, 13},
+		{"// Заказ", 5, 8, 5},
+	}
+
+	for _, test := range tests {
+		length, column := getLineLength(test.line, 4, test.limit)
+		if length != test.expectedLength || column != test.expectedColumn {
+			t.Errorf("getLineLength(%q) = %d, %d, expected %d, %d",
+				test.line, length, column, test.expectedLength, test.expectedColumn)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/oshokin/protolinter/internal/logger"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ruleTracer logs every evaluation of a check on a descriptor and accumulates the time spent per check.
+// All methods are no-ops on a nil receiver, so tracing can be disabled by not creating it.
+type ruleTracer struct {
+	mu    sync.Mutex
+	ctx   context.Context //nolint: containedctx // Used to write trace log lines.
+	now   func() time.Time
+	stats map[string]*ruleStats
+}
+
+// ruleStats holds the number of evaluations of a check and the wall time spent on them.
+type ruleStats struct {
+	check   string
+	runs    int
+	elapsed time.Duration
+}
+
+func newRuleTracer(ctx context.Context) *ruleTracer {
+	return newRuleTracerWithClock(ctx, time.Now)
+}
+
+// newRuleTracerWithClock creates a rule tracer measuring evaluations with the clock.
+func newRuleTracerWithClock(ctx context.Context, clock func() time.Time) *ruleTracer {
+	return &ruleTracer{
+		ctx:   ctx,
+		now:   clock,
+		stats: make(map[string]*ruleStats),
+	}
+}
+
+// runRule evaluates the check on the descriptor unless the check is excluded,
+// tracing the evaluation if rule debugging is enabled.
+func (c *ProtoChecker) runRule(check string, desc protoreflect.Descriptor, evaluate func()) {
+	if c.config.IsCheckExcluded(check) {
+		return
+	}
+
+	defer c.startRule(check, desc)()
+
+	evaluate()
+}
+
+// start records the beginning of an evaluation of the check on the descriptor,
+// the returned function records its end.
+func (t *ruleTracer) start(check string, desc protoreflect.Descriptor) func() {
+	if t == nil {
+		return func() {}
+	}
+
+	startedAt := t.now()
+
+	return func() {
+		elapsed := t.now().Sub(startedAt)
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		stats, ok := t.stats[check]
+		if !ok {
+			stats = &ruleStats{check: check}
+			t.stats[check] = stats
+		}
+
+		stats.runs++
+		stats.elapsed += elapsed
+
+		logger.InfoKV(t.ctx, "Rule evaluated",
+			"check", check,
+			"descriptor", string(desc.FullName()),
+			"elapsed", elapsed.String())
+	}
+}
+
+// finish logs the table of checks sorted by the total time spent on them.
+func (t *ruleTracer) finish() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]*ruleStats, 0, len(t.stats))
+	for _, v := range t.stats {
+		stats = append(stats, v)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].elapsed != stats[j].elapsed {
+			return stats[i].elapsed > stats[j].elapsed
+		}
+
+		return stats[i].check < stats[j].check
+	})
+
+	var (
+		sb     strings.Builder
+		writer = tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+	)
+
+	fmt.Fprintln(writer, "CHECK\tRUNS\tTOTAL\tAVERAGE")
+
+	for _, v := range stats {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n",
+			v.check,
+			v.runs,
+			v.elapsed,
+			v.elapsed/time.Duration(v.runs))
+	}
+
+	_ = writer.Flush()
+
+	logger.Infof(t.ctx, "Time spent per rule:\n%s", sb.String())
+}
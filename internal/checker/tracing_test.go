@@ -0,0 +1,138 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/apipb"
+)
+
+func TestRuleTracer(t *testing.T) {
+	var (
+		ctx, logs = newObservedContext()
+		clock     = &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+		tracer    = newRuleTracerWithClock(ctx, clock.Now)
+		messages  = apipb.File_google_protobuf_api_proto.Messages()
+	)
+
+	evaluate := func(check string, elapsed time.Duration) {
+		finish := tracer.start(check, messages.Get(0))
+		clock.advance(elapsed)
+		finish()
+	}
+
+	evaluate(MessageNotEmpty, 10*time.Millisecond)
+	evaluate(FieldHasNoDescription, 40*time.Millisecond)
+	evaluate(MessageNotEmpty, 30*time.Millisecond)
+	evaluate(MethodHasVersion, 40*time.Millisecond)
+
+	entries := logs.TakeAll()
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 evaluations to be logged, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if entries[0].Message != "Rule evaluated" ||
+		fields["check"] != MessageNotEmpty ||
+		fields["descriptor"] != "google.protobuf.Api" ||
+		fields["elapsed"] != "10ms" {
+		t.Errorf("unexpected evaluation log entry: %s %v", entries[0].Message, fields)
+	}
+
+	tracer.finish()
+
+	// Checks are sorted by the total time, ties by name.
+	expected := strings.Join([]string{
+		"Time spent per rule:",
+		"CHECK                     RUNS  TOTAL  AVERAGE",
+		"field_has_no_description  1     40ms   40ms",
+		"message_not_empty         2     40ms   20ms",
+		"method_has_version        1     40ms   40ms",
+		"",
+	}, "\n")
+
+	if messages := getLogMessages(logs); len(messages) != 1 || messages[0] != expected {
+		t.Errorf("expected table:\n%s\ngot:\n%v", expected, messages)
+	}
+
+	var nilTracer *ruleTracer
+
+	nilTracer.start(MessageNotEmpty, messages.Get(0))()
+	nilTracer.finish()
+}
+
+func TestRuleTracerCountsEvaluationsOfCheckRun(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package orders.v1;
+
+// Order is an order.
+message Order {
+  // ID of the order.
+  string order_id = 1;
+  // Comment of the order.
+  string comment = 2;
+}
+`
+
+	var (
+		ctx, logs = newObservedContext()
+		fileName  = filepath.Join(t.TempDir(), "orders.proto")
+	)
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	checker := NewProtoChecker(ctx, nil)
+	checker.tracer = newRuleTracer(ctx)
+
+	if _, err := checker.CheckFiles(ctx, fileName); err != nil {
+		t.Fatalf("failed to check file: %s", err.Error())
+	}
+
+	evaluations := make(map[string]int)
+	for _, entry := range logs.FilterMessage("Rule evaluated").All() {
+		evaluations[entry.ContextMap()["check"].(string)]++
+	}
+
+	// Every field is evaluated by field_has_correct_json_name.
+	if evaluations[FieldHasCorrectJSONName] != 2 {
+		t.Errorf("expected 2 evaluations of %s, got %d", FieldHasCorrectJSONName, evaluations[FieldHasCorrectJSONName])
+	}
+
+	if len(checker.tracer.stats) != len(evaluations) {
+		t.Errorf("expected %d traced checks, got %d", len(evaluations), len(checker.tracer.stats))
+	}
+
+	for check, stats := range checker.tracer.stats {
+		if stats.runs != evaluations[check] {
+			t.Errorf("expected %d runs of %s, got %d", evaluations[check], check, stats.runs)
+		}
+	}
+
+	checker.tracer.finish()
+
+	tables := logs.FilterMessageSnippet("Time spent per rule:").All()
+	if len(tables) != 1 {
+		t.Fatalf("expected one table, got %d", len(tables))
+	}
+
+	// Every row of the table sums the runs of its check.
+	rows := make(map[string]string)
+	for _, line := range strings.Split(tables[0].Message, "\n")[2:] {
+		if columns := strings.Fields(line); len(columns) == 4 {
+			rows[columns[0]] = columns[1]
+		}
+	}
+
+	for check, runs := range evaluations {
+		if rows[check] != strconv.Itoa(runs) {
+			t.Errorf("expected table to list %s evaluated %d times, got %q", check, runs, rows[check])
+		}
+	}
+}
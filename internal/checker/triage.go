@@ -0,0 +1,436 @@
+package checker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Actions offered for a finding in the "triage" subcommand.
+const (
+	triageActionFix      = "f"
+	triageActionSuppress = "s"
+	triageActionExclude  = "e"
+	triageActionNext     = "n"
+	triageActionQuit     = "q"
+)
+
+// errTriageQuit stops the triage session on request.
+var errTriageQuit = errors.New("triage is stopped")
+
+// triageSession steps through findings asking what to do with every one of them.
+// Edits are made right away, so quitting keeps everything done before.
+type triageSession struct {
+	config     *config.Config
+	configPath string
+	input      *bufio.Reader
+	output     io.Writer
+	summary    TriageSummary
+	// insertedLines are the one-based lines of the files directives were inserted before,
+	// in the numbering of the files when they were checked.
+	insertedLines map[string][]int
+	// formattedFiles are the files rewritten by the formatter.
+	formattedFiles map[string]struct{}
+	// excludedDescriptors are the descriptors excluded during the session.
+	excludedDescriptors []protoreflect.FullName
+	// suppressedDescriptors map the checks to the descriptors they are suppressed for during the session.
+	suppressedDescriptors map[string][]protoreflect.FullName
+}
+
+// triageItem is a finding with the line edits related to it are made at.
+type triageItem struct {
+	finding *Finding
+	// line is the declaration line of the descriptor for findings of descriptors,
+	// otherwise the line of the finding.
+	line int
+}
+
+// newTriageSession creates a triage session reading actions from the input and writing findings to the output.
+func newTriageSession(cfg *config.Config, configPath string, input io.Reader, output io.Writer) *triageSession {
+	return &triageSession{
+		config:                cfg,
+		configPath:            configPath,
+		input:                 bufio.NewReader(input),
+		output:                output,
+		insertedLines:         make(map[string][]int),
+		formattedFiles:        make(map[string]struct{}),
+		suppressedDescriptors: make(map[string][]protoreflect.FullName),
+	}
+}
+
+// run steps through the findings of the results until they are over or the session is stopped.
+func (s *triageSession) run(results []*CheckResult) (*TriageSummary, error) {
+	items := s.orderFindings(results)
+
+	for i, item := range items {
+		if s.isResolved(item.finding) {
+			continue
+		}
+
+		err := s.triage(item, i+1, len(items))
+		if errors.Is(err, errTriageQuit) {
+			s.summary.Skipped += len(items) - i - 1
+
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &s.summary, nil
+}
+
+// orderFindings returns the findings ordered so that edits don't shift the lines of the findings
+// handled after them: the files are sorted by path, the findings of a file go from the bottom up,
+// and the findings fixed by formatting go last, because formatting rewrites the whole file.
+func (s *triageSession) orderFindings(results []*CheckResult) []*triageItem {
+	var items []*triageItem
+
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			line := finding.Line
+
+			if finding.descriptor != nil {
+				location := finding.descriptor.ParentFile().SourceLocations().ByDescriptor(finding.descriptor)
+				if location.StartLine >= 0 && location.StartLine+1 <= line {
+					line = location.StartLine + 1
+				}
+			}
+
+			items = append(items, &triageItem{finding: finding, line: line})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+
+		if a.finding.Path != b.finding.Path {
+			return a.finding.Path < b.finding.Path
+		}
+
+		if aFixable, bFixable := s.isFixable(a.finding), s.isFixable(b.finding); aFixable != bFixable {
+			return bFixable
+		}
+
+		if a.line != b.line {
+			return a.line > b.line
+		}
+
+		return a.finding.Column > b.finding.Column
+	})
+
+	return items
+}
+
+// isResolved returns true if the finding was handled along with another one:
+// the file was formatted or the descriptor was excluded or suppressed, counting it in the summary.
+func (s *triageSession) isResolved(finding *Finding) bool {
+	if _, ok := s.formattedFiles[finding.Path]; ok && s.isFixable(finding) {
+		s.summary.Fixed++
+
+		return true
+	}
+
+	if finding.descriptor == nil {
+		return false
+	}
+
+	name := finding.descriptor.FullName()
+
+	for _, excluded := range s.excludedDescriptors {
+		if isNestedName(name, excluded) {
+			s.summary.Excluded++
+
+			return true
+		}
+	}
+
+	for _, suppressed := range s.suppressedDescriptors[finding.Check] {
+		if isNestedName(name, suppressed) {
+			s.summary.Suppressed++
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// triage shows the finding and applies the chosen action, asking again if the action is unknown.
+func (s *triageSession) triage(item *triageItem, number, count int) error {
+	finding := item.finding
+	actions := s.getActions(finding)
+
+	fmt.Fprintf(s.output, "[%d/%d] %s\n", number, count, s.formatFinding(finding))
+
+	if line, ok := s.readLine(finding.Path, finding.Line); ok {
+		fmt.Fprintf(s.output, "    %s\n", line)
+	}
+
+	for {
+		fmt.Fprintf(s.output, "%s? ", formatTriageActions(actions))
+
+		answer, err := s.input.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read action: %w", err)
+		}
+
+		action := strings.ToLower(strings.TrimSpace(answer))
+		if action == "" {
+			action = triageActionNext
+
+			// The input is over, the rest of the findings are skipped.
+			if errors.Is(err, io.EOF) {
+				fmt.Fprintln(s.output)
+
+				action = triageActionQuit
+			}
+		}
+
+		if !containsString(actions, action) {
+			fmt.Fprintf(s.output, "Unknown action %q\n", action)
+
+			continue
+		}
+
+		return s.apply(item, action)
+	}
+}
+
+// apply performs the action chosen for the finding.
+func (s *triageSession) apply(item *triageItem, action string) error {
+	finding := item.finding
+
+	switch action {
+	case triageActionFix:
+		if _, err := formatFile(finding.Path, &FormatOptions{Write: true}); err != nil {
+			return err
+		}
+
+		s.formattedFiles[finding.Path] = struct{}{}
+		s.summary.Fixed++
+
+		fmt.Fprintf(s.output, "Formatted %s\n", finding.Path)
+	case triageActionSuppress:
+		if err := s.insertDirective(finding.Path, item.line, finding.Check); err != nil {
+			return err
+		}
+
+		name := finding.descriptor.FullName()
+		s.suppressedDescriptors[finding.Check] = append(s.suppressedDescriptors[finding.Check], name)
+		s.summary.Suppressed++
+
+		fmt.Fprintf(s.output, "Suppressed %s for %s\n", finding.Check, name)
+	case triageActionExclude:
+		name := finding.descriptor.FullName()
+		if err := config.AddExcludedDescriptors(s.configPath, []string{string(name)}); err != nil {
+			return err
+		}
+
+		s.excludedDescriptors = append(s.excludedDescriptors, name)
+		s.summary.Excluded++
+
+		fmt.Fprintf(s.output, "Excluded %s in %s\n", name, s.configPath)
+	case triageActionNext:
+		s.summary.Skipped++
+	case triageActionQuit:
+		s.summary.Skipped++
+
+		return errTriageQuit
+	}
+
+	return nil
+}
+
+// getActions returns the actions applicable to the finding.
+func (s *triageSession) getActions(finding *Finding) []string {
+	var actions []string
+
+	if s.isFixable(finding) {
+		actions = append(actions, triageActionFix)
+	}
+
+	if finding.descriptor != nil {
+		actions = append(actions, triageActionSuppress, triageActionExclude)
+	}
+
+	return append(actions, triageActionNext, triageActionQuit)
+}
+
+// isFixable returns true if formatting the file fixes the finding.
+func (s *triageSession) isFixable(finding *Finding) bool {
+	return isFixedByFormatting(s.config, finding.Check)
+}
+
+// isFixedByFormatting returns true if formatting the file fixes the findings of the check.
+// The formatter indents by two spaces, so only that indentation is fixed by it.
+func isFixedByFormatting(cfg *config.Config, check string) bool {
+	switch check {
+	case FileElementOrder:
+		return true
+	case StyleIndentation:
+		style, width := cfg.GetIndentation()
+
+		return style == config.IndentationSpaces && width == config.DefaultIndentationWidth
+	default:
+		return false
+	}
+}
+
+// formatFinding formats the finding with its line shifted by the directives inserted above it.
+func (s *triageSession) formatFinding(finding *Finding) string {
+	shifted := *finding
+	if shifted.Line > 0 {
+		shifted.Line = s.shiftLine(finding.Path, finding.Line)
+	}
+
+	return formatFindingText(&shifted)
+}
+
+// readLine returns the line of the file the finding points to, if it can be read.
+func (s *triageSession) readLine(path string, line int) (string, bool) {
+	if line <= 0 {
+		return "", false
+	}
+
+	lines, err := readFileLines(path)
+	if err != nil {
+		return "", false
+	}
+
+	index := s.shiftLine(path, line) - 1
+	if index >= len(lines) {
+		return "", false
+	}
+
+	return strings.TrimSpace(lines[index]), true
+}
+
+// insertDirective inserts a directive disabling the check above the declaration at the line and its leading comments,
+// or appends the check to the directive already there.
+// The directive is surrounded by blank lines, so it's a detached comment of the declaration
+// rather than a part of its documentation or a trailing comment of the previous declaration.
+func (s *triageSession) insertDirective(path string, line int, check string) error {
+	lines, err := readFileLines(path)
+	if err != nil {
+		return err
+	}
+
+	index := s.shiftLine(path, line) - 1
+	if index < 0 || index >= len(lines) {
+		return fmt.Errorf("line %d of file %s is out of range", line, path)
+	}
+
+	start := index
+	for start > 0 && isCommentLine(lines[start-1]) {
+		start--
+	}
+
+	if start > 1 && strings.TrimSpace(lines[start-1]) == "" {
+		previous := strings.TrimSpace(lines[start-2])
+		if strings.HasPrefix(previous, "// "+directiveName+" ") &&
+			!strings.Contains(previous, directiveReasonSeparator) {
+			lines[start-2] += ", " + check
+
+			return writeFileLines(path, lines)
+		}
+	}
+
+	var (
+		declaration = lines[index]
+		indentation = declaration[:len(declaration)-len(strings.TrimLeft(declaration, " \t"))]
+		inserted    = []string{fmt.Sprintf("%s// %s %s", indentation, directiveName, check), ""}
+	)
+
+	if start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		inserted = append([]string{""}, inserted...)
+	}
+
+	lines = append(lines[:start], append(inserted, lines[start:]...)...)
+
+	// The leading comments aren't edited, so they're as many lines above the declaration as in the checked file.
+	for range inserted {
+		s.insertedLines[path] = append(s.insertedLines[path], line-(index-start))
+	}
+
+	return writeFileLines(path, lines)
+}
+
+// isCommentLine returns true if the line consists of a comment only.
+func isCommentLine(line string) bool {
+	line = strings.TrimSpace(line)
+
+	return strings.HasPrefix(line, "//") ||
+		strings.HasPrefix(line, "/*") ||
+		strings.HasPrefix(line, "*") ||
+		strings.HasSuffix(line, "*/")
+}
+
+// shiftLine returns the line of the file as it was checked in the numbering of the file with the inserted directives.
+func (s *triageSession) shiftLine(path string, line int) int {
+	result := line
+
+	for _, inserted := range s.insertedLines[path] {
+		if inserted <= line {
+			result++
+		}
+	}
+
+	return result
+}
+
+// formatTriageActions formats the list of actions like "[f]ix, [n]ext".
+func formatTriageActions(actions []string) string {
+	names := map[string]string{
+		triageActionFix:      "[f]ix",
+		triageActionSuppress: "[s]uppress",
+		triageActionExclude:  "[e]xclude",
+		triageActionNext:     "[n]ext",
+		triageActionQuit:     "[q]uit",
+	}
+
+	result := make([]string, 0, len(actions))
+	for _, action := range actions {
+		result = append(result, names[action])
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// isNestedName returns true if the name is the parent name or a name nested in it.
+func isNestedName(name, parent protoreflect.FullName) bool {
+	return name == parent || strings.HasPrefix(string(name), string(parent)+".")
+}
+
+// readFileLines returns the lines of the file without line terminators.
+func readFileLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	return strings.Split(string(data), "\n"), nil
+}
+
+// writeFileLines writes the lines back to the file keeping its permissions.
+func writeFileLines(path string, lines []string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to get info of file %s: %w", path, err)
+	}
+
+	if err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+)
+
+func TestTriageSession(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+message Order {
+  string id = 1;
+  string note = 2;
+}
+`
+
+	var (
+		dir        = t.TempDir()
+		path       = filepath.Join(dir, "shop.proto")
+		configPath = filepath.Join(dir, ".protolinter.yaml")
+		file       = compileTriageFile(t, path, source)
+		order      = file.Messages().ByName("Order")
+		id         = order.Fields().ByName("id")
+		note       = order.Fields().ByName("note")
+	)
+
+	result := &CheckResult{
+		File: file,
+		Findings: []*Finding{
+			{Check: DescriptorIsReferenced, Path: path, Line: 5, Column: 1, descriptor: order},
+			{Check: FieldHasNoDescription, Path: path, Line: 6, Column: 3, descriptor: id},
+			{Check: FieldHasNoDescription, Path: path, Line: 7, Column: 3, descriptor: note},
+			{Check: FieldDescriptionEndsWithDot, Path: path, Line: 7, Column: 3, descriptor: note},
+			{Check: StyleIndentation, Path: path, Line: 6, Column: 1},
+		},
+	}
+
+	// Both findings of the note are suppressed by a single directive, the unknown action is asked again,
+	// the id is excluded and the input is over at the message.
+	var output bytes.Buffer
+
+	session := newTriageSession(nil, configPath, strings.NewReader("s\ns\nx\ne\n"), &output)
+
+	summary, err := session.run([]*CheckResult{result})
+	if err != nil {
+		t.Fatalf("failed to triage: %s", err.Error())
+	}
+
+	expectedSummary := TriageSummary{Suppressed: 2, Excluded: 1, Skipped: 2}
+	if *summary != expectedSummary {
+		t.Errorf("unexpected summary %+v, expected %+v", *summary, expectedSummary)
+	}
+
+	if !strings.Contains(output.String(), `Unknown action "x"`) {
+		t.Errorf("expected the unknown action to be reported, got:\n%s", output.String())
+	}
+
+	expectedSource := strings.Replace(source,
+		"  string note = 2;",
+		"\n  // protolinter:disable field_has_no_description, field_description_ends_with_dot\n\n  string note = 2;",
+		1)
+
+	if actual := readTriageFile(t, path); actual != expectedSource {
+		t.Errorf("unexpected file content:\n%s", actual)
+	}
+
+	if actual := readTriageFile(t, configPath); actual != "excluded_descriptors:\n  - shop.Order.id\n" {
+		t.Errorf("unexpected configuration:\n%s", actual)
+	}
+}
+
+func TestTriageSessionKeepsDocumentation(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+// Order is an order.
+message Order {
+  // ID of the order.
+  string id = 1 [json_name = "orderId"];
+}
+
+// Status is a status of an order.
+enum Status {
+  // Status isn't set.
+  STATUS_UNSPECIFIED = 0;
+  STATUS_NEW = 1;
+}
+`
+
+	var (
+		ctx    = context.Background()
+		dir    = t.TempDir()
+		path   = filepath.Join(dir, "shop.proto")
+		file   = compileTriageFile(t, path, source)
+		id     = file.Messages().ByName("Order").Fields().ByName("id")
+		status = file.Enums().ByName("Status").Values().ByName("STATUS_NEW")
+	)
+
+	result := &CheckResult{
+		File: file,
+		Findings: []*Finding{
+			{Check: FieldHasCorrectJSONName, Path: path, Line: 8, Column: 3, descriptor: id},
+			{Check: DescriptorIsReferenced, Path: path, Line: 15, Column: 3, descriptor: status},
+		},
+	}
+
+	session := newTriageSession(nil, filepath.Join(dir, ".protolinter.yaml"), strings.NewReader("s\ns\n"), &bytes.Buffer{})
+	if _, err := session.run([]*CheckResult{result}); err != nil {
+		t.Fatalf("failed to triage: %s", err.Error())
+	}
+
+	expectedSource := strings.NewReplacer(
+		"  // ID of the order.\n",
+		"\n  // protolinter:disable field_has_correct_json_name\n\n  // ID of the order.\n",
+		"  STATUS_NEW",
+		"\n  // protolinter:disable descriptor_is_referenced\n\n  STATUS_NEW",
+	).Replace(source)
+
+	if actual := readTriageFile(t, path); actual != expectedSource {
+		t.Errorf("unexpected file content:\n%s", actual)
+	}
+
+	results, err := NewProtoChecker(ctx, nil).CheckFiles(ctx, path)
+	if err != nil {
+		t.Fatalf("failed to check the triaged file: %s", err.Error())
+	}
+
+	// The directives suppress the findings, but don't document the descriptors.
+	var actual []string
+
+	for _, finding := range results[0].Findings {
+		switch finding.Check {
+		case FieldHasCorrectJSONName, EnumValueHasComments, CommentNotTrivial:
+			actual = append(actual, fmt.Sprintf("%s %s", finding.Check, finding.descriptor.Name()))
+		}
+	}
+
+	expected := []string{EnumValueHasComments + " STATUS_NEW"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got findings %v, want %v", actual, expected)
+	}
+}
+
+func TestTriageSessionFix(t *testing.T) {
+	const source = `syntax = "proto3";
+
+package shop;
+
+message Order {
+    string id = 1;
+    string note = 2;
+}
+`
+
+	var (
+		dir  = t.TempDir()
+		path = filepath.Join(dir, "shop.proto")
+		file = compileTriageFile(t, path, source)
+	)
+
+	result := &CheckResult{
+		File: file,
+		Findings: []*Finding{
+			{Check: StyleIndentation, Path: path, Line: 6, Column: 1},
+			{Check: StyleIndentation, Path: path, Line: 7, Column: 1},
+		},
+	}
+
+	// Formatting the file fixes the second finding as well.
+	session := newTriageSession(nil, filepath.Join(dir, ".protolinter.yaml"), strings.NewReader("f\n"), &bytes.Buffer{})
+
+	summary, err := session.run([]*CheckResult{result})
+	if err != nil {
+		t.Fatalf("failed to triage: %s", err.Error())
+	}
+
+	if expected := (TriageSummary{Fixed: 2}); *summary != expected {
+		t.Errorf("unexpected summary %+v, expected %+v", *summary, expected)
+	}
+
+	if actual := readTriageFile(t, path); !strings.Contains(actual, "\n  string id = 1;\n  string note = 2;\n") {
+		t.Errorf("expected the file to be formatted, got:\n%s", actual)
+	}
+}
+
+func compileTriageFile(t *testing.T, path, source string) linker.File {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err.Error())
+	}
+
+	compiler := &protocompile.Compiler{
+		Resolver:       &protocompile.SourceResolver{},
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+
+	files, err := compiler.Compile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	return files[0]
+}
+
+func readTriageFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %s", path, err.Error())
+	}
+
+	return string(data)
+}
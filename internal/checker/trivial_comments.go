@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+// commentStopWords are words ignored in comments by the loose strictness of the comment_not_trivial check.
+var commentStopWords = map[string]struct{}{
+	"a":   {},
+	"an":  {},
+	"the": {},
+	"of":  {},
+}
+
+// isTrivialComment returns true if the comment merely restates the identifier,
+// e.g. "Order id." on order_id. The prefix is the part of the identifier that may be omitted
+// in the comment, like the enum name in enum value names.
+func isTrivialComment(comment, identifier, prefix, strictness string) bool {
+	commentWords := splitWords(comment)
+	if len(commentWords) == 0 {
+		return false
+	}
+
+	identifierWords := splitWords(identifier)
+
+	if strictness == config.TrivialCommentsLoose {
+		identifierWordsMap := make(map[string]struct{}, len(identifierWords))
+		for _, word := range identifierWords {
+			identifierWordsMap[word] = struct{}{}
+		}
+
+		for _, word := range commentWords {
+			_, isIdentifierWord := identifierWordsMap[word]
+			_, isStopWord := commentStopWords[word]
+
+			if !isIdentifierWord && !isStopWord {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if equalWords(commentWords, identifierWords) {
+		return true
+	}
+
+	prefixWords := splitWords(prefix)
+	if len(prefixWords) == 0 || len(prefixWords) >= len(identifierWords) ||
+		!equalWords(identifierWords[:len(prefixWords)], prefixWords) {
+		return false
+	}
+
+	return equalWords(commentWords, identifierWords[len(prefixWords):])
+}
+
+// splitWords splits text into lowercase words on non-alphanumeric characters
+// and lowercase-to-uppercase transitions, so "order_id", "ORDER_ID", "orderId" and "Order id."
+// are split the same way.
+func splitWords(text string) []string {
+	var (
+		result  []string
+		current []rune
+		prev    rune
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+
+		prev = r
+	}
+
+	flush()
+
+	return result
+}
+
+func equalWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
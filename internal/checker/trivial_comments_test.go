@@ -0,0 +1,34 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestIsTrivialComment(t *testing.T) {
+	tests := []struct {
+		comment    string
+		identifier string
+		prefix     string
+		strictness string
+		expected   bool
+	}{
+		{" Order id.\n", "order_id", "", config.TrivialCommentsExact, true},
+		{" The order id.\n", "order_id", "", config.TrivialCommentsExact, false},
+		{" The order id.\n", "order_id", "", config.TrivialCommentsLoose, true},
+		{" Id.\n", "order_id", "", config.TrivialCommentsLoose, true},
+		{" Id of the order in the billing system.\n", "order_id", "", config.TrivialCommentsLoose, false},
+		{" Paid.\n", "ORDER_STATUS_PAID", "OrderStatus", config.TrivialCommentsExact, true},
+		{" Status.\n", "ORDER_STATUS_PAID", "OrderStatus", config.TrivialCommentsExact, false},
+		{"", "order_id", "", config.TrivialCommentsLoose, false},
+	}
+
+	for _, test := range tests {
+		actual := isTrivialComment(test.comment, test.identifier, test.prefix, test.strictness)
+		if actual != test.expected {
+			t.Errorf("isTrivialComment(%q, %q, %q, %q) = %t, expected %t",
+				test.comment, test.identifier, test.prefix, test.strictness, actual, test.expected)
+		}
+	}
+}
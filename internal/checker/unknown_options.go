@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"context"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/logger"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkUnknownOptions reports options of the descriptor set by extensions unknown to the linker,
+// since checks reading options can't see their values. The findings are warnings regardless of the severity
+// of the check, the extensions are named if they are defined in the configured extension sources.
+// Only descriptors loaded from descriptor sets reach it, the compiler rejects unknown extensions in source files.
+func (c *ProtoChecker) checkUnknownOptions(
+	desc protoreflect.Descriptor,
+	result *CheckResult,
+	kind string,
+	logName string,
+) {
+	c.runRule(UnknownOptionDetected, desc, func() {
+		options := desc.Options().ProtoReflect()
+
+		for _, number := range findUnknownFieldNumbers(options.GetUnknown()) {
+			extension := c.optionExtensions.find(options.Descriptor().FullName(), number)
+			if extension == nil {
+				result.AddWarningf(
+					UnknownOptionDetected,
+					desc,
+					"%s %s has option with unknown field number %d, checks can't read its value",
+					result.localize(kind),
+					logName,
+					number)
+
+				continue
+			}
+
+			result.AddWarningf(
+				UnknownOptionDetected,
+				desc,
+				"%s %s has option %s unknown to its file, checks can't read its value until %s is imported",
+				result.localize(kind),
+				logName,
+				extension.FullName(),
+				extension.ParentFile().Path())
+		}
+	})
+}
+
+// findUnknownFieldNumbers returns the numbers of the fields encoded in the unknown fields of a message,
+// in the order they first appear.
+func findUnknownFieldNumbers(unknown protoreflect.RawFields) []protoreflect.FieldNumber {
+	var (
+		result []protoreflect.FieldNumber
+		seen   = make(map[protoreflect.FieldNumber]struct{})
+	)
+
+	for len(unknown) > 0 {
+		number, _, length := protowire.ConsumeField(unknown)
+		if length < 0 {
+			break
+		}
+
+		unknown = unknown[length:]
+
+		if _, ok := seen[number]; ok {
+			continue
+		}
+
+		seen[number] = struct{}{}
+
+		result = append(result, number)
+	}
+
+	return result
+}
+
+// loadOptionExtensions compiles the configured extension sources, so unknown options can be named.
+// Sources failing to compile are reported and ignored, since naming extensions is only a hint:
+// the options aren't interpreted again with the found extensions, so checks still can't read their values.
+func (c *ProtoChecker) loadOptionExtensions(ctx context.Context) *optionExtensionIndex {
+	sources := c.config.GetUnknownOptionExtensionSources()
+	if len(sources) == 0 {
+		return &optionExtensionIndex{}
+	}
+
+	files, err := compileCacheFromContext(ctx).compile(ctx, c.compiler, sources)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to compile option extension sources %s: %s", sources, err.Error())
+
+		return &optionExtensionIndex{}
+	}
+
+	result := &optionExtensionIndex{
+		resolvers: make([]linker.Resolver, 0, len(files)),
+	}
+
+	for _, file := range files {
+		result.resolvers = append(result.resolvers, linker.ResolverFromFile(file))
+	}
+
+	return result
+}
+
+// find returns the extension of the options message with the number
+// defined in the extension sources or their imports, nil if there is none.
+func (i *optionExtensionIndex) find(
+	message protoreflect.FullName,
+	number protoreflect.FieldNumber,
+) protoreflect.ExtensionTypeDescriptor {
+	if i == nil {
+		return nil
+	}
+
+	for _, resolver := range i.resolvers {
+		if extension, err := resolver.FindExtensionByNumber(message, number); err == nil {
+			return extension.TypeDescriptor()
+		}
+	}
+
+	return nil
+}
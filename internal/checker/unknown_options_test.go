@@ -0,0 +1,105 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/oshokin/protolinter/internal/config"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckUnknownOptions(t *testing.T) {
+	const (
+		googleAPIHTTPFieldNumber = 72295728
+		customFieldNumber        = 50001
+	)
+
+	// Options of descriptors loaded without the files defining their extensions keep them as unknown fields.
+	var unknown []byte
+
+	unknown = protowire.AppendTag(unknown, googleAPIHTTPFieldNumber, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, nil)
+	unknown = protowire.AppendTag(unknown, customFieldNumber, protowire.VarintType)
+	unknown = protowire.AppendVarint(unknown, 1)
+	unknown = protowire.AppendTag(unknown, customFieldNumber, protowire.VarintType)
+	unknown = protowire.AppendVarint(unknown, 2)
+
+	methodOptions := new(descriptorpb.MethodOptions)
+	methodOptions.ProtoReflect().SetUnknown(unknown)
+
+	fileProto := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("orders.proto"),
+		Package: proto.String("orders"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("GetOrderV1Request")},
+			{Name: proto.String("GetOrderV1Response")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("OrderService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("GetOrderV1"),
+						InputType:  proto.String(".orders.GetOrderV1Request"),
+						OutputType: proto.String(".orders.GetOrderV1Response"),
+						Options:    methodOptions,
+					},
+				},
+			},
+		},
+	}
+
+	fileDescriptor, err := protodesc.NewFile(fileProto, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("failed to create file: %s", err.Error())
+	}
+
+	file, err := linker.NewFileRecursive(fileDescriptor)
+	if err != nil {
+		t.Fatalf("failed to create file: %s", err.Error())
+	}
+
+	var (
+		ctx = context.Background()
+		cfg = &config.Config{
+			UnknownOptions: config.UnknownOptionsOptions{
+				ExtensionSources: []string{"google/api/annotations.proto"},
+			},
+		}
+		checker = NewProtoChecker(ctx, cfg, "testdata/include")
+	)
+
+	checker.optionExtensions = checker.loadOptionExtensions(ctx)
+
+	var messages []string
+
+	for _, finding := range checker.checkFile(file, newDescriptorIndex(linker.Files{file})).Findings {
+		if finding.Check != UnknownOptionDetected {
+			continue
+		}
+
+		if finding.Severity != config.SeverityWarning {
+			t.Errorf("got severity %s, want %s", finding.Severity, config.SeverityWarning)
+		}
+
+		messages = append(messages, finding.Message)
+	}
+
+	expected := []string{
+		"Method GetOrderV1 has option google.api.http unknown to its file, " +
+			"checks can't read its value until google/api/annotations.proto is imported",
+		"Method GetOrderV1 has option with unknown field number 50001, " +
+			"checks can't read its value",
+	}
+
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got findings:\n%s\nwant:\n%s", strings.Join(messages, "\n"), strings.Join(expected, "\n"))
+	}
+}
@@ -3,11 +3,13 @@ package checker
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
-	"net/http"
-	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -28,7 +30,15 @@ const (
 	githubDownloadLinkPattern = "https://raw.githubusercontent.com/%s/%s/master/%s"
 )
 
-func getSourceResolver(ctx context.Context, cfg *config.Config) *protocompile.SourceResolver {
+func getSourceResolver(
+	ctx context.Context,
+	cfg *config.Config,
+	dependencies *dependencyRegistry,
+	modules *moduleRegistry,
+	importRoots []string,
+) *protocompile.SourceResolver {
+	rewrites := getImportRewrites(cfg)
+
 	return &protocompile.SourceResolver{
 		Accessor: func(path string) (io.ReadCloser, error) {
 			_, err := os.Stat(path)
@@ -36,38 +46,25 @@ func getSourceResolver(ctx context.Context, cfg *config.Config) *protocompile.So
 				return os.Open(path)
 			}
 
-			switch {
-			case strings.HasPrefix(path, googleAPIPrefix):
-				path, err = url.JoinPath(googleAPIsGitHubPath, path)
-				if err != nil {
-					return nil, err
-				}
-			case strings.HasPrefix(path, protocGenOpenAPIV2Prefix):
-				path, err = url.JoinPath(grpcGatewayGitHubPath, path)
-				if err != nil {
-					return nil, err
+			if modulePath, ok := modules.resolve(path); ok {
+				if _, err = os.Stat(modulePath); err == nil {
+					return os.Open(modulePath)
 				}
 			}
 
-			resource := getDownloadLink(path)
-			if cfg.GetVerboseMode() {
-				logger.Warnf(ctx, "Downloading proto dependency, %s: %s, %s: %s",
-					common.FileNameTag, path,
-					common.URLTag, resource)
-			}
-
-			request, err := http.NewRequestWithContext(ctx, http.MethodGet, resource, nil)
-			if err != nil {
-				return nil, err
+			for _, root := range importRoots {
+				rootedPath := filepath.Join(root, path)
+				if _, err = os.Stat(rootedPath); err == nil {
+					return os.Open(rootedPath)
+				}
 			}
 
-			response, err := http.DefaultClient.Do(request)
-			if err != nil {
-				return nil, err
+			path = rewriteImportPath(path, rewrites)
+			if _, err = os.Stat(path); err == nil {
+				return os.Open(path)
 			}
-			defer response.Body.Close()
 
-			body, err := io.ReadAll(response.Body)
+			body, err := downloadDependency(ctx, cfg, dependencies, path)
 			if err != nil {
 				return nil, err
 			}
@@ -77,6 +74,211 @@ func getSourceResolver(ctx context.Context, cfg *config.Config) *protocompile.So
 	}
 }
 
+// defaultImportRewrites is a list of import rewrite rules for well-known third-party protos.
+var defaultImportRewrites = []*config.ImportRewrite{
+	{
+		Prefix:      googleAPIPrefix,
+		Replacement: strings.Join([]string{googleAPIsGitHubPath, googleAPIPrefix}, "/"),
+	},
+	{
+		Prefix:      protocGenOpenAPIV2Prefix,
+		Replacement: strings.Join([]string{grpcGatewayGitHubPath, protocGenOpenAPIV2Prefix}, "/"),
+	},
+}
+
+// getImportRewrites returns the import rewrite rules from the configuration
+// followed by the default ones, so the configured rules take precedence.
+func getImportRewrites(cfg *config.Config) []*config.ImportRewrite {
+	configuredRewrites := cfg.GetImportRewrites()
+
+	result := make([]*config.ImportRewrite, 0, len(configuredRewrites)+len(defaultImportRewrites))
+	result = append(result, configuredRewrites...)
+
+	return append(result, defaultImportRewrites...)
+}
+
+// rewriteImportPath replaces the prefix of the first matching rewrite rule in the import path.
+func rewriteImportPath(path string, rewrites []*config.ImportRewrite) string {
+	for _, rewrite := range rewrites {
+		if rest, ok := strings.CutPrefix(path, rewrite.Prefix); ok {
+			return strings.Join([]string{rewrite.Replacement, rest}, "")
+		}
+	}
+
+	return path
+}
+
+func downloadDependency(
+	ctx context.Context,
+	cfg *config.Config,
+	dependencies *dependencyRegistry,
+	path string,
+) ([]byte, error) {
+	resource := getDownloadLink(path)
+	if cfg.GetVerboseMode() {
+		logger.Infof(ctx, "Downloading proto dependency, %s: %s, %s: %s",
+			common.FileNameTag, path,
+			common.URLTag, resource)
+	}
+
+	// Cached contents may have been downloaded under another configuration, so they are validated again.
+	cache := downloadCacheFromContext(ctx)
+	if body, ok := cache.get(resource); ok {
+		if err := validateDownloadedDependency(cfg, path, body); err != nil {
+			return nil, err
+		}
+
+		dependencies.add(path, resource, body)
+
+		return body, nil
+	}
+
+	if body, ok := getRemoteCacheEntry(ctx, cfg, resource); ok {
+		if err := validateDownloadedDependency(cfg, path, body); err != nil {
+			return nil, fmt.Errorf("remote cache: %w", err)
+		}
+
+		cache.put(resource, body)
+		dependencies.add(path, resource, body)
+
+		return body, nil
+	}
+
+	body, err := fetchDependency(ctx, cfg, dependencies, path, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateDownloadedDependency(cfg, path, body); err != nil {
+		return nil, err
+	}
+
+	putRemoteCacheEntry(ctx, cfg, resource, body)
+	cache.put(resource, body)
+	dependencies.add(path, resource, body)
+
+	return body, nil
+}
+
+// validateContentType returns an error if the content type of the downloaded dependency isn't allowed,
+// e.g. if a proxy served an HTML page instead of the file. Parameters of the content type are ignored.
+func validateContentType(cfg *config.Config, path, contentType string) error {
+	allowedTypes := cfg.GetDownloadAllowedContentTypes()
+	if len(allowedTypes) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	for _, allowedType := range allowedTypes {
+		if mediaType == allowedType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("content type %q of downloaded %s isn't allowed, allowed types: %s",
+		contentType,
+		path,
+		strings.Join(allowedTypes, ", "))
+}
+
+// validateDownloadedDependency returns an error if the contents of the dependency exceed the maximum size
+// or don't match the checksum pinned in the configuration.
+func validateDownloadedDependency(cfg *config.Config, path string, body []byte) error {
+	if maxSize := cfg.GetDownloadMaxSize(); int64(len(body)) > maxSize {
+		return fmt.Errorf("downloaded %s exceeds the maximum size of %d bytes", path, maxSize)
+	}
+
+	expectedChecksum := cfg.GetDownloadChecksum(path)
+	if expectedChecksum == "" {
+		return nil
+	}
+
+	checksum := sha256.Sum256(body)
+	if actualChecksum := hex.EncodeToString(checksum[:]); actualChecksum != expectedChecksum {
+		return fmt.Errorf("checksum %s of downloaded %s doesn't match pinned checksum %s",
+			actualChecksum,
+			path,
+			expectedChecksum)
+	}
+
+	return nil
+}
+
+// withDownloadCache returns a copy of the context carrying a new download cache.
+func withDownloadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, downloadCacheKey{}, &downloadCache{
+		items: make(map[string][]byte),
+	})
+}
+
+func downloadCacheFromContext(ctx context.Context) *downloadCache {
+	cache, _ := ctx.Value(downloadCacheKey{}).(*downloadCache)
+
+	return cache
+}
+
+func (c *downloadCache) get(resource string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	body, ok := c.items[resource]
+
+	return body, ok
+}
+
+func (c *downloadCache) put(resource string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[resource] = body
+}
+
+func (r *dependencyRegistry) add(path, resource string, body []byte) {
+	if r == nil {
+		return
+	}
+
+	checksum := sha256.Sum256(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items = append(r.items, &RemoteDependency{
+		Path:   path,
+		URL:    resource,
+		SHA256: hex.EncodeToString(checksum[:]),
+		Size:   len(body),
+	})
+}
+
+func (r *dependencyRegistry) list() []*RemoteDependency {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*RemoteDependency, len(r.items))
+	copy(result, r.items)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result
+}
+
 func getDownloadLink(importPath string) string {
 	if !strings.HasPrefix(importPath, githubDomain) {
 		return importPath
@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+func TestRewriteImportPath(t *testing.T) {
+	cfg := &config.Config{
+		ImportRewrites: []*config.ImportRewrite{
+			{Prefix: "google/api/", Replacement: "third_party/googleapis/google/api/"},
+			{Prefix: "company/", Replacement: "https://artifactory.example.com/protos/company/"},
+			{Prefix: "github.com/company/legacy/", Replacement: "github.com/company/protos/legacy/"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		path         string
+		expectedPath string
+		expectedLink string
+	}{
+		{
+			name:         "default rule",
+			path:         "google/api/annotations.proto",
+			expectedPath: "github.com/googleapis/googleapis/google/api/annotations.proto",
+			expectedLink: "https://raw.githubusercontent.com/googleapis/googleapis/master/google/api/annotations.proto",
+		},
+		{
+			name:         "configured rule takes precedence over default one",
+			cfg:          cfg,
+			path:         "google/api/annotations.proto",
+			expectedPath: "third_party/googleapis/google/api/annotations.proto",
+			expectedLink: "third_party/googleapis/google/api/annotations.proto",
+		},
+		{
+			name:         "default rule without matching configured rule",
+			cfg:          cfg,
+			path:         "protoc-gen-openapiv2/options/annotations.proto",
+			expectedPath: "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-openapiv2/options/annotations.proto",
+			expectedLink: "https://raw.githubusercontent.com/grpc-ecosystem/grpc-gateway/master/" +
+				"protoc-gen-openapiv2/options/annotations.proto",
+		},
+		{
+			name:         "URL replacement",
+			cfg:          cfg,
+			path:         "company/orders/v1/orders.proto",
+			expectedPath: "https://artifactory.example.com/protos/company/orders/v1/orders.proto",
+			expectedLink: "https://artifactory.example.com/protos/company/orders/v1/orders.proto",
+		},
+		{
+			name:         "GitHub replacement",
+			cfg:          cfg,
+			path:         "github.com/company/legacy/orders.proto",
+			expectedPath: "github.com/company/protos/legacy/orders.proto",
+			expectedLink: "https://raw.githubusercontent.com/company/protos/master/legacy/orders.proto",
+		},
+		{
+			name:         "no matching rule",
+			cfg:          cfg,
+			path:         "orders/v1/orders.proto",
+			expectedPath: "orders/v1/orders.proto",
+			expectedLink: "orders/v1/orders.proto",
+		},
+	}
+
+	for _, test := range tests {
+		actual := rewriteImportPath(test.path, getImportRewrites(test.cfg))
+		if actual != test.expectedPath {
+			t.Errorf("%s: expected path %s, got %s", test.name, test.expectedPath, actual)
+		}
+
+		if link := getDownloadLink(actual); link != test.expectedLink {
+			t.Errorf("%s: expected download link %s, got %s", test.name, test.expectedLink, link)
+		}
+	}
+}
+
+func TestSourceResolverReadsRewrittenLocalDirectory(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		dir      = t.TempDir()
+		fileName = filepath.Join(dir, "orders.proto")
+		// A local copy of googleapis replaces the default download from GitHub.
+		cfg = &config.Config{
+			ImportRewrites: []*config.ImportRewrite{
+				{Prefix: "google/api/", Replacement: filepath.Join(dir, "third_party", "google", "api") + "/"},
+			},
+		}
+	)
+
+	writeTestFileWithDirs(t, filepath.Join(dir, "third_party", "google", "api", "local.proto"),
+		"syntax = \"proto3\";\n\npackage google.api;\n\nmessage Local {}\n")
+
+	source := "syntax = \"proto3\";\n\npackage orders;\n\nimport \"google/api/local.proto\";\n\n" +
+		"message Order {\n  google.api.Local local = 1;\n}\n"
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	if _, err := NewProtoChecker(ctx, cfg).compiler.Compile(ctx, fileName); err != nil {
+		t.Fatalf("failed to compile file importing the rewritten path: %s", err.Error())
+	}
+}
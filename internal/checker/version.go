@@ -0,0 +1,101 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/oshokin/protolinter/internal/common"
+)
+
+// latestReleaseURL is the GitHub API endpoint describing the latest release of the linter.
+const latestReleaseURL = "https://api.github.com/repos/oshokin/protolinter/releases/latest"
+
+// NewVersionInfo returns the build information of the linter and the number of its checks.
+func NewVersionInfo() *VersionInfo {
+	buildInfo := common.GetBuildInfo()
+
+	return &VersionInfo{
+		Version:   buildInfo.Version,
+		Commit:    buildInfo.Commit,
+		GoVersion: buildInfo.GoVersion,
+		RuleCount: len(registeredRules),
+	}
+}
+
+// checkUpdate queries the releases API and fills in the latest version.
+// Development builds are never reported as outdated, since their version is unknown.
+func (v *VersionInfo) checkUpdate(ctx context.Context, resource string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, resource, nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("releases API responded with status %d", response.StatusCode)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(&release); err != nil {
+		return err
+	}
+
+	if release.TagName == "" {
+		return errors.New("latest release has no tag")
+	}
+
+	v.LatestVersion = release.TagName
+	v.IsUpdateAvailable = common.IsVersionOlder(v.Version, v.LatestVersion)
+
+	return nil
+}
+
+// WriteJSON writes the version information as JSON.
+func (v *VersionInfo) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// WriteText writes the version information in a human-readable form.
+func (v *VersionInfo) WriteText(w io.Writer) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "protolinter %s\n", v.Version)
+
+	if v.Commit != "" {
+		fmt.Fprintf(&sb, "commit: %s\n", v.Commit)
+	}
+
+	fmt.Fprintf(&sb, "go: %s\n", v.GoVersion)
+	fmt.Fprintf(&sb, "rules: %d\n", v.RuleCount)
+
+	if v.LatestVersion != "" {
+		status := "up to date"
+		if v.IsUpdateAvailable {
+			status = "update available"
+		}
+
+		fmt.Fprintf(&sb, "latest: %s (%s)\n", v.LatestVersion, status)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
@@ -0,0 +1,24 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionInfoCheckUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v1.3.0"}`))
+	}))
+	defer server.Close()
+
+	info := &VersionInfo{Version: "v1.2.0"}
+	if err := info.checkUpdate(context.Background(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if info.LatestVersion != "v1.3.0" || !info.IsUpdateAvailable {
+		t.Errorf("expected update to v1.3.0 to be available, got %+v", info)
+	}
+}
@@ -0,0 +1,97 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// methodVersionRegexp matches the version suffix of a method name, e.g. "V1" of "GetOrderV1".
+	methodVersionRegexp = regexp.MustCompile(`V(\d+)$`)
+	// packageVersionRegexp matches the version segment of a package, e.g. "v1" or "v2beta1".
+	packageVersionRegexp = regexp.MustCompile(`^v(\d+)((alpha|beta)\d*)?$`)
+)
+
+// checkServiceMethodVersions checks that all versioned methods of the service share the same version.
+// Methods without a version are left to method_has_version.
+func (c *ProtoChecker) checkServiceMethodVersions(service protoreflect.ServiceDescriptor,
+	result *CheckResult,
+	serviceName string,
+) {
+	c.runRule(ServiceMethodsSingleVersion, service, func() {
+		var (
+			methods  = service.Methods()
+			versions []string
+		)
+
+		for i := 0; i < methods.Len(); i++ {
+			version := getMethodVersion(methods.Get(i))
+			if version != "" && !containsString(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+
+		if len(versions) > 1 {
+			result.AddFindingf(
+				ServiceMethodsSingleVersion,
+				service,
+				"Methods of service %s have different versions: V%s",
+				serviceName,
+				strings.Join(versions, ", V"))
+		}
+	})
+}
+
+// checkMethodPackageVersion checks that the version of the method matches the version segment of its package.
+// Methods without a version and packages without a version segment aren't checked.
+func (c *ProtoChecker) checkMethodPackageVersion(method protoreflect.MethodDescriptor,
+	result *CheckResult,
+	methodLogName string,
+) {
+	c.runRule(PackageVersionMatchesMethods, method, func() {
+		var (
+			packageName    = method.ParentFile().Package()
+			packageVersion = getPackageVersion(packageName)
+			methodVersion  = getMethodVersion(method)
+		)
+
+		if packageVersion == "" || methodVersion == "" || packageVersion == methodVersion {
+			return
+		}
+
+		result.AddFindingf(
+			PackageVersionMatchesMethods,
+			method,
+			"Version V%s of method %s doesn't match version v%s of package %s",
+			methodVersion,
+			methodLogName,
+			packageVersion,
+			packageName)
+	})
+}
+
+// getMethodVersion returns the number of the version suffix of the method name,
+// or an empty string if the method has no version.
+func getMethodVersion(method protoreflect.MethodDescriptor) string {
+	match := methodVersionRegexp.FindStringSubmatch(string(method.Name()))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// getPackageVersion returns the number of the last version segment of the package,
+// or an empty string if the package has no version segment.
+func getPackageVersion(packageName protoreflect.FullName) string {
+	segments := strings.Split(string(packageName), ".")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if match := packageVersionRegexp.FindStringSubmatch(segments[i]); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
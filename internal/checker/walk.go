@@ -0,0 +1,105 @@
+package checker
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// Walk visits the descriptors declared in the file in the order they are nested:
+// services with their methods, then messages, enums and extensions of the file.
+// A message is entered before its fields, oneofs, nested messages, enums and extensions and left after them.
+// Descendants of a service, a message or an enum are skipped if entering it returns false.
+// Files imported by the file aren't visited, walk the files returned by its Imports to visit them.
+func Walk(file protoreflect.FileDescriptor, visitor Visitor) {
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		if !visitor.EnterService(service) {
+			continue
+		}
+
+		methods := service.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			visitor.Method(methods.Get(j))
+		}
+	}
+
+	walkMessages(file.Messages(), visitor)
+	walkEnums(file.Enums(), visitor)
+	walkExtensions(file.Extensions(), visitor)
+}
+
+// EnterService does nothing and returns true, so methods of the service are visited.
+func (BaseVisitor) EnterService(protoreflect.ServiceDescriptor) bool {
+	return true
+}
+
+// Method does nothing.
+func (BaseVisitor) Method(protoreflect.MethodDescriptor) {}
+
+// EnterMessage does nothing and returns true, so descendants of the message are visited.
+func (BaseVisitor) EnterMessage(protoreflect.MessageDescriptor) bool {
+	return true
+}
+
+// LeaveMessage does nothing.
+func (BaseVisitor) LeaveMessage(protoreflect.MessageDescriptor) {}
+
+// Field does nothing.
+func (BaseVisitor) Field(protoreflect.FieldDescriptor) {}
+
+// Oneof does nothing.
+func (BaseVisitor) Oneof(protoreflect.OneofDescriptor) {}
+
+// Extension does nothing.
+func (BaseVisitor) Extension(protoreflect.ExtensionDescriptor) {}
+
+// EnterEnum does nothing and returns true, so values of the enum are visited.
+func (BaseVisitor) EnterEnum(protoreflect.EnumDescriptor) bool {
+	return true
+}
+
+// EnumValue does nothing.
+func (BaseVisitor) EnumValue(protoreflect.EnumValueDescriptor) {}
+
+func walkMessages(messages protoreflect.MessageDescriptors, visitor Visitor) {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		if !visitor.EnterMessage(message) {
+			continue
+		}
+
+		fields := message.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			visitor.Field(fields.Get(j))
+		}
+
+		oneofs := message.Oneofs()
+		for j := 0; j < oneofs.Len(); j++ {
+			visitor.Oneof(oneofs.Get(j))
+		}
+
+		walkMessages(message.Messages(), visitor)
+		walkEnums(message.Enums(), visitor)
+		walkExtensions(message.Extensions(), visitor)
+
+		visitor.LeaveMessage(message)
+	}
+}
+
+func walkEnums(enums protoreflect.EnumDescriptors, visitor Visitor) {
+	for i := 0; i < enums.Len(); i++ {
+		enum := enums.Get(i)
+		if !visitor.EnterEnum(enum) {
+			continue
+		}
+
+		values := enum.Values()
+		for j := 0; j < values.Len(); j++ {
+			visitor.EnumValue(values.Get(j))
+		}
+	}
+}
+
+func walkExtensions(extensions protoreflect.ExtensionDescriptors, visitor Visitor) {
+	for i := 0; i < extensions.Len(); i++ {
+		visitor.Extension(extensions.Get(i))
+	}
+}
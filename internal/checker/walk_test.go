@@ -0,0 +1,134 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// recordingVisitor records the visited descriptors, skipping the descendants of the named one.
+type recordingVisitor struct {
+	BaseVisitor
+	skipped string
+	events  []string
+}
+
+func (v *recordingVisitor) record(event string, desc protoreflect.Descriptor) bool {
+	v.events = append(v.events, fmt.Sprintf("%s %s", event, desc.Name()))
+
+	return string(desc.Name()) != v.skipped
+}
+
+func (v *recordingVisitor) EnterService(service protoreflect.ServiceDescriptor) bool {
+	return v.record("service", service)
+}
+
+func (v *recordingVisitor) Method(method protoreflect.MethodDescriptor) {
+	v.record("method", method)
+}
+
+func (v *recordingVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	return v.record("enter", message)
+}
+
+func (v *recordingVisitor) LeaveMessage(message protoreflect.MessageDescriptor) {
+	v.record("leave", message)
+}
+
+func (v *recordingVisitor) Field(field protoreflect.FieldDescriptor) {
+	v.record("field", field)
+}
+
+func (v *recordingVisitor) Oneof(oneof protoreflect.OneofDescriptor) {
+	v.record("oneof", oneof)
+}
+
+func (v *recordingVisitor) Extension(extension protoreflect.ExtensionDescriptor) {
+	v.record("extension", extension)
+}
+
+func (v *recordingVisitor) EnterEnum(enum protoreflect.EnumDescriptor) bool {
+	return v.record("enum", enum)
+}
+
+func (v *recordingVisitor) EnumValue(value protoreflect.EnumValueDescriptor) {
+	v.record("value", value)
+}
+
+func TestWalk(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "orders.proto")
+	source := `syntax = "proto2";
+
+package orders;
+
+service OrderService {
+  rpc GetOrder(Order) returns (Order);
+}
+
+message Order {
+  oneof id {
+    string name = 1;
+  }
+
+  message Item {
+    optional string sku = 1;
+  }
+
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+  }
+
+  extensions 100 to 200;
+}
+
+message Skipped {
+  optional string ignored = 1;
+}
+
+extend Order {
+  optional string note = 100;
+}
+`
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	ctx := context.Background()
+
+	files, err := NewProtoChecker(ctx, nil).compiler.Compile(ctx, fileName)
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err.Error())
+	}
+
+	visitor := &recordingVisitor{
+		skipped: "Skipped",
+	}
+
+	Walk(files[0], visitor)
+
+	expected := []string{
+		"service OrderService",
+		"method GetOrder",
+		"enter Order",
+		"field name",
+		"oneof id",
+		"enter Item",
+		"field sku",
+		"leave Item",
+		"enum Status",
+		"value STATUS_UNSPECIFIED",
+		"leave Order",
+		"enter Skipped",
+		"extension note",
+	}
+
+	if !reflect.DeepEqual(visitor.events, expected) {
+		t.Errorf("got events:\n%v\nwant:\n%v", visitor.events, expected)
+	}
+}
@@ -0,0 +1,246 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kinds of symbols found by the "where" and "grep" subcommands.
+const (
+	symbolKindPackage   = "package"
+	symbolKindService   = "service"
+	symbolKindMethod    = "method"
+	symbolKindMessage   = "message"
+	symbolKindField     = "field"
+	symbolKindOneof     = "oneof"
+	symbolKindExtension = "extension"
+	symbolKindEnum      = "enum"
+	symbolKindEnumValue = "value"
+)
+
+// SymbolKinds lists the kinds of symbols, in the order they are declared in files.
+var SymbolKinds = []string{
+	symbolKindPackage,
+	symbolKindService,
+	symbolKindMethod,
+	symbolKindMessage,
+	symbolKindField,
+	symbolKindOneof,
+	symbolKindExtension,
+	symbolKindEnum,
+	symbolKindEnumValue,
+}
+
+// packageFieldNumber is the number of the package field of google.protobuf.FileDescriptorProto.
+const packageFieldNumber = 2
+
+// FindSymbols compiles the provided protobuf files and returns the symbols whose full names start with the prefix,
+// declared in the files and in the files they import, so the prefix can be used as an excluded_descriptors entry.
+func (c *ProtoChecker) FindSymbols(ctx context.Context, prefix string, files ...string) (*SymbolReport, error) {
+	return c.searchSymbols(ctx, files, true, func(symbol *SymbolLocation) bool {
+		return strings.HasPrefix(symbol.Name, prefix)
+	})
+}
+
+// GrepSymbols compiles the provided protobuf files and returns the symbols declared in them
+// whose full names match the pattern and whose kinds are among the specified ones.
+// If the list of kinds is empty, symbols of all kinds are returned.
+func (c *ProtoChecker) GrepSymbols(
+	ctx context.Context,
+	pattern *regexp.Regexp,
+	kinds []string,
+	files ...string,
+) (*SymbolReport, error) {
+	return c.searchSymbols(ctx, files, false, func(symbol *SymbolLocation) bool {
+		return (len(kinds) == 0 || containsString(kinds, symbol.Kind)) && pattern.MatchString(symbol.Name)
+	})
+}
+
+// searchSymbols compiles the files and returns the symbols declared in them matching the function,
+// sorted by full name and file path. Symbols of imported files are searched as well if specified.
+func (c *ProtoChecker) searchSymbols(
+	ctx context.Context,
+	files []string,
+	withImports bool,
+	match func(symbol *SymbolLocation) bool,
+) (*SymbolReport, error) {
+	c.modules.addForFiles(files)
+
+	parsedFiles, err := c.compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile files %s: %w", files, err)
+	}
+
+	var (
+		result  = new(SymbolReport)
+		visited = make(map[string]struct{})
+	)
+
+	var addFile func(file protoreflect.FileDescriptor)
+
+	addFile = func(file protoreflect.FileDescriptor) {
+		if _, ok := visited[file.Path()]; ok {
+			return
+		}
+
+		visited[file.Path()] = struct{}{}
+
+		result.Symbols = append(result.Symbols, findFileSymbols(file, match)...)
+
+		if !withImports {
+			return
+		}
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			addFile(imports.Get(i).FileDescriptor)
+		}
+	}
+
+	for _, parsedFile := range parsedFiles {
+		addFile(parsedFile)
+	}
+
+	sort.Slice(result.Symbols, func(i, j int) bool {
+		a, b := result.Symbols[i], result.Symbols[j]
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.Path < b.Path
+	})
+
+	return result, nil
+}
+
+// WriteText writes the symbols to the writer as a table.
+func (r *SymbolReport) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint: gomnd // Padding between columns.
+
+	if _, err := fmt.Fprintln(writer, "SYMBOL\tKIND\tLOCATION"); err != nil {
+		return err
+	}
+
+	for _, symbol := range r.Symbols {
+		location := symbol.Path
+		if symbol.Line > 0 {
+			location = fmt.Sprintf("%s:%d", symbol.Path, symbol.Line)
+		}
+
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t%s\n", symbol.Name, symbol.Kind, location); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// WriteJSON writes the symbols to the writer as indented JSON.
+func (r *SymbolReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode symbols: %w", err)
+	}
+
+	return nil
+}
+
+// findFileSymbols returns the package of the file and the descriptors declared in it matching the function.
+func findFileSymbols(file protoreflect.FileDescriptor, match func(symbol *SymbolLocation) bool) []*SymbolLocation {
+	visitor := &symbolVisitor{
+		file:  file,
+		match: match,
+	}
+
+	if file.Package() != "" {
+		visitor.add(file, symbolKindPackage, file.SourceLocations().ByPath(protoreflect.SourcePath{packageFieldNumber}))
+	}
+
+	Walk(file, visitor)
+
+	return visitor.symbols
+}
+
+// EnterService adds the service.
+func (v *symbolVisitor) EnterService(service protoreflect.ServiceDescriptor) bool {
+	v.addDescriptor(service, symbolKindService)
+
+	return true
+}
+
+// Method adds the method.
+func (v *symbolVisitor) Method(method protoreflect.MethodDescriptor) {
+	v.addDescriptor(method, symbolKindMethod)
+}
+
+// EnterMessage adds the message, map entries and their fields aren't symbols users declare.
+func (v *symbolVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	if message.IsMapEntry() {
+		return false
+	}
+
+	v.addDescriptor(message, symbolKindMessage)
+
+	return true
+}
+
+// Field adds the field.
+func (v *symbolVisitor) Field(field protoreflect.FieldDescriptor) {
+	v.addDescriptor(field, symbolKindField)
+}
+
+// Oneof adds the oneof unless it's synthetic.
+func (v *symbolVisitor) Oneof(oneof protoreflect.OneofDescriptor) {
+	if !oneof.IsSynthetic() {
+		v.addDescriptor(oneof, symbolKindOneof)
+	}
+}
+
+// Extension adds the extension.
+func (v *symbolVisitor) Extension(extension protoreflect.ExtensionDescriptor) {
+	v.addDescriptor(extension, symbolKindExtension)
+}
+
+// EnterEnum adds the enum.
+func (v *symbolVisitor) EnterEnum(enum protoreflect.EnumDescriptor) bool {
+	v.addDescriptor(enum, symbolKindEnum)
+
+	return true
+}
+
+// EnumValue adds the enum value.
+func (v *symbolVisitor) EnumValue(value protoreflect.EnumValueDescriptor) {
+	v.addDescriptor(value, symbolKindEnumValue)
+}
+
+func (v *symbolVisitor) addDescriptor(desc protoreflect.Descriptor, kind string) {
+	v.add(desc, kind, v.file.SourceLocations().ByDescriptor(desc))
+}
+
+func (v *symbolVisitor) add(desc protoreflect.Descriptor, kind string, location protoreflect.SourceLocation) {
+	symbol := &SymbolLocation{
+		Name: string(desc.FullName()),
+		Kind: kind,
+		Path: v.file.Path(),
+	}
+
+	// Locations aren't found in files without source info, like standard imports.
+	if location.Path != nil {
+		symbol.Line = location.StartLine + 1
+	}
+
+	if v.match(symbol) {
+		v.symbols = append(v.symbols, symbol)
+	}
+}
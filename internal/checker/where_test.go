@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFindSymbols(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "orders.proto")
+	source := `syntax = "proto3";
+
+package foo.bar;
+
+message OrderV1 {
+  string status = 1;
+  optional string status_reason = 2;
+}
+
+message Order {}
+`
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	ctx := context.Background()
+
+	report, err := NewProtoChecker(ctx, nil).FindSymbols(ctx, "foo.bar.OrderV1.status", fileName)
+	if err != nil {
+		t.Fatalf("failed to find symbols: %s", err.Error())
+	}
+
+	var output bytes.Buffer
+	if err = report.WriteText(&output); err != nil {
+		t.Fatalf("failed to write symbols: %s", err.Error())
+	}
+
+	expected := "SYMBOL                         KIND   LOCATION\n" +
+		"foo.bar.OrderV1.status         field  " + fileName + ":6\n" +
+		"foo.bar.OrderV1.status_reason  field  " + fileName + ":7\n"
+
+	if output.String() != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", output.String(), expected)
+	}
+}
+
+func TestGrepSymbols(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "orders.proto")
+	source := `syntax = "proto3";
+
+package foo.bar;
+
+import "google/protobuf/timestamp.proto";
+
+message Order {
+  string order_id = 1;
+  string customer_id = 2;
+  google.protobuf.Timestamp created_at = 3;
+}
+
+message Customer {
+  string customer_id = 1;
+}
+`
+
+	if err := os.WriteFile(fileName, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	ctx := context.Background()
+
+	report, err := NewProtoChecker(ctx, nil).GrepSymbols(ctx,
+		regexp.MustCompile(`^foo\.bar\.Order\.|_id$`),
+		[]string{symbolKindField},
+		fileName)
+	if err != nil {
+		t.Fatalf("failed to search symbols: %s", err.Error())
+	}
+
+	var names []string
+	for _, symbol := range report.Symbols {
+		names = append(names, symbol.Name)
+	}
+
+	expected := []string{
+		"foo.bar.Customer.customer_id",
+		"foo.bar.Order.created_at",
+		"foo.bar.Order.customer_id",
+		"foo.bar.Order.order_id",
+	}
+
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, want %v", names, expected)
+	}
+}
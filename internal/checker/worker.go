@@ -0,0 +1,240 @@
+package checker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oshokin/protolinter/internal/logger"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// WorkerProtocolProto is the default protocol of Bazel workers, exchanging length-delimited protobuf messages.
+	WorkerProtocolProto = "proto"
+	// WorkerProtocolJSON is the protocol of Bazel workers exchanging JSON messages.
+	WorkerProtocolJSON = "json"
+
+	// Field numbers of the WorkRequest and WorkResponse messages defined by Bazel in worker_protocol.proto.
+	workRequestArgumentsField  protowire.Number = 1
+	workRequestIDField         protowire.Number = 3
+	workRequestCancelField     protowire.Number = 4
+	workResponseExitCodeField  protowire.Number = 1
+	workResponseOutputField    protowire.Number = 2
+	workResponseRequestIDField protowire.Number = 3
+)
+
+type (
+	// WorkerOptions holds the parameters of the persistent worker mode.
+	WorkerOptions struct {
+		Protocol string // Protocol of the messages: proto or json.
+		// Handle runs the command with the arguments of a work request, writing its output to the writer,
+		// and returns the exit code.
+		Handle func(ctx context.Context, args []string, output io.Writer) int
+	}
+
+	// workRequest is a request to run the command sent by Bazel.
+	workRequest struct {
+		Arguments []string `json:"arguments"`
+		RequestID int32    `json:"requestId"`
+		Cancel    bool     `json:"cancel"`
+	}
+
+	// workResponse is the result of a work request sent back to Bazel.
+	workResponse struct {
+		ExitCode  int32  `json:"exitCode"`
+		Output    string `json:"output"`
+		RequestID int32  `json:"requestId"`
+	}
+
+	// workerCodec reads work requests and writes work responses in the format of the protocol.
+	workerCodec interface {
+		readRequest() (*workRequest, error)
+		writeResponse(response *workResponse) error
+	}
+
+	protoWorkerCodec struct {
+		reader *bufio.Reader
+		writer io.Writer
+	}
+
+	jsonWorkerCodec struct {
+		decoder *json.Decoder
+		encoder *json.Encoder
+	}
+)
+
+// ExecuteWorker runs the Bazel persistent worker loop: work requests are read from stdin
+// and handled one by one until stdin is closed, the responses are written to stdout.
+// Downloaded dependencies and compiled files are cached across requests.
+func ExecuteWorker(ctx context.Context, opts *WorkerOptions) {
+	codec, err := newWorkerCodec(opts.Protocol, os.Stdin, os.Stdout)
+	if err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+
+	ctx = WithCompileCache(withDownloadCache(ctx), NewCompileCache())
+
+	if err = serveWorkRequests(ctx, codec, opts.Handle); err != nil {
+		logger.Fatal(ctx, err.Error())
+	}
+}
+
+func newWorkerCodec(protocol string, r io.Reader, w io.Writer) (workerCodec, error) {
+	switch protocol {
+	case "", WorkerProtocolProto:
+		return &protoWorkerCodec{
+			reader: bufio.NewReader(r),
+			writer: w,
+		}, nil
+	case WorkerProtocolJSON:
+		return &jsonWorkerCodec{
+			decoder: json.NewDecoder(r),
+			encoder: json.NewEncoder(w),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown worker protocol: %s", protocol)
+	}
+}
+
+// serveWorkRequests handles the work requests until the input is closed.
+// Requests are handled sequentially, so by the time a cancel request is read,
+// the request it refers to is already answered and the cancel request is ignored.
+func serveWorkRequests(
+	ctx context.Context,
+	codec workerCodec,
+	handle func(ctx context.Context, args []string, output io.Writer) int,
+) error {
+	for {
+		request, err := codec.readRequest()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read work request: %w", err)
+		}
+
+		if request.Cancel {
+			continue
+		}
+
+		var output bytes.Buffer
+
+		exitCode := handleWorkRequest(ctx, request, &output, handle)
+
+		err = codec.writeResponse(&workResponse{
+			ExitCode:  int32(exitCode),
+			Output:    output.String(),
+			RequestID: request.RequestID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write work response: %w", err)
+		}
+	}
+}
+
+// handleWorkRequest runs the request, turning a panic into a failed response,
+// so a single broken request doesn't take the worker down.
+func handleWorkRequest(
+	ctx context.Context,
+	request *workRequest,
+	output io.Writer,
+	handle func(ctx context.Context, args []string, output io.Writer) int,
+) (exitCode int) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(output, "Work request failed: %v\n", r)
+
+			exitCode = 1
+		}
+	}()
+
+	return handle(ctx, request.Arguments, output)
+}
+
+func (c *protoWorkerCodec) readRequest() (*workRequest, error) {
+	size, err := binary.ReadUvarint(c.reader)
+	if err != nil {
+		return nil, err
+	}
+
+	message := make([]byte, size)
+	if _, err = io.ReadFull(c.reader, message); err != nil {
+		return nil, err
+	}
+
+	result := new(workRequest)
+
+	for len(message) > 0 {
+		number, wireType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+
+		message = message[n:]
+
+		switch {
+		case number == workRequestArgumentsField && wireType == protowire.BytesType:
+			var argument string
+
+			argument, n = protowire.ConsumeString(message)
+			result.Arguments = append(result.Arguments, argument)
+		case number == workRequestIDField && wireType == protowire.VarintType:
+			var v uint64
+
+			v, n = protowire.ConsumeVarint(message)
+			result.RequestID = int32(v)
+		case number == workRequestCancelField && wireType == protowire.VarintType:
+			var v uint64
+
+			v, n = protowire.ConsumeVarint(message)
+			result.Cancel = protowire.DecodeBool(v)
+		default:
+			// Inputs, verbosity and the sandbox directory aren't used.
+			n = protowire.ConsumeFieldValue(number, wireType, message)
+		}
+
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+
+		message = message[n:]
+	}
+
+	return result, nil
+}
+
+func (c *protoWorkerCodec) writeResponse(response *workResponse) error {
+	var message []byte
+
+	message = protowire.AppendTag(message, workResponseExitCodeField, protowire.VarintType)
+	message = protowire.AppendVarint(message, uint64(response.ExitCode))
+	message = protowire.AppendTag(message, workResponseOutputField, protowire.BytesType)
+	message = protowire.AppendString(message, response.Output)
+	message = protowire.AppendTag(message, workResponseRequestIDField, protowire.VarintType)
+	message = protowire.AppendVarint(message, uint64(response.RequestID))
+
+	_, err := c.writer.Write(protowire.AppendBytes(nil, message))
+
+	return err
+}
+
+func (c *jsonWorkerCodec) readRequest() (*workRequest, error) {
+	result := new(workRequest)
+	if err := c.decoder.Decode(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *jsonWorkerCodec) writeResponse(response *workResponse) error {
+	return c.encoder.Encode(response)
+}
@@ -0,0 +1,155 @@
+package checker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestServeWorkRequestsProto(t *testing.T) {
+	var input []byte
+
+	for i, args := range [][]string{{"a.proto"}, {"--fail-fast", "b.proto"}} {
+		var message []byte
+
+		for _, arg := range args {
+			message = protowire.AppendTag(message, workRequestArgumentsField, protowire.BytesType)
+			message = protowire.AppendString(message, arg)
+		}
+
+		// Inputs must be skipped.
+		message = protowire.AppendTag(message, 2, protowire.BytesType)
+		message = protowire.AppendBytes(message, []byte{0x0a, 0x01, 'x'})
+		message = protowire.AppendTag(message, workRequestIDField, protowire.VarintType)
+		message = protowire.AppendVarint(message, uint64(i+1))
+
+		input = protowire.AppendBytes(input, message)
+	}
+
+	var output bytes.Buffer
+
+	codec, err := newWorkerCodec(WorkerProtocolProto, bytes.NewReader(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = serveWorkRequests(context.Background(), codec, handleTestWorkRequest); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		reader    = bufio.NewReader(&output)
+		responses []*workResponse
+	)
+
+	for {
+		size, err := binary.ReadUvarint(reader)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message := make([]byte, size)
+		if _, err = io.ReadFull(reader, message); err != nil {
+			t.Fatal(err)
+		}
+
+		responses = append(responses, decodeTestWorkResponse(t, message))
+	}
+
+	expectedResponses := []*workResponse{
+		{ExitCode: 0, Output: "a.proto\n", RequestID: 1},
+		{ExitCode: 1, Output: "Work request failed: b.proto\n", RequestID: 2},
+	}
+	if !reflect.DeepEqual(responses, expectedResponses) {
+		t.Errorf("expected responses %+v, got %+v", expectedResponses, responses)
+	}
+}
+
+func TestServeWorkRequestsJSON(t *testing.T) {
+	var (
+		input = `{"arguments": ["a.proto"], "requestId": 3}
+{"arguments": ["a.proto"], "requestId": 3, "cancel": true}
+{"arguments": ["c.proto"], "sandboxDir": "sandbox"}`
+		output bytes.Buffer
+	)
+
+	codec, err := newWorkerCodec(WorkerProtocolJSON, strings.NewReader(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = serveWorkRequests(context.Background(), codec, handleTestWorkRequest); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOutput := `{"exitCode":0,"output":"a.proto\n","requestId":3}
+{"exitCode":0,"output":"c.proto\n","requestId":0}
+`
+	if output.String() != expectedOutput {
+		t.Errorf("expected output %q, got %q", expectedOutput, output.String())
+	}
+}
+
+// handleTestWorkRequest echoes the last argument, panicking on arguments with flags.
+func handleTestWorkRequest(_ context.Context, args []string, output io.Writer) int {
+	if len(args) > 1 {
+		panic(args[len(args)-1])
+	}
+
+	fmt.Fprintln(output, args[0])
+
+	return 0
+}
+
+func decodeTestWorkResponse(t *testing.T, message []byte) *workResponse {
+	t.Helper()
+
+	result := new(workResponse)
+
+	for len(message) > 0 {
+		number, _, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+
+		message = message[n:]
+
+		switch number {
+		case workResponseOutputField:
+			var v string
+
+			v, n = protowire.ConsumeString(message)
+			result.Output = v
+		default:
+			var v uint64
+
+			v, n = protowire.ConsumeVarint(message)
+
+			if number == workResponseExitCodeField {
+				result.ExitCode = int32(v)
+			} else {
+				result.RequestID = int32(v)
+			}
+		}
+
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+
+		message = message[n:]
+	}
+
+	return result
+}
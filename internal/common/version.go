@@ -0,0 +1,130 @@
+package common
+
+import (
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// devVersion is the version of builds having neither injected nor module version information.
+const devVersion = "dev"
+
+// pseudoVersionRegexp matches the timestamp and revision suffix of Go pseudo-versions like v0.0.0-20230101000000-abcdef123456.
+var pseudoVersionRegexp = regexp.MustCompile(`\d{14}-[0-9a-f]{12}(\+.*)?$`)
+
+// Build information injected at build time, for example:
+//
+//	go build -ldflags "-X github.com/oshokin/protolinter/internal/common.version=1.2.0
+//	                   -X github.com/oshokin/protolinter/internal/common.commit=$(git rev-parse HEAD)"
+//
+// If they aren't injected, they are taken from the build information embedded by the Go toolchain.
+var (
+	version string
+	commit  string
+)
+
+// BuildInfo describes the build of the linter.
+type BuildInfo struct {
+	Version   string // Version of the linter.
+	Commit    string // Revision the linter was built from, empty if unknown.
+	GoVersion string // Version of Go the linter was built with.
+}
+
+// Version returns the version of the linter.
+func Version() string {
+	return GetBuildInfo().Version
+}
+
+// GetBuildInfo returns the build information of the linter,
+// preferring the values injected at build time to the ones embedded by the Go toolchain.
+func GetBuildInfo() *BuildInfo {
+	result := &BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		// Modules installed with "go install module@version" have their version embedded.
+		if result.Version == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			result.Version = info.Main.Version
+		}
+
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && result.Commit == "" {
+				result.Commit = setting.Value
+			}
+		}
+	}
+
+	if result.Version == "" {
+		result.Version = devVersion
+	}
+
+	return result
+}
+
+// IsVersionOlder reports whether the semantic version is older than the other one.
+// Prefixes "v" are ignored, versions that can't be parsed are never considered older.
+func IsVersionOlder(version, other string) bool {
+	parsedVersion, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+
+	parsedOther, ok := parseVersion(other)
+	if !ok {
+		return false
+	}
+
+	for i := range parsedVersion {
+		if parsedVersion[i] != parsedOther[i] {
+			return parsedVersion[i] < parsedOther[i]
+		}
+	}
+
+	return false
+}
+
+// IsValidVersion reports whether the version is a semantic version like 1.4.0 or v1.4.0.
+func IsValidVersion(version string) bool {
+	_, ok := parseVersion(version)
+
+	return ok
+}
+
+// IsReleaseVersion reports whether the version identifies a release,
+// rather than a development build or a Go pseudo-version of an untagged commit.
+func IsReleaseVersion(version string) bool {
+	return IsValidVersion(version) && !pseudoVersionRegexp.MatchString(version)
+}
+
+// parseVersion returns the major, minor and patch numbers of the version, ignoring pre-release and build suffixes.
+func parseVersion(version string) ([3]int, bool) {
+	var result [3]int
+
+	version = strings.TrimPrefix(version, "v")
+
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) > len(result) {
+		return result, false
+	}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return result, false
+		}
+
+		result[i] = number
+	}
+
+	return result, true
+}
@@ -0,0 +1,46 @@
+package common
+
+import "testing"
+
+func TestIsVersionOlder(t *testing.T) {
+	testCases := []struct {
+		version  string
+		other    string
+		expected bool
+	}{
+		{"1.0.0", "v1.0.1", true},
+		{"v1.2.0", "v1.10.0", true},
+		{"1.2", "1.2.1", true},
+		{"v2.0.0", "v1.9.9", false},
+		{"v1.2.0", "v1.2.0", false},
+		{"v1.2.0-rc.1+abc", "v1.3.0", true},
+		{"dev", "v1.3.0", false},
+		{"6e3fb17-dirty", "v1.3.0", false},
+	}
+
+	for _, tc := range testCases {
+		if actual := IsVersionOlder(tc.version, tc.other); actual != tc.expected {
+			t.Errorf("IsVersionOlder(%s, %s): expected %t, got %t", tc.version, tc.other, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsReleaseVersion(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{"1.4.0", true},
+		{"v1.4.0-rc.1", true},
+		{"dev", false},
+		{"6e3fb17-dirty", false},
+		{"v0.0.0-20261016004329-6e3fb179c61a+dirty", false},
+		{"v1.4.1-0.20261016004329-6e3fb179c61a", false},
+	}
+
+	for _, tc := range testCases {
+		if actual := IsReleaseVersion(tc.version); actual != tc.expected {
+			t.Errorf("IsReleaseVersion(%s): expected %t, got %t", tc.version, tc.expected, actual)
+		}
+	}
+}
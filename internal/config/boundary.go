@@ -0,0 +1,39 @@
+package config
+
+import "strings"
+
+// packagePatternWildcard is the suffix of package patterns matching nested packages.
+const packagePatternWildcard = ".*"
+
+// FindImportBoundary returns the pattern of the import boundary forbidding
+// the package to depend on the other package, or an empty string if the dependency is allowed.
+func (cfg *Config) FindImportBoundary(fromPackage, toPackage string) string {
+	if fromPackage == toPackage {
+		return ""
+	}
+
+	for _, boundary := range cfg.GetImportBoundaries() {
+		if !matchPackagePattern(boundary.From, fromPackage) {
+			continue
+		}
+
+		for _, pattern := range boundary.Forbid {
+			if matchPackagePattern(pattern, toPackage) {
+				return pattern
+			}
+		}
+	}
+
+	return ""
+}
+
+// matchPackagePattern returns true if the package matches the pattern,
+// which is either a package name or a package name followed by ".*".
+func matchPackagePattern(pattern, packageName string) bool {
+	prefix, isWildcard := strings.CutSuffix(pattern, packagePatternWildcard)
+	if !isWildcard {
+		return pattern == packageName
+	}
+
+	return packageName == prefix || strings.HasPrefix(packageName, strings.Join([]string{prefix, "."}, ""))
+}
@@ -0,0 +1,38 @@
+package config
+
+import "testing"
+
+func TestFindImportBoundary(t *testing.T) {
+	cfg := &Config{
+		ImportBoundaries: []*ImportBoundary{
+			{
+				From:   "payments.*",
+				Forbid: []string{"orders.internal.*", "billing"},
+			},
+		},
+	}
+
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{from: "payments.v1", to: "orders.internal.v1", expected: "orders.internal.*"},
+		{from: "payments", to: "orders.internal", expected: "orders.internal.*"},
+		{from: "payments.v1", to: "billing", expected: "billing"},
+		{from: "payments.v1", to: "billing.v1", expected: ""},
+		{from: "payments.v1", to: "orders.internalx", expected: ""},
+		{from: "paymentsx.v1", to: "orders.internal.v1", expected: ""},
+		{from: "orders.v1", to: "orders.internal.v1", expected: ""},
+	}
+
+	for _, test := range tests {
+		if actual := cfg.FindImportBoundary(test.from, test.to); actual != test.expected {
+			t.Errorf("expected %q for %s importing %s, got %q", test.expected, test.from, test.to, actual)
+		}
+	}
+
+	if actual := (*Config)(nil).FindImportBoundary("payments.v1", "billing"); actual != "" {
+		t.Errorf("expected no boundary for nil configuration, got %q", actual)
+	}
+}
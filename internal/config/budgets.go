@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultFindingsBudgetKey is the key of max_findings_per_package applying to packages matching no pattern.
+const DefaultFindingsBudgetKey = "default"
+
+// HasFindingsBudgets returns true if the numbers of findings are limited per package
+// rather than failing the run on any error.
+func (cfg *Config) HasFindingsBudgets() bool {
+	return cfg != nil && len(cfg.findingsBudgets) > 0
+}
+
+// GetPackageFindingsBudget returns the number of error findings the package may have
+// and false if the number isn't limited.
+// The longest pattern matching the package applies, then the default budget.
+func (cfg *Config) GetPackageFindingsBudget(packageName string) (int, bool) {
+	if !cfg.HasFindingsBudgets() {
+		return 0, false
+	}
+
+	var (
+		result       int
+		isFound      bool
+		foundPattern string
+	)
+
+	for pattern, budget := range cfg.findingsBudgets {
+		if pattern == DefaultFindingsBudgetKey || !matchPackagePattern(pattern, packageName) {
+			continue
+		}
+
+		if !isFound || len(pattern) > len(foundPattern) {
+			result, isFound, foundPattern = budget, true, pattern
+		}
+	}
+
+	if isFound {
+		return result, true
+	}
+
+	result, isFound = cfg.findingsBudgets[DefaultFindingsBudgetKey]
+
+	return result, isFound
+}
+
+// flattenFindingsBudgets turns the budgets of max_findings_per_package into a map of package patterns.
+// The loader splits keys by dots, so "legacy.*: 50" is read as a map nested into "legacy",
+// its keys are joined back with the prefix.
+func flattenFindingsBudgets(budgets map[string]interface{}, prefix string) (map[string]int, error) {
+	result := make(map[string]int, len(budgets))
+
+	for key, value := range budgets {
+		pattern := key
+		if prefix != "" {
+			pattern = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			nestedBudgets, err := flattenFindingsBudgets(nested, pattern)
+			if err != nil {
+				return nil, err
+			}
+
+			for nestedPattern, budget := range nestedBudgets {
+				result[nestedPattern] = budget
+			}
+
+			continue
+		}
+
+		budget, err := parseFindingsBudget(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget of packages %s in max_findings_per_package: %w", pattern, err)
+		}
+
+		result[pattern] = budget
+	}
+
+	return result, nil
+}
+
+func parseFindingsBudget(value interface{}) (int, error) {
+	var result int
+
+	switch v := value.(type) {
+	case int:
+		result = v
+	case int64:
+		result = int(v)
+	case uint64:
+		result = int(v)
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("%v is not a whole number", v)
+		}
+
+		result = int(v)
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+
+	if result < 0 {
+		return 0, fmt.Errorf("%d is negative", result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetPackageFindingsBudget(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".protolinter.yaml")
+
+	source := `max_findings_per_package:
+  default: 0
+  "legacy.*": 50
+  legacy.orders: 3
+`
+
+	if err := os.WriteFile(configPath, []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	tests := []struct {
+		packageName string
+		expected    int
+	}{
+		{packageName: "legacy", expected: 50},
+		{packageName: "legacy.payments", expected: 50},
+		{packageName: "legacy.orders", expected: 3},
+		{packageName: "legacy.orders.v1", expected: 50},
+		{packageName: "orders", expected: 0},
+	}
+
+	for _, tt := range tests {
+		budget, ok := cfg.GetPackageFindingsBudget(tt.packageName)
+		if !ok || budget != tt.expected {
+			t.Errorf("got budget %d (%t) of %s, want %d", budget, ok, tt.packageName, tt.expected)
+		}
+	}
+
+	var unlimited *Config
+	if _, ok := unlimited.GetPackageFindingsBudget("orders"); ok {
+		t.Error("budget is limited without configuration")
+	}
+}
+
+func TestFlattenFindingsBudgetsRejectsNegative(t *testing.T) {
+	if _, err := flattenFindingsBudgets(map[string]interface{}{"legacy": -1}, ""); err == nil {
+		t.Error("negative budget is accepted")
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	checkSeveritiesKey = "check_severities"
+	escalationsKey     = "escalate"
+	overridesKey       = "overrides"
+)
+
+// CheckReference is a mention of a check in the configuration.
+type CheckReference struct {
+	Check string // Name of the check.
+	Key   string // Key of the configuration the check is mentioned under, like excluded_checks.
+}
+
+// GetCheckReferences returns the mentions of checks in all sections of the configuration:
+// excluded checks, severities, escalation policies and overrides.
+func (cfg *Config) GetCheckReferences() []*CheckReference {
+	if cfg == nil {
+		return nil
+	}
+
+	var result []*CheckReference
+
+	for _, check := range cfg.ExcludedChecks {
+		result = append(result, &CheckReference{Check: check, Key: excludedChecksKey})
+	}
+
+	for _, check := range getSortedKeys(cfg.CheckSeverities) {
+		result = append(result, &CheckReference{Check: check, Key: checkSeveritiesKey})
+	}
+
+	for _, escalation := range cfg.Escalations {
+		result = append(result, &CheckReference{Check: escalation.Check, Key: escalationsKey})
+	}
+
+	for i, override := range cfg.Overrides {
+		for _, check := range override.ExcludedChecks {
+			result = append(result, &CheckReference{
+				Check: check,
+				Key:   fmt.Sprintf("%s[%d].%s", overridesKey, i, excludedChecksKey),
+			})
+		}
+
+		for _, check := range getSortedKeys(override.CheckSeverities) {
+			result = append(result, &CheckReference{
+				Check: check,
+				Key:   fmt.Sprintf("%s[%d].%s", overridesKey, i, checkSeveritiesKey),
+			})
+		}
+	}
+
+	return result
+}
+
+// RenameChecks replaces the names of checks with the new ones in all sections of the configuration,
+// so configurations written for renamed checks keep working.
+func (cfg *Config) RenameChecks(newNames map[string]string) {
+	if cfg == nil || len(newNames) == 0 {
+		return
+	}
+
+	renameChecksInList(cfg.ExcludedChecks, newNames)
+	cfg.CheckSeverities = renameChecksInSeverities(cfg.CheckSeverities, newNames)
+
+	for _, escalation := range cfg.Escalations {
+		if newName, ok := newNames[escalation.Check]; ok {
+			escalation.Check = newName
+		}
+	}
+
+	for _, override := range cfg.Overrides {
+		renameChecksInList(override.ExcludedChecks, newNames)
+		override.CheckSeverities = renameChecksInSeverities(override.CheckSeverities, newNames)
+	}
+
+	if cfg.excludedChecksMap == nil {
+		return
+	}
+
+	cfg.excludedChecksMap = make(map[string]struct{}, len(cfg.ExcludedChecks))
+	for _, check := range cfg.ExcludedChecks {
+		cfg.excludedChecksMap[check] = struct{}{}
+	}
+}
+
+func renameChecksInList(checks []string, newNames map[string]string) {
+	for i, check := range checks {
+		if newName, ok := newNames[check]; ok {
+			checks[i] = newName
+		}
+	}
+}
+
+// renameChecksInSeverities returns the severities keyed by the new names of checks.
+// If a check is listed under both names, the severity set for the new name wins.
+func renameChecksInSeverities(severities map[string]Severity, newNames map[string]string) map[string]Severity {
+	if len(severities) == 0 {
+		return severities
+	}
+
+	result := make(map[string]Severity, len(severities))
+
+	for check, severity := range severities {
+		newName, ok := newNames[check]
+		if !ok {
+			result[check] = severity
+
+			continue
+		}
+
+		if _, isSet := severities[newName]; !isSet {
+			result[newName] = severity
+		}
+	}
+
+	return result
+}
+
+func getSortedKeys(severities map[string]Severity) []string {
+	result := make([]string, 0, len(severities))
+	for check := range severities {
+		result = append(result, check)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
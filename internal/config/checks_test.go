@@ -0,0 +1,51 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenameChecks(t *testing.T) {
+	cfg := &Config{
+		ExcludedChecks: []string{"old_check", "other_check"},
+		CheckSeverities: map[string]Severity{
+			"old_check":   SeverityWarning,
+			"other_check": SeverityError,
+		},
+		Escalations: []*Escalation{{Check: "old_check"}},
+	}
+
+	cfg.RenameChecks(map[string]string{"old_check": "new_check"})
+
+	if expected := []string{"new_check", "other_check"}; !reflect.DeepEqual(cfg.ExcludedChecks, expected) {
+		t.Errorf("expected excluded checks %v, got %v", expected, cfg.ExcludedChecks)
+	}
+
+	expectedSeverities := map[string]Severity{
+		"new_check":   SeverityWarning,
+		"other_check": SeverityError,
+	}
+	if !reflect.DeepEqual(cfg.CheckSeverities, expectedSeverities) {
+		t.Errorf("expected severities %v, got %v", expectedSeverities, cfg.CheckSeverities)
+	}
+
+	if cfg.Escalations[0].Check != "new_check" {
+		t.Errorf("expected escalated check new_check, got %s", cfg.Escalations[0].Check)
+	}
+}
+
+func TestRenameChecksKeepsSeverityOfNewName(t *testing.T) {
+	cfg := &Config{
+		CheckSeverities: map[string]Severity{
+			"old_check": SeverityWarning,
+			"new_check": SeverityError,
+		},
+	}
+
+	cfg.RenameChecks(map[string]string{"old_check": "new_check"})
+
+	expected := map[string]Severity{"new_check": SeverityError}
+	if !reflect.DeepEqual(cfg.CheckSeverities, expected) {
+		t.Errorf("expected severities %v, got %v", expected, cfg.CheckSeverities)
+	}
+}
@@ -1,45 +1,257 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/oshokin/protolinter/internal/common"
 	"github.com/spf13/viper"
 )
 
-// DefaultConfigName - default configuration file name.
-const DefaultConfigName = ".protolinter.yaml"
+const (
+	// DefaultConfigName - default configuration file name.
+	DefaultConfigName = ".protolinter.yaml"
 
-// LoadConfig loads the configuration from the specified file using Viper.
+	// DefaultFieldDescriptionMinLength is the default minimum number of characters in a field description.
+	DefaultFieldDescriptionMinLength = 10
+	// DefaultFieldDescriptionMaxLength is the default maximum number of characters in a field description.
+	DefaultFieldDescriptionMaxLength = 500
+	// DefaultHTTPPathMaxSegments is the default maximum number of segments in an HTTP path.
+	DefaultHTTPPathMaxSegments = 6
+	// DefaultServiceMaxMethods is the default maximum number of methods in a service.
+	DefaultServiceMaxMethods = 40
+	// DefaultPackageMaxServices is the default maximum number of services in a package.
+	DefaultPackageMaxServices = 10
+	// DefaultFileMaxLines is the default maximum number of lines in a file.
+	DefaultFileMaxLines = 2000
+	// DefaultFileMaxMessages is the default maximum number of top-level messages in a file.
+	DefaultFileMaxMessages = 100
+	// DefaultMaxLineLength is the default maximum number of characters in a line of a file.
+	DefaultMaxLineLength = 120
+	// DefaultIndentationWidth is the default number of spaces in a level of indentation.
+	DefaultIndentationWidth = 2
+	// DefaultDownloadMaxSize is the default maximum size of a downloaded dependency in bytes.
+	DefaultDownloadMaxSize = 1 << 20
+	// DefaultDownloadMaxConcurrency is the default maximum number of dependencies downloaded at the same time.
+	DefaultDownloadMaxConcurrency = 4
+	// DefaultDownloadMaxAttempts is the default maximum number of attempts to download a dependency.
+	DefaultDownloadMaxAttempts = 3
+	// DefaultErrorWeight is the default weight of errors in scores.
+	DefaultErrorWeight = 1.0
+	// DefaultWarningWeight is the default weight of warnings in scores.
+	DefaultWarningWeight = 0.5
+	// DefaultCategoryWeight is the default weight of categories of checks in scores.
+	DefaultCategoryWeight = 1.0
+
+	// FileHeaderYearPlaceholder is replaced in the header template with a year or a range of years.
+	FileHeaderYearPlaceholder = "{year}"
+
+	// EscalationDateLayout is the layout of dates in escalation policies.
+	EscalationDateLayout = "2006-01-02"
+)
+
+var (
+	// DefaultMapKeyAllowedTypes is the default list of key types maps may use.
+	DefaultMapKeyAllowedTypes = []string{"string", "int64"}
+	// DefaultMapKeyWarningTypes is the default list of key types of maps reported as warnings,
+	// since JSON gateways stringify them inconsistently.
+	DefaultMapKeyWarningTypes = []string{"int32"}
+
+	// DefaultAcronyms is the default list of acronyms checked by identifier_acronym_style.
+	DefaultAcronyms = []string{"ID", "URL", "HTTP", "API"}
+
+	// DefaultTasks is the default list of tasks the run command executes.
+	DefaultTasks = []string{TaskCompile, TaskLint}
+	// SupportedTasks is the list of tasks the run command can execute.
+	SupportedTasks = []string{TaskCompile, TaskLint, TaskScore, TaskCoverage, TaskBreaking, TaskOpenAPI}
+
+	// DefaultMethodVerbs is the default list of verbs of method names along with the HTTP methods
+	// they may be bound to.
+	DefaultMethodVerbs = []*MethodVerb{
+		{Prefix: "Get", HTTPMethods: []string{"get"}},
+		{Prefix: "List", HTTPMethods: []string{"get"}},
+		{Prefix: "Create", HTTPMethods: []string{"post"}},
+		{Prefix: "Update", HTTPMethods: []string{"put", "patch"}},
+		{Prefix: "Delete", HTTPMethods: []string{"delete"}},
+	}
+
+	// DefaultDownloadAllowedContentTypes is the default list of content types downloaded dependencies
+	// may be served with, GitHub serves raw files as text/plain.
+	DefaultDownloadAllowedContentTypes = []string{"text/plain", "application/octet-stream"}
+
+	// DefaultEnvironmentURLPatterns is the default list of patterns of environment-specific values
+	// forbidden in options by the option_no_environment_urls check.
+	DefaultEnvironmentURLPatterns = []string{
+		`localhost`,
+		`127\.0\.0\.1`,
+		`0\.0\.0\.0`,
+		`\bstaging\.`,
+		`\.internal\b`,
+		`\.local\b`,
+	}
+
+	// defaultEnvironmentURLRegexps are the compiled DefaultEnvironmentURLPatterns.
+	defaultEnvironmentURLRegexps, _ = compileEnvironmentURLPatterns(DefaultEnvironmentURLPatterns)
+
+	// DefaultSensitiveDataPatterns is the default list of patterns of names of fields holding sensitive data
+	// checked by the field_sensitive_data_annotation check. Pagination tokens aren't matched.
+	DefaultSensitiveDataPatterns = []string{
+		`password`,
+		`^token$`,
+		`(^|_)(access|refresh|auth|api|session|bearer|id)_token$`,
+		`(^|_)ssn($|_)`,
+		`card_number`,
+	}
+
+	// DefaultPIIPatterns is the default list of patterns of names of fields holding personal data
+	// checked by the field_pii_debug_redact check.
+	DefaultPIIPatterns = []string{
+		`email`,
+		`phone`,
+		`(^|_)(first|middle|last|full)_name$`,
+		`birth`,
+		`address`,
+		`passport`,
+		`(^|_)ssn($|_)`,
+	}
+
+	// DefaultNonSensitivePatterns is the default list of patterns of names of fields that can't hold personal data
+	// reported by the field_pii_debug_redact check in the strict mode.
+	DefaultNonSensitivePatterns = []string{
+		`^id$`,
+		`(^|_)(create|update|delete)d?_(at|time)$`,
+		`(^|_)(next_)?page_(size|token)$`,
+		`(^|_)(status|state)$`,
+	}
+
+	// defaultPIIRegexps are the compiled DefaultPIIPatterns.
+	defaultPIIRegexps, _ = compileCaseInsensitivePatterns(DefaultPIIPatterns, "field_pii_debug_redact")
+
+	// defaultNonSensitiveRegexps are the compiled DefaultNonSensitivePatterns.
+	defaultNonSensitiveRegexps, _ = compileCaseInsensitivePatterns(
+		DefaultNonSensitivePatterns,
+		"field_pii_debug_redact")
+
+	// defaultSensitiveDataRegexps are the compiled DefaultSensitiveDataPatterns.
+	defaultSensitiveDataRegexps, _ = compileCaseInsensitivePatterns(
+		DefaultSensitiveDataPatterns,
+		"field_sensitive_data_annotation")
+
+	// sha256Regexp matches SHA-256 checksums in hex.
+	sha256Regexp = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+	// mapKeyTypes is a list of types protobuf allows as map keys.
+	mapKeyTypes = map[string]struct{}{
+		"int32": {}, "int64": {}, "uint32": {}, "uint64": {}, "sint32": {}, "sint64": {},
+		"fixed32": {}, "fixed64": {}, "sfixed32": {}, "sfixed64": {}, "bool": {}, "string": {},
+	}
+)
+
+// LoadConfig loads the configuration from the specified file using Viper,
+// applying the overrides set by environment variables and flags bound by BindOverrideFlags.
 // If the filename is empty, it loads the default configuration file.
+// If the file doesn't exist and nothing is overridden, it returns nil.
+// The file is read by its exact path, its format is chosen by the extension,
+// files without an extension or with an extension Viper doesn't support are read as YAML.
 func LoadConfig(filename string) (*Config, error) {
 	if filename == "" {
 		filename = DefaultConfigName
 	}
 
-	if _, err := os.Open(filename); os.IsNotExist(err) {
-		return nil, nil
+	v := viper.New()
+
+	isOverridden, err := applyOverrides(v)
+	if err != nil {
+		return nil, err
 	}
 
-	viper.SetConfigName(filename)
+	if _, err = os.Stat(filename); os.IsNotExist(err) {
+		if !isOverridden {
+			return nil, nil
+		}
+	} else {
+		v.SetConfigFile(filename)
 
-	err := viper.ReadInConfig()
-	if err != nil {
+		if !isSupportedConfigExtension(filepath.Ext(filename)) {
+			v.SetConfigType("yaml")
+		}
+
+		if err = v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = applyProfile(v); err != nil {
 		return nil, err
 	}
 
 	var container Config
 
-	err = viper.Unmarshal(&container)
+	err = v.Unmarshal(&container)
 	if err != nil {
 		return nil, err
 	}
 
 	result := &container
-	result.fillInnerData()
+	if err = result.fillInnerData(); err != nil {
+		return nil, err
+	}
+
+	// Files with excluded descriptors are downloaded by the configured client, so they are imported afterwards.
+	if err = result.importExcludedDescriptors(); err != nil {
+		return nil, err
+	}
+
+	if err = result.checkMinVersion(common.Version()); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
 
 	return result, nil
 }
 
+// isSupportedConfigExtension returns true if Viper can tell the format of a configuration file by the extension.
+func isSupportedConfigExtension(extension string) bool {
+	extension = strings.TrimPrefix(extension, ".")
+
+	for _, v := range viper.SupportedExts {
+		if extension == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkMinVersion returns an error if the version of the linter is older than the one required by the configuration.
+// Development builds can't be compared with releases, so they are always allowed to run.
+func (cfg *Config) checkMinVersion(version string) error {
+	minVersion := cfg.GetMinVersion()
+	if minVersion == "" || !common.IsReleaseVersion(version) {
+		return nil
+	}
+
+	if common.IsVersionOlder(version, minVersion) {
+		return fmt.Errorf("configuration requires protolinter %s or newer, installed version is %s", minVersion, version)
+	}
+
+	return nil
+}
+
+// GetMinVersion returns the value of MinVersion from the Config struct.
+// If the Config is nil, it returns an empty string, meaning any version is allowed.
+func (cfg *Config) GetMinVersion() string {
+	if cfg != nil {
+		return cfg.MinVersion
+	}
+
+	return ""
+}
+
 // GetVerboseMode returns the value of VerboseMode from the Config struct.
 // If the Config is nil or VerboseMode is not set, it returns false.
 func (cfg *Config) GetVerboseMode() bool {
@@ -60,6 +272,16 @@ func (cfg *Config) GetOmitCoordinates() bool {
 	return false
 }
 
+// GetLocale returns the language of diagnostic messages.
+// If the Config is nil or the locale is not set, it returns LocaleEnglish.
+func (cfg *Config) GetLocale() string {
+	if cfg != nil && cfg.Locale != "" {
+		return cfg.Locale
+	}
+
+	return LocaleEnglish
+}
+
 // GetExcludedChecks returns the list of excluded checks from the Config struct.
 // If the Config is nil or ExcludedChecks is not set, it returns an empty slice.
 func (cfg *Config) GetExcludedChecks() []string {
@@ -70,7 +292,8 @@ func (cfg *Config) GetExcludedChecks() []string {
 	return nil
 }
 
-// GetExcludedDescriptors returns the list of excluded descriptors from the Config struct.
+// GetExcludedDescriptors returns the list of excluded descriptors from the Config struct,
+// including the ones imported from excluded_descriptors_files.
 // If the Config is nil or ExcludedDescriptors is not set, it returns an empty slice.
 func (cfg *Config) GetExcludedDescriptors() []string {
 	if cfg != nil {
@@ -80,25 +303,931 @@ func (cfg *Config) GetExcludedDescriptors() []string {
 	return nil
 }
 
+// GetInlineExcludedDescriptors returns the list of excluded descriptors declared in the configuration file itself,
+// without the ones imported from excluded_descriptors_files.
+// If the Config is nil or ExcludedDescriptors is not set, it returns an empty slice.
+func (cfg *Config) GetInlineExcludedDescriptors() []string {
+	if cfg != nil {
+		return cfg.ExcludedDescriptors[:len(cfg.ExcludedDescriptors)-cfg.importedDescriptorsCount]
+	}
+
+	return nil
+}
+
+// GetOwnershipFile returns the value of OwnershipFile from the Config struct.
+// If the Config is nil or OwnershipFile is not set, it returns an empty string.
+func (cfg *Config) GetOwnershipFile() string {
+	if cfg != nil {
+		return cfg.OwnershipFile
+	}
+
+	return ""
+}
+
+// GetModuleName returns the value of ModuleName from the Config struct.
+// If the Config is nil or ModuleName is not set, it returns an empty string.
+func (cfg *Config) GetModuleName() string {
+	if cfg != nil {
+		return cfg.ModuleName
+	}
+
+	return ""
+}
+
+// GetImportRewrites returns the list of import rewrite rules from the Config struct.
+// If the Config is nil or ImportRewrites is not set, it returns an empty slice.
+func (cfg *Config) GetImportRewrites() []*ImportRewrite {
+	if cfg != nil {
+		return cfg.ImportRewrites
+	}
+
+	return nil
+}
+
+// GetImportBoundaries returns the list of rules forbidding packages to depend on other packages.
+// If the Config is nil or ImportBoundaries is not set, it returns an empty slice.
+func (cfg *Config) GetImportBoundaries() []*ImportBoundary {
+	if cfg != nil {
+		return cfg.ImportBoundaries
+	}
+
+	return nil
+}
+
+// GetDeprecatedImports returns the list of packages and files other files must not depend on.
+// If the Config is nil or DeprecatedImports is not set, it returns an empty slice.
+func (cfg *Config) GetDeprecatedImports() []*DeprecatedImport {
+	if cfg != nil {
+		return cfg.DeprecatedImports
+	}
+
+	return nil
+}
+
+// GetTrivialCommentsStrictness returns the strictness of the comment_not_trivial check.
+// If the Config is nil or the strictness is not set, it returns TrivialCommentsExact.
+func (cfg *Config) GetTrivialCommentsStrictness() string {
+	if cfg != nil && cfg.TrivialComments.Strictness != "" {
+		return cfg.TrivialComments.Strictness
+	}
+
+	return TrivialCommentsExact
+}
+
+// GetFieldDescriptionLengthBounds returns the minimum and maximum number of characters in a field description.
+// If the Config is nil or the bounds are not set, it returns the default bounds.
+func (cfg *Config) GetFieldDescriptionLengthBounds() (int, int) {
+	minLength, maxLength := DefaultFieldDescriptionMinLength, DefaultFieldDescriptionMaxLength
+	if cfg == nil {
+		return minLength, maxLength
+	}
+
+	if cfg.FieldDescriptionLength.Min > 0 {
+		minLength = cfg.FieldDescriptionLength.Min
+	}
+
+	if cfg.FieldDescriptionLength.Max > 0 {
+		maxLength = cfg.FieldDescriptionLength.Max
+	}
+
+	return minLength, maxLength
+}
+
+// GetCommentSyntax returns the syntax documentation comments must use.
+// If the Config is nil or the syntax is not set, it returns CommentSyntaxLine.
+func (cfg *Config) GetCommentSyntax() string {
+	if cfg != nil && cfg.CommentStyle.Syntax != "" {
+		return cfg.CommentStyle.Syntax
+	}
+
+	return CommentSyntaxLine
+}
+
+// GetMessageCyclesMaxDepth returns the maximum number of messages in an allowed reference cycle.
+// If the Config is nil or the depth is not set, it returns 0, which means cycles are forbidden.
+func (cfg *Config) GetMessageCyclesMaxDepth() int {
+	if cfg != nil {
+		return cfg.MessageCycles.MaxDepth
+	}
+
+	return 0
+}
+
+// GetHTTPPathMaxSegments returns the maximum number of segments in an HTTP path, not counting the version prefix.
+// If the Config is nil or the number is not set, it returns DefaultHTTPPathMaxSegments.
+func (cfg *Config) GetHTTPPathMaxSegments() int {
+	if cfg != nil && cfg.HTTPPathMaxDepth.MaxSegments > 0 {
+		return cfg.HTTPPathMaxDepth.MaxSegments
+	}
+
+	return DefaultHTTPPathMaxSegments
+}
+
+// GetAcronyms returns the acronyms whose spelling in names is checked, in upper case.
+// If the Config is nil or the list is not set, it returns DefaultAcronyms.
+func (cfg *Config) GetAcronyms() []string {
+	if cfg == nil || cfg.AcronymStyle.Acronyms == nil {
+		return DefaultAcronyms
+	}
+
+	result := make([]string, 0, len(cfg.AcronymStyle.Acronyms))
+	for _, acronym := range cfg.AcronymStyle.Acronyms {
+		result = append(result, strings.ToUpper(acronym))
+	}
+
+	return result
+}
+
+// GetAcronymStyle returns the spelling of acronyms in names.
+// If the Config is nil or the style is not set, it returns AcronymStyleCapitalized.
+func (cfg *Config) GetAcronymStyle() string {
+	if cfg != nil && cfg.AcronymStyle.Style != "" {
+		return cfg.AcronymStyle.Style
+	}
+
+	return AcronymStyleCapitalized
+}
+
+// GetServiceMaxMethods returns the maximum number of methods in a service.
+// If the Config is nil or the number is not set, it returns DefaultServiceMaxMethods.
+func (cfg *Config) GetServiceMaxMethods() int {
+	if cfg != nil && cfg.ServiceMaxMethods.MaxMethods > 0 {
+		return cfg.ServiceMaxMethods.MaxMethods
+	}
+
+	return DefaultServiceMaxMethods
+}
+
+// GetPackageMaxServices returns the maximum number of services in a package.
+// If the Config is nil or the number is not set, it returns DefaultPackageMaxServices.
+func (cfg *Config) GetPackageMaxServices() int {
+	if cfg != nil && cfg.PackageMaxServices.MaxServices > 0 {
+		return cfg.PackageMaxServices.MaxServices
+	}
+
+	return DefaultPackageMaxServices
+}
+
+// GetFileMaxLines returns the maximum number of lines in a file.
+// If the Config is nil or the number is not set, it returns DefaultFileMaxLines.
+func (cfg *Config) GetFileMaxLines() int {
+	if cfg != nil && cfg.FileMaxLines.MaxLines > 0 {
+		return cfg.FileMaxLines.MaxLines
+	}
+
+	return DefaultFileMaxLines
+}
+
+// GetFileMaxMessages returns the maximum number of top-level messages in a file.
+// If the Config is nil or the number is not set, it returns DefaultFileMaxMessages.
+func (cfg *Config) GetFileMaxMessages() int {
+	if cfg != nil && cfg.FileMaxMessages.MaxMessages > 0 {
+		return cfg.FileMaxMessages.MaxMessages
+	}
+
+	return DefaultFileMaxMessages
+}
+
+// GetFileHeaderPatterns returns the patterns the lines of the header of every file must match,
+// one per line of the template. If the Config is nil or the template is not set, it returns nil.
+func (cfg *Config) GetFileHeaderPatterns() []*regexp.Regexp {
+	if cfg != nil {
+		return cfg.FileHeader.linePatterns
+	}
+
+	return nil
+}
+
+// GetEnvironmentURLPatterns returns the case-insensitive patterns of environment-specific values
+// forbidden in options. If the Config is nil or the patterns are not set, it returns DefaultEnvironmentURLPatterns.
+func (cfg *Config) GetEnvironmentURLPatterns() []*regexp.Regexp {
+	if cfg != nil && cfg.EnvironmentURLs.patterns != nil {
+		return cfg.EnvironmentURLs.patterns
+	}
+
+	return defaultEnvironmentURLRegexps
+}
+
+// GetSensitiveDataPatterns returns the case-insensitive patterns of names of fields holding sensitive data.
+// If the Config is nil or the patterns are not set, it returns DefaultSensitiveDataPatterns.
+func (cfg *Config) GetSensitiveDataPatterns() []*regexp.Regexp {
+	if cfg != nil && cfg.SensitiveData.patterns != nil {
+		return cfg.SensitiveData.patterns
+	}
+
+	return defaultSensitiveDataRegexps
+}
+
+// GetSensitiveDataOptions returns the full names of field options marking a field as sensitive.
+// If the Config is nil, it returns nil, so only debug_redact is accepted.
+func (cfg *Config) GetSensitiveDataOptions() []string {
+	if cfg != nil {
+		return cfg.SensitiveData.Options
+	}
+
+	return nil
+}
+
+// GetPIIPatterns returns the case-insensitive patterns of names of fields holding personal data.
+// If the Config is nil or the patterns are not set, it returns DefaultPIIPatterns.
+func (cfg *Config) GetPIIPatterns() []*regexp.Regexp {
+	if cfg != nil && cfg.PIIDebugRedact.patterns != nil {
+		return cfg.PIIDebugRedact.patterns
+	}
+
+	return defaultPIIRegexps
+}
+
+// GetPIIAnnotations returns the full names of field options marking a field as holding personal data.
+// If the Config is nil, it returns nil.
+func (cfg *Config) GetPIIAnnotations() []string {
+	if cfg != nil {
+		return cfg.PIIDebugRedact.Annotations
+	}
+
+	return nil
+}
+
+// GetNonSensitivePatterns returns the case-insensitive patterns of names of fields that can't hold personal data.
+// If the Config is nil or the patterns are not set, it returns DefaultNonSensitivePatterns.
+func (cfg *Config) GetNonSensitivePatterns() []*regexp.Regexp {
+	if cfg != nil && cfg.PIIDebugRedact.nonSensitivePatterns != nil {
+		return cfg.PIIDebugRedact.nonSensitivePatterns
+	}
+
+	return defaultNonSensitiveRegexps
+}
+
+// IsPIIDebugRedactStrict returns true if debug_redact is forbidden on fields that can't hold personal data.
+func (cfg *Config) IsPIIDebugRedactStrict() bool {
+	return cfg != nil && cfg.PIIDebugRedact.Strict
+}
+
+// GetMaxLineLength returns the maximum number of characters in a line of a file.
+// If the Config is nil or the length is not set, it returns DefaultMaxLineLength.
+func (cfg *Config) GetMaxLineLength() int {
+	if cfg != nil && cfg.MaxLineLength.MaxLength > 0 {
+		return cfg.MaxLineLength.MaxLength
+	}
+
+	return DefaultMaxLineLength
+}
+
+// GetIndentation returns the style of indentation and the number of spaces in a level of indentation.
+// If the Config is nil or the options are not set, it returns IndentationSpaces and DefaultIndentationWidth.
+func (cfg *Config) GetIndentation() (string, int) {
+	var (
+		style = IndentationSpaces
+		width = DefaultIndentationWidth
+	)
+
+	if cfg != nil {
+		if cfg.Indentation.Style != "" {
+			style = cfg.Indentation.Style
+		}
+
+		if cfg.Indentation.Width > 0 {
+			width = cfg.Indentation.Width
+		}
+	}
+
+	return style, width
+}
+
+// GetMapKeyTypes returns the key types maps may use and the key types reported as warnings.
+// If the Config is nil or the lists are not set, it returns DefaultMapKeyAllowedTypes and DefaultMapKeyWarningTypes.
+func (cfg *Config) GetMapKeyTypes() ([]string, []string) {
+	var (
+		allowed = DefaultMapKeyAllowedTypes
+		warning = DefaultMapKeyWarningTypes
+	)
+
+	if cfg != nil {
+		if cfg.MapKeyTypes.AllowedTypes != nil {
+			allowed = cfg.MapKeyTypes.AllowedTypes
+		}
+
+		if cfg.MapKeyTypes.WarningTypes != nil {
+			warning = cfg.MapKeyTypes.WarningTypes
+		}
+	}
+
+	return allowed, warning
+}
+
+// GetMethodVerbs returns the verbs of method names along with the HTTP methods they may be bound to.
+// If the Config is nil or the verbs are not set, it returns DefaultMethodVerbs.
+func (cfg *Config) GetMethodVerbs() []*MethodVerb {
+	if cfg != nil && cfg.MethodVerbs.Verbs != nil {
+		return cfg.MethodVerbs.Verbs
+	}
+
+	return DefaultMethodVerbs
+}
+
+// GetCreateResponseStrictness returns the strictness of the method_create_returns_resource check.
+// If the Config is nil or the strictness is not set, it returns CreateResponseEmbedded.
+func (cfg *Config) GetCreateResponseStrictness() string {
+	if cfg != nil && cfg.CreateResponse.Strictness != "" {
+		return cfg.CreateResponse.Strictness
+	}
+
+	return CreateResponseEmbedded
+}
+
+// GetUnknownOptionExtensionSources returns the import paths of files defining option extensions
+// unknown options are looked up in. If the Config is nil, it returns nil.
+func (cfg *Config) GetUnknownOptionExtensionSources() []string {
+	if cfg == nil {
+		return nil
+	}
+
+	return cfg.UnknownOptions.ExtensionSources
+}
+
+// GetTasks returns the list of tasks the run command executes in order.
+// If the Config is nil or the tasks are not set, it returns DefaultTasks.
+func (cfg *Config) GetTasks() []string {
+	if cfg != nil && len(cfg.Tasks) > 0 {
+		return cfg.Tasks
+	}
+
+	return DefaultTasks
+}
+
+// GetBreakingAgainst returns the path to the baseline descriptor set of the breaking task.
+// If the Config is nil, it returns an empty string.
+func (cfg *Config) GetBreakingAgainst() string {
+	if cfg == nil {
+		return ""
+	}
+
+	return cfg.Breaking.Against
+}
+
+// GetOpenAPICommand returns the generator command of the openapi task.
+// If the Config is nil, it returns nil.
+func (cfg *Config) GetOpenAPICommand() []string {
+	if cfg == nil {
+		return nil
+	}
+
+	return cfg.OpenAPI.Command
+}
+
+// GetDownloadMaxSize returns the maximum size of a downloaded dependency in bytes.
+// If the Config is nil or the size is not set, it returns DefaultDownloadMaxSize.
+func (cfg *Config) GetDownloadMaxSize() int64 {
+	if cfg != nil && cfg.Downloads.MaxSize > 0 {
+		return cfg.Downloads.MaxSize
+	}
+
+	return DefaultDownloadMaxSize
+}
+
+// GetDownloadMaxConcurrency returns the maximum number of dependencies downloaded at the same time.
+// If the Config is nil or the number is not set, it returns DefaultDownloadMaxConcurrency.
+func (cfg *Config) GetDownloadMaxConcurrency() int {
+	if cfg != nil && cfg.Downloads.MaxConcurrency > 0 {
+		return cfg.Downloads.MaxConcurrency
+	}
+
+	return DefaultDownloadMaxConcurrency
+}
+
+// GetDownloadMaxAttempts returns the maximum number of attempts to download a dependency.
+// If the Config is nil or the number is not set, it returns DefaultDownloadMaxAttempts.
+func (cfg *Config) GetDownloadMaxAttempts() int {
+	if cfg != nil && cfg.Downloads.MaxAttempts > 0 {
+		return cfg.Downloads.MaxAttempts
+	}
+
+	return DefaultDownloadMaxAttempts
+}
+
+// GetDownloadAllowedContentTypes returns the list of content types downloaded dependencies may be served with,
+// an empty list allows any type.
+// If the Config is nil or the list is not set, it returns DefaultDownloadAllowedContentTypes.
+func (cfg *Config) GetDownloadAllowedContentTypes() []string {
+	if cfg != nil && cfg.Downloads.AllowedContentTypes != nil {
+		return cfg.Downloads.AllowedContentTypes
+	}
+
+	return DefaultDownloadAllowedContentTypes
+}
+
+// GetDownloadChecksum returns the SHA-256 checksum pinned for the dependency with the import path.
+// If the Config is nil or no checksum is pinned, it returns an empty string.
+func (cfg *Config) GetDownloadChecksum(path string) string {
+	if cfg == nil {
+		return ""
+	}
+
+	for _, checksum := range cfg.Downloads.Checksums {
+		if checksum.Path == path {
+			return checksum.SHA256
+		}
+	}
+
+	return ""
+}
+
+// GetRemoteCacheURL returns the base URL of the remote cache of downloaded dependencies.
+// If the Config is nil or the remote cache is not configured, it returns an empty string.
+func (cfg *Config) GetRemoteCacheURL() string {
+	if cfg != nil {
+		return cfg.Cache.RemoteURL
+	}
+
+	return ""
+}
+
+// IsRemoteCacheReadOnly returns true if entries are only read from the remote cache.
+// If the Config is nil, it returns false.
+func (cfg *Config) IsRemoteCacheReadOnly() bool {
+	if cfg != nil {
+		return cfg.Cache.ReadOnly
+	}
+
+	return false
+}
+
+// GetSeverityWeight returns the weight of findings of the severity in scores.
+// If the Config is nil or the weight is not set, it returns DefaultErrorWeight or DefaultWarningWeight.
+func (cfg *Config) GetSeverityWeight(severity Severity) float64 {
+	if cfg != nil {
+		if weight, ok := cfg.Score.SeverityWeights[severity]; ok {
+			return weight
+		}
+	}
+
+	if severity == SeverityWarning {
+		return DefaultWarningWeight
+	}
+
+	return DefaultErrorWeight
+}
+
+// GetCategoryWeight returns the weight of findings of checks of the category in scores.
+// If the Config is nil or the weight is not set, it returns DefaultCategoryWeight.
+func (cfg *Config) GetCategoryWeight(category string) float64 {
+	if cfg != nil {
+		if weight, ok := cfg.Score.CategoryWeights[category]; ok {
+			return weight
+		}
+	}
+
+	return DefaultCategoryWeight
+}
+
+// GetMethodIOAllowedPackages returns the list of package prefixes of shared types
+// methods may use as input or output from any package.
+// If the Config is nil, it returns nil.
+func (cfg *Config) GetMethodIOAllowedPackages() []string {
+	if cfg != nil {
+		return cfg.MethodIOSamePackage.AllowedPackages
+	}
+
+	return nil
+}
+
+// GetMethodIOColocationPolicy returns where input and output messages of methods may be defined.
+// If the Config is nil or the policy is not set, it returns ColocationSameFile.
+func (cfg *Config) GetMethodIOColocationPolicy() string {
+	if cfg != nil && cfg.MethodIOColocated.Policy != "" {
+		return cfg.MethodIOColocated.Policy
+	}
+
+	return ColocationSameFile
+}
+
+// GetExternalDocsAllowedDomains returns the list of domains external docs URLs must belong to.
+// If the Config is nil, it returns nil, so any domain is allowed.
+func (cfg *Config) GetExternalDocsAllowedDomains() []string {
+	if cfg != nil {
+		return cfg.ExternalDocs.AllowedDomains
+	}
+
+	return nil
+}
+
+// GetExtensionPolicy returns the policy of extension declarations.
+// If the Config is nil or the policy is not set, it returns ExtensionPolicyDocument.
+func (cfg *Config) GetExtensionPolicy() string {
+	if cfg != nil && cfg.ExtensionPolicy != "" {
+		return cfg.ExtensionPolicy
+	}
+
+	return ExtensionPolicyDocument
+}
+
+// AreGoogleAPIServiceOptionsRequired returns true if services must set
+// google.api.default_host and google.api.oauth_scopes options.
+func (cfg *Config) AreGoogleAPIServiceOptionsRequired() bool {
+	if cfg != nil {
+		return cfg.GoogleAPIServiceOptions == GoogleAPIServiceOptionsRequired
+	}
+
+	return false
+}
+
+// AreGoPackageDirectoriesRequired returns true if go_package options must point
+// to existing directories of the Go module.
+func (cfg *Config) AreGoPackageDirectoriesRequired() bool {
+	if cfg != nil {
+		return cfg.GoPackageDirectories == GoPackageDirectoriesRequired
+	}
+
+	return false
+}
+
 // IsCheckExcluded checks if a specific check is excluded based on the configuration.
+// If checks are selected with WithOnlyChecks, every other check is excluded,
+// regardless of excluded checks and overrides.
 func (cfg *Config) IsCheckExcluded(name string) bool {
 	if cfg == nil {
 		return false
 	}
 
+	if cfg.onlyChecks != nil {
+		_, isSelected := cfg.onlyChecks[name]
+
+		return !isSelected
+	}
+
 	_, isExcluded := cfg.excludedChecksMap[name]
 
-	return isExcluded
+	return isExcluded || cfg.isExcludedByDeprecatedOptions(name)
 }
 
-func (cfg *Config) fillInnerData() {
+// GetCheckSeverity returns the severity of the check at the specified moment,
+// taking escalation policies into account.
+// If an escalation of the check is scheduled after the moment, it is returned as well.
+// If the check has no severity set, it starts with the severity the earliest escalation of the check
+// escalates from, so the escalation has a grace period, or with SeverityError if the check isn't escalated.
+// If the Config is nil, it returns SeverityError.
+func (cfg *Config) GetCheckSeverity(name string, now time.Time) (Severity, *Escalation) {
 	if cfg == nil {
-		return
+		return SeverityError, nil
+	}
+
+	severity, ok := cfg.CheckSeverities[name]
+	if !ok {
+		severity = cfg.getInitialSeverity(name)
+	}
+
+	var upcoming *Escalation
+
+	for _, escalation := range cfg.Escalations {
+		if escalation.Check != name || escalation.From != severity {
+			continue
+		}
+
+		if now.Before(escalation.afterDate) {
+			if upcoming == nil || escalation.afterDate.Before(upcoming.afterDate) {
+				upcoming = escalation
+			}
+
+			continue
+		}
+
+		severity = escalation.To
+	}
+
+	return severity, upcoming
+}
+
+// getInitialSeverity returns the severity the earliest escalation of the check escalates from,
+// or SeverityError if the check isn't escalated.
+func (cfg *Config) getInitialSeverity(name string) Severity {
+	var earliest *Escalation
+
+	for _, escalation := range cfg.Escalations {
+		if escalation.Check == name && (earliest == nil || escalation.afterDate.Before(earliest.afterDate)) {
+			earliest = escalation
+		}
+	}
+
+	if earliest == nil {
+		return SeverityError
+	}
+
+	return earliest.From
+}
+
+// IsValid returns true if the severity is one of the known severities.
+func (s Severity) IsValid() bool {
+	return s == SeverityError || s == SeverityWarning
+}
+
+// IsLocaleSupported returns true if diagnostic messages can be emitted in the locale,
+// an empty locale stands for the default one.
+func IsLocaleSupported(locale string) bool {
+	switch locale {
+	case "", LocaleEnglish, LocaleRussian:
+		return true
+	default:
+		return false
+	}
+}
+
+func (cfg *Config) fillInnerData() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.MinVersion != "" && !common.IsValidVersion(cfg.MinVersion) {
+		return fmt.Errorf("invalid min_version %q, expected a version like 1.4.0", cfg.MinVersion)
+	}
+
+	for name, severity := range cfg.CheckSeverities {
+		if !severity.IsValid() {
+			return fmt.Errorf("unknown severity %q of check %s", severity, name)
+		}
+	}
+
+	for _, escalation := range cfg.Escalations {
+		afterDate, err := time.Parse(EscalationDateLayout, escalation.After)
+		if err != nil {
+			return fmt.Errorf("invalid date %q in escalation of check %s: %w", escalation.After, escalation.Check, err)
+		}
+
+		if escalation.From == "" {
+			escalation.From = SeverityWarning
+		}
+
+		if escalation.To == "" {
+			escalation.To = SeverityError
+		}
+
+		if !escalation.From.IsValid() || !escalation.To.IsValid() {
+			return fmt.Errorf("unknown severity in escalation of check %s", escalation.Check)
+		}
+
+		escalation.afterDate = afterDate
+	}
+
+	if !IsLocaleSupported(cfg.Locale) {
+		return fmt.Errorf("unsupported locale %q", cfg.Locale)
+	}
+
+	switch cfg.AcronymStyle.Style {
+	case "", AcronymStyleCapitalized, AcronymStyleUpper:
+	default:
+		return fmt.Errorf("unknown style %q of identifier_acronym_style check", cfg.AcronymStyle.Style)
+	}
+
+	switch cfg.MethodIOColocated.Policy {
+	case "", ColocationSameFile, ColocationSameDirectory, ColocationMessagesFile:
+	default:
+		return fmt.Errorf("unknown policy %q of method_io_colocated check", cfg.MethodIOColocated.Policy)
+	}
+
+	switch cfg.TrivialComments.Strictness {
+	case "", TrivialCommentsExact, TrivialCommentsLoose:
+	default:
+		return fmt.Errorf("unknown strictness %q of comment_not_trivial check", cfg.TrivialComments.Strictness)
+	}
+
+	switch cfg.CreateResponse.Strictness {
+	case "", CreateResponseEmbedded, CreateResponseResource:
+	default:
+		return fmt.Errorf("unknown strictness %q of method_create_returns_resource check", cfg.CreateResponse.Strictness)
+	}
+
+	switch cfg.CommentStyle.Syntax {
+	case "", CommentSyntaxLine, CommentSyntaxBlock:
+	default:
+		return fmt.Errorf("unknown comment syntax %q of comment_style checks", cfg.CommentStyle.Syntax)
+	}
+
+	switch cfg.GoogleAPIServiceOptions {
+	case "", GoogleAPIServiceOptionsRequired:
+	default:
+		return fmt.Errorf("unknown value %q of google_api_service_options", cfg.GoogleAPIServiceOptions)
+	}
+
+	switch cfg.GoPackageDirectories {
+	case "", GoPackageDirectoriesRequired:
+	default:
+		return fmt.Errorf("unknown value %q of go_package_directories", cfg.GoPackageDirectories)
+	}
+
+	switch cfg.ExtensionPolicy {
+	case "", ExtensionPolicyDocument, ExtensionPolicyForbid:
+	default:
+		return fmt.Errorf("unknown extension policy %q", cfg.ExtensionPolicy)
+	}
+
+	if cfg.MessageCycles.MaxDepth < 0 {
+		return fmt.Errorf("negative maximum depth %d of message_no_cycles check", cfg.MessageCycles.MaxDepth)
+	}
+
+	if cfg.HTTPPathMaxDepth.MaxSegments < 0 {
+		return fmt.Errorf("negative maximum number of segments %d of http_path_max_depth check",
+			cfg.HTTPPathMaxDepth.MaxSegments)
+	}
+
+	if cfg.ServiceMaxMethods.MaxMethods < 0 {
+		return fmt.Errorf("negative maximum number of methods %d of service_max_methods check",
+			cfg.ServiceMaxMethods.MaxMethods)
+	}
+
+	if cfg.PackageMaxServices.MaxServices < 0 {
+		return fmt.Errorf("negative maximum number of services %d of package_max_services check",
+			cfg.PackageMaxServices.MaxServices)
+	}
+
+	if cfg.FileMaxLines.MaxLines < 0 {
+		return fmt.Errorf("negative maximum number of lines %d of file_max_lines check",
+			cfg.FileMaxLines.MaxLines)
+	}
+
+	if cfg.FileMaxMessages.MaxMessages < 0 {
+		return fmt.Errorf("negative maximum number of messages %d of file_max_messages check",
+			cfg.FileMaxMessages.MaxMessages)
+	}
+
+	if minLength, maxLength := cfg.GetFieldDescriptionLengthBounds(); minLength > maxLength {
+		return fmt.Errorf("minimum length %d of field description exceeds maximum length %d", minLength, maxLength)
+	}
+
+	cfg.FileHeader.linePatterns = compileFileHeaderTemplate(cfg.FileHeader.Template)
+
+	if len(cfg.EnvironmentURLs.Patterns) > 0 {
+		patterns, err := compileEnvironmentURLPatterns(cfg.EnvironmentURLs.Patterns)
+		if err != nil {
+			return err
+		}
+
+		cfg.EnvironmentURLs.patterns = patterns
+	}
+
+	if len(cfg.SensitiveData.Patterns) > 0 {
+		patterns, err := compileCaseInsensitivePatterns(cfg.SensitiveData.Patterns, "field_sensitive_data_annotation")
+		if err != nil {
+			return err
+		}
+
+		cfg.SensitiveData.patterns = patterns
+	}
+
+	if len(cfg.PIIDebugRedact.Patterns) > 0 {
+		patterns, err := compileCaseInsensitivePatterns(cfg.PIIDebugRedact.Patterns, "field_pii_debug_redact")
+		if err != nil {
+			return err
+		}
+
+		cfg.PIIDebugRedact.patterns = patterns
+	}
+
+	if len(cfg.PIIDebugRedact.NonSensitivePatterns) > 0 {
+		patterns, err := compileCaseInsensitivePatterns(cfg.PIIDebugRedact.NonSensitivePatterns, "field_pii_debug_redact")
+		if err != nil {
+			return err
+		}
+
+		cfg.PIIDebugRedact.nonSensitivePatterns = patterns
+	}
+
+	if cfg.MaxLineLength.MaxLength < 0 {
+		return fmt.Errorf("negative maximum length %d of style_max_line_length check", cfg.MaxLineLength.MaxLength)
+	}
+
+	for _, keyTypes := range [][]string{cfg.MapKeyTypes.AllowedTypes, cfg.MapKeyTypes.WarningTypes} {
+		for _, keyType := range keyTypes {
+			if _, ok := mapKeyTypes[keyType]; !ok {
+				return fmt.Errorf("unknown map key type %q of map_key_type_allowed check", keyType)
+			}
+		}
+	}
+
+	switch cfg.Indentation.Style {
+	case "", IndentationSpaces, IndentationTabs:
+	default:
+		return fmt.Errorf("unknown style %q of style_indentation check", cfg.Indentation.Style)
+	}
+
+	if cfg.Indentation.Width < 0 {
+		return fmt.Errorf("negative width %d of style_indentation check", cfg.Indentation.Width)
+	}
+
+	if cfg.Downloads.MaxSize < 0 {
+		return fmt.Errorf("negative maximum size %d of downloads", cfg.Downloads.MaxSize)
+	}
+
+	if cfg.Downloads.MaxConcurrency < 0 || cfg.Downloads.MaxAttempts < 0 {
+		return fmt.Errorf("negative maximum concurrency %d or attempts %d of downloads",
+			cfg.Downloads.MaxConcurrency,
+			cfg.Downloads.MaxAttempts)
+	}
+
+	for i, contentType := range cfg.Downloads.AllowedContentTypes {
+		cfg.Downloads.AllowedContentTypes[i] = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	for _, checksum := range cfg.Downloads.Checksums {
+		if checksum.Path == "" || !sha256Regexp.MatchString(checksum.SHA256) {
+			return fmt.Errorf("invalid checksum %q of dependency %q, expected a path and a SHA-256 hex string",
+				checksum.SHA256,
+				checksum.Path)
+		}
+
+		checksum.SHA256 = strings.ToLower(checksum.SHA256)
+	}
+
+	if remoteURL := cfg.Cache.RemoteURL; remoteURL != "" &&
+		!strings.HasPrefix(remoteURL, "http://") && !strings.HasPrefix(remoteURL, "https://") {
+		return fmt.Errorf("invalid cache.remote_url %q, expected an HTTP(S) URL", remoteURL)
+	}
+
+	// The client is kept by copies of the configuration, so certificates are loaded once.
+	if cfg.httpClient == nil {
+		client, err := newHTTPClient(&cfg.HTTPClient)
+		if err != nil {
+			return err
+		}
+
+		cfg.httpClient = client
+	}
+
+	for severity, weight := range cfg.Score.SeverityWeights {
+		if !severity.IsValid() {
+			return fmt.Errorf("unknown severity %q in score weights", severity)
+		}
+
+		if weight < 0 {
+			return fmt.Errorf("negative weight %g of severity %s in score weights", weight, severity)
+		}
+	}
+
+	for category, weight := range cfg.Score.CategoryWeights {
+		if weight < 0 {
+			return fmt.Errorf("negative weight %g of category %s in score weights", weight, category)
+		}
+	}
+
+	for _, verb := range cfg.MethodVerbs.Verbs {
+		if verb == nil || verb.Prefix == "" || len(verb.HTTPMethods) == 0 {
+			return errors.New("verb of method_verb_matches_http_method check must specify prefix and http methods")
+		}
+
+		for _, httpMethod := range verb.HTTPMethods {
+			switch strings.ToLower(httpMethod) {
+			case "get", "put", "post", "delete", "patch":
+			default:
+				return fmt.Errorf("unknown HTTP method %q of verb %s of method_verb_matches_http_method check",
+					httpMethod,
+					verb.Prefix)
+			}
+		}
+	}
+
+	for _, task := range cfg.Tasks {
+		switch {
+		case !containsTask(SupportedTasks, task):
+			return fmt.Errorf("unknown task %q, supported tasks are: %s", task, strings.Join(SupportedTasks, ", "))
+		case task == TaskBreaking && cfg.Breaking.Against == "":
+			return errors.New("breaking task requires the baseline descriptor set in breaking.against")
+		case task == TaskOpenAPI && len(cfg.OpenAPI.Command) == 0:
+			return errors.New("openapi task requires the generator command in openapi.command")
+		}
+	}
+
+	for _, boundary := range cfg.ImportBoundaries {
+		if boundary.From == "" || len(boundary.Forbid) == 0 {
+			return errors.New("import boundary must specify from and forbid packages")
+		}
+	}
+
+	for _, deprecated := range cfg.DeprecatedImports {
+		if deprecated.Package == "" && deprecated.Path == "" {
+			return errors.New("deprecated import must specify a package or a path prefix")
+		}
+	}
+
+	budgets, err := flattenFindingsBudgets(cfg.MaxFindingsPerPackage, "")
+	if err != nil {
+		return err
+	}
+
+	cfg.findingsBudgets = budgets
+
+	for _, override := range cfg.Overrides {
+		if override.Package == "" && override.Path == "" {
+			return errors.New("override must specify a package or a path prefix")
+		}
+
+		for name, severity := range override.CheckSeverities {
+			if !severity.IsValid() {
+				return fmt.Errorf("unknown severity %q of check %s in override", severity, name)
+			}
+		}
 	}
 
 	checks := cfg.GetExcludedChecks()
 	if len(checks) == 0 {
-		return
+		return nil
 	}
 
 	checksMap := make(map[string]struct{}, len(checks))
@@ -107,4 +1236,63 @@ func (cfg *Config) fillInnerData() {
 	}
 
 	cfg.excludedChecksMap = checksMap
+
+	return nil
+}
+
+// compileFileHeaderTemplate turns every line of the header template into a pattern matching the whole line,
+// the year placeholder matches a year or a range of years.
+// Trailing spaces of lines and empty lines around the template are ignored.
+func compileFileHeaderTemplate(template string) []*regexp.Regexp {
+	template = strings.Trim(template, "\n")
+	if strings.TrimSpace(template) == "" {
+		return nil
+	}
+
+	var (
+		lines  = strings.Split(template, "\n")
+		result = make([]*regexp.Regexp, 0, len(lines))
+	)
+
+	for _, line := range lines {
+		pattern := strings.ReplaceAll(
+			regexp.QuoteMeta(strings.TrimRight(line, " \t")),
+			regexp.QuoteMeta(FileHeaderYearPlaceholder),
+			`\d{4}(-\d{4})?`)
+
+		result = append(result, regexp.MustCompile("^"+pattern+"$"))
+	}
+
+	return result
+}
+
+// compileEnvironmentURLPatterns compiles the patterns of the option_no_environment_urls check case-insensitively.
+func compileEnvironmentURLPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	return compileCaseInsensitivePatterns(patterns, "option_no_environment_urls")
+}
+
+// compileCaseInsensitivePatterns compiles the patterns of the check case-insensitively.
+func compileCaseInsensitivePatterns(patterns []string, check string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		compiled, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q of %s check: %w", pattern, check, err)
+		}
+
+		result = append(result, compiled)
+	}
+
+	return result, nil
+}
+
+func containsTask(tasks []string, task string) bool {
+	for _, v := range tasks {
+		if task == v {
+			return true
+		}
+	}
+
+	return false
 }
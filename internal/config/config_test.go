@@ -0,0 +1,161 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckMinVersion(t *testing.T) {
+	testCases := []struct {
+		minVersion string
+		version    string
+		isAllowed  bool
+	}{
+		{"", "1.0.0", true},
+		{"1.4.0", "v1.4.0", true},
+		{"1.4.0", "v1.5.0", true},
+		{"1.4.0", "v1.3.9", false},
+		{"1.4", "1.3.0", false},
+		{"1.4.0", "dev", true},
+		{"1.4.0", "v0.0.0-20261016004329-6e3fb179c61a", true},
+	}
+
+	for _, tc := range testCases {
+		cfg := &Config{MinVersion: tc.minVersion}
+
+		if err := cfg.checkMinVersion(tc.version); (err == nil) != tc.isAllowed {
+			t.Errorf("min version %q, version %s: expected allowed %t, got error %v",
+				tc.minVersion,
+				tc.version,
+				tc.isAllowed,
+				err)
+		}
+	}
+
+	if err := (&Config{MinVersion: "latest"}).fillInnerData(); err == nil {
+		t.Error("expected invalid min_version to be rejected")
+	}
+}
+
+func TestGetScoreWeights(t *testing.T) {
+	var cfg *Config
+
+	if cfg.GetSeverityWeight(SeverityError) != DefaultErrorWeight ||
+		cfg.GetSeverityWeight(SeverityWarning) != DefaultWarningWeight ||
+		cfg.GetCategoryWeight("documentation") != DefaultCategoryWeight {
+		t.Error("expected default weights for nil configuration")
+	}
+
+	cfg = &Config{
+		Score: ScoreOptions{
+			SeverityWeights: map[Severity]float64{SeverityWarning: 0},
+			CategoryWeights: map[string]float64{"documentation": 2},
+		},
+	}
+
+	if cfg.GetSeverityWeight(SeverityWarning) != 0 || cfg.GetCategoryWeight("documentation") != 2 {
+		t.Error("expected configured weights to be used")
+	}
+
+	cfg.Score.SeverityWeights = map[Severity]float64{"fatal": 1}
+	if err := cfg.fillInnerData(); err == nil {
+		t.Error("expected unknown severity in score weights to be rejected")
+	}
+}
+
+func TestGetMapKeyTypes(t *testing.T) {
+	var cfg *Config
+
+	allowed, warning := cfg.GetMapKeyTypes()
+	if len(allowed) != len(DefaultMapKeyAllowedTypes) || len(warning) != len(DefaultMapKeyWarningTypes) {
+		t.Error("expected default key types for nil configuration")
+	}
+
+	cfg = &Config{MapKeyTypes: MapKeyTypesOptions{WarningTypes: []string{}}}
+	if _, warning = cfg.GetMapKeyTypes(); len(warning) != 0 {
+		t.Error("expected empty list of warning types to disable warnings")
+	}
+
+	cfg.MapKeyTypes.AllowedTypes = []string{"string", "Order"}
+	if err := cfg.fillInnerData(); err == nil {
+		t.Error("expected unknown map key type to be rejected")
+	}
+}
+
+func TestGetMethodVerbs(t *testing.T) {
+	var cfg *Config
+
+	if verbs := cfg.GetMethodVerbs(); len(verbs) != len(DefaultMethodVerbs) {
+		t.Error("expected default verbs for nil configuration")
+	}
+
+	cfg = &Config{MethodVerbs: MethodVerbsOptions{
+		Verbs: []*MethodVerb{{Prefix: "Search", HTTPMethods: []string{"GET", "post"}}},
+	}}
+	if err := cfg.fillInnerData(); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	cfg.MethodVerbs.Verbs[0].HTTPMethods = []string{"fetch"}
+	if err := cfg.fillInnerData(); err == nil {
+		t.Error("expected unknown HTTP method to be rejected")
+	}
+}
+
+func TestValidateTasks(t *testing.T) {
+	tests := []struct {
+		tasks         []string
+		breaking      BreakingOptions
+		openAPI       OpenAPIOptions
+		expectedError string
+	}{
+		{tasks: []string{TaskCompile, TaskLint, TaskScore, TaskCoverage}},
+		{
+			tasks:    []string{TaskCompile, TaskBreaking, TaskOpenAPI},
+			breaking: BreakingOptions{Against: "baseline.binpb"},
+			openAPI:  OpenAPIOptions{Command: []string{"generator", DescriptorSetPlaceholder}},
+		},
+		{tasks: []string{TaskCompile, TaskBreaking}, expectedError: "breaking.against"},
+		{tasks: []string{TaskOpenAPI}, expectedError: "openapi.command"},
+		{tasks: []string{"deploy"}, expectedError: `unknown task "deploy"`},
+	}
+
+	for _, test := range tests {
+		err := (&Config{
+			Tasks:    test.tasks,
+			Breaking: test.breaking,
+			OpenAPI:  test.openAPI,
+		}).fillInnerData()
+
+		switch {
+		case test.expectedError == "" && err != nil:
+			t.Errorf("unexpected error for tasks %v: %s", test.tasks, err.Error())
+		case test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)):
+			t.Errorf("expected error %q for tasks %v, got %v", test.expectedError, test.tasks, err)
+		}
+	}
+}
+
+func TestIsHostAllowed(t *testing.T) {
+	var cfg *Config
+
+	if !cfg.IsHostAllowed("raw.githubusercontent.com") || cfg.IsHostAllowed("example.com") {
+		t.Error("expected only default hosts to be allowed for nil configuration")
+	}
+
+	cfg = &Config{
+		AllowedHosts:   []string{"artifactory.example.com"},
+		Cache:          CacheOptions{RemoteURL: "https://cache.example.com:8443/protolinter"},
+		ImportRewrites: []*ImportRewrite{{Prefix: "vendor/", Replacement: "https://mirror.example.com/vendor/"}},
+	}
+
+	for _, host := range []string{"Artifactory.example.com", "cache.example.com", "mirror.example.com"} {
+		if !cfg.IsHostAllowed(host) {
+			t.Errorf("expected host %s to be allowed", host)
+		}
+	}
+
+	if cfg.IsHostAllowed("github.com") {
+		t.Error("expected allowed_hosts to replace the default hosts")
+	}
+}
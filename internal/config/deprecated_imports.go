@@ -0,0 +1,27 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// FindDeprecatedImport returns the first deprecated import matching the package and the path of a file,
+// or nil if the file is not deprecated.
+func (cfg *Config) FindDeprecatedImport(packageName, path string) *DeprecatedImport {
+	for _, deprecated := range cfg.GetDeprecatedImports() {
+		if deprecated.matches(packageName, path) {
+			return deprecated
+		}
+	}
+
+	return nil
+}
+
+// matches returns true if the file of the package at the path is deprecated.
+func (d *DeprecatedImport) matches(packageName, path string) bool {
+	if d.Package != "" && !matchPackagePattern(d.Package, packageName) {
+		return false
+	}
+
+	return d.Path == "" || strings.HasPrefix(path, filepath.ToSlash(d.Path))
+}
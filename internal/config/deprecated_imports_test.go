@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestFindDeprecatedImport(t *testing.T) {
+	cfg := &Config{
+		DeprecatedImports: []*DeprecatedImport{
+			{Package: "orders.v1beta.*", Replacement: "orders.v1"},
+			{Path: "legacy/"},
+			{Package: "payments.v1", Path: "payments/v1/old_"},
+		},
+	}
+
+	tests := []struct {
+		packageName string
+		path        string
+		expected    int
+	}{
+		{packageName: "orders.v1beta", path: "orders/v1beta/orders.proto", expected: 0},
+		{packageName: "orders.v1beta.internal", path: "orders/v1beta/internal/audit.proto", expected: 0},
+		{packageName: "orders.v1", path: "orders/v1/orders.proto", expected: -1},
+		{packageName: "common.v1", path: "legacy/common.proto", expected: 1},
+		{packageName: "payments.v1", path: "payments/v1/old_payment.proto", expected: 2},
+		{packageName: "payments.v1", path: "payments/v1/payment.proto", expected: -1},
+		{packageName: "payments.v2", path: "payments/v1/old_payment.proto", expected: -1},
+	}
+
+	for _, test := range tests {
+		var expected *DeprecatedImport
+		if test.expected >= 0 {
+			expected = cfg.DeprecatedImports[test.expected]
+		}
+
+		if actual := cfg.FindDeprecatedImport(test.packageName, test.path); actual != expected {
+			t.Errorf("expected %v for %s of package %s, got %v", expected, test.path, test.packageName, actual)
+		}
+	}
+
+	if actual := (*Config)(nil).FindDeprecatedImport("orders.v1beta", "orders/v1beta/orders.proto"); actual != nil {
+		t.Errorf("expected no deprecated import for nil configuration, got %v", actual)
+	}
+}
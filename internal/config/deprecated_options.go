@@ -0,0 +1,32 @@
+package config
+
+// deprecationExpiredCheck is the check reporting deprecated descriptors after their removal date,
+// which replaced the fail_after_date option of deprecated_field_has_removal_note.
+const deprecationExpiredCheck = "deprecation_expired"
+
+// GetDeprecatedOptions returns the deprecated options set in the configuration.
+func (cfg *Config) GetDeprecatedOptions() []*DeprecatedOption {
+	if cfg == nil {
+		return nil
+	}
+
+	var result []*DeprecatedOption
+
+	if cfg.RemovalNote.FailAfterDate != nil {
+		result = append(result, &DeprecatedOption{
+			Key: "deprecated_field_has_removal_note.fail_after_date",
+			Replacement: "expired removal dates are reported by the deprecation_expired check, " +
+				"add it to excluded_checks instead of setting the option to false",
+		})
+	}
+
+	return result
+}
+
+// isExcludedByDeprecatedOptions returns true if a deprecated option turns the check off:
+// fail_after_date set to false excludes deprecation_expired, as it kept expired removal dates unreported.
+func (cfg *Config) isExcludedByDeprecatedOptions(name string) bool {
+	failAfterDate := cfg.RemovalNote.FailAfterDate
+
+	return name == deprecationExpiredCheck && failAfterDate != nil && !*failAfterDate
+}
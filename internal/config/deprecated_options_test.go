@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFailAfterDateAlias(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".protolinter.yaml")
+
+	tests := []struct {
+		source     string
+		isExcluded bool
+		deprecated int
+	}{
+		{source: "verbose_mode: false\n", isExcluded: false, deprecated: 0},
+		{source: "deprecated_field_has_removal_note:\n  fail_after_date: true\n", isExcluded: false, deprecated: 1},
+		{source: "deprecated_field_has_removal_note:\n  fail_after_date: false\n", isExcluded: true, deprecated: 1},
+	}
+
+	for _, test := range tests {
+		if err := os.WriteFile(configPath, []byte(test.source), 0o600); err != nil {
+			t.Fatalf("failed to write configuration: %s", err.Error())
+		}
+
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("failed to load configuration: %s", err.Error())
+		}
+
+		if actual := cfg.IsCheckExcluded(deprecationExpiredCheck); actual != test.isExcluded {
+			t.Errorf("%q: expected %s to be excluded: %v, got %v",
+				test.source,
+				deprecationExpiredCheck,
+				test.isExcluded,
+				actual)
+		}
+
+		if actual := len(cfg.GetDeprecatedOptions()); actual != test.deprecated {
+			t.Errorf("%q: expected %d deprecated options, got %d", test.source, test.deprecated, actual)
+		}
+
+		if cfg.WithoutExclusions().IsCheckExcluded(deprecationExpiredCheck) {
+			t.Errorf("%q: %s is excluded without exclusions", test.source, deprecationExpiredCheck)
+		}
+
+		if cfg.WithOnlyChecks([]string{deprecationExpiredCheck}).IsCheckExcluded(deprecationExpiredCheck) {
+			t.Errorf("%q: %s selected by --only-check is excluded", test.source, deprecationExpiredCheck)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of environment variables overriding keys of the configuration,
+// e.g. PROTOLINTER_VERBOSE_MODE overrides verbose_mode and PROTOLINTER_COMMENT_STYLE_SYNTAX overrides comment_style.syntax.
+const EnvPrefix = "PROTOLINTER"
+
+// overrideKey is a key of the configuration that can be overridden by an environment variable and a flag.
+type overrideKey struct {
+	name string
+	kind reflect.Kind
+}
+
+var (
+	// overrideKeys lists the keys of the configuration holding scalars or lists of strings,
+	// maps and lists of structures can only be set in the configuration file.
+	overrideKeys = collectOverrideKeys(reflect.TypeOf(Config{}), "")
+	// overrideFlags maps keys of the configuration to the flags overriding them.
+	overrideFlags = make(map[string]*pflag.Flag)
+)
+
+// AddOverrideFlags defines a flag for every key of the configuration that can be overridden,
+// named after the key with dashes instead of underscores and dots, e.g. --excluded-checks
+// or --comment-style-syntax. Flags already defined by the command are kept as is.
+// The generated flags are hidden from the help, so they don't bury the flags of the command.
+func AddOverrideFlags(flags *pflag.FlagSet) {
+	for _, key := range overrideKeys {
+		name := getOverrideFlagName(key.name)
+		if flags.Lookup(name) != nil {
+			continue
+		}
+
+		usage := "overrides " + key.name + " of the configuration"
+
+		switch key.kind {
+		case reflect.Bool:
+			flags.Bool(name, false, usage)
+		case reflect.Int:
+			flags.Int(name, 0, usage)
+		case reflect.Int64:
+			flags.Int64(name, 0, usage)
+		case reflect.Float64:
+			flags.Float64(name, 0, usage)
+		case reflect.Slice:
+			flags.StringSlice(name, nil, usage+", comma-separated")
+		default:
+			flags.String(name, "", usage)
+		}
+
+		_ = flags.MarkHidden(name)
+	}
+}
+
+// BindOverrideFlags makes the flags defined by AddOverrideFlags override the keys of the configuration
+// loaded by LoadConfig. Only flags set on the command line take effect.
+func BindOverrideFlags(flags *pflag.FlagSet) {
+	for _, key := range overrideKeys {
+		if flag := flags.Lookup(getOverrideFlagName(key.name)); flag != nil {
+			overrideFlags[key.name] = flag
+		}
+	}
+}
+
+// applyOverrides sets the keys of the configuration overridden by environment variables and flags,
+// flags take precedence over environment variables, which take precedence over the configuration file.
+// It returns true if any key is overridden.
+func applyOverrides(v *viper.Viper) (bool, error) {
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	var isOverridden bool
+
+	for _, key := range overrideKeys {
+		if err := v.BindEnv(key.name); err != nil {
+			return false, err
+		}
+
+		if flag, ok := overrideFlags[key.name]; ok && flag.Changed {
+			if err := v.BindPFlag(key.name, flag); err != nil {
+				return false, err
+			}
+		}
+
+		isOverridden = isOverridden || v.IsSet(key.name)
+	}
+
+	return isOverridden, nil
+}
+
+// collectOverrideKeys returns the keys of the fields of the structure and nested structures
+// holding scalars or lists of strings, prefixed with the specified prefix.
+func collectOverrideKeys(structType reflect.Type, prefix string) []*overrideKey {
+	var result []*overrideKey
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		name := field.Tag.Get("mapstructure")
+		if name == "" || !field.IsExported() {
+			continue
+		}
+
+		name = prefix + name
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			result = append(result, collectOverrideKeys(field.Type, name+".")...)
+		case reflect.Slice:
+			if field.Type.Elem().Kind() == reflect.String {
+				result = append(result, &overrideKey{name: name, kind: reflect.Slice})
+			}
+		case reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64, reflect.String:
+			result = append(result, &overrideKey{name: name, kind: field.Type.Kind()})
+		default:
+		}
+	}
+
+	return result
+}
+
+// getOverrideFlagName returns the name of the flag overriding the key of the configuration.
+func getOverrideFlagName(key string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(key)
+}
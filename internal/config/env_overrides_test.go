@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestLoadConfigOverrides(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), DefaultConfigName)
+
+	cfg, err := LoadConfig(fileName)
+	if err != nil || cfg != nil {
+		t.Fatalf("expected no configuration without the file and overrides, got %v, %v", cfg, err)
+	}
+
+	err = os.WriteFile(fileName, []byte(`
+excluded_checks:
+  - method_has_version
+comment_style:
+  syntax: line
+message_no_cycles:
+  max_depth: 1
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write configuration file: %s", err.Error())
+	}
+
+	flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	AddOverrideFlags(flags)
+
+	if err = flags.Parse([]string{"--comment-style-syntax=block"}); err != nil {
+		t.Fatalf("failed to parse flags: %s", err.Error())
+	}
+
+	BindOverrideFlags(flags)
+	t.Cleanup(func() { overrideFlags = make(map[string]*pflag.Flag) })
+
+	t.Setenv("PROTOLINTER_VERBOSE_MODE", "true")
+	t.Setenv("PROTOLINTER_EXCLUDED_CHECKS", "method_has_version,field_has_no_description")
+	t.Setenv("PROTOLINTER_COMMENT_STYLE_SYNTAX", "line")
+
+	cfg, err = LoadConfig(fileName)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	if !cfg.GetVerboseMode() {
+		t.Error("expected verbose mode to be set by the environment variable")
+	}
+
+	expectedChecks := []string{"method_has_version", "field_has_no_description"}
+	if !reflect.DeepEqual(cfg.GetExcludedChecks(), expectedChecks) {
+		t.Errorf("expected excluded checks %v, got %v", expectedChecks, cfg.GetExcludedChecks())
+	}
+
+	if syntax := cfg.GetCommentSyntax(); syntax != CommentSyntaxBlock {
+		t.Errorf("expected the flag to take precedence over the environment variable, got syntax %q", syntax)
+	}
+
+	if cfg.MessageCycles.MaxDepth != 1 {
+		t.Errorf("expected the value from the file to be kept, got maximum depth %d", cfg.MessageCycles.MaxDepth)
+	}
+
+	cfg, err = LoadConfig(filepath.Join(t.TempDir(), DefaultConfigName))
+	if err != nil || cfg == nil || !cfg.GetVerboseMode() {
+		t.Errorf("expected overrides to apply without the configuration file, got %v, %v", cfg, err)
+	}
+}
+
+func TestAddOverrideFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	flags.String("excluded-checks", "", "checks excluded by the command")
+
+	AddOverrideFlags(flags)
+
+	// Flags defined by the command stay visible, the generated ones are hidden from the help.
+	if flag := flags.Lookup("excluded-checks"); flag.Hidden || flag.Usage != "checks excluded by the command" {
+		t.Errorf("expected the flag of the command to be kept as is, got %+v", flag)
+	}
+
+	flag := flags.Lookup("comment-style-syntax")
+	if flag == nil || !flag.Hidden {
+		t.Fatalf("expected hidden override flag, got %+v", flag)
+	}
+
+	if usage := flags.FlagUsages(); usage != "      --excluded-checks string   checks excluded by the command\n" {
+		t.Errorf("expected only the flag of the command in the help, got:\n%s", usage)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// excludedDescriptorsDownloadTimeout limits the time of downloading a file with excluded descriptors.
+const excludedDescriptorsDownloadTimeout = 30 * time.Second
+
+// importExcludedDescriptors reads the files listed in excluded_descriptors_files
+// and appends their descriptors to the excluded ones declared in the configuration itself.
+func (cfg *Config) importExcludedDescriptors() error {
+	if cfg == nil {
+		return nil
+	}
+
+	for _, source := range cfg.ExcludedDescriptorsFiles {
+		data, err := cfg.readExcludedDescriptorsFile(source)
+		if err != nil {
+			return fmt.Errorf("failed to read excluded descriptors from %s: %w", source, err)
+		}
+
+		descriptors := parseExcludedDescriptors(data)
+		cfg.ExcludedDescriptors = append(cfg.ExcludedDescriptors, descriptors...)
+		cfg.importedDescriptorsCount += len(descriptors)
+	}
+
+	return nil
+}
+
+// readExcludedDescriptorsFile returns the contents of the local file or the file downloaded by the HTTP(S) URL.
+func (cfg *Config) readExcludedDescriptorsFile(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), excludedDescriptorsDownloadTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if host := request.URL.Hostname(); !cfg.IsHostAllowed(host) {
+		return nil, fmt.Errorf("host %s isn't allowed, add it to allowed_hosts to download from it", host)
+	}
+
+	response, err := cfg.GetHTTPClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded with status %d", response.StatusCode)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+// parseExcludedDescriptors returns the descriptors listed in the file,
+// which is either a YAML list or a plain text file with one descriptor per line.
+// Empty lines and lines starting with # are ignored in plain text files.
+func parseExcludedDescriptors(data []byte) []string {
+	var list []string
+	if err := yaml.Unmarshal(data, &list); err == nil {
+		return list
+	}
+
+	var result []string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		result = append(result, line)
+	}
+
+	return result
+}
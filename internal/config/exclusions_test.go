@@ -0,0 +1,75 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestImportExcludedDescriptors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("- orders.v1.Order\n- orders.v1.Item\n"))
+	}))
+	defer server.Close()
+
+	fileName := filepath.Join(t.TempDir(), "baseline.txt")
+	if err := os.WriteFile(fileName, []byte("# Generated baseline.\n\norders.v1.OrderService\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %s", err.Error())
+	}
+
+	cfg := &Config{
+		AllowedHosts:             []string{serverURL.Hostname()},
+		ExcludedDescriptors:      []string{"orders.v1.Legacy"},
+		ExcludedDescriptorsFiles: []string{fileName, server.URL},
+	}
+
+	if err := cfg.importExcludedDescriptors(); err != nil {
+		t.Fatalf("failed to import excluded descriptors: %s", err.Error())
+	}
+
+	expected := []string{"orders.v1.Legacy", "orders.v1.OrderService", "orders.v1.Order", "orders.v1.Item"}
+	if !reflect.DeepEqual(cfg.GetExcludedDescriptors(), expected) {
+		t.Errorf("expected excluded descriptors %v, got %v", expected, cfg.GetExcludedDescriptors())
+	}
+
+	if inline := cfg.GetInlineExcludedDescriptors(); !reflect.DeepEqual(inline, []string{"orders.v1.Legacy"}) {
+		t.Errorf("expected only the inline excluded descriptor, got %v", inline)
+	}
+
+	cfg.ExcludedDescriptorsFiles = []string{filepath.Join(t.TempDir(), "missing.txt")}
+	if err := cfg.importExcludedDescriptors(); err == nil {
+		t.Error("expected an error for the missing file")
+	}
+}
+
+func TestImportExcludedDescriptorsFromDisallowedHost(t *testing.T) {
+	var requested bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requested = true
+		_, _ = w.Write([]byte("- orders.v1.Order\n"))
+	}))
+	defer server.Close()
+
+	cfg := &Config{ExcludedDescriptorsFiles: []string{server.URL}}
+	if err := cfg.importExcludedDescriptors(); err == nil {
+		t.Error("expected an error for the host missing from allowed_hosts")
+	}
+
+	if requested {
+		t.Error("expected no request to the host missing from allowed_hosts")
+	}
+
+	if descriptors := cfg.GetExcludedDescriptors(); len(descriptors) != 0 {
+		t.Errorf("expected no excluded descriptors, got %v", descriptors)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultAllowedHosts is the default list of hosts dependencies may be downloaded from.
+var DefaultAllowedHosts = []string{"github.com", "raw.githubusercontent.com"}
+
+// IsHostAllowed returns true if dependencies may be downloaded from the host,
+// listed in allowed_hosts or, if it's not set, in DefaultAllowedHosts.
+// Hosts of the remote cache and of URLs import paths are rewritten to are always allowed,
+// since they are configured explicitly.
+func (cfg *Config) IsHostAllowed(host string) bool {
+	if cfg == nil {
+		return containsHost(DefaultAllowedHosts, host)
+	}
+
+	hosts := cfg.AllowedHosts
+	if hosts == nil {
+		hosts = DefaultAllowedHosts
+	}
+
+	if containsHost(hosts, host) {
+		return true
+	}
+
+	mirrors := []string{cfg.Cache.RemoteURL}
+	for _, rewrite := range cfg.ImportRewrites {
+		mirrors = append(mirrors, rewrite.Replacement)
+	}
+
+	for _, mirror := range mirrors {
+		if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+			continue
+		}
+
+		if mirrorURL, err := url.Parse(mirror); err == nil && strings.EqualFold(mirrorURL.Hostname(), host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+
+	return false
+}
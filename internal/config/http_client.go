@@ -0,0 +1,129 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// maxHTTPRedirects is the maximum number of redirects followed by the client, the same as of http.DefaultClient.
+const maxHTTPRedirects = 10
+
+// ErrHostNotAllowed is returned when a request is redirected to a host not listed in allowed_hosts.
+var ErrHostNotAllowed = errors.New("host isn't allowed")
+
+// GetHTTPClient returns the client of the HTTP requests made for the resolver:
+// downloads of dependencies, the remote cache and files with excluded descriptors.
+// If the Config is nil or the client isn't configured, it returns a copy of http.DefaultClient.
+// The client doesn't follow redirects to hosts that aren't allowed, see IsHostAllowed.
+func (cfg *Config) GetHTTPClient() *http.Client {
+	result := *http.DefaultClient
+	if cfg != nil && cfg.httpClient != nil {
+		result = *cfg.httpClient
+	}
+
+	result.CheckRedirect = cfg.checkRedirect
+
+	return &result
+}
+
+// checkRedirect stops following redirects to hosts that aren't allowed,
+// so allowed hosts can't make the resolver contact other ones.
+func (cfg *Config) checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxHTTPRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxHTTPRedirects)
+	}
+
+	if host := request.URL.Hostname(); !cfg.IsHostAllowed(host) {
+		return fmt.Errorf("%w: redirected to host %s, add it to allowed_hosts to download from it",
+			ErrHostNotAllowed,
+			host)
+	}
+
+	return nil
+}
+
+// newHTTPClient creates the client configured by the options, or returns nil if nothing is configured.
+func newHTTPClient(options *HTTPClientOptions) (*http.Client, error) {
+	if options.ProxyURL == "" && options.ClientCertificate == "" && options.ClientKey == "" &&
+		options.CACertificate == "" {
+		return nil, nil
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("default HTTP transport can't be configured")
+	}
+
+	transport := defaultTransport.Clone()
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http_client.proxy_url: %w", err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported scheme %q of http_client.proxy_url, expected http, https or socks5",
+				proxyURL.Scheme)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newTLSConfig returns the TLS configuration with the client certificate and the trusted CA certificates,
+// or nil if neither is configured.
+func newTLSConfig(options *HTTPClientOptions) (*tls.Config, error) {
+	if (options.ClientCertificate == "") != (options.ClientKey == "") {
+		return nil, errors.New("http_client.client_certificate and http_client.client_key must be set together")
+	}
+
+	if options.ClientCertificate == "" && options.CACertificate == "" {
+		return nil, nil
+	}
+
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if options.ClientCertificate != "" {
+		certificate, err := tls.LoadX509KeyPair(options.ClientCertificate, options.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	if options.CACertificate != "" {
+		data, err := os.ReadFile(options.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in CA certificate file %s", options.CACertificate)
+		}
+
+		result.RootCAs = pool
+	}
+
+	return result, nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	client, err := newHTTPClient(&HTTPClientOptions{})
+	if err != nil || client != nil {
+		t.Fatalf("expected no client without options, got %v, %v", client, err)
+	}
+
+	if defaultClient := (&Config{}).GetHTTPClient(); defaultClient.Transport != nil || defaultClient.CheckRedirect == nil {
+		t.Error("expected the default transport with the redirect check without options")
+	}
+
+	client, err = newHTTPClient(&HTTPClientOptions{ProxyURL: "socks5://127.0.0.1:1080"})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err.Error())
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("expected HTTP transport")
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "https://artifactory.example.com/", nil)
+
+	proxyURL, err := transport.Proxy(request)
+	if err != nil || proxyURL.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("unexpected proxy %v, %v", proxyURL, err)
+	}
+
+	invalidOptions := []*HTTPClientOptions{
+		{ProxyURL: "ftp://127.0.0.1:21"},
+		{ClientCertificate: "client.pem"},
+		{CACertificate: filepath.Join(t.TempDir(), "missing.pem")},
+	}
+
+	for _, options := range invalidOptions {
+		if _, err = newHTTPClient(options); err == nil {
+			t.Errorf("expected options %+v to be rejected", options)
+		}
+	}
+}
+
+func TestNewHTTPClientMutualTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+
+	defer server.Close()
+
+	dir := t.TempDir()
+	options := &HTTPClientOptions{
+		ClientCertificate: filepath.Join(dir, "client.pem"),
+		ClientKey:         filepath.Join(dir, "client-key.pem"),
+		CACertificate:     filepath.Join(dir, "ca.pem"),
+	}
+
+	writePEM(t, options.CACertificate, "CERTIFICATE", server.Certificate().Raw)
+	writeClientCertificate(t, options.ClientCertificate, options.ClientKey)
+
+	cfg := &Config{HTTPClient: *options}
+	if err := cfg.fillInnerData(); err != nil {
+		t.Fatalf("failed to fill config: %s", err.Error())
+	}
+
+	response, err := cfg.GetHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to request server: %s", err.Error())
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status %d", response.StatusCode)
+	}
+}
+
+func writeClientCertificate(t *testing.T, certificatePath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "protolinter"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err.Error())
+	}
+
+	keyData, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %s", err.Error())
+	}
+
+	writePEM(t, certificatePath, "CERTIFICATE", certificate)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyData)
+}
+
+func writePEM(t *testing.T, path, blockType string, data []byte) {
+	t.Helper()
+
+	content := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data})
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err.Error())
+	}
+}
+
+func TestHTTPClientRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("syntax = \"proto3\";\n"))
+	}))
+	defer target.Close()
+
+	// The target is reached by the name localhost, while the redirecting server is allowed by its IP address.
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, targetURL, http.StatusFound)
+	}))
+	defer server.Close()
+
+	for _, cfg := range []*Config{
+		{AllowedHosts: []string{"127.0.0.1"}},
+		{AllowedHosts: []string{"127.0.0.1"}, HTTPClient: HTTPClientOptions{ProxyURL: "http://127.0.0.1:1"}},
+		nil,
+	} {
+		if cfg != nil {
+			if err := cfg.fillInnerData(); err != nil {
+				t.Fatalf("failed to fill configuration: %s", err.Error())
+			}
+
+			// The proxy isn't reachable, requests to the test servers must not go through it.
+			if client := cfg.httpClient; client != nil {
+				client.Transport.(*http.Transport).Proxy = nil
+			}
+		}
+
+		response, err := cfg.GetHTTPClient().Get(server.URL)
+		if err == nil {
+			response.Body.Close()
+			t.Errorf("expected the redirect to a disallowed host to fail for configuration %+v", cfg)
+
+			continue
+		}
+
+		if !errors.Is(err, ErrHostNotAllowed) {
+			t.Errorf("expected ErrHostNotAllowed, got %s", err.Error())
+		}
+	}
+
+	cfg := &Config{AllowedHosts: []string{"127.0.0.1", "localhost"}}
+
+	response, err := cfg.GetHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the redirect to an allowed host to succeed, got %s", err.Error())
+	}
+
+	response.Body.Close()
+}
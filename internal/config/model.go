@@ -1,14 +1,544 @@
 package config
 
-// Config represents the configuration read from the file.
-type Config struct {
-	// VerboseMode specifies whether to show verbose messages, such as when downloading dependencies.
-	VerboseMode bool `mapstructure:"verbose_mode"`
-	// OmitCoordinates specifies whether to omit source file coordinates from error messages.
-	OmitCoordinates bool `mapstructure:"omit_coordinates"`
-	// ExcludedChecks is a list of checks that should be excluded from analysis.
-	ExcludedChecks []string `mapstructure:"excluded_checks"`
-	// ExcludedDescriptors is a list of full protopaths that should be excluded from analysis.
-	ExcludedDescriptors []string `mapstructure:"excluded_descriptors"`
-	excludedChecksMap   map[string]struct{}
-}
+import (
+	"net/http"
+	"regexp"
+	"time"
+)
+
+type (
+	// Config represents the configuration read from the file.
+	Config struct {
+		// MinVersion is the minimum version of the linter required by the configuration.
+		MinVersion string `mapstructure:"min_version"`
+		// VerboseMode specifies whether to show verbose messages, such as when downloading dependencies.
+		VerboseMode bool `mapstructure:"verbose_mode"`
+		// OmitCoordinates specifies whether to omit source file coordinates from error messages.
+		OmitCoordinates bool `mapstructure:"omit_coordinates"`
+		// Locale is the language of diagnostic messages, names of checks are never translated.
+		Locale string `mapstructure:"locale"`
+		// Profile is the name of the profile from Profiles applied over the rest of the configuration.
+		Profile string `mapstructure:"profile"`
+		// Profiles maps names of profiles to the keys of the configuration they override.
+		Profiles map[string]interface{} `mapstructure:"profiles"`
+		// ExcludedChecks is a list of checks that should be excluded from analysis.
+		ExcludedChecks []string `mapstructure:"excluded_checks"`
+		// ExcludedDescriptors is a list of full protopaths that should be excluded from analysis.
+		ExcludedDescriptors []string `mapstructure:"excluded_descriptors"`
+		// ExcludedDescriptorsFiles is a list of paths or URLs of files with more excluded descriptors,
+		// either YAML lists or plain text files with one descriptor per line.
+		ExcludedDescriptorsFiles []string `mapstructure:"excluded_descriptors_files"`
+		// CheckSeverities maps check names to their severities, checks not listed here are errors.
+		CheckSeverities map[string]Severity `mapstructure:"check_severities"`
+		// Escalations is a list of policies changing the severity of checks after a date.
+		Escalations []*Escalation `mapstructure:"escalate"`
+		// OwnershipFile is the path to the CODEOWNERS or ownership YAML file used to annotate findings.
+		OwnershipFile string `mapstructure:"ownership_file"`
+		// ModuleName overrides the Go module name of the working directory used to resolve imports locally.
+		ModuleName string `mapstructure:"module_name"`
+		// HTTPClient holds the options of the client of HTTP requests made for the resolver.
+		HTTPClient HTTPClientOptions `mapstructure:"http_client"`
+		// AllowedHosts is a list of hosts dependencies may be downloaded from,
+		// hosts of the remote cache and of URLs in import rewrites are always allowed.
+		AllowedHosts []string `mapstructure:"allowed_hosts"`
+		// Downloads holds the limits of downloaded dependencies.
+		Downloads DownloadsOptions `mapstructure:"downloads"`
+		// Cache holds the options of the shared remote cache of downloaded dependencies.
+		Cache CacheOptions `mapstructure:"cache"`
+		// ImportRewrites is a list of rules rewriting import paths before they are resolved.
+		ImportRewrites []*ImportRewrite `mapstructure:"import_rewrites"`
+		// TrivialComments holds the options of the comment_not_trivial check.
+		TrivialComments TrivialCommentsOptions `mapstructure:"comment_not_trivial"`
+		// FieldDescriptionLength holds the options of the field_description_length check.
+		FieldDescriptionLength FieldDescriptionLengthOptions `mapstructure:"field_description_length"`
+		// CommentStyle holds the options of the comment_style_* checks.
+		CommentStyle CommentStyleOptions `mapstructure:"comment_style"`
+		// MessageCycles holds the options of the message_no_cycles check.
+		MessageCycles MessageCyclesOptions `mapstructure:"message_no_cycles"`
+		// HTTPPathMaxDepth holds the options of the http_path_max_depth check.
+		HTTPPathMaxDepth HTTPPathMaxDepthOptions `mapstructure:"http_path_max_depth"`
+		// ServiceMaxMethods holds the options of the service_max_methods check.
+		ServiceMaxMethods ServiceMaxMethodsOptions `mapstructure:"service_max_methods"`
+		// PackageMaxServices holds the options of the package_max_services check.
+		PackageMaxServices PackageMaxServicesOptions `mapstructure:"package_max_services"`
+		// FileMaxLines holds the options of the file_max_lines check.
+		FileMaxLines FileMaxLinesOptions `mapstructure:"file_max_lines"`
+		// FileMaxMessages holds the options of the file_max_messages check.
+		FileMaxMessages FileMaxMessagesOptions `mapstructure:"file_max_messages"`
+		// MethodIOSamePackage holds the options of the method_io_same_package check.
+		MethodIOSamePackage MethodIOSamePackageOptions `mapstructure:"method_io_same_package"`
+		// MethodIOColocated holds the options of the method_io_colocated check.
+		MethodIOColocated MethodIOColocatedOptions `mapstructure:"method_io_colocated"`
+		// FileHeader holds the options of the file_has_header check.
+		FileHeader FileHeaderOptions `mapstructure:"file_has_header"`
+		// MaxLineLength holds the options of the style_max_line_length check.
+		MaxLineLength MaxLineLengthOptions `mapstructure:"style_max_line_length"`
+		// Indentation holds the options of the style_indentation check.
+		Indentation IndentationOptions `mapstructure:"style_indentation"`
+		// ExternalDocs holds the options of the swagger_external_docs_valid_url check.
+		ExternalDocs ExternalDocsOptions `mapstructure:"swagger_external_docs_valid_url"`
+		// EnvironmentURLs holds the options of the option_no_environment_urls check.
+		EnvironmentURLs EnvironmentURLsOptions `mapstructure:"option_no_environment_urls"`
+		// SensitiveData holds the options of the field_sensitive_data_annotation check.
+		SensitiveData SensitiveDataOptions `mapstructure:"field_sensitive_data_annotation"`
+		// PIIDebugRedact holds the options of the field_pii_debug_redact check.
+		PIIDebugRedact PIIDebugRedactOptions `mapstructure:"field_pii_debug_redact"`
+		// MapKeyTypes holds the options of the map_key_type_allowed check.
+		MapKeyTypes MapKeyTypesOptions `mapstructure:"map_key_type_allowed"`
+		// RemovalNote holds the deprecated options of the deprecated_field_has_removal_note check.
+		RemovalNote RemovalNoteOptions `mapstructure:"deprecated_field_has_removal_note"`
+		// MethodVerbs holds the options of the method_verb_matches_http_method check.
+		MethodVerbs MethodVerbsOptions `mapstructure:"method_verb_matches_http_method"`
+		// CreateResponse holds the options of the method_create_returns_resource check.
+		CreateResponse CreateResponseOptions `mapstructure:"method_create_returns_resource"`
+		// AcronymStyle holds the options of the identifier_acronym_style check.
+		AcronymStyle AcronymStyleOptions `mapstructure:"identifier_acronym_style"`
+		// UnknownOptions holds the options of the unknown_option_detected check.
+		UnknownOptions UnknownOptionsOptions `mapstructure:"unknown_option_detected"`
+		// ExtensionPolicy defines whether extensions are allowed if documented or forbidden entirely.
+		ExtensionPolicy string `mapstructure:"extension_policy"`
+		// GoogleAPIServiceOptions defines whether google.api.default_host and google.api.oauth_scopes
+		// service options are required.
+		GoogleAPIServiceOptions string `mapstructure:"google_api_service_options"`
+		// GoPackageDirectories defines whether go_package options must point to existing directories
+		// of the Go module.
+		GoPackageDirectories string `mapstructure:"go_package_directories"`
+		// Score holds the weights of findings used by the score command.
+		Score ScoreOptions `mapstructure:"score"`
+		// Tasks is the list of tasks the run command executes in order.
+		Tasks []string `mapstructure:"tasks"`
+		// Breaking holds the options of the breaking task.
+		Breaking BreakingOptions `mapstructure:"breaking"`
+		// OpenAPI holds the options of the openapi task.
+		OpenAPI OpenAPIOptions `mapstructure:"openapi"`
+		// ImportBoundaries is a list of rules forbidding packages to depend on other packages.
+		ImportBoundaries []*ImportBoundary `mapstructure:"import_boundaries"`
+		// DeprecatedImports is a list of packages and files other files must not depend on.
+		DeprecatedImports []*DeprecatedImport `mapstructure:"deprecated_imports"`
+		// MaxFindingsPerPackage maps package patterns to the numbers of error findings packages matching them
+		// may have, the "default" key applies to packages matching no pattern.
+		// If set, the run fails only if a package has more error findings than its budget.
+		// Patterns containing dots are loaded as nested maps, they are flattened into findingsBudgets.
+		MaxFindingsPerPackage map[string]interface{} `mapstructure:"max_findings_per_package"`
+		// Overrides is a list of blocks changing excluded checks and severities within a package or path prefix.
+		Overrides                []*Override `mapstructure:"overrides"`
+		excludedChecksMap        map[string]struct{}
+		onlyDescriptors          []string
+		onlyChecks               map[string]struct{}
+		importedDescriptorsCount int
+		findingsBudgets          map[string]int
+		httpClient               *http.Client
+	}
+
+	// Override changes excluded checks and severities of checks
+	// for descriptors within a package prefix or files within a path prefix.
+	// If both prefixes are set, both must match.
+	Override struct {
+		// Package is the prefix of full names of descriptors the override applies to.
+		Package string `mapstructure:"package"`
+		// Path is the prefix of paths of files the override applies to.
+		Path string `mapstructure:"path"`
+		// ExcludedChecks is a list of checks additionally excluded within the scope.
+		ExcludedChecks []string `mapstructure:"excluded_checks"`
+		// CheckSeverities maps check names to their severities within the scope.
+		CheckSeverities map[string]Severity `mapstructure:"check_severities"`
+	}
+
+	// ImportBoundary forbids files of packages matching a pattern to import files
+	// or reference types of packages matching other patterns.
+	// A pattern is either a package name or a package name followed by ".*",
+	// which matches the package and all packages nested in it.
+	ImportBoundary struct {
+		// From is the pattern of packages the rule applies to.
+		From string `mapstructure:"from"`
+		// Forbid is a list of patterns of packages the matching packages must not depend on.
+		Forbid []string `mapstructure:"forbid"`
+	}
+
+	// DeprecatedImport marks packages or files as deprecated, so files must not import them
+	// nor reference their types. If both the package pattern and the path prefix are set, both must match.
+	DeprecatedImport struct {
+		// Package is the pattern of deprecated packages, a package name or a package name followed by ".*".
+		Package string `mapstructure:"package"`
+		// Path is the prefix of paths of deprecated files.
+		Path string `mapstructure:"path"`
+		// Replacement is the package or file to use instead, suggested by findings.
+		Replacement string `mapstructure:"replacement"`
+	}
+
+	// ImportRewrite replaces the prefix of an import path.
+	// The replacement may point to a directory, a GitHub repository path or a URL.
+	ImportRewrite struct {
+		// Prefix is the prefix of import paths to rewrite.
+		Prefix string `mapstructure:"prefix"`
+		// Replacement is the string the prefix is replaced with.
+		Replacement string `mapstructure:"replacement"`
+	}
+
+	// TrivialCommentsOptions holds the options of the comment_not_trivial check.
+	TrivialCommentsOptions struct {
+		// Strictness defines which comments are trivial: exact or loose.
+		Strictness string `mapstructure:"strictness"`
+	}
+
+	// FieldDescriptionLengthOptions holds the options of the field_description_length check.
+	FieldDescriptionLengthOptions struct {
+		// Min is the minimum number of characters in a field description.
+		Min int `mapstructure:"min"`
+		// Max is the maximum number of characters in a field description.
+		Max int `mapstructure:"max"`
+	}
+
+	// CommentStyleOptions holds the options of the comment_style_* checks.
+	CommentStyleOptions struct {
+		// Syntax is the syntax documentation comments must use: line or block.
+		Syntax string `mapstructure:"syntax"`
+	}
+
+	// MessageCyclesOptions holds the options of the message_no_cycles check.
+	MessageCyclesOptions struct {
+		// MaxDepth is the maximum number of messages in an allowed cycle, 0 forbids cycles entirely.
+		MaxDepth int `mapstructure:"max_depth"`
+	}
+
+	// HTTPPathMaxDepthOptions holds the options of the http_path_max_depth check.
+	HTTPPathMaxDepthOptions struct {
+		// MaxSegments is the maximum number of segments in an HTTP path, not counting the version prefix.
+		MaxSegments int `mapstructure:"max_segments"`
+	}
+
+	// AcronymStyleOptions holds the options of the identifier_acronym_style check.
+	AcronymStyleOptions struct {
+		// Acronyms is the list of acronyms whose spelling in names is checked.
+		Acronyms []string `mapstructure:"acronyms"`
+		// Style is the spelling of acronyms, AcronymStyleCapitalized or AcronymStyleUpper.
+		Style string `mapstructure:"style"`
+	}
+
+	// ServiceMaxMethodsOptions holds the options of the service_max_methods check.
+	ServiceMaxMethodsOptions struct {
+		// MaxMethods is the maximum number of methods in a service.
+		MaxMethods int `mapstructure:"max_methods"`
+	}
+
+	// PackageMaxServicesOptions holds the options of the package_max_services check.
+	PackageMaxServicesOptions struct {
+		// MaxServices is the maximum number of services in a package.
+		MaxServices int `mapstructure:"max_services"`
+	}
+
+	// FileMaxLinesOptions holds the options of the file_max_lines check.
+	FileMaxLinesOptions struct {
+		// MaxLines is the maximum number of lines in a file.
+		MaxLines int `mapstructure:"max_lines"`
+	}
+
+	// FileMaxMessagesOptions holds the options of the file_max_messages check.
+	FileMaxMessagesOptions struct {
+		// MaxMessages is the maximum number of top-level messages in a file.
+		MaxMessages int `mapstructure:"max_messages"`
+	}
+
+	// MethodIOSamePackageOptions holds the options of the method_io_same_package check.
+	MethodIOSamePackageOptions struct {
+		// AllowedPackages is a list of package prefixes of shared types methods may use from any package.
+		AllowedPackages []string `mapstructure:"allowed_packages"`
+	}
+
+	// MethodIOColocatedOptions holds the options of the method_io_colocated check.
+	MethodIOColocatedOptions struct {
+		// Policy defines where input and output messages of methods may be defined:
+		// ColocationSameFile, ColocationSameDirectory or ColocationMessagesFile.
+		Policy string `mapstructure:"policy"`
+	}
+
+	// FileHeaderOptions holds the options of the file_has_header check.
+	FileHeaderOptions struct {
+		// Template is the text the first comment block of every file must start with,
+		// {year} matches a year or a range of years like 2020-2024.
+		Template     string `mapstructure:"template"`
+		linePatterns []*regexp.Regexp
+	}
+
+	// MaxLineLengthOptions holds the options of the style_max_line_length check.
+	MaxLineLengthOptions struct {
+		// MaxLength is the maximum number of characters in a line, tabs count as the indentation width.
+		MaxLength int `mapstructure:"max_length"`
+	}
+
+	// IndentationOptions holds the options of the style_indentation check.
+	IndentationOptions struct {
+		// Style defines whether lines are indented with spaces or tabs.
+		Style string `mapstructure:"style"`
+		// Width is the number of spaces in a level of indentation.
+		Width int `mapstructure:"width"`
+	}
+
+	// ExternalDocsOptions holds the options of the swagger_external_docs_valid_url check.
+	ExternalDocsOptions struct {
+		// AllowedDomains is a list of domains external docs URLs must belong to, subdomains included.
+		AllowedDomains []string `mapstructure:"allowed_domains"`
+	}
+
+	// EnvironmentURLsOptions holds the options of the option_no_environment_urls check.
+	EnvironmentURLsOptions struct {
+		// Patterns is a list of regular expressions of environment-specific values forbidden in options,
+		// matched case-insensitively.
+		Patterns []string `mapstructure:"patterns"`
+		patterns []*regexp.Regexp
+	}
+
+	// SensitiveDataOptions holds the options of the field_sensitive_data_annotation check.
+	SensitiveDataOptions struct {
+		// Patterns is a list of regular expressions of names of fields holding sensitive data,
+		// matched case-insensitively.
+		Patterns []string `mapstructure:"patterns"`
+		// Options is a list of full names of field options marking a field as sensitive,
+		// debug_redact is accepted regardless of it.
+		Options  []string `mapstructure:"options"`
+		patterns []*regexp.Regexp
+	}
+
+	// PIIDebugRedactOptions holds the options of the field_pii_debug_redact check.
+	PIIDebugRedactOptions struct {
+		// Patterns is a list of regular expressions of names of fields holding personal data,
+		// matched case-insensitively.
+		Patterns []string `mapstructure:"patterns"`
+		// Annotations is a list of full names of field options marking a field as holding personal data.
+		Annotations []string `mapstructure:"annotations"`
+		// NonSensitivePatterns is a list of regular expressions of names of fields that can't hold personal data,
+		// matched case-insensitively, used in the strict mode along with boolean and enum fields.
+		NonSensitivePatterns []string `mapstructure:"non_sensitive_patterns"`
+		// Strict defines whether debug_redact is forbidden on fields that can't hold personal data.
+		Strict               bool `mapstructure:"strict"`
+		patterns             []*regexp.Regexp
+		nonSensitivePatterns []*regexp.Regexp
+	}
+
+	// MapKeyTypesOptions holds the options of the map_key_type_allowed check.
+	MapKeyTypesOptions struct {
+		// AllowedTypes is a list of key types maps may use.
+		AllowedTypes []string `mapstructure:"allowed_types"`
+		// WarningTypes is a list of key types maps may use, reported as warnings.
+		WarningTypes []string `mapstructure:"warning_types"`
+	}
+
+	// MethodVerbsOptions holds the options of the method_verb_matches_http_method check.
+	MethodVerbsOptions struct {
+		// Verbs is a list of verbs of method names along with the HTTP methods they may be bound to.
+		Verbs []*MethodVerb `mapstructure:"verbs"`
+	}
+
+	// MethodVerb maps a verb method names start with to the HTTP methods the methods may be bound to.
+	MethodVerb struct {
+		// Prefix is the verb, e.g. Get, matched if followed by an upper case letter, a digit or the end of the name.
+		Prefix string `mapstructure:"prefix"`
+		// HTTPMethods is a list of HTTP methods of google.api.http the methods may be bound to, e.g. get.
+		HTTPMethods []string `mapstructure:"http_methods"`
+	}
+
+	// CreateResponseOptions holds the options of the method_create_returns_resource check.
+	CreateResponseOptions struct {
+		// Strictness defines which responses return the resource: embedded or resource.
+		Strictness string `mapstructure:"strictness"`
+	}
+
+	// UnknownOptionsOptions holds the options of the unknown_option_detected check.
+	UnknownOptionsOptions struct {
+		// ExtensionSources is a list of import paths of files defining option extensions,
+		// used to name the extensions options unknown to the checked files are set by.
+		ExtensionSources []string `mapstructure:"extension_sources"`
+	}
+
+	// DownloadsOptions holds the limits of downloaded dependencies.
+	DownloadsOptions struct {
+		// MaxSize is the maximum size of a downloaded dependency in bytes.
+		MaxSize int64 `mapstructure:"max_size"`
+		// AllowedContentTypes is a list of content types downloaded dependencies may be served with,
+		// nil means the default list, an empty list allows any type.
+		AllowedContentTypes []string `mapstructure:"allowed_content_types"`
+		// MaxConcurrency is the maximum number of dependencies downloaded at the same time.
+		MaxConcurrency int `mapstructure:"max_concurrency"`
+		// MaxAttempts is the maximum number of attempts to download a dependency, 1 disables retries.
+		MaxAttempts int `mapstructure:"max_attempts"`
+		// Checksums is a list of SHA-256 checksums pinned for dependencies.
+		Checksums []*DependencyChecksum `mapstructure:"checksums"`
+	}
+
+	// DependencyChecksum pins the SHA-256 checksum of the contents of a downloaded dependency.
+	DependencyChecksum struct {
+		// Path is the import path of the dependency as recorded in manifests.
+		Path string `mapstructure:"path"`
+		// SHA256 is the expected checksum of the contents in hex.
+		SHA256 string `mapstructure:"sha256"`
+	}
+
+	// HTTPClientOptions holds the options of the client of HTTP requests made for the resolver.
+	HTTPClientOptions struct {
+		// ProxyURL is the URL of the HTTP(S) or SOCKS5 proxy, e.g. socks5://proxy.example.com:1080.
+		// If it's not set, the proxy is taken from the HTTPS_PROXY and HTTP_PROXY environment variables.
+		ProxyURL string `mapstructure:"proxy_url"`
+		// ClientCertificate is the path to the PEM file of the client certificate used for mTLS.
+		ClientCertificate string `mapstructure:"client_certificate"`
+		// ClientKey is the path to the PEM file of the private key of the client certificate.
+		ClientKey string `mapstructure:"client_key"`
+		// CACertificate is the path to the PEM file of CA certificates trusted instead of the system ones.
+		CACertificate string `mapstructure:"ca_certificate"`
+	}
+
+	// CacheOptions holds the options of the shared remote cache of downloaded dependencies.
+	CacheOptions struct {
+		// RemoteURL is the base URL of an HTTP or S3-compatible storage entries are read from and written to.
+		RemoteURL string `mapstructure:"remote_url"`
+		// ReadOnly defines whether entries are only read from the remote cache, e.g. by untrusted runners.
+		ReadOnly bool `mapstructure:"read_only"`
+	}
+
+	// RemovalNoteOptions holds the deprecated options of the deprecated_field_has_removal_note check.
+	RemovalNoteOptions struct {
+		// FailAfterDate defined whether deprecated fields still present after their removal date are reported.
+		// Deprecated: the deprecation_expired check reports them, false excludes that check, nil means unset.
+		FailAfterDate *bool `mapstructure:"fail_after_date"`
+	}
+
+	// DeprecatedOption describes an option that is still honored but superseded by another setting.
+	DeprecatedOption struct {
+		Key         string // Key of the option in the configuration file.
+		Replacement string // Description of the setting to use instead.
+	}
+
+	// ScoreOptions holds the weights of findings used by the score command.
+	// The penalty of a finding is the product of the weights of its severity and the category of its check.
+	ScoreOptions struct {
+		// SeverityWeights maps severities to their weights.
+		SeverityWeights map[Severity]float64 `mapstructure:"severity_weights"`
+		// CategoryWeights maps categories of checks to their weights.
+		CategoryWeights map[string]float64 `mapstructure:"category_weights"`
+	}
+
+	// BreakingOptions holds the options of the breaking task.
+	BreakingOptions struct {
+		// Against is the path to the descriptor set of the baseline the files are compared with,
+		// e.g. built by "buf build -o" or "protoc --descriptor_set_out --include_source_info".
+		Against string `mapstructure:"against"`
+	}
+
+	// OpenAPIOptions holds the options of the openapi task.
+	OpenAPIOptions struct {
+		// Command is the generator command with its arguments. The DescriptorSetPlaceholder argument is replaced
+		// by the path to the descriptor set of the compiled files, the FilesPlaceholder one by the files.
+		Command []string `mapstructure:"command"`
+	}
+
+	// Escalation describes a policy changing the severity of a check after the specified date.
+	Escalation struct {
+		// Check is the name of the escalated check.
+		Check string `mapstructure:"check"`
+		// After is the date in YYYY-MM-DD format starting from which the escalation is applied.
+		After string `mapstructure:"after"`
+		// From is the severity the check has before the escalation.
+		From Severity `mapstructure:"from"`
+		// To is the severity the check has after the escalation.
+		To        Severity `mapstructure:"to"`
+		afterDate time.Time
+	}
+
+	// Severity defines how a failed check affects the result of the run.
+	Severity string
+)
+
+const (
+	// AcronymStyleCapitalized spells acronyms in names like words, e.g. GetHttpUrlV1.
+	AcronymStyleCapitalized = "capitalized"
+	// AcronymStyleUpper spells acronyms in names in upper case, e.g. GetHTTPURLV1.
+	AcronymStyleUpper = "upper"
+)
+
+const (
+	// ColocationSameFile requires input and output messages to be defined in the file of the service.
+	ColocationSameFile = "same_file"
+	// ColocationSameDirectory requires input and output messages to be defined in the directory of the service.
+	ColocationSameDirectory = "same_directory"
+	// ColocationMessagesFile requires input and output messages to be defined in the file of the service
+	// or in its sibling with the MessagesFileSuffix, e.g. orders_messages.proto for orders.proto.
+	ColocationMessagesFile = "messages_file"
+	// MessagesFileSuffix is the suffix of files paired with service files by ColocationMessagesFile.
+	MessagesFileSuffix = "_messages.proto"
+)
+
+const (
+	// TrivialCommentsExact flags comments consisting of exactly the words of the identifier.
+	TrivialCommentsExact = "exact"
+	// TrivialCommentsLoose flags comments having no words except the words of the identifier and articles.
+	TrivialCommentsLoose = "loose"
+)
+
+const (
+	// CreateResponseEmbedded accepts responses being the resource or having a field of the resource type.
+	CreateResponseEmbedded = "embedded"
+	// CreateResponseResource accepts only responses being the resource itself, as AIP-133 suggests.
+	CreateResponseResource = "resource"
+)
+
+const (
+	// CommentSyntaxLine requires documentation comments to use // line comments.
+	CommentSyntaxLine = "line"
+	// CommentSyntaxBlock requires documentation comments to use /* */ block comments.
+	CommentSyntaxBlock = "block"
+)
+
+const (
+	// IndentationSpaces requires lines to be indented with spaces, a multiple of the indentation width.
+	IndentationSpaces = "spaces"
+	// IndentationTabs requires lines to be indented with tabs.
+	IndentationTabs = "tabs"
+)
+
+const (
+	// ExtensionPolicyDocument allows extensions and extension ranges, requiring ranges to be documented.
+	ExtensionPolicyDocument = "document"
+	// ExtensionPolicyForbid forbids extensions and extension ranges entirely.
+	ExtensionPolicyForbid = "forbid"
+)
+
+const (
+	// LocaleEnglish is the default language of diagnostic messages.
+	LocaleEnglish = "en"
+	// LocaleRussian is the Russian language of diagnostic messages.
+	LocaleRussian = "ru"
+)
+
+const (
+	// TaskCompile compiles files, reporting syntax and link errors.
+	TaskCompile = "compile"
+	// TaskLint checks files like the check command.
+	TaskLint = "lint"
+	// TaskScore ranks packages by a score of compliance with the checks.
+	TaskScore = "score"
+	// TaskCoverage reports how many descriptors every check covers after exclusions.
+	TaskCoverage = "coverage"
+	// TaskBreaking compares files with the baseline descriptor set, reporting changes breaking compatibility.
+	TaskBreaking = "breaking"
+	// TaskOpenAPI runs the configured generator of OpenAPI documents on the compiled files.
+	TaskOpenAPI = "openapi"
+)
+
+const (
+	// DescriptorSetPlaceholder is the argument of the openapi command replaced by the path to the descriptor set.
+	DescriptorSetPlaceholder = "{descriptor_set}"
+	// FilesPlaceholder is the argument of the openapi command replaced by the paths to the files.
+	FilesPlaceholder = "{files}"
+)
+
+// GoogleAPIServiceOptionsRequired requires services to set google.api.default_host and google.api.oauth_scopes.
+const GoogleAPIServiceOptionsRequired = "required"
+
+// GoPackageDirectoriesRequired requires go_package options to point to existing directories of the Go module.
+const GoPackageDirectoriesRequired = "required"
+
+const (
+	// SeverityError marks findings that fail the run.
+	SeverityError Severity = "error"
+	// SeverityWarning marks findings that are reported but don't fail the run.
+	SeverityWarning Severity = "warning"
+)
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ForScope returns the configuration effective for the descriptor with the specified full name
+// declared in the file with the specified path, applying the matching overrides in the order they are listed.
+// If no override matches, the configuration itself is returned.
+func (cfg *Config) ForScope(path, fullName string) *Config {
+	if cfg == nil || len(cfg.Overrides) == 0 {
+		return cfg
+	}
+
+	var (
+		result  *Config
+		slashed = filepath.ToSlash(path)
+	)
+
+	for _, override := range cfg.Overrides {
+		if !override.matches(slashed, fullName) {
+			continue
+		}
+
+		if result == nil {
+			result = cfg.clone()
+		}
+
+		result.ExcludedChecks = append(result.ExcludedChecks, override.ExcludedChecks...)
+
+		for name, severity := range override.CheckSeverities {
+			result.CheckSeverities[name] = severity
+		}
+	}
+
+	if result == nil {
+		return cfg
+	}
+
+	result.excludedChecksMap = make(map[string]struct{}, len(result.ExcludedChecks))
+	for _, check := range result.ExcludedChecks {
+		result.excludedChecksMap[check] = struct{}{}
+	}
+
+	return result
+}
+
+// clone returns a copy of the configuration, which lists of excluded checks
+// and severities can be changed without affecting the original.
+func (cfg *Config) clone() *Config {
+	result := *cfg
+
+	result.ExcludedChecks = make([]string, len(cfg.ExcludedChecks))
+	copy(result.ExcludedChecks, cfg.ExcludedChecks)
+
+	result.CheckSeverities = make(map[string]Severity, len(cfg.CheckSeverities))
+	for name, severity := range cfg.CheckSeverities {
+		result.CheckSeverities[name] = severity
+	}
+
+	return &result
+}
+
+func (o *Override) matches(path, fullName string) bool {
+	if o.Package != "" && !strings.HasPrefix(fullName, o.Package) {
+		return false
+	}
+
+	if o.Path != "" && !strings.HasPrefix(path, filepath.ToSlash(o.Path)) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForScope(t *testing.T) {
+	cfg := &Config{
+		ExcludedChecks: []string{"method_has_version"},
+		CheckSeverities: map[string]Severity{
+			"field_has_no_description": SeverityError,
+		},
+		Overrides: []*Override{
+			{
+				Package:        "internal.",
+				ExcludedChecks: []string{"field_has_no_description"},
+			},
+			{
+				Path: "api/legacy/",
+				CheckSeverities: map[string]Severity{
+					"enum_value_has_comments": SeverityWarning,
+				},
+			},
+		},
+	}
+
+	if err := cfg.fillInnerData(); err != nil {
+		t.Fatal(err)
+	}
+
+	if scoped := cfg.ForScope("api/orders.proto", "orders.v1.Order"); scoped != cfg {
+		t.Error("expected the configuration itself when no override matches")
+	}
+
+	internal := cfg.ForScope("api/orders.proto", "internal.orders.v1.Order")
+	if !internal.IsCheckExcluded("field_has_no_description") || !internal.IsCheckExcluded("method_has_version") {
+		t.Errorf("expected checks to be excluded within the package, got %v", internal.ExcludedChecks)
+	}
+
+	if cfg.IsCheckExcluded("field_has_no_description") {
+		t.Error("override must not change the original configuration")
+	}
+
+	legacy := cfg.ForScope("api/legacy/orders.proto", "orders.v1.Order")
+	if severity, _ := legacy.GetCheckSeverity("enum_value_has_comments", time.Time{}); severity != SeverityWarning {
+		t.Errorf("expected warning severity within the path, got %s", severity)
+	}
+
+	if _, ok := cfg.CheckSeverities["enum_value_has_comments"]; ok {
+		t.Error("override must not change the original severities")
+	}
+}
+
+func TestOverrideWithoutScope(t *testing.T) {
+	cfg := &Config{
+		Overrides: []*Override{{ExcludedChecks: []string{"method_has_version"}}},
+	}
+
+	if err := cfg.fillInnerData(); err == nil {
+		t.Error("expected an error for an override without package and path")
+	}
+}
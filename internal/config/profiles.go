@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	profileKey  = "profile"
+	profilesKey = "profiles"
+)
+
+// applyProfile merges the keys of the selected profile over the keys of the configuration file.
+// Maps are merged key by key, other values including lists are replaced.
+// Environment variables and flags still take precedence over the profile.
+func applyProfile(v *viper.Viper) error {
+	name := strings.ToLower(v.GetString(profileKey))
+	if name == "" {
+		return nil
+	}
+
+	profiles := v.GetStringMap(profilesKey)
+
+	profile, ok := profiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q, available profiles: %s", name, strings.Join(getProfileNames(profiles), ", "))
+	}
+
+	settings, ok := profile.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("profile %q must be a mapping of configuration keys", name)
+	}
+
+	return v.MergeConfigMap(settings)
+}
+
+// getProfileNames returns the sorted names of the profiles.
+func getProfileNames(profiles map[string]interface{}) []string {
+	result := make([]string, 0, len(profiles))
+	for name := range profiles {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
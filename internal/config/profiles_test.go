@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigProfile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), DefaultConfigName)
+
+	err := os.WriteFile(fileName, []byte(`
+excluded_checks:
+  - method_has_version
+check_severities:
+  field_has_no_description: warning
+profiles:
+  strict:
+    excluded_checks: []
+    check_severities:
+      method_has_swagger_tags: warning
+  local:
+    verbose_mode: true
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write configuration file: %s", err.Error())
+	}
+
+	cfg, err := LoadConfig(fileName)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	if len(cfg.GetExcludedChecks()) != 1 || cfg.GetVerboseMode() {
+		t.Errorf("expected no profile to be applied by default, got %+v", cfg)
+	}
+
+	t.Setenv("PROTOLINTER_PROFILE", "strict")
+
+	cfg, err = LoadConfig(fileName)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	if len(cfg.GetExcludedChecks()) != 0 {
+		t.Errorf("expected the profile to replace excluded checks, got %v", cfg.GetExcludedChecks())
+	}
+
+	if len(cfg.CheckSeverities) != 2 {
+		t.Errorf("expected the profile to merge severities of checks, got %v", cfg.CheckSeverities)
+	}
+
+	t.Setenv("PROTOLINTER_PROFILE", "nightly")
+
+	if _, err = LoadConfig(fileName); err == nil {
+		t.Error("expected an error for the unknown profile")
+	}
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	excludedChecksKey      = "excluded_checks"
+	excludedDescriptorsKey = "excluded_descriptors"
+	configFileMode         = 0o644
+	yamlIndent             = 2
+)
+
+// WithExcludedChecks returns a copy of the configuration with the specified list of excluded checks.
+func (cfg *Config) WithExcludedChecks(checks []string) *Config {
+	var result Config
+	if cfg != nil {
+		result = *cfg
+	}
+
+	result.ExcludedChecks = checks
+	result.excludedChecksMap = nil
+	_ = result.fillInnerData()
+
+	return &result
+}
+
+// WithoutExclusions returns a copy of the configuration with excluded checks, excluded descriptors
+// and checks excluded by overrides removed, so every check runs on every descriptor.
+func (cfg *Config) WithoutExclusions() *Config {
+	result := cfg.WithExcludedChecks(nil)
+	result.ExcludedDescriptors = nil
+	result.importedDescriptorsCount = 0
+	result.RemovalNote.FailAfterDate = nil
+
+	overrides := make([]*Override, 0, len(result.Overrides))
+
+	for _, override := range result.Overrides {
+		withoutExclusions := *override
+		withoutExclusions.ExcludedChecks = nil
+		overrides = append(overrides, &withoutExclusions)
+	}
+
+	result.Overrides = overrides
+
+	return result
+}
+
+// RemoveExclusions rewrites the configuration file removing the specified entries
+// from the excluded_checks and excluded_descriptors sections, keeping everything else intact.
+func RemoveExclusions(filename string, checks, descriptors []string) error {
+	return rewriteConfigFile(filename, false, func(root *yaml.Node) {
+		removeSequenceValues(root, excludedChecksKey, checks)
+		removeSequenceValues(root, excludedDescriptorsKey, descriptors)
+	})
+}
+
+// AddExcludedDescriptors rewrites the configuration file appending the specified descriptors
+// to the excluded_descriptors section, keeping everything else intact.
+// The file is created if it doesn't exist.
+func AddExcludedDescriptors(filename string, descriptors []string) error {
+	return rewriteConfigFile(filename, true, func(root *yaml.Node) {
+		appendSequenceValues(root, excludedDescriptorsKey, descriptors, nil)
+	})
+}
+
+// GenerateConfig returns the contents of the configuration file with the specified descriptors appended
+// to the excluded_descriptors section, keeping everything else intact.
+// Every appended descriptor is preceded by the lines of its comment, if there are any,
+// the lines of the note are added to the comment of the section itself.
+// A missing configuration file is treated as an empty one.
+func GenerateConfig(
+	filename string,
+	descriptors []string,
+	comments map[string][]string,
+	note []string,
+) ([]byte, error) {
+	return editConfigFile(filename, true, func(root *yaml.Node) {
+		appendSequenceValues(root, excludedDescriptorsKey, descriptors, comments)
+		appendKeyComment(root, excludedDescriptorsKey, note)
+	})
+}
+
+// rewriteConfigFile applies the edit to the root mapping of the configuration file and writes it back.
+// If create is set, a missing or empty file is treated as an empty mapping.
+func rewriteConfigFile(filename string, create bool, edit func(root *yaml.Node)) error {
+	data, err := editConfigFile(filename, create, edit)
+	if err != nil || data == nil {
+		return err
+	}
+
+	if err = os.WriteFile(filename, data, configFileMode); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	return nil
+}
+
+// editConfigFile applies the edit to the root mapping of the configuration file and returns the result.
+// If create is set, a missing or empty file is treated as an empty mapping, otherwise nil is returned for it.
+func editConfigFile(filename string, create bool, edit func(root *yaml.Node)) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil && !(create && errors.Is(err, os.ErrNotExist)) {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		if !create {
+			return nil, nil
+		}
+
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	edit(document.Content[0])
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	if err = encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration file: %w", err)
+	}
+
+	if err = encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// appendSequenceValues appends the values missing from the sequence of the key,
+// adding the key to the mapping if it isn't there.
+// Appended values are preceded by the lines of their comments, if there are any.
+func appendSequenceValues(mapping *yaml.Node, key string, values []string, comments map[string][]string) {
+	if mapping.Kind != yaml.MappingNode || len(values) == 0 {
+		return
+	}
+
+	var sequence *yaml.Node
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			sequence = mapping.Content[i+1]
+
+			break
+		}
+	}
+
+	if sequence == nil {
+		sequence = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			sequence)
+	}
+
+	// A null value like "excluded_descriptors:" becomes an empty sequence.
+	if sequence.Kind != yaml.SequenceNode {
+		*sequence = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+
+	existingValues := make(map[string]struct{}, len(sequence.Content))
+	for _, item := range sequence.Content {
+		existingValues[item.Value] = struct{}{}
+	}
+
+	for _, v := range values {
+		if _, ok := existingValues[v]; ok {
+			continue
+		}
+
+		existingValues[v] = struct{}{}
+
+		item := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+		if lines := comments[v]; len(lines) > 0 {
+			item.HeadComment = "# " + strings.Join(lines, "\n# ")
+		}
+
+		sequence.Content = append(sequence.Content, item)
+	}
+}
+
+// appendKeyComment appends the lines to the comment preceding the key, adding the key to the mapping
+// with an empty sequence if it isn't there.
+func appendKeyComment(mapping *yaml.Node, key string, lines []string) {
+	if mapping.Kind != yaml.MappingNode || len(lines) == 0 {
+		return
+	}
+
+	var keyNode *yaml.Node
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			keyNode = mapping.Content[i]
+
+			break
+		}
+	}
+
+	if keyNode == nil {
+		keyNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+		mapping.Content = append(mapping.Content, keyNode, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"})
+	}
+
+	comment := "# " + strings.Join(lines, "\n# ")
+	if keyNode.HeadComment != "" {
+		comment = keyNode.HeadComment + "\n" + comment
+	}
+
+	keyNode.HeadComment = comment
+}
+
+func removeSequenceValues(mapping *yaml.Node, key string, values []string) {
+	if mapping.Kind != yaml.MappingNode || len(values) == 0 {
+		return
+	}
+
+	removedValues := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		removedValues[v] = struct{}{}
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+		if keyNode.Value != key || valueNode.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		keptItems := make([]*yaml.Node, 0, len(valueNode.Content))
+
+		for _, item := range valueNode.Content {
+			if _, ok := removedValues[item.Value]; !ok {
+				keptItems = append(keptItems, item)
+			}
+		}
+
+		valueNode.Content = keptItems
+	}
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigSeverities(t *testing.T) {
+	const content = `check_severities:
+  field_has_no_description: warning
+  enum_value_has_comments: error
+escalate:
+  - check: field_has_no_description
+    after: "2025-07-01"
+  - check: enum_value_has_comments
+    after: "2025-01-01"
+    from: error
+    to: warning
+`
+
+	filename := filepath.Join(t.TempDir(), ".protolinter.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := LoadConfig(filename)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	var (
+		beforeEscalation = time.Date(2025, 6, 30, 23, 59, 0, 0, time.UTC)
+		afterEscalation  = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		check            string
+		now              time.Time
+		expected         Severity
+		expectedUpcoming string
+	}{
+		{check: "field_has_no_description", now: beforeEscalation, expected: SeverityWarning, expectedUpcoming: "2025-07-01"},
+		{check: "field_has_no_description", now: afterEscalation, expected: SeverityError},
+		{check: "enum_value_has_comments", now: beforeEscalation, expected: SeverityWarning},
+		{check: "message_has_comments", now: beforeEscalation, expected: SeverityError},
+	}
+
+	for _, test := range tests {
+		severity, upcoming := cfg.GetCheckSeverity(test.check, test.now)
+		if severity != test.expected {
+			t.Errorf("expected severity %s of %s at %s, got %s", test.expected, test.check, test.now, severity)
+		}
+
+		var upcomingDate string
+		if upcoming != nil {
+			upcomingDate = upcoming.After
+		}
+
+		if upcomingDate != test.expectedUpcoming {
+			t.Errorf("expected upcoming escalation %q of %s at %s, got %q",
+				test.expectedUpcoming, test.check, test.now, upcomingDate)
+		}
+	}
+
+	if severity, upcoming := (*Config)(nil).GetCheckSeverity("field_has_no_description", afterEscalation); severity != SeverityError ||
+		upcoming != nil {
+		t.Errorf("expected error severity without escalation for nil configuration, got %s and %v", severity, upcoming)
+	}
+}
+
+func TestLoadConfigEscalationWithoutSeverity(t *testing.T) {
+	const content = `escalate:
+  - check: field_has_no_description
+    after: "2099-01-01"
+    from: warning
+    to: error
+`
+
+	filename := filepath.Join(t.TempDir(), ".protolinter.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write configuration: %s", err.Error())
+	}
+
+	cfg, err := LoadConfig(filename)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	// Until the date comes, the check is reported with the severity it's escalated from.
+	severity, upcoming := cfg.GetCheckSeverity("field_has_no_description", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if severity != SeverityWarning || upcoming == nil || upcoming.After != "2099-01-01" {
+		t.Errorf("expected warning severity with the upcoming escalation, got %s and %v", severity, upcoming)
+	}
+
+	severity, upcoming = cfg.GetCheckSeverity("field_has_no_description", time.Date(2099, 1, 2, 0, 0, 0, 0, time.UTC))
+	if severity != SeverityError || upcoming != nil {
+		t.Errorf("expected error severity after the escalation, got %s and %v", severity, upcoming)
+	}
+}
+
+func TestValidateSeverities(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		isValid bool
+	}{
+		{
+			name:    "known severities",
+			cfg:     &Config{CheckSeverities: map[string]Severity{"a": SeverityWarning, "b": SeverityError}},
+			isValid: true,
+		},
+		{
+			name: "unknown severity",
+			cfg:  &Config{CheckSeverities: map[string]Severity{"a": "fatal"}},
+		},
+		{
+			name:    "escalation with default severities",
+			cfg:     &Config{Escalations: []*Escalation{{Check: "a", After: "2025-07-01"}}},
+			isValid: true,
+		},
+		{
+			name: "escalation with invalid date",
+			cfg:  &Config{Escalations: []*Escalation{{Check: "a", After: "01.07.2025"}}},
+		},
+		{
+			name: "escalation with unknown severity",
+			cfg:  &Config{Escalations: []*Escalation{{Check: "a", After: "2025-07-01", To: "fatal"}}},
+		},
+	}
+
+	for _, test := range tests {
+		err := test.cfg.fillInnerData()
+		if test.isValid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		}
+
+		if !test.isValid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+
+	escalation := &Escalation{Check: "a", After: "2025-07-01"}
+	if err := (&Config{Escalations: []*Escalation{escalation}}).fillInnerData(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if escalation.From != SeverityWarning || escalation.To != SeverityError {
+		t.Errorf("expected escalation from warning to error by default, got from %s to %s", escalation.From, escalation.To)
+	}
+}
+
+func TestLoadConfigByPath(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "protolinter.yaml", content: "verbose_mode: true\n"},
+		{name: "protolinter", content: "verbose_mode: true\n"},
+		{name: "protolinter.conf", content: "verbose_mode: true\n"},
+		{name: "protolinter.json", content: `{"verbose_mode": true}`},
+	}
+
+	for _, test := range tests {
+		filename := filepath.Join(dir, test.name)
+		if err := os.WriteFile(filename, []byte(test.content), 0o600); err != nil {
+			t.Fatalf("failed to write configuration: %s", err.Error())
+		}
+
+		cfg, err := LoadConfig(filename)
+		if err != nil {
+			t.Errorf("failed to load configuration %s: %s", test.name, err.Error())
+
+			continue
+		}
+
+		if !cfg.GetVerboseMode() {
+			t.Errorf("expected verbose mode to be read from %s", test.name)
+		}
+	}
+
+	if cfg, err := LoadConfig(filepath.Join(dir, "missing.yaml")); cfg != nil || err != nil {
+		t.Errorf("expected no configuration and no error for the missing file, got %v and %v", cfg, err)
+	}
+}
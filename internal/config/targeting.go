@@ -0,0 +1,84 @@
+package config
+
+import "strings"
+
+// WithOnlyDescriptors returns a copy of the configuration reporting findings
+// only for descriptors having one of the specified full name prefixes.
+// An empty list targets all descriptors.
+func (cfg *Config) WithOnlyDescriptors(prefixes []string) *Config {
+	var result Config
+	if cfg != nil {
+		result = *cfg
+	}
+
+	result.onlyDescriptors = prefixes
+
+	return &result
+}
+
+// WithOnlyChecks returns a copy of the configuration running only the specified checks,
+// even if they are excluded by the configuration. An empty list runs the checks as configured.
+func (cfg *Config) WithOnlyChecks(checks []string) *Config {
+	var result Config
+	if cfg != nil {
+		result = *cfg
+	}
+
+	result.onlyChecks = nil
+
+	if len(checks) > 0 {
+		result.onlyChecks = make(map[string]struct{}, len(checks))
+		for _, check := range checks {
+			result.onlyChecks[check] = struct{}{}
+		}
+	}
+
+	return &result
+}
+
+// WithLocale returns a copy of the configuration emitting diagnostic messages in the specified language.
+// An empty locale keeps the language as configured.
+func (cfg *Config) WithLocale(locale string) *Config {
+	var result Config
+	if cfg != nil {
+		result = *cfg
+	}
+
+	if locale != "" {
+		result.Locale = locale
+	}
+
+	return &result
+}
+
+// IsDescriptorTargeted returns true if findings of the descriptor with the specified full name are reported,
+// i.e. no descriptors are targeted or the name starts with one of the targeted prefixes.
+func (cfg *Config) IsDescriptorTargeted(fullName string) bool {
+	if cfg == nil || len(cfg.onlyDescriptors) == 0 {
+		return true
+	}
+
+	for _, prefix := range cfg.onlyDescriptors {
+		if strings.HasPrefix(fullName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDescriptorOnTargetPath returns true if the descriptor with the specified full name is targeted
+// or may contain targeted descriptors, so it has to be walked.
+func (cfg *Config) IsDescriptorOnTargetPath(fullName string) bool {
+	if cfg.IsDescriptorTargeted(fullName) {
+		return true
+	}
+
+	for _, prefix := range cfg.onlyDescriptors {
+		if strings.HasPrefix(prefix, fullName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package config
+
+import "testing"
+
+func TestWithOnlyDescriptors(t *testing.T) {
+	cfg := (*Config)(nil).WithOnlyDescriptors([]string{"orders.v1.OrderService", "orders.v1.Order."})
+
+	tests := []struct {
+		fullName       string
+		isTargeted     bool
+		isOnTargetPath bool
+	}{
+		{fullName: "orders.v1.OrderService", isTargeted: true, isOnTargetPath: true},
+		{fullName: "orders.v1.OrderService.GetOrderV1", isTargeted: true, isOnTargetPath: true},
+		{fullName: "orders.v1.Order.id", isTargeted: true, isOnTargetPath: true},
+		{fullName: "orders.v1.Order", isTargeted: false, isOnTargetPath: true},
+		{fullName: "orders.v1", isTargeted: false, isOnTargetPath: true},
+		{fullName: "orders.v1.Payment", isTargeted: false, isOnTargetPath: false},
+	}
+
+	for _, test := range tests {
+		if actual := cfg.IsDescriptorTargeted(test.fullName); actual != test.isTargeted {
+			t.Errorf("expected %s to be targeted: %t, got %t", test.fullName, test.isTargeted, actual)
+		}
+
+		if actual := cfg.IsDescriptorOnTargetPath(test.fullName); actual != test.isOnTargetPath {
+			t.Errorf("expected %s to be on target path: %t, got %t", test.fullName, test.isOnTargetPath, actual)
+		}
+	}
+
+	if !(*Config)(nil).IsDescriptorTargeted("orders.v1.Payment") {
+		t.Error("expected all descriptors to be targeted without targets")
+	}
+}
+
+func TestWithOnlyChecks(t *testing.T) {
+	cfg := &Config{ExcludedChecks: []string{"method_has_version", "method_has_http_path"}}
+	if err := cfg.fillInnerData(); err != nil {
+		t.Fatal(err)
+	}
+
+	selected := cfg.WithOnlyChecks([]string{"method_has_version"})
+	if selected.IsCheckExcluded("method_has_version") {
+		t.Error("expected the selected check to run even if it's excluded")
+	}
+
+	if !selected.IsCheckExcluded("field_has_no_description") {
+		t.Error("expected checks not selected to be excluded")
+	}
+
+	if unchanged := cfg.WithOnlyChecks(nil); !unchanged.IsCheckExcluded("method_has_http_path") ||
+		unchanged.IsCheckExcluded("field_has_no_description") {
+		t.Error("expected checks to run as configured without selection")
+	}
+}
@@ -0,0 +1,662 @@
+// Package formatter reformats protobuf files into the canonical layout.
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+)
+
+const (
+	indentUnit         = "  "
+	continuationIndent = 2
+	lineCommentPrefix  = "//"
+)
+
+// printer writes the nodes of the parsed file token by token with the canonical spacing.
+type printer struct {
+	fileNode *ast.FileNode
+	buf      bytes.Buffer
+	indent   int
+	// lineStart is true if nothing is written to the current line yet.
+	lineStart bool
+	// needNewline is true if the last written comment is a line comment, so nothing can follow it.
+	needNewline bool
+	// noSpace suppresses the space before the next token.
+	noSpace bool
+	// lastToken is the text of the last token written to the current line.
+	lastToken string
+	// lastLine is the source line the last written token or comment ends at.
+	lastLine int
+	// padding holds the number of spaces aligning the equal signs of consecutive options.
+	padding map[*ast.OptionNode]int
+	// pendingPadding is the number of spaces written before the next token.
+	pendingPadding int
+	// printedComments holds the comments already written, as some are written ahead of their nodes.
+	printedComments map[ast.Item]struct{}
+}
+
+// Format returns the file reformatted into the canonical layout:
+// the syntax, the package, sorted imports, aligned options and then definitions,
+// indented by two spaces with comments kept next to the elements they belong to.
+func Format(fileName string, data []byte) ([]byte, error) {
+	fileNode, err := parser.Parse(fileName, bytes.NewReader(data), reporter.NewHandler(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", fileName, err)
+	}
+
+	p := &printer{
+		fileNode:  fileNode,
+		lineStart: true,
+		padding:   make(map[*ast.OptionNode]int),
+
+		printedComments: make(map[ast.Item]struct{}),
+	}
+
+	p.printFile()
+
+	result := p.buf.Bytes()
+
+	// The result is parsed once more, so a formatting error never corrupts the file.
+	if _, err = parser.Parse(fileName, bytes.NewReader(result), reporter.NewHandler(nil)); err != nil {
+		return nil, fmt.Errorf("failed to format file %s, the result is invalid: %w", fileName, err)
+	}
+
+	return result, nil
+}
+
+// printFile writes the elements of the file grouped in the canonical order,
+// the groups and top-level definitions are separated by blank lines.
+func (p *printer) printFile() {
+	var (
+		packages    []ast.Node
+		imports     []*ast.ImportNode
+		options     []*ast.OptionNode
+		definitions []ast.Node
+	)
+
+	for _, decl := range p.fileNode.Decls {
+		switch node := decl.(type) {
+		case *ast.PackageNode:
+			packages = append(packages, node)
+		case *ast.ImportNode:
+			imports = append(imports, node)
+		case *ast.OptionNode:
+			options = append(options, node)
+		case *ast.EmptyDeclNode:
+			if p.hasComments(node) {
+				definitions = append(definitions, node)
+			}
+		default:
+			definitions = append(definitions, node)
+		}
+	}
+
+	sort.SliceStable(imports, func(i, j int) bool {
+		return imports[i].Name.AsString() < imports[j].Name.AsString()
+	})
+
+	singleLineOptions := make([]*ast.OptionNode, 0, len(options))
+	for _, option := range options {
+		if p.isSingleLine(option) {
+			singleLineOptions = append(singleLineOptions, option)
+		}
+	}
+
+	p.alignOptions(singleLineOptions)
+
+	groups := make([][]ast.Node, 0, 4) //nolint: gomnd // Syntax, package, imports and options.
+	if p.fileNode.Syntax != nil {
+		groups = append(groups, []ast.Node{p.fileNode.Syntax})
+	}
+
+	groups = append(groups, packages)
+
+	importNodes := make([]ast.Node, 0, len(imports))
+	for _, node := range imports {
+		importNodes = append(importNodes, node)
+	}
+
+	optionNodes := make([]ast.Node, 0, len(options))
+	for _, node := range options {
+		optionNodes = append(optionNodes, node)
+	}
+
+	groups = append(groups, importNodes, optionNodes)
+
+	printed := false
+
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		if printed {
+			p.blankLine()
+		}
+
+		for _, node := range group {
+			p.breakLine()
+			p.printNode(node)
+		}
+
+		printed = true
+	}
+
+	for _, node := range definitions {
+		if printed {
+			p.blankLine()
+		}
+
+		p.breakLine()
+		p.printNode(node)
+
+		printed = true
+	}
+
+	if comments := p.getLeadingComments(p.fileNode.EOF); len(comments) > 0 {
+		if printed {
+			p.blankLine()
+		}
+
+		p.printCommentLines(comments)
+	}
+
+	p.breakLine()
+}
+
+// printNode writes the node, declarations with bodies are written as indented blocks.
+func (p *printer) printNode(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.MessageNode, *ast.EnumNode, *ast.ServiceNode, *ast.RPCNode,
+		*ast.OneofNode, *ast.ExtendNode, *ast.GroupNode:
+		p.printBlock(n.(ast.CompositeNode)) //nolint: forcetypeassert // All the listed nodes are composite.
+	case *ast.MessageLiteralNode:
+		p.printMessageLiteral(n)
+	case *ast.CompoundStringLiteralNode:
+		p.printCompoundString(n)
+	case *ast.CompoundIdentNode, *ast.OptionNameNode, *ast.FieldReferenceNode,
+		*ast.NegativeIntLiteralNode, *ast.SignedFloatLiteralNode:
+		p.printAtomic(n.(ast.CompositeNode)) //nolint: forcetypeassert // All the listed nodes are composite.
+	case ast.TerminalNode:
+		p.printTerminal(n)
+	case ast.CompositeNode:
+		p.printChildren(n, n.Children())
+	}
+}
+
+// printChildren writes the children of the node on the current line.
+func (p *printer) printChildren(parent ast.Node, children []ast.Node) {
+	for _, child := range children {
+		switch n := parent.(type) {
+		case *ast.RPCNode:
+			// The request type is written right after the name of the method.
+			if child == n.Input {
+				p.noSpace = true
+			}
+		case *ast.OptionNode:
+			if child == n.Equals {
+				p.pendingPadding = p.padding[n]
+			}
+		}
+
+		p.printNode(child)
+	}
+}
+
+// printBlock writes the declaration header and its body enclosed in braces.
+// Declarations without a body, like methods ending with a semicolon, are written as statements.
+func (p *printer) printBlock(node ast.CompositeNode) {
+	children := node.Children()
+
+	for i, child := range children {
+		if brace, ok := child.(*ast.RuneNode); ok && brace.Rune == '{' {
+			p.printChildren(node, children[:i])
+			p.printBody(children[i:])
+
+			return
+		}
+	}
+
+	p.printChildren(node, children)
+}
+
+// printMessageLiteral writes the message literal of an option value,
+// literals spanning several lines in the source are written one field per line.
+func (p *printer) printMessageLiteral(node *ast.MessageLiteralNode) {
+	if len(node.Elements) == 0 || p.isSingleLine(node) {
+		p.printChildren(node, node.Children())
+
+		return
+	}
+
+	p.printBody(node.Children())
+}
+
+// printCompoundString writes the concatenated string literals,
+// literals spanning several lines in the source are written one part per continuation line.
+func (p *printer) printCompoundString(node *ast.CompoundStringLiteralNode) {
+	if p.isSingleLine(node) {
+		p.printChildren(node, node.Children())
+
+		return
+	}
+
+	p.indent += continuationIndent
+
+	for i, child := range node.Children() {
+		if i > 0 {
+			p.breakLine()
+		}
+
+		p.printNode(child)
+	}
+
+	p.indent -= continuationIndent
+}
+
+// printBody writes the elements between the opening and closing tokens, one element per line.
+// Blank lines between the elements are kept, but never more than one in a row.
+func (p *printer) printBody(children []ast.Node) {
+	var (
+		opening  = children[0]
+		closing  = children[len(children)-1]
+		elements = children[1 : len(children)-1]
+		comments []ast.Comment
+	)
+
+	p.printTerminal(opening)
+
+	if comments = p.getLeadingComments(closing); !p.hasElements(elements) && len(comments) == 0 {
+		p.printTerminal(closing)
+
+		return
+	}
+
+	p.indent++
+
+	p.alignBodyOptions(elements)
+
+	var (
+		lastEnd = p.endLine(opening)
+		first   = true
+	)
+
+	for _, element := range elements {
+		switch node := element.(type) {
+		case *ast.RuneNode:
+			// Separators of message literal fields stay on the line of the field.
+			p.printTerminal(node)
+			lastEnd = p.endLine(node)
+
+			continue
+		case *ast.EmptyDeclNode:
+			if !p.hasComments(node) {
+				continue
+			}
+		}
+
+		// Comments starting on the line of the previous element stay on that line.
+		p.printSameLineComments(element)
+		p.breakLine()
+
+		if !first && p.startLine(element) > lastEnd+1 {
+			p.blankLine()
+		}
+
+		p.printNode(element)
+
+		first = false
+		lastEnd = p.endLine(element)
+	}
+
+	p.printSameLineComments(closing)
+
+	if comments = p.getLeadingComments(closing); len(comments) > 0 {
+		p.breakLine()
+
+		if !first && comments[0].Start().Line > lastEnd+1 {
+			p.blankLine()
+		}
+
+		p.printCommentLines(comments)
+	}
+
+	p.indent--
+
+	p.breakLine()
+	p.writeToken(p.fileNode.NodeInfo(closing).RawText())
+	p.lastLine = p.fileNode.NodeInfo(closing).End().Line
+	p.printTrailingComments(closing)
+}
+
+// printAtomic writes the node as a single token without spaces between its parts,
+// like qualified names, option names and negative numbers.
+func (p *printer) printAtomic(node ast.CompositeNode) {
+	p.printLeadingComments(node)
+	p.writeToken(p.getAtomicText(node))
+	p.lastLine = p.fileNode.NodeInfo(node).End().Line
+	p.printTrailingComments(node)
+}
+
+// getAtomicText returns the parts of the node joined without spaces.
+// The source text is kept as is if there are comments between the parts.
+func (p *printer) getAtomicText(node ast.CompositeNode) string {
+	terminals := getTerminals(node, nil)
+
+	var text strings.Builder
+
+	for i, terminal := range terminals {
+		info := p.fileNode.NodeInfo(terminal)
+		if (i > 0 && info.LeadingComments().Len() > 0) ||
+			(i < len(terminals)-1 && info.TrailingComments().Len() > 0) {
+			return p.fileNode.NodeInfo(node).RawText()
+		}
+
+		text.WriteString(info.RawText())
+	}
+
+	return text.String()
+}
+
+// printTerminal writes the token with its comments.
+func (p *printer) printTerminal(node ast.Node) {
+	p.printLeadingComments(node)
+	info := p.fileNode.NodeInfo(node)
+
+	p.writeToken(info.RawText())
+	p.lastLine = info.End().Line
+	p.printTrailingComments(node)
+}
+
+// printLeadingComments writes the comments preceding the node.
+// Comments preceding a statement are written on their own lines keeping blank lines between them.
+func (p *printer) printLeadingComments(node ast.Node) {
+	var (
+		info     = p.fileNode.NodeInfo(node)
+		comments = p.getLeadingComments(node)
+	)
+
+	if len(comments) == 0 {
+		return
+	}
+
+	if !p.lineStart {
+		for _, comment := range comments {
+			p.printComment(comment)
+		}
+
+		p.breakLineIfNeeded(info.Start().Line)
+
+		return
+	}
+
+	p.printCommentLines(comments)
+
+	if info.Start().Line > comments[len(comments)-1].End().Line+1 {
+		p.blankLine()
+	}
+}
+
+// printTrailingComments writes the comments following the node.
+func (p *printer) printTrailingComments(node ast.Node) {
+	comments := p.fileNode.NodeInfo(node).TrailingComments()
+	for i := 0; i < comments.Len(); i++ {
+		p.printComment(comments.Index(i))
+	}
+}
+
+// printSameLineComments writes the leading comments of the node
+// starting on the source line of the last written token.
+func (p *printer) printSameLineComments(node ast.Node) {
+	for _, comment := range p.getLeadingComments(node) {
+		if p.lineStart || comment.Start().Line != p.lastLine {
+			return
+		}
+
+		p.printComment(comment)
+	}
+}
+
+// getLeadingComments returns the comments preceding the node not written yet.
+func (p *printer) getLeadingComments(node ast.Node) []ast.Comment {
+	var (
+		comments = p.fileNode.NodeInfo(node).LeadingComments()
+		result   = make([]ast.Comment, 0, comments.Len())
+	)
+
+	for i := 0; i < comments.Len(); i++ {
+		if _, ok := p.printedComments[comments.Index(i).AsItem()]; !ok {
+			result = append(result, comments.Index(i))
+		}
+	}
+
+	return result
+}
+
+// printCommentLines writes every comment on its own line keeping blank lines between them.
+func (p *printer) printCommentLines(comments []ast.Comment) {
+	for i, comment := range comments {
+		p.breakLine()
+
+		if i > 0 && comment.Start().Line > comments[i-1].End().Line+1 {
+			p.blankLine()
+		}
+
+		p.printComment(comment)
+	}
+
+	p.breakLine()
+}
+
+// printComment writes the comment on the current line,
+// unless the comment starts on a line below the last written token.
+func (p *printer) printComment(comment ast.Comment) {
+	if _, ok := p.printedComments[comment.AsItem()]; ok {
+		return
+	}
+
+	p.printedComments[comment.AsItem()] = struct{}{}
+
+	p.breakLineIfNeeded(comment.Start().Line)
+
+	text := strings.TrimRight(comment.RawText(), " \t\r\n")
+
+	p.writeToken(text)
+	p.lastLine = comment.End().Line
+
+	// Nothing but a line break can follow a line comment.
+	if strings.HasPrefix(text, lineCommentPrefix) {
+		p.needNewline = true
+	}
+}
+
+// breakLineIfNeeded starts a new line if the source line is below the last written one.
+func (p *printer) breakLineIfNeeded(line int) {
+	if p.lineStart || line <= p.lastLine {
+		return
+	}
+
+	p.breakLine()
+
+	if line > p.lastLine+1 {
+		p.blankLine()
+	}
+}
+
+// writeToken writes the token separating it from the previous one by a space if needed.
+func (p *printer) writeToken(text string) {
+	if p.needNewline {
+		p.breakLine()
+	}
+
+	switch {
+	case p.lineStart:
+		p.buf.WriteString(strings.Repeat(indentUnit, p.indent))
+	case p.pendingPadding > 0:
+		p.buf.WriteString(strings.Repeat(" ", p.pendingPadding+1))
+	case !p.noSpace && needsSpace(p.lastToken, text):
+		p.buf.WriteByte(' ')
+	}
+
+	p.buf.WriteString(text)
+
+	p.lineStart = false
+	p.noSpace = false
+	p.pendingPadding = 0
+	p.lastToken = text
+}
+
+// breakLine ends the current line, if anything is written to it.
+func (p *printer) breakLine() {
+	if !p.lineStart {
+		p.buf.WriteByte('\n')
+	}
+
+	p.lineStart = true
+	p.needNewline = false
+	p.lastToken = ""
+}
+
+// blankLine ends the current line and adds an empty one.
+func (p *printer) blankLine() {
+	p.breakLine()
+	p.buf.WriteByte('\n')
+}
+
+// alignOptions pads the names of the options, so their equal signs are written in one column.
+func (p *printer) alignOptions(options []*ast.OptionNode) {
+	if len(options) < 2 { //nolint: gomnd // A single option has nothing to be aligned with.
+		return
+	}
+
+	var (
+		widths   = make([]int, len(options))
+		maxWidth int
+	)
+
+	for i, option := range options {
+		widths[i] = utf8.RuneCountInString(p.getAtomicText(option.Name))
+		if widths[i] > maxWidth {
+			maxWidth = widths[i]
+		}
+	}
+
+	for i, option := range options {
+		p.padding[option] = maxWidth - widths[i]
+	}
+}
+
+// alignBodyOptions aligns the runs of single-line options of the body
+// not separated by blank lines or other elements.
+func (p *printer) alignBodyOptions(elements []ast.Node) {
+	var run []*ast.OptionNode
+
+	for _, element := range elements {
+		option, ok := element.(*ast.OptionNode)
+		ok = ok && p.isSingleLine(option)
+
+		if ok && (len(run) == 0 || p.startLine(option) <= p.endLine(run[len(run)-1])+1) {
+			run = append(run, option)
+
+			continue
+		}
+
+		p.alignOptions(run)
+
+		run = nil
+		if ok {
+			run = append(run, option)
+		}
+	}
+
+	p.alignOptions(run)
+}
+
+// isSingleLine returns true if the node starts and ends on the same source line.
+func (p *printer) isSingleLine(node ast.Node) bool {
+	info := p.fileNode.NodeInfo(node)
+
+	return info.Start().Line == info.End().Line
+}
+
+// hasElements returns true if the body has elements to be written.
+func (p *printer) hasElements(elements []ast.Node) bool {
+	for _, element := range elements {
+		if node, ok := element.(*ast.EmptyDeclNode); !ok || p.hasComments(node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasComments returns true if the node is preceded or followed by comments.
+func (p *printer) hasComments(node ast.Node) bool {
+	info := p.fileNode.NodeInfo(node)
+
+	return info.LeadingComments().Len() > 0 || info.TrailingComments().Len() > 0
+}
+
+// startLine returns the source line the node starts at including its leading comments.
+func (p *printer) startLine(node ast.Node) int {
+	if comments := p.getLeadingComments(node); len(comments) > 0 {
+		return comments[0].Start().Line
+	}
+
+	return p.fileNode.NodeInfo(node).Start().Line
+}
+
+// endLine returns the source line the node ends at including its trailing comments.
+func (p *printer) endLine(node ast.Node) int {
+	info := p.fileNode.NodeInfo(node)
+	if comments := info.TrailingComments(); comments.Len() > 0 {
+		return comments.Index(comments.Len() - 1).End().Line
+	}
+
+	return info.End().Line
+}
+
+// getTerminals appends the tokens of the node to the result in the source order.
+func getTerminals(node ast.Node, result []ast.TerminalNode) []ast.TerminalNode {
+	switch n := node.(type) {
+	case ast.TerminalNode:
+		return append(result, n)
+	case ast.CompositeNode:
+		for _, child := range n.Children() {
+			result = getTerminals(child, result)
+		}
+	}
+
+	return result
+}
+
+// needsSpace returns true if the tokens are separated by a space in the canonical layout.
+func needsSpace(previous, current string) bool {
+	if previous == "" {
+		return false
+	}
+
+	// Comments are always separated from the code.
+	if strings.HasPrefix(current, "/") {
+		return true
+	}
+
+	switch current {
+	case ";", ",", ":", ")", "]", ">", "}", "<":
+		return false
+	}
+
+	switch previous {
+	case "(", "[", "<", "{":
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,143 @@
+package formatter
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name: "file layout",
+			source: `// Copyright 2024 Acme Corp.
+
+syntax   =   "proto3";
+import "z.proto";
+package shop.v1;
+// Money types.
+import "a.proto";
+option java_multiple_files=true;
+option go_package = "example.com/shop/v1";
+message   Order{}
+`,
+			expected: `// Copyright 2024 Acme Corp.
+
+syntax = "proto3";
+
+package shop.v1;
+
+// Money types.
+import "a.proto";
+import "z.proto";
+
+option java_multiple_files = true;
+option go_package          = "example.com/shop/v1";
+
+message Order {}
+`,
+		},
+		{
+			name: "blocks and comments",
+			source: `syntax = "proto3";
+message Order {
+    // Identifier of the order.
+    string id=1;  // Never empty.
+    map < string , .shop.v1.Order > children = 2 [ deprecated = true, json_name="kids" ];
+
+
+
+    int32 total = 3 [default = -5]; /* Cents. */ // Rounded.
+    reserved 4 to 6, 9;
+    oneof kind { string a = 10; int64 b = 11; }
+    enum Status { STATUS_UNSPECIFIED = 0; }
+    // End of the order.
+};
+// The end.
+`,
+			expected: `syntax = "proto3";
+
+message Order {
+  // Identifier of the order.
+  string id = 1; // Never empty.
+  map<string, .shop.v1.Order> children = 2 [deprecated = true, json_name = "kids"];
+
+  int32 total = 3 [default = -5]; /* Cents. */ // Rounded.
+  reserved 4 to 6, 9;
+  oneof kind {
+    string a = 10;
+    int64 b = 11;
+  }
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+  }
+  // End of the order.
+}
+
+// The end.
+`,
+		},
+		{
+			name: "services and option values",
+			source: `syntax = "proto3";
+service OrderService {
+  option (google.api.oauth_scopes) = "https://example.com/orders,"
+                                     "https://example.com/orders.readonly";
+  rpc GetOrder ( Order ) returns ( stream Order ) {
+      option (google.api.http) = {
+        get: "/v1/orders/{id}"
+      };
+      option idempotency_level=NO_SIDE_EFFECTS;
+      option deprecated = true;
+  }
+  rpc ListOrders(Order) returns (Order) { option (google.api.http) = { get: "/v1/orders" }; }
+}
+`,
+			expected: `syntax = "proto3";
+
+service OrderService {
+  option (google.api.oauth_scopes) = "https://example.com/orders,"
+      "https://example.com/orders.readonly";
+  rpc GetOrder(Order) returns (stream Order) {
+    option (google.api.http) = {
+      get: "/v1/orders/{id}"
+    };
+    option idempotency_level = NO_SIDE_EFFECTS;
+    option deprecated        = true;
+  }
+  rpc ListOrders(Order) returns (Order) {
+    option (google.api.http) = {get: "/v1/orders"};
+  }
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := Format("test.proto", []byte(test.source))
+		if err != nil {
+			t.Fatalf("%s: failed to format: %s", test.name, err.Error())
+		}
+
+		if string(actual) != test.expected {
+			t.Errorf("%s: unexpected result:\n%s\nexpected:\n%s", test.name, actual, test.expected)
+		}
+
+		again, err := Format("test.proto", actual)
+		if err != nil {
+			t.Fatalf("%s: failed to format the result: %s", test.name, err.Error())
+		}
+
+		if string(again) != string(actual) {
+			t.Errorf("%s: formatting isn't idempotent:\n%s", test.name, again)
+		}
+	}
+}
+
+func TestFormatInvalidFile(t *testing.T) {
+	if _, err := Format("test.proto", []byte("message Order {")); err == nil {
+		t.Error("expected an error for the invalid file")
+	}
+}
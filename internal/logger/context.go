@@ -47,7 +47,7 @@ func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
 }
 
 func getLogger(ctx context.Context) *zap.SugaredLogger {
-	l := global
+	l := Logger()
 	if logger, ok := ctx.Value(loggerContextKey).(*zap.SugaredLogger); ok {
 		l = logger
 	}
@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"os"
+	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -10,6 +11,7 @@ import (
 
 var (
 	global       *zap.SugaredLogger
+	globalMu     sync.RWMutex
 	defaultLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
 )
 
@@ -34,7 +36,7 @@ func New(level zapcore.LevelEnabler, options ...zap.Option) *zap.SugaredLogger {
 	})
 	core := zapcore.NewCore(
 		defaultEncoder,
-		zapcore.AddSync(os.Stdout),
+		zapcore.AddSync(os.Stderr),
 		level,
 	)
 
@@ -48,15 +50,28 @@ func Level() zapcore.Level {
 
 // Logger returns the global logger.
 func Logger() *zap.SugaredLogger {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+
 	return global
 }
 
 // SetLogger sets the global logger.
-// This function is not thread-safe.
+// The global logger is only a fallback for contexts without a logger,
+// runs should put their own logger into the context instead.
 func SetLogger(l *zap.SugaredLogger) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
 	global = l
 }
 
+// SetLevel changes the logging level of the global logger.
+// Loggers created by NewFromOptions have their own levels and aren't affected.
+func SetLevel(level zapcore.Level) {
+	defaultLevel.SetLevel(level)
+}
+
 // Debug writes a debug level message using the logger from the context.
 func Debug(ctx context.Context, args ...interface{}) {
 	FromContext(ctx).Debug(args...)
@@ -140,6 +155,14 @@ func FatalKV(ctx context.Context, message string, kvs ...interface{}) {
 	FromContext(ctx).Fatalw(message, kvs...)
 }
 
+// Exit flushes the logger from the context and then calls os.Exit with the code,
+// so the logs written before are never lost.
+func Exit(ctx context.Context, code int) {
+	_ = FromContext(ctx).Sync()
+
+	exit(code)
+}
+
 // Panic writes a panic level message
 // using the logger from the context and then calls panic().
 func Panic(ctx context.Context, args ...interface{}) {
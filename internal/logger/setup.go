@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// FormatConsole is the human-readable log format.
+	FormatConsole = "console"
+	// FormatJSON is the structured log format suitable for CI systems.
+	FormatJSON = "json"
+
+	logFileMode = 0o644
+)
+
+// exit terminates the process, it's replaced in tests.
+var exit = os.Exit
+
+// Options holds the settings of the global logger.
+type Options struct {
+	Format   string    // Log format: console or json.
+	FilePath string    // Path to the log file, logs are written to stderr if it's empty.
+	Quiet    bool      // Whether to suppress messages below the warning level.
+	Writer   io.Writer // Writer the logs are written to if the log file isn't specified, stderr is used if it's nil.
+}
+
+// NewFromOptions creates a logger built from the options, having its own logging level,
+// so concurrent runs don't affect each other. The global logger is left intact.
+// Logs are written to stderr unless a log file is specified,
+// so they never mix with the results written to stdout.
+// The returned function closes the log file and must be called when the run is finished,
+// fatal messages close it on their own before the process exits.
+func NewFromOptions(opts *Options) (*zap.SugaredLogger, func() error, error) {
+	var (
+		writer = io.Writer(os.Stderr)
+		closer = func() error { return nil }
+	)
+
+	if opts.Writer != nil {
+		writer = opts.Writer
+	}
+
+	if opts.FilePath != "" {
+		file, err := os.OpenFile(opts.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, logFileMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+
+		writer = file
+		closer = file.Close
+	}
+
+	encoder, err := newEncoder(opts.Format)
+	if err != nil {
+		_ = closer()
+
+		return nil, nil, err
+	}
+
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	if opts.Quiet {
+		level.SetLevel(zap.WarnLevel)
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.AddSync(writer), level)
+
+	return zap.New(core, zap.WithFatalHook(&closingFatalHook{closer: closer})).Sugar(), closer, nil
+}
+
+// closingFatalHook closes the log file before the process exits after a fatal message,
+// because deferred functions and the caller's cleanup aren't run by os.Exit.
+type closingFatalHook struct {
+	closer func() error
+}
+
+// OnWrite implements zapcore.CheckWriteHook.
+func (h *closingFatalHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	_ = h.closer()
+
+	exit(1)
+}
+
+func newEncoder(format string) (zapcore.Encoder, error) {
+	encoderConfig := zapcore.EncoderConfig{
+		MessageKey:     "message",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+
+	switch format {
+	case "", FormatConsole:
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case FormatJSON:
+		encoderConfig.LevelKey = "level"
+		encoderConfig.TimeKey = "time"
+
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", format)
+	}
+}
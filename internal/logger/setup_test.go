@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromOptionsFormats(t *testing.T) {
+	var console bytes.Buffer
+
+	log, closer, err := NewFromOptions(&Options{Writer: &console})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	log.Infow("Checked files", "count", 2)
+
+	if err = closer(); err != nil {
+		t.Errorf("expected closer without log file to succeed, got %s", err.Error())
+	}
+
+	// The console format is the default one.
+	if console.String() != "Checked files\t{\"count\": 2}\n" {
+		t.Errorf("expected console log line, got %q", console.String())
+	}
+
+	var structured bytes.Buffer
+
+	log, _, err = NewFromOptions(&Options{Format: FormatJSON, Writer: &structured})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	log.Infow("Checked files", "count", 2)
+
+	var entry map[string]interface{}
+	if err = json.Unmarshal(structured.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON log line, got %q", structured.String())
+	}
+
+	if entry["level"] != "info" || entry["message"] != "Checked files" || entry["count"] != 2.0 || entry["time"] == nil {
+		t.Errorf("unexpected JSON log entry: %v", entry)
+	}
+
+	if _, _, err = NewFromOptions(&Options{Format: "xml"}); err == nil {
+		t.Error("expected error for unknown log format")
+	}
+}
+
+func TestNewFromOptionsQuiet(t *testing.T) {
+	var buf bytes.Buffer
+
+	log, _, err := NewFromOptions(&Options{Quiet: true, Writer: &buf})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	log.Info("Checked files")
+	log.Warn("Skipped files")
+
+	if output := buf.String(); strings.Contains(output, "Checked files") || !strings.Contains(output, "Skipped files") {
+		t.Errorf("expected only warnings to be written, got %q", output)
+	}
+
+	// Every logger has its own level, so the quiet one doesn't affect the others.
+	buf.Reset()
+
+	log, _, err = NewFromOptions(&Options{Writer: &buf})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	if log.Info("Checked files"); !strings.Contains(buf.String(), "Checked files") {
+		t.Errorf("expected info to be written by another logger, got %q", buf.String())
+	}
+}
+
+func TestNewFromOptionsFile(t *testing.T) {
+	var (
+		filePath = filepath.Join(t.TempDir(), "protolinter.log")
+		writer   bytes.Buffer
+	)
+
+	if err := os.WriteFile(filePath, []byte("previous run\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	log, closer, err := NewFromOptions(&Options{FilePath: filePath, Writer: &writer})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	log.Info("Checked files")
+
+	if err = closer(); err != nil {
+		t.Fatalf("failed to close log file: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %s", err.Error())
+	}
+
+	// The log file is appended to and takes precedence over the writer.
+	if !strings.HasPrefix(string(data), "previous run\n") || !strings.Contains(string(data), "Checked files") {
+		t.Errorf("expected log to be appended to the file, got %q", string(data))
+	}
+
+	if writer.Len() > 0 {
+		t.Errorf("expected nothing to be written to the writer, got %q", writer.String())
+	}
+
+	if _, _, err = NewFromOptions(&Options{FilePath: filepath.Join(filePath, "nested.log")}); err == nil {
+		t.Error("expected error for log file that can't be opened")
+	}
+}
+
+func TestFatalClosesLogFile(t *testing.T) {
+	var exitCodes []int
+
+	exit = func(code int) { exitCodes = append(exitCodes, code) }
+	defer func() { exit = os.Exit }()
+
+	filePath := filepath.Join(t.TempDir(), "protolinter.log")
+
+	log, closer, err := NewFromOptions(&Options{FilePath: filePath})
+	if err != nil {
+		t.Fatalf("failed to create logger: %s", err.Error())
+	}
+
+	ctx := ToContext(context.Background(), log)
+
+	Info(ctx, "Checked files")
+	Fatal(ctx, "Failed to write report")
+
+	// The hook has already closed the file.
+	if err = closer(); err == nil {
+		t.Error("expected log file to be closed before exiting")
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %s", err.Error())
+	}
+
+	if !strings.Contains(string(data), "Checked files") || !strings.Contains(string(data), "Failed to write report") {
+		t.Errorf("expected log file to contain all messages, got %q", string(data))
+	}
+
+	Exit(ctx, 2)
+
+	if len(exitCodes) != 2 || exitCodes[0] != 1 || exitCodes[1] != 2 {
+		t.Errorf("expected exit codes [1 2], got %v", exitCodes)
+	}
+}
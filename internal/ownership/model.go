@@ -0,0 +1,33 @@
+package ownership
+
+import "regexp"
+
+type (
+	// Owners holds ownership rules in the order they were defined.
+	// The last matching rule takes precedence, like in CODEOWNERS files.
+	Owners struct {
+		// root is the directory paths are matched relative to, empty for the working directory.
+		root  string
+		rules []*rule
+	}
+
+	// File represents the structure of the ownership YAML file.
+	File struct {
+		// Rules is a list of path patterns with their owners.
+		Rules []*FileRule `yaml:"rules"`
+	}
+
+	// FileRule maps a CODEOWNERS-style path pattern to a list of owners.
+	FileRule struct {
+		// Pattern is a CODEOWNERS-style path pattern.
+		Pattern string `yaml:"pattern"`
+		// Owners is a list of owners of the matching paths.
+		Owners []string `yaml:"owners"`
+	}
+
+	rule struct {
+		pattern string
+		regexp  *regexp.Regexp
+		owners  []string
+	}
+)
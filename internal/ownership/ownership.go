@@ -0,0 +1,267 @@
+package ownership
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCodeOwnersPaths is a list of locations where CODEOWNERS files are searched for by default.
+var DefaultCodeOwnersPaths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// codeOwnersDirectories is a list of repository subdirectories CODEOWNERS files may be placed in.
+// Patterns of such files are still relative to the repository root.
+var codeOwnersDirectories = []string{".github", ".gitlab", "docs"}
+
+// Load reads ownership rules from the specified file.
+// Files with .yaml or .yml extension are read as ownership YAML files,
+// all other files are read as CODEOWNERS files.
+// Patterns are matched relative to the directory containing the file,
+// or to its parent directory for CODEOWNERS files placed in .github, .gitlab or docs.
+// If the filename is empty, it looks for a CODEOWNERS file in the default locations
+// and returns nil if none is found.
+func Load(filename string) (*Owners, error) {
+	if filename == "" {
+		for _, v := range DefaultCodeOwnersPaths {
+			if _, err := os.Stat(v); err == nil {
+				filename = v
+
+				break
+			}
+		}
+
+		if filename == "" {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ownership file: %w", err)
+	}
+
+	var result *Owners
+
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		result, err = parseYAML(data)
+	default:
+		result, err = parseCodeOwners(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.root, err = getRootDirectory(filename); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Find returns the owners of the specified path, absolute or relative to the working directory.
+// If the Owners is nil, the path is outside the root directory or no rule matches the path, it returns nil.
+func (o *Owners) Find(path string) []string {
+	if o == nil {
+		return nil
+	}
+
+	if o.root != "" {
+		absolutePath, err := filepath.Abs(path)
+		if err != nil {
+			return nil
+		}
+
+		if path, err = filepath.Rel(o.root, absolutePath); err != nil || strings.HasPrefix(path, "..") {
+			return nil
+		}
+	}
+
+	path = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if o.rules[i].regexp.MatchString(path) {
+			return o.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+func parseYAML(data []byte) (*Owners, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ownership file: %w", err)
+	}
+
+	result := &Owners{
+		rules: make([]*rule, 0, len(file.Rules)),
+	}
+
+	for _, v := range file.Rules {
+		if err := result.addRule(v.Pattern, v.Owners); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// getRootDirectory returns the absolute path of the directory patterns of the ownership file are relative to.
+func getRootDirectory(filename string) (string, error) {
+	absolutePath, err := filepath.Abs(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ownership file path: %w", err)
+	}
+
+	result := filepath.Dir(absolutePath)
+
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		return result, nil
+	}
+
+	for _, v := range codeOwnersDirectories {
+		if filepath.Base(result) == v {
+			return filepath.Dir(result), nil
+		}
+	}
+
+	return result, nil
+}
+
+func parseCodeOwners(data []byte) (*Owners, error) {
+	var (
+		result        = new(Owners)
+		scanner       = bufio.NewScanner(bytes.NewReader(data))
+		sectionOwners []string
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if commentIndex := strings.Index(line, " #"); commentIndex >= 0 {
+			line = line[:commentIndex]
+		}
+
+		// GitLab sections look like "[Section name][2] @owner", they don't define paths,
+		// but set the default owners of the section's rules listed without owners.
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			sectionOwners = parseSectionOwners(line)
+
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+
+		if err := result.addRule(fields[0], owners); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS file: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseSectionOwners returns the default owners of the GitLab section header.
+func parseSectionOwners(line string) []string {
+	line = strings.TrimPrefix(line, "^")
+
+	// Skips the section name and the optional number of required approvals.
+	for strings.HasPrefix(line, "[") {
+		closingIndex := strings.Index(line, "]")
+		if closingIndex < 0 {
+			return nil
+		}
+
+		line = line[closingIndex+1:]
+	}
+
+	return strings.Fields(line)
+}
+
+func (o *Owners) addRule(pattern string, owners []string) error {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid ownership pattern %q: %w", pattern, err)
+	}
+
+	o.rules = append(o.rules, &rule{
+		pattern: pattern,
+		regexp:  re,
+		owners:  owners,
+	})
+
+	return nil
+}
+
+// compilePattern converts a CODEOWNERS-style pattern into a regular expression.
+// Patterns containing a slash (except a trailing one) are anchored to the repository root,
+// other patterns match at any depth. A pattern matches a path itself and everything below it,
+// patterns with a trailing slash match directories only, i.e. everything below them.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	var (
+		isAnchored  = strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+		isDirectory = strings.HasSuffix(pattern, "/")
+		trimmed     = strings.Trim(pattern, "/")
+		sb          strings.Builder
+	)
+
+	sb.WriteString("^")
+
+	if !isAnchored {
+		sb.WriteString("(.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			sb.WriteString("(.*/)?")
+
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			sb.WriteString(".*")
+
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if isDirectory {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,159 @@
+package ownership
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		// Anchored patterns.
+		{pattern: "/api/", path: "api/orders.proto", expected: true},
+		{pattern: "/api/", path: "services/api/orders.proto", expected: false},
+		{pattern: "api/orders/", path: "api/orders/v1/orders.proto", expected: true},
+		{pattern: "api/orders/", path: "proto/api/orders/v1/orders.proto", expected: false},
+		{pattern: "/api/orders.proto", path: "api/orders.proto", expected: true},
+		{pattern: "/api/orders.proto", path: "api/orders.proto.bak", expected: false},
+		// Unanchored patterns.
+		{pattern: "orders.proto", path: "orders.proto", expected: true},
+		{pattern: "orders.proto", path: "api/v1/orders.proto", expected: true},
+		{pattern: "orders", path: "api/orders/v1/orders.proto", expected: true},
+		{pattern: "orders", path: "api/orders_v1/orders.proto", expected: false},
+		// Single asterisk.
+		{pattern: "*", path: "api/v1/orders.proto", expected: true},
+		{pattern: "*.proto", path: "api/v1/orders.proto", expected: true},
+		{pattern: "*.proto", path: "api/v1/orders.yaml", expected: false},
+		{pattern: "/api/*.proto", path: "api/orders.proto", expected: true},
+		{pattern: "/api/*.proto", path: "api/v1/orders.proto", expected: false},
+		{pattern: "api/*/orders.proto", path: "api/v1/orders.proto", expected: true},
+		{pattern: "api/*/orders.proto", path: "api/v1/internal/orders.proto", expected: false},
+		{pattern: "api/v?/", path: "api/v1/orders.proto", expected: true},
+		// Double asterisk.
+		{pattern: "**/orders.proto", path: "orders.proto", expected: true},
+		{pattern: "**/orders.proto", path: "api/v1/orders.proto", expected: true},
+		{pattern: "api/**/orders.proto", path: "api/orders.proto", expected: true},
+		{pattern: "api/**/orders.proto", path: "api/v1/internal/orders.proto", expected: true},
+		{pattern: "api/**", path: "api/v1/orders.proto", expected: true},
+		{pattern: "api/**", path: "proto/api/v1/orders.proto", expected: false},
+		// Trailing slash.
+		{pattern: "orders/", path: "api/orders/v1/orders.proto", expected: true},
+		{pattern: "orders/", path: "api/orders", expected: false},
+		{pattern: "/api/", path: "api", expected: false},
+		{pattern: "/api", path: "api", expected: true},
+		// Special characters are matched literally.
+		{pattern: "/api/v1.0/", path: "api/v1.0/orders.proto", expected: true},
+		{pattern: "/api/v1.0/", path: "api/v100/orders.proto", expected: false},
+	}
+
+	for _, test := range tests {
+		re, err := compilePattern(test.pattern)
+		if err != nil {
+			t.Fatalf("failed to compile pattern %q: %s", test.pattern, err.Error())
+		}
+
+		if actual := re.MatchString(test.path); actual != test.expected {
+			t.Errorf("expected %t for pattern %q and path %s, got %t", test.expected, test.pattern, test.path, actual)
+		}
+	}
+}
+
+func TestParseCodeOwners(t *testing.T) {
+	data := strings.Join([]string{
+		"# Default owners.",
+		"*                     @company/platform",
+		"/api/                 @company/api-team # Public API.",
+		"/api/payments/        @company/payments",
+		"/api/payments/legacy/",
+		"",
+		"[Orders] @company/orders",
+		"/api/orders/",
+		"/api/orders/admin/    @company/orders-admin",
+		"",
+		"^[Docs][2] @company/docs @company/writers",
+		"*.md",
+	}, "\n")
+
+	owners, err := parseCodeOwners([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to parse CODEOWNERS: %s", err.Error())
+	}
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "buf.yaml", expected: []string{"@company/platform"}},
+		{path: "api/common.proto", expected: []string{"@company/api-team"}},
+		{path: "./api/common.proto", expected: []string{"@company/api-team"}},
+		{path: "api/payments/v1/payments.proto", expected: []string{"@company/payments"}},
+		{path: "api/payments/legacy/payments.proto", expected: nil},
+		{path: "api/orders/v1/orders.proto", expected: []string{"@company/orders"}},
+		{path: "api/orders/admin/admin.proto", expected: []string{"@company/orders-admin"}},
+		{path: "api/orders/README.md", expected: []string{"@company/docs", "@company/writers"}},
+	}
+
+	for _, test := range tests {
+		if actual := owners.Find(test.path); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("expected owners %v of %s, got %v", test.expected, test.path, actual)
+		}
+	}
+
+	if actual := (*Owners)(nil).Find("api/common.proto"); actual != nil {
+		t.Errorf("expected no owners for nil rules, got %v", actual)
+	}
+}
+
+func TestLoadResolvesPathsRelativeToRoot(t *testing.T) {
+	var (
+		root     = t.TempDir()
+		filename = filepath.Join(root, ".github", "CODEOWNERS")
+	)
+
+	for _, directory := range []string{filepath.Dir(filename), filepath.Join(root, "api")} {
+		if err := os.MkdirAll(directory, 0o700); err != nil {
+			t.Fatalf("failed to create directory: %s", err.Error())
+		}
+	}
+
+	if err := os.WriteFile(filename, []byte("/api/ @company/api-team\n"), 0o600); err != nil {
+		t.Fatalf("failed to write CODEOWNERS: %s", err.Error())
+	}
+
+	owners, err := Load(filename)
+	if err != nil {
+		t.Fatalf("failed to load CODEOWNERS: %s", err.Error())
+	}
+
+	expected := []string{"@company/api-team"}
+	if actual := owners.Find(filepath.Join(root, "api", "orders.proto")); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected owners %v of the absolute path, got %v", expected, actual)
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %s", err.Error())
+	}
+
+	defer func() {
+		_ = os.Chdir(workingDirectory)
+	}()
+
+	if err = os.Chdir(filepath.Join(root, "api")); err != nil {
+		t.Fatalf("failed to change working directory: %s", err.Error())
+	}
+
+	if actual := owners.Find("orders.proto"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected owners %v of the path relative to a subdirectory, got %v", expected, actual)
+	}
+
+	if actual := owners.Find(filepath.Join("..", "..", "orders.proto")); actual != nil {
+		t.Errorf("expected no owners of the path outside the root, got %v", actual)
+	}
+}
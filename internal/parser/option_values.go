@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OptionValues provides typed access to the values set in an option message, such as google.api.http.
+//
+// Values are addressed by paths of field names separated by dots, e.g. "externalDocs.url".
+// Fields are named by their JSON names, text names are accepted as well.
+// A segment may select an element of a repeated field by its index or an entry of a map by its key,
+// e.g. "additionalBindings[0].get" or "responses[404].description".
+// Without an index, a segment selects all elements of a repeated field or all entries of a map,
+// ordered by key, so "additionalBindings.get" returns the paths of all additional bindings.
+type OptionValues struct {
+	message protoreflect.Message
+}
+
+// NewOptionValues creates an accessor of the values set in the message.
+func NewOptionValues(msg protoreflect.Message) *OptionValues {
+	return &OptionValues{
+		message: msg,
+	}
+}
+
+// Has returns true if any value is set at the path.
+func (v *OptionValues) Has(path string) bool {
+	return len(v.resolve(path)) > 0
+}
+
+// GetString returns the first value set at the path, formatted like in JSON,
+// or an empty string if no value is set or it's a message without a string form.
+func (v *OptionValues) GetString(path string) string {
+	for _, value := range v.resolve(path) {
+		if result, err := value.format(); err == nil {
+			return result
+		}
+	}
+
+	return ""
+}
+
+// GetStringSlice returns all values set at the path, formatted like in JSON.
+// Messages without a string form are skipped.
+func (v *OptionValues) GetStringSlice(path string) []string {
+	var result []string
+
+	for _, value := range v.resolve(path) {
+		if formatted, err := value.format(); err == nil {
+			result = append(result, formatted)
+		}
+	}
+
+	return result
+}
+
+// GetMessage returns the accessor of the first message set at the path, nil if no message is set.
+func (v *OptionValues) GetMessage(path string) *OptionValues {
+	messages := v.GetMessages(path)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return messages[0]
+}
+
+// GetMessages returns the accessors of all messages set at the path,
+// e.g. of every element of a repeated message field.
+func (v *OptionValues) GetMessages(path string) []*OptionValues {
+	var result []*OptionValues
+
+	for _, value := range v.resolve(path) {
+		if value.isMessage() {
+			result = append(result, NewOptionValues(value.value.Message()))
+		}
+	}
+
+	return result
+}
+
+// optionValue is a single value found at a path, an element of a repeated field or an entry of a map
+// are separate values.
+type optionValue struct {
+	field protoreflect.FieldDescriptor // Field holding the value, the value field of the entry for maps.
+	value protoreflect.Value
+}
+
+func (o optionValue) isMessage() bool {
+	kind := o.field.Kind()
+
+	return kind == protoreflect.MessageKind || kind == protoreflect.GroupKind
+}
+
+func (o optionValue) format() (string, error) {
+	return parseProtoMessageFieldValue(o.field, o.value)
+}
+
+// resolve returns the values found at the path, intermediate segments must select messages.
+func (v *OptionValues) resolve(path string) []optionValue {
+	if v == nil || v.message == nil || path == "" {
+		return nil
+	}
+
+	var (
+		segments = strings.Split(path, ".")
+		current  = []protoreflect.Message{v.message}
+		result   []optionValue
+	)
+
+	for i, segment := range segments {
+		result = nil
+
+		for _, message := range current {
+			result = append(result, resolveSegment(message, segment)...)
+		}
+
+		if i == len(segments)-1 {
+			break
+		}
+
+		current = current[:0]
+
+		for _, value := range result {
+			if value.isMessage() {
+				current = append(current, value.value.Message())
+			}
+		}
+	}
+
+	return result
+}
+
+// resolveSegment returns the values of the field of the message named by the segment,
+// selecting an element or an entry if the segment has an index or a key in square brackets.
+func resolveSegment(message protoreflect.Message, segment string) []optionValue {
+	name, key, hasKey := splitPathSegment(segment)
+
+	fd := findFieldByName(message.Descriptor(), name)
+	if fd == nil || !message.Has(fd) {
+		return nil
+	}
+
+	value := message.Get(fd)
+
+	switch {
+	case fd.IsList():
+		list := value.List()
+
+		if hasKey {
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= list.Len() {
+				return nil
+			}
+
+			return []optionValue{{field: fd, value: list.Get(index)}}
+		}
+
+		result := make([]optionValue, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			result = append(result, optionValue{field: fd, value: list.Get(i)})
+		}
+
+		return result
+	case fd.IsMap():
+		return resolveMapEntries(fd, value.Map(), key, hasKey)
+	case hasKey:
+		return nil
+	default:
+		return []optionValue{{field: fd, value: value}}
+	}
+}
+
+// resolveMapEntries returns the value of the entry with the key if specified, otherwise values of all entries
+// ordered by key.
+func resolveMapEntries(fd protoreflect.FieldDescriptor, mp protoreflect.Map, key string, hasKey bool) []optionValue {
+	type entry struct {
+		key   string
+		value protoreflect.Value
+	}
+
+	var entries []entry
+
+	mp.Range(func(k protoreflect.MapKey, value protoreflect.Value) bool {
+		formattedKey, err := parseProtoMessageFieldValue(fd.MapKey(), k.Value())
+		if err != nil || (hasKey && formattedKey != key) {
+			return true
+		}
+
+		entries = append(entries, entry{key: formattedKey, value: value})
+
+		return true
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	result := make([]optionValue, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, optionValue{field: fd.MapValue(), value: e.value})
+	}
+
+	return result
+}
+
+// splitPathSegment splits a segment like "responses[404]" into the field name and the key in brackets.
+func splitPathSegment(segment string) (string, string, bool) {
+	start := strings.IndexByte(segment, '[')
+	if start < 0 || !strings.HasSuffix(segment, "]") {
+		return segment, "", false
+	}
+
+	return segment[:start], segment[start+1 : len(segment)-1], true
+}
+
+// findFieldByName returns the field of the message with the JSON or text name, nil if there is none.
+func findFieldByName(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+
+	if fd := fields.ByJSONName(name); fd != nil {
+		return fd
+	}
+
+	return fields.ByTextName(name)
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestOptionValues(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name: proto.String("orders.proto"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Order"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), JsonName: proto.String("id")},
+					{Name: proto.String("status"), JsonName: proto.String("status")},
+				},
+			},
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("sku"), JsonName: proto.String("sku")},
+				},
+			},
+		},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("example.com/orders"),
+		},
+	}
+
+	values := NewOptionValues(file.ProtoReflect())
+
+	testCases := []struct {
+		path     string
+		expected []string
+	}{
+		{"name", []string{"orders.proto"}},
+		{"options.goPackage", []string{"example.com/orders"}},
+		{"options.go_package", []string{"example.com/orders"}},
+		{"messageType.name", []string{"Order", "Item"}},
+		{"messageType[1].name", []string{"Item"}},
+		{"messageType.field.name", []string{"id", "status", "sku"}},
+		{"messageType[0].field[1].name", []string{"status"}},
+		{"messageType[2].name", nil},
+		{"package", nil},
+		{"unknown", nil},
+	}
+
+	for _, tc := range testCases {
+		if actual := values.GetStringSlice(tc.path); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: got %v, want %v", tc.path, actual, tc.expected)
+		}
+	}
+
+	if actual := values.GetString("messageType.name"); actual != "Order" {
+		t.Errorf("got first message name %q, want Order", actual)
+	}
+
+	messages := values.GetMessages("messageType")
+	if len(messages) != 2 || messages[1].GetString("field.name") != "sku" {
+		t.Errorf("got %d messages, want 2 with the second one having field sku", len(messages))
+	}
+
+	if values.GetMessage("sourceCodeInfo") != nil {
+		t.Error("got accessor of unset message")
+	}
+}
+
+func TestOptionValuesMap(t *testing.T) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"404": map[string]interface{}{"description": "Not found"},
+		"200": map[string]interface{}{"description": "OK"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create struct: %s", err.Error())
+	}
+
+	values := NewOptionValues(value.ProtoReflect())
+
+	descriptions := values.GetStringSlice("fields.structValue.fields[description].stringValue")
+	if !reflect.DeepEqual(descriptions, []string{"OK", "Not found"}) {
+		t.Errorf("got descriptions %v ordered by key, want [OK Not found]", descriptions)
+	}
+
+	if actual := values.GetString("fields[404].structValue.fields[description].stringValue"); actual != "Not found" {
+		t.Errorf("got description %q, want Not found", actual)
+	}
+
+	if values.Has("fields[500]") {
+		t.Error("missing map entry is found")
+	}
+}
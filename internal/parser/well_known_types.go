@@ -2,6 +2,7 @@ package parser
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -26,6 +27,17 @@ const (
 	bytesValueFieldNumber protoreflect.FieldNumber = 1
 
 	fieldMaskFullName protoreflect.FullName = "google.protobuf.FieldMask"
+
+	structMessageFullName    protoreflect.FullName    = "google.protobuf.Struct"
+	structFieldsFieldNumber  protoreflect.FieldNumber = 1
+	valueMessageFullName     protoreflect.FullName    = "google.protobuf.Value"
+	listValueMessageFullName protoreflect.FullName    = "google.protobuf.ListValue"
+	listValuesFieldNumber    protoreflect.FieldNumber = 1
+
+	anyMessageFullName    protoreflect.FullName    = "google.protobuf.Any"
+	anyTypeURLFieldNumber protoreflect.FieldNumber = 1
+	anyValueFieldNumber   protoreflect.FieldNumber = 2
+	emptyMessageFullName  protoreflect.FullName    = "google.protobuf.Empty"
 )
 
 func marshalTimestamp(m protoreflect.Message) (string, error) {
@@ -90,3 +102,102 @@ func marshalBytes(m protoreflect.Message) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(val), nil
 }
+
+// marshalStruct renders google.protobuf.Struct, Value and ListValue as compact JSON with sorted keys.
+func marshalStruct(m protoreflect.Message) (string, error) {
+	value, err := structToInterface(m)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", m.Descriptor().FullName(), err)
+	}
+
+	return string(result), nil
+}
+
+// structToInterface converts google.protobuf.Struct, Value or ListValue into the value encoding/json renders
+// the same way as protojson does.
+func structToInterface(m protoreflect.Message) (interface{}, error) {
+	fds := m.Descriptor().Fields()
+
+	switch m.Descriptor().FullName() {
+	case structMessageFullName:
+		result := make(map[string]interface{})
+
+		var finalErr error
+
+		m.Get(fds.ByNumber(structFieldsFieldNumber)).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			value, err := structToInterface(v.Message())
+			if err != nil {
+				finalErr = err
+				return false
+			}
+
+			result[k.String()] = value
+
+			return true
+		})
+
+		return result, finalErr
+	case listValueMessageFullName:
+		list := m.Get(fds.ByNumber(listValuesFieldNumber)).List()
+		result := make([]interface{}, 0, list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			value, err := structToInterface(list.Get(i).Message())
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, value)
+		}
+
+		return result, nil
+	case valueMessageFullName:
+		fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("kind"))
+		if fd == nil {
+			return nil, nil
+		}
+
+		v := m.Get(fd)
+
+		switch fd.Kind() {
+		case protoreflect.EnumKind:
+			return nil, nil
+		case protoreflect.DoubleKind:
+			number := v.Float()
+			if math.IsNaN(number) || math.IsInf(number, 0) {
+				return nil, fmt.Errorf("%s: invalid number %v", valueMessageFullName, number)
+			}
+
+			return number, nil
+		case protoreflect.StringKind:
+			return v.String(), nil
+		case protoreflect.BoolKind:
+			return v.Bool(), nil
+		default:
+			return structToInterface(v.Message())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported message type: %q", string(m.Descriptor().FullName()))
+	}
+}
+
+// marshalAny renders google.protobuf.Any as JSON holding its type URL and the base64-encoded value,
+// since the type of the value isn't necessarily known.
+func marshalAny(m protoreflect.Message) (string, error) {
+	fds := m.Descriptor().Fields()
+
+	result, err := json.Marshal(map[string]string{
+		"@type": m.Get(fds.ByNumber(anyTypeURLFieldNumber)).String(),
+		"value": base64.StdEncoding.EncodeToString(m.Get(fds.ByNumber(anyValueFieldNumber)).Bytes()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", anyMessageFullName, err)
+	}
+
+	return string(result), nil
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEncodeMessage(t *testing.T) {
+	structValue, err := structpb.NewStruct(map[string]interface{}{
+		"name":    "orders",
+		"limits":  []interface{}{1, 2.5},
+		"enabled": true,
+		"parent":  nil,
+	})
+	if err != nil {
+		t.Fatalf("failed to create struct: %s", err.Error())
+	}
+
+	testCases := []struct {
+		name     string
+		message  protoreflect.ProtoMessage
+		expected string
+	}{
+		{
+			name:     "struct",
+			message:  structValue,
+			expected: `{"enabled":true,"limits":[1,2.5],"name":"orders","parent":null}`,
+		},
+		{
+			name:     "value",
+			message:  structpb.NewStringValue("orders"),
+			expected: `"orders"`,
+		},
+		{
+			name:     "empty value",
+			message:  &structpb.Value{},
+			expected: `null`,
+		},
+		{
+			name:     "list value",
+			message:  &structpb.ListValue{Values: []*structpb.Value{structpb.NewBoolValue(false)}},
+			expected: `[false]`,
+		},
+		{
+			name:     "any",
+			message:  &anypb.Any{TypeUrl: "type.googleapis.com/orders.Order", Value: []byte{1, 2}},
+			expected: `{"@type":"type.googleapis.com/orders.Order","value":"AQI="}`,
+		},
+		{
+			name:     "empty",
+			message:  &emptypb.Empty{},
+			expected: `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		message := tc.message.ProtoReflect()
+
+		actual, err := encodeMessage(message.Descriptor(), protoreflect.ValueOfMessage(message))
+		if err != nil {
+			t.Errorf("%s: failed to encode: %s", tc.name, err.Error())
+
+			continue
+		}
+
+		if actual != tc.expected {
+			t.Errorf("%s: got %s, want %s", tc.name, actual, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Package ruletest runs checks against golden protobuf fixtures.
+//
+// Fixtures are laid out as <dir>/<check>/good.proto and <dir>/<check>/bad.proto.
+// The good fixture must produce no findings of the check, while every finding of the check
+// in the bad fixture must be announced by an "// expect: <check>" comment placed at the end
+// of the line the descriptor is declared on, and every such comment must be matched by a finding.
+// A check mentioned several times in the comment expects as many findings on the line.
+// An optional <dir>/<check>/config.yaml is used as the configuration of the run,
+// and protos from <dir>/include are available for import.
+package ruletest
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/config"
+)
+
+const (
+	// GoodFixtureName is the name of the fixture that must pass the check.
+	GoodFixtureName = "good.proto"
+	// BadFixtureName is the name of the fixture that must fail the check.
+	BadFixtureName = "bad.proto"
+	// ConfigFileName is the name of the optional configuration file of the fixtures.
+	ConfigFileName = "config.yaml"
+	// IncludeDirName is the name of the directory containing protos available for import.
+	IncludeDirName = "include"
+)
+
+var expectCommentRegexp = regexp.MustCompile(`//\s*expect:\s*(.+)$`)
+
+// Run runs the fixtures of every check found in the directory.
+// Each subdirectory except the include directory must be named after a known check.
+func Run(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read fixtures directory: %s", err.Error())
+	}
+
+	knownChecks := make(map[string]struct{})
+	for _, rule := range checker.Rules() {
+		knownChecks[rule.Name] = struct{}{}
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == IncludeDirName {
+			continue
+		}
+
+		check := entry.Name()
+		if _, ok := knownChecks[check]; !ok {
+			t.Errorf("fixtures directory %s doesn't match any known check", check)
+
+			continue
+		}
+
+		t.Run(check, func(t *testing.T) {
+			RunCheck(t, dir, check)
+		})
+	}
+}
+
+// RunCheck runs the good and bad fixtures of a single check.
+func RunCheck(t *testing.T, dir, check string) {
+	t.Helper()
+
+	checkDir := filepath.Join(dir, check)
+
+	cfg, err := config.LoadConfig(filepath.Join(checkDir, ConfigFileName))
+	if err != nil {
+		t.Fatalf("failed to load configuration: %s", err.Error())
+	}
+
+	goodFixture := filepath.Join(checkDir, GoodFixtureName)
+	if findings := getFindingLines(t, cfg, dir, goodFixture, check); len(findings) > 0 {
+		t.Errorf("%s: expected no findings, got findings on lines %v", goodFixture, findings)
+	}
+
+	badFixture := filepath.Join(checkDir, BadFixtureName)
+
+	expectedLines := getExpectedLines(t, badFixture, check)
+	if len(expectedLines) == 0 {
+		t.Fatalf("%s: no \"// expect: %s\" comments found", badFixture, check)
+	}
+
+	actualLines := getFindingLines(t, cfg, dir, badFixture, check)
+	if !equalLines(expectedLines, actualLines) {
+		t.Errorf("%s: expected findings on lines %v, got %v", badFixture, expectedLines, actualLines)
+	}
+}
+
+// getFindingLines checks the fixture and returns the sorted one-based lines of the check's findings.
+func getFindingLines(t *testing.T, cfg *config.Config, dir, fixture, check string) []int {
+	t.Helper()
+
+	ctx := context.Background()
+	protoChecker := checker.NewProtoChecker(ctx, cfg, filepath.Join(dir, IncludeDirName))
+
+	results, err := protoChecker.CheckFiles(ctx, fixture)
+	if err != nil {
+		t.Fatalf("%s: failed to check: %s", fixture, err.Error())
+	}
+
+	var result []int
+
+	for _, cr := range results {
+		for _, finding := range cr.Findings {
+			if finding.Check != check {
+				continue
+			}
+
+			// Findings are located by one-based lines, the same as the lines of expect comments are counted.
+			result = append(result, finding.Line)
+		}
+	}
+
+	sort.Ints(result)
+
+	return result
+}
+
+// getExpectedLines returns the sorted one-based lines having an expect comment mentioning the check.
+func getExpectedLines(t *testing.T, fixture, check string) []int {
+	t.Helper()
+
+	file, err := os.Open(fixture)
+	if err != nil {
+		t.Fatalf("failed to open fixture: %s", err.Error())
+	}
+	defer file.Close()
+
+	var (
+		result     []int
+		lineNumber int
+		scanner    = bufio.NewScanner(file)
+	)
+
+	for scanner.Scan() {
+		lineNumber++
+
+		matches := expectCommentRegexp.FindStringSubmatch(scanner.Text())
+		if len(matches) == 0 {
+			continue
+		}
+
+		for _, v := range strings.Split(matches[1], ",") {
+			if strings.TrimSpace(v) == check {
+				result = append(result, lineNumber)
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		t.Fatalf("failed to read fixture: %s", err.Error())
+	}
+
+	return result
+}
+
+func equalLines(expected, actual []int) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
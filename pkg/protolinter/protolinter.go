@@ -0,0 +1,63 @@
+// Package protolinter exposes the linter to programs embedding it and to authors of custom rules.
+package protolinter
+
+import (
+	"context"
+
+	"github.com/oshokin/protolinter/internal/checker"
+	"github.com/oshokin/protolinter/internal/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type (
+	// CheckOptions holds the options of a check run, the same as the flags of the check command.
+	CheckOptions = checker.CheckOptions
+
+	// CompileCache keeps compiled files across check runs within a process, so the files
+	// are compiled again only if one of the sources read while compiling them has changed.
+	// It is safe for concurrent use.
+	CompileCache = checker.CompileCache
+
+	// Visitor receives the descriptors visited by Walk.
+	// Embed BaseVisitor to implement only the methods of interest.
+	Visitor = checker.Visitor
+
+	// BaseVisitor implements Visitor entering every descriptor and doing nothing else.
+	BaseVisitor = checker.BaseVisitor
+
+	// OptionValues provides typed access to the values set in an option message, such as google.api.http.
+	// Values are addressed by dot-separated paths of JSON or text field names,
+	// e.g. "additionalBindings[0].get" or "responses[404].description".
+	OptionValues = parser.OptionValues
+)
+
+// NewCompileCache creates an empty cache of compiled files.
+func NewCompileCache() *CompileCache {
+	return checker.NewCompileCache()
+}
+
+// WithCompileCache returns a copy of the context carrying the cache,
+// checks run with the context reuse the files compiled by previous runs.
+func WithCompileCache(ctx context.Context, cache *CompileCache) context.Context {
+	return checker.WithCompileCache(ctx, cache)
+}
+
+// RunCheck checks the files matching the patterns, writes the report and returns whether the run passed.
+// Unlike the check command, it never exits, so it can be run many times within a process.
+// Runs sharing a context made by WithCompileCache reuse the files compiled by each other.
+func RunCheck(ctx context.Context, patterns []string, opts *CheckOptions) (bool, error) {
+	return checker.RunCheck(ctx, patterns, opts)
+}
+
+// Walk visits the descriptors declared in the file, e.g. a compiled linker.File, in the order they are nested:
+// services with their methods, then messages, enums and extensions of the file.
+// Descendants of a service, a message or an enum are skipped if entering it returns false.
+// Files imported by the file aren't visited.
+func Walk(file protoreflect.FileDescriptor, visitor Visitor) {
+	checker.Walk(file, visitor)
+}
+
+// NewOptionValues creates an accessor of the values set in the option message.
+func NewOptionValues(msg protoreflect.Message) *OptionValues {
+	return parser.NewOptionValues(msg)
+}
@@ -0,0 +1,60 @@
+package protolinter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oshokin/protolinter/pkg/protolinter"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/apipb"
+)
+
+// messageVisitor records the names of the visited messages.
+type messageVisitor struct {
+	protolinter.BaseVisitor
+	messages []string
+}
+
+func (v *messageVisitor) EnterMessage(message protoreflect.MessageDescriptor) bool {
+	v.messages = append(v.messages, string(message.Name()))
+
+	return true
+}
+
+func TestWalk(t *testing.T) {
+	visitor := new(messageVisitor)
+	protolinter.Walk(apipb.File_google_protobuf_api_proto, visitor)
+
+	// Messages of the imported source_context.proto and type.proto aren't visited.
+	expected := []string{"Api", "Method", "Mixin"}
+	if !reflect.DeepEqual(visitor.messages, expected) {
+		t.Errorf("expected messages %v, got %v", expected, visitor.messages)
+	}
+}
+
+func TestOptionValues(t *testing.T) {
+	options := &descriptorpb.FileOptions{
+		GoPackage: proto.String("github.com/company/orders/v1;ordersv1"),
+		UninterpretedOption: []*descriptorpb.UninterpretedOption{
+			{IdentifierValue: proto.String("first")},
+			{IdentifierValue: proto.String("second")},
+		},
+	}
+
+	values := protolinter.NewOptionValues(options.ProtoReflect())
+
+	if actual := values.GetString("goPackage"); actual != options.GetGoPackage() {
+		t.Errorf("expected go package %s, got %s", options.GetGoPackage(), actual)
+	}
+
+	expected := []string{"first", "second"}
+	if actual := values.GetStringSlice("uninterpretedOption.identifierValue"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected identifiers %v, got %v", expected, actual)
+	}
+
+	if actual := values.GetMessages("uninterpretedOption"); len(actual) != 2 {
+		t.Errorf("expected 2 uninterpreted options, got %d", len(actual))
+	}
+}